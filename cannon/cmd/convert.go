@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+var (
+	ConvertInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of the state to convert, as JSON or in the binary state format, optionally gzip compressed.",
+		TakesFile: true,
+		Required:  true,
+	}
+	ConvertOutputFlag = &cli.PathFlag{
+		Name:      "output",
+		Usage:     "path to write the converted state to, in the binary state format if it ends in .bin or .bin.gz, as JSON otherwise. Compressed if it ends in .gz.",
+		TakesFile: true,
+		Required:  true,
+	}
+)
+
+func Convert(ctx *cli.Context) error {
+	state, err := mipsevm.LoadState(ctx.Path(ConvertInputFlag.Name))
+	if err != nil {
+		return err
+	}
+	if err := writeState(ctx.Path(ConvertOutputFlag.Name), state, false); err != nil {
+		return fmt.Errorf("failed to write state: %w", err)
+	}
+	return nil
+}
+
+var ConvertCommand = &cli.Command{
+	Name:  "convert",
+	Usage: "Convert a state between JSON and the binary state format",
+	Description: "Convert a state or snapshot between JSON and the binary state format. " +
+		"The input format is detected from its content, the output format from its file extension.",
+	Action: Convert,
+	Flags: []cli.Flag{
+		ConvertInputFlag,
+		ConvertOutputFlag,
+	},
+}
@@ -24,7 +24,7 @@ var (
 	}
 	LoadELFOutFlag = &cli.PathFlag{
 		Name:     "out",
-		Usage:    "Output path to write JSON state to. State is dumped to stdout if set to empty string.",
+		Usage:    "Output path to write state to, in the binary state format if it ends in .bin or .bin.gz, as JSON otherwise. State is dumped to stdout as JSON if set to empty string.",
 		Value:    "state.json",
 		Required: false,
 	}
@@ -69,13 +69,13 @@ func LoadELF(ctx *cli.Context) error {
 	if err := writeJSON[*mipsevm.Metadata](ctx.Path(LoadELFMetaFlag.Name), meta, false); err != nil {
 		return fmt.Errorf("failed to output metadata: %w", err)
 	}
-	return writeJSON[*mipsevm.State](ctx.Path(LoadELFOutFlag.Name), state, true)
+	return writeState(ctx.Path(LoadELFOutFlag.Name), state, true)
 }
 
 var LoadELFCommand = &cli.Command{
 	Name:        "load-elf",
-	Usage:       "Load ELF file into Cannon JSON state",
-	Description: "Load ELF file into Cannon JSON state, optionally patch out functions",
+	Usage:       "Load ELF file into Cannon state",
+	Description: "Load ELF file into Cannon state, optionally patch out functions",
 	Action:      LoadELF,
 	Flags: []cli.Flag{
 		LoadELFPathFlag,
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"time"
@@ -21,14 +22,14 @@ import (
 var (
 	RunInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state. Stdin if left empty.",
+		Usage:     "path of input state, as JSON or in the binary state format, optionally gzip compressed.",
 		TakesFile: true,
 		Value:     "state.json",
 		Required:  true,
 	}
 	RunOutputFlag = &cli.PathFlag{
 		Name:      "output",
-		Usage:     "path of output JSON state. Stdout if left empty.",
+		Usage:     "path of output state, in the binary state format if it ends in .bin or .bin.gz, as JSON otherwise. Stdout if left empty.",
 		TakesFile: true,
 		Value:     "out.json",
 		Required:  false,
@@ -54,8 +55,13 @@ var (
 	}
 	RunSnapshotFmtFlag = &cli.StringFlag{
 		Name:     "snapshot-fmt",
-		Usage:    "format for snapshot output file names.",
-		Value:    "state-%d.json",
+		Usage:    "format for snapshot output file names. Snapshots are written in the binary state format if the name ends in .bin or .bin.gz, as JSON otherwise.",
+		Value:    "state-%d.bin.gz",
+		Required: false,
+	}
+	RunStateHashesFmtFlag = &cli.StringFlag{
+		Name:     "state-hashes-fmt",
+		Usage:    "format for the file name to write the post-state hash of every executed step to, formatted with the first step. Not written if empty.",
 		Required: false,
 	}
 	RunStopAtFlag = &cli.GenericFlag{
@@ -80,6 +86,16 @@ var (
 		Name:  "pprof.cpu",
 		Usage: "enable pprof cpu profiling",
 	}
+	RunPProfGuestFlag = &cli.PathFlag{
+		Name:      "pprof.guest",
+		Usage:     "path to write a pprof profile of the instructions executed, and memory pages touched, by each function of the guest program to. Uses the symbols of --meta. Not profiled if empty.",
+		TakesFile: true,
+	}
+	RunPProfGuestFoldedFlag = &cli.PathFlag{
+		Name:      "pprof.guest-folded",
+		Usage:     "path to write the instructions executed by each call stack of the guest program to, as folded stacks for flame graph tools. Uses the symbols of --meta. Not profiled if empty.",
+		TakesFile: true,
+	}
 )
 
 type Proof struct {
@@ -208,7 +224,7 @@ func Run(ctx *cli.Context) error {
 		defer profile.Start(profile.NoShutdownHook, profile.ProfilePath("."), profile.CPUProfile).Stop()
 	}
 
-	state, err := loadJSON[mipsevm.State](ctx.Path(RunInputFlag.Name))
+	state, err := mipsevm.LoadState(ctx.Path(RunInputFlag.Name))
 	if err != nil {
 		return err
 	}
@@ -271,6 +287,32 @@ func Run(ctx *cli.Context) error {
 	start := time.Now()
 	startStep := state.Step
 
+	var stateHashes *mipsevm.StateHashWriter
+	if stateHashesFmt := ctx.String(RunStateHashesFmtFlag.Name); stateHashesFmt != "" {
+		stateHashes, err = mipsevm.NewStateHashWriter(fmt.Sprintf(stateHashesFmt, startStep), startStep)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err := stateHashes.Close(); err != nil {
+				l.Error("failed to close state hashes", "err", err)
+			}
+		}()
+	}
+
+	var profiler *mipsevm.Profiler
+	profilePath := ctx.Path(RunPProfGuestFlag.Name)
+	foldedPath := ctx.Path(RunPProfGuestFoldedFlag.Name)
+	if profilePath != "" || foldedPath != "" {
+		profiler = mipsevm.NewProfiler(meta)
+		// write the profile when stopped early too, e.g. when interrupted
+		defer func() {
+			if err := writeGuestProfile(profiler, profilePath, foldedPath); err != nil {
+				l.Error("failed to write guest profile", "err", err)
+			}
+		}()
+	}
+
 	// avoid symbol lookups every instruction by preparing a matcher func
 	sleepCheck := meta.SymbolMatcher("runtime.notesleep")
 
@@ -304,8 +346,12 @@ func Run(ctx *cli.Context) error {
 			break
 		}
 
+		if profiler != nil {
+			profiler.Record(state)
+		}
+
 		if snapshotAt(state) {
-			if err := writeJSON(fmt.Sprintf(snapshotFmt, step), state, false); err != nil {
+			if err := writeState(fmt.Sprintf(snapshotFmt, step), state, false); err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
 		}
@@ -338,20 +384,53 @@ func Run(ctx *cli.Context) error {
 			if err := writeJSON(fmt.Sprintf(proofFmt, step), proof, true); err != nil {
 				return fmt.Errorf("failed to write proof data: %w", err)
 			}
+			if stateHashes != nil {
+				if err := stateHashes.Write(postStateHash); err != nil {
+					return fmt.Errorf("failed to write post-state hash of step %d: %w", step, err)
+				}
+			}
 		} else {
 			_, err = stepFn(false)
 			if err != nil {
 				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.PC, err)
 			}
+			if stateHashes != nil {
+				if err := stateHashes.Write(crypto.Keccak256Hash(state.EncodeWitness())); err != nil {
+					return fmt.Errorf("failed to write post-state hash of step %d: %w", step, err)
+				}
+			}
 		}
 	}
 
-	if err := writeJSON(ctx.Path(RunOutputFlag.Name), state, true); err != nil {
+	if err := writeState(ctx.Path(RunOutputFlag.Name), state, true); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	return nil
 }
 
+func writeGuestProfile(profiler *mipsevm.Profiler, profilePath string, foldedPath string) error {
+	for _, out := range []struct {
+		path  string
+		write func(io.Writer) error
+	}{{profilePath, profiler.WriteProfile}, {foldedPath, profiler.WriteFolded}} {
+		if out.path == "" {
+			continue
+		}
+		f, err := os.OpenFile(out.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open %q: %w", out.path, err)
+		}
+		if err := out.write(f); err != nil {
+			_ = f.Close()
+			return fmt.Errorf("failed to write %q: %w", out.path, err)
+		}
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("failed to close %q: %w", out.path, err)
+		}
+	}
+	return nil
+}
+
 var RunCommand = &cli.Command{
 	Name:        "run",
 	Usage:       "Run VM step(s) and generate proof data to replicate onchain.",
@@ -364,9 +443,12 @@ var RunCommand = &cli.Command{
 		RunProofFmtFlag,
 		RunSnapshotAtFlag,
 		RunSnapshotFmtFlag,
+		RunStateHashesFmtFlag,
 		RunStopAtFlag,
 		RunMetaFlag,
 		RunInfoAtFlag,
 		RunPProfCPU,
+		RunPProfGuestFlag,
+		RunPProfGuestFoldedFlag,
 	},
 }
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// writeState writes a VM state in the binary state format if the path ends in ".bin" or ".bin.gz",
+// and as JSON otherwise. The state is gzip compressed if the path ends in ".gz".
+func writeState(outputPath string, state *mipsevm.State, outIfEmpty bool) error {
+	if !isBinary(outputPath) {
+		return writeJSON(outputPath, state, outIfEmpty)
+	}
+	f, err := os.OpenFile(outputPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("failed to open output file: %w", err)
+	}
+	defer f.Close()
+	w := bufio.NewWriterSize(f, 1<<20)
+	out := io.Writer(w)
+	var g *gzip.Writer
+	if isGzip(outputPath) {
+		g = gzip.NewWriter(w)
+		out = g
+	}
+	if err := state.Serialize(out); err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if g != nil {
+		if err := g.Close(); err != nil {
+			return fmt.Errorf("failed to close gzip writer: %w", err)
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output file: %w", err)
+	}
+	return f.Close()
+}
+
+func isBinary(path string) bool {
+	return strings.HasSuffix(strings.TrimSuffix(path, ".gz"), ".bin")
+}
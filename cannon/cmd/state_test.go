@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestRoundTripState(t *testing.T) {
+	for _, name := range []string{"state.json", "state.json.gz", "state.bin", "state.bin.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), name)
+			state := testState()
+			require.NoError(t, writeState(file, state, false))
+
+			result, err := mipsevm.LoadState(file)
+			require.NoError(t, err)
+			require.Equal(t, state.EncodeWitness(), result.EncodeWitness())
+			require.Equal(t, state.LastHint, result.LastHint)
+		})
+	}
+}
+
+func TestWriteStateFormat(t *testing.T) {
+	dir := t.TempDir()
+
+	jsonFile := filepath.Join(dir, "state.json")
+	require.NoError(t, writeState(jsonFile, testState(), false))
+	content, err := os.ReadFile(jsonFile)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(content, &mipsevm.State{}), "should write JSON")
+
+	binFile := filepath.Join(dir, "state.bin")
+	require.NoError(t, writeState(binFile, testState(), false))
+	content, err = os.ReadFile(binFile)
+	require.NoError(t, err)
+	require.Equal(t, mipsevm.StateBinaryMagic[:], content[:4], "should write the binary state format")
+}
+
+func TestLoadStateDetectsFormat(t *testing.T) {
+	dir := t.TempDir()
+	// Binary content in a file named as JSON, and compressed content in a file without the gzip extension
+	binFile := filepath.Join(dir, "state.bin.gz")
+	require.NoError(t, writeState(binFile, testState(), false))
+	misnamed := filepath.Join(dir, "state.json")
+	require.NoError(t, os.Rename(binFile, misnamed))
+
+	result, err := mipsevm.LoadState(misnamed)
+	require.NoError(t, err)
+	require.Equal(t, testState().EncodeWitness(), result.EncodeWitness())
+}
+
+func TestConvert(t *testing.T) {
+	dir := t.TempDir()
+	jsonFile := filepath.Join(dir, "state.json")
+	binFile := filepath.Join(dir, "state.bin.gz")
+	backFile := filepath.Join(dir, "back.json")
+	require.NoError(t, writeState(jsonFile, testState(), false))
+
+	app := &cli.App{Commands: []*cli.Command{ConvertCommand}}
+	require.NoError(t, app.Run([]string{"cannon", "convert", "--input", jsonFile, "--output", binFile}))
+	require.NoError(t, app.Run([]string{"cannon", "convert", "--input", binFile, "--output", backFile}))
+
+	expected, err := os.ReadFile(jsonFile)
+	require.NoError(t, err)
+	actual, err := os.ReadFile(backFile)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expected), string(actual))
+}
+
+func testState() *mipsevm.State {
+	state := &mipsevm.State{
+		Memory:   mipsevm.NewMemory(),
+		PC:       0x1000,
+		NextPC:   0x1004,
+		Step:     42,
+		LastHint: []byte{0, 0, 0, 1, 0xaa},
+	}
+	state.Registers[29] = 0x7fff_0000
+	state.Memory.SetMemory(0x1000, 0x24020001)
+	state.Memory.SetMemory(0x7fff_0000, 123)
+	return state
+}
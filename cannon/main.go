@@ -21,6 +21,7 @@ func main() {
 	app.Commands = []*cli.Command{
 		cmd.LoadELFCommand,
 		cmd.RunCommand,
+		cmd.ConvertCommand,
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 
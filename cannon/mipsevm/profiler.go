@@ -0,0 +1,238 @@
+package mipsevm
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/google/pprof/profile"
+)
+
+// maxProfileStackDepth bounds the depth of the call stacks tracked by the [Profiler].
+// Calls made deeper than this are attributed to the deepest tracked caller.
+const maxProfileStackDepth = 1024
+
+// profileFrame is a node in the tree of call stacks seen by the profiler.
+type profileFrame struct {
+	parent int
+	callPC uint32
+	depth  int
+}
+
+type profileCall struct {
+	parent int
+	callPC uint32
+}
+
+type profileKey struct {
+	stack int
+	pc    uint32
+}
+
+// Profiler attributes the instructions executed by the guest program, and the memory pages it touches,
+// to the guest functions executing them, using the symbols of the program metadata.
+//
+// The call stack of the guest is not part of the VM state, so the profiler keeps a shadow stack:
+// jal, and jalr linking to $ra, push a frame once their delay slot is executed, and jr $ra pops the frames up to
+// the one returning to its target. If the target is not on the shadow stack, e.g. after a goroutine switch,
+// the shadow stack is reset, so the profile may show partial stacks.
+type Profiler struct {
+	meta *Metadata
+
+	frames []profileFrame
+	calls  map[profileCall]int
+	stack  int
+
+	// pending stack change of the last call or return, applied after its delay slot
+	pendingCall   bool
+	pendingCallPC uint32
+	pendingReturn bool
+	pendingTarget uint32
+
+	samples map[profileKey]*[2]int64
+	touched []uint64
+}
+
+func NewProfiler(meta *Metadata) *Profiler {
+	return &Profiler{
+		meta:    meta,
+		frames:  []profileFrame{{parent: -1}},
+		calls:   make(map[profileCall]int),
+		samples: make(map[profileKey]*[2]int64),
+		touched: make([]uint64, MaxPageCount/64),
+	}
+}
+
+// Record attributes the next instruction executed from state, and the memory pages it touches, to the current
+// call stack. It must be called before each step.
+func (p *Profiler) Record(state *State) {
+	pc := state.PC
+	insn := state.Memory.GetMemory(pc)
+	opcode := insn >> 26
+
+	touches := p.touch(pc >> PageAddrSize)
+	if opcode >= 0x20 {
+		// memory access at M[R[rs]+SignExtImm], see mipsStep
+		addr := state.Registers[(insn>>21)&0x1F] + SE(insn&0xFFFF, 16)
+		touches += p.touch(addr >> PageAddrSize)
+	}
+	key := profileKey{stack: p.stack, pc: pc}
+	counts, ok := p.samples[key]
+	if !ok {
+		counts = new([2]int64)
+		p.samples[key] = counts
+	}
+	counts[0] += 1
+	counts[1] += touches
+
+	// The instruction is the delay slot of the last call or return, which now takes effect
+	if p.pendingCall {
+		p.push(p.pendingCallPC)
+		p.pendingCall = false
+	} else if p.pendingReturn {
+		p.pop(p.pendingTarget)
+		p.pendingReturn = false
+	}
+
+	fun := insn & 0x3F
+	rsReg := (insn >> 21) & 0x1F
+	rdReg := (insn >> 11) & 0x1F
+	if opcode == 3 || (opcode == 0 && fun == 9 && rdReg == 31) { // jal, jalr $ra
+		p.pendingCall = true
+		p.pendingCallPC = pc
+	} else if opcode == 0 && fun == 8 && rsReg == 31 { // jr $ra
+		p.pendingReturn = true
+		p.pendingTarget = state.Registers[31]
+	}
+}
+
+// touch marks the page as touched, returning 1 if it was not touched before.
+func (p *Profiler) touch(pageIndex uint32) int64 {
+	i, bit := pageIndex/64, uint64(1)<<(pageIndex%64)
+	if p.touched[i]&bit != 0 {
+		return 0
+	}
+	p.touched[i] |= bit
+	return 1
+}
+
+func (p *Profiler) push(callPC uint32) {
+	if p.frames[p.stack].depth >= maxProfileStackDepth {
+		return
+	}
+	call := profileCall{parent: p.stack, callPC: callPC}
+	frame, ok := p.calls[call]
+	if !ok {
+		frame = len(p.frames)
+		p.frames = append(p.frames, profileFrame{parent: p.stack, callPC: callPC, depth: p.frames[p.stack].depth + 1})
+		p.calls[call] = frame
+	}
+	p.stack = frame
+}
+
+func (p *Profiler) pop(target uint32) {
+	for frame := p.stack; frame > 0; frame = p.frames[frame].parent {
+		if p.frames[frame].callPC+8 == target {
+			p.stack = p.frames[frame].parent
+			return
+		}
+	}
+	p.stack = 0
+}
+
+// addresses returns the addresses of the call stack of the sample, from the executed instruction to the outermost call.
+func (p *Profiler) addresses(key profileKey) []uint32 {
+	out := []uint32{key.pc}
+	for frame := key.stack; frame > 0; frame = p.frames[frame].parent {
+		out = append(out, p.frames[frame].callPC)
+	}
+	return out
+}
+
+// sortedKeys returns the keys of the samples in a deterministic order.
+func (p *Profiler) sortedKeys() []profileKey {
+	keys := make([]profileKey, 0, len(p.samples))
+	for k := range p.samples {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].stack != keys[j].stack {
+			return keys[i].stack < keys[j].stack
+		}
+		return keys[i].pc < keys[j].pc
+	})
+	return keys
+}
+
+// Profile builds a pprof profile of the recorded instructions and page touches.
+func (p *Profiler) Profile() *profile.Profile {
+	mapping := &profile.Mapping{ID: 1, Start: 0, Limit: 1 << 32, File: "guest", HasFunctions: true}
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "instructions", Unit: "count"},
+			{Type: "page_touches", Unit: "count"},
+		},
+		PeriodType: &profile.ValueType{Type: "instructions", Unit: "count"},
+		Period:     1,
+		Mapping:    []*profile.Mapping{mapping},
+	}
+	functions := make(map[string]*profile.Function)
+	locations := make(map[uint32]*profile.Location)
+	location := func(addr uint32) *profile.Location {
+		if loc, ok := locations[addr]; ok {
+			return loc
+		}
+		name := p.meta.LookupSymbol(addr)
+		fn, ok := functions[name]
+		if !ok {
+			fn = &profile.Function{ID: uint64(len(prof.Function) + 1), Name: name, SystemName: name}
+			functions[name] = fn
+			prof.Function = append(prof.Function, fn)
+		}
+		loc := &profile.Location{ID: uint64(len(prof.Location) + 1), Mapping: mapping, Address: uint64(addr), Line: []profile.Line{{Function: fn}}}
+		locations[addr] = loc
+		prof.Location = append(prof.Location, loc)
+		return loc
+	}
+	for _, key := range p.sortedKeys() {
+		counts := p.samples[key]
+		sample := &profile.Sample{Value: []int64{counts[0], counts[1]}}
+		for _, addr := range p.addresses(key) {
+			sample.Location = append(sample.Location, location(addr))
+		}
+		prof.Sample = append(prof.Sample, sample)
+	}
+	return prof
+}
+
+// WriteProfile writes the gzip compressed pprof profile of the recorded instructions and page touches to w.
+func (p *Profiler) WriteProfile(w io.Writer) error {
+	return p.Profile().Write(w)
+}
+
+// WriteFolded writes the recorded instructions to w as folded stacks, the input format of flame graph tools:
+// one line per call stack, with the guest functions from the outermost call to the executing function
+// separated by semicolons, followed by the number of instructions executed.
+func (p *Profiler) WriteFolded(w io.Writer) error {
+	folded := make(map[string]int64)
+	for key, counts := range p.samples {
+		addrs := p.addresses(key)
+		names := make([]string, len(addrs))
+		for i, addr := range addrs {
+			names[len(addrs)-1-i] = p.meta.LookupSymbol(addr)
+		}
+		folded[strings.Join(names, ";")] += counts[0]
+	}
+	stacks := make([]string, 0, len(folded))
+	for stack := range folded {
+		stacks = append(stacks, stack)
+	}
+	sort.Strings(stacks)
+	for _, stack := range stacks {
+		if _, err := fmt.Fprintf(w, "%s %d\n", stack, folded[stack]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
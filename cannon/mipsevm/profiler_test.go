@@ -0,0 +1,106 @@
+package mipsevm
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+)
+
+// profilerTestState creates a program in which main calls fn, which loads from the stack and returns.
+func profilerTestState() (*State, *Metadata) {
+	state := &State{Memory: NewMemory(), PC: 0x1000, NextPC: 0x1004}
+	state.Registers[29] = 0x7fff_0000
+	state.Memory.SetMemory(0x1000, 3<<26|0x2000>>2) // jal fn
+	state.Memory.SetMemory(0x1004, 0)               // nop
+	state.Memory.SetMemory(0x1008, 0)               // nop
+	state.Memory.SetMemory(0x2000, 0x8FA80000)      // lw $t0, 0($sp)
+	state.Memory.SetMemory(0x2004, 0x03E00008)      // jr $ra
+	state.Memory.SetMemory(0x2008, 0)               // nop
+	meta := &Metadata{Symbols: []Symbol{
+		{Name: "main", Start: 0x1000, Size: 0x100},
+		{Name: "fn", Start: 0x2000, Size: 0x100},
+	}}
+	return state, meta
+}
+
+func runProfiler(t *testing.T, state *State, profiler *Profiler, steps int) {
+	us := NewInstrumentedState(state, nil, nil, nil)
+	for i := 0; i < steps; i++ {
+		profiler.Record(state)
+		_, err := us.Step(false)
+		require.NoError(t, err)
+	}
+}
+
+func TestProfiler(t *testing.T) {
+	t.Run("Folded", func(t *testing.T) {
+		state, meta := profilerTestState()
+		profiler := NewProfiler(meta)
+		runProfiler(t, state, profiler, 6)
+		require.Equal(t, uint32(0x100c), state.PC, "should return to main")
+
+		var buf bytes.Buffer
+		require.NoError(t, profiler.WriteFolded(&buf))
+		require.Equal(t, "main 3\nmain;fn 3\n", buf.String())
+	})
+
+	t.Run("Profile", func(t *testing.T) {
+		state, meta := profilerTestState()
+		profiler := NewProfiler(meta)
+		runProfiler(t, state, profiler, 6)
+
+		var buf bytes.Buffer
+		require.NoError(t, profiler.WriteProfile(&buf))
+		prof, err := profile.Parse(&buf)
+		require.NoError(t, err)
+		require.NoError(t, prof.CheckValid())
+		require.Equal(t, "instructions", prof.SampleType[0].Type)
+		require.Equal(t, "page_touches", prof.SampleType[1].Type)
+
+		// Total instructions and page touches, by the function executing them
+		totals := make(map[string][2]int64)
+		for _, sample := range prof.Sample {
+			name := sample.Location[0].Line[0].Function.Name
+			total := totals[name]
+			total[0] += sample.Value[0]
+			total[1] += sample.Value[1]
+			totals[name] = total
+		}
+		require.Equal(t, [2]int64{3, 1}, totals["main"], "main touches its code page")
+		require.Equal(t, [2]int64{3, 2}, totals["fn"], "fn touches its code page and the stack page")
+
+		for _, sample := range prof.Sample {
+			if sample.Location[0].Line[0].Function.Name == "fn" {
+				require.Len(t, sample.Location, 2)
+				require.Equal(t, "main", sample.Location[1].Line[0].Function.Name)
+				require.Equal(t, uint64(0x1000), sample.Location[1].Address, "caller location should be the call site")
+			}
+		}
+	})
+
+	t.Run("ResetOnUnknownReturn", func(t *testing.T) {
+		state, meta := profilerTestState()
+		profiler := NewProfiler(meta)
+		runProfiler(t, state, profiler, 3)
+		require.NotZero(t, profiler.stack, "should be in fn")
+		// Return somewhere else than the caller, as a goroutine switch would
+		state.Registers[31] = 0x1004
+		runProfiler(t, state, profiler, 3)
+		require.Zero(t, profiler.stack, "should reset the stack")
+	})
+
+	t.Run("ReusesStacks", func(t *testing.T) {
+		state, meta := profilerTestState()
+		// Call fn again after it returns
+		state.Memory.SetMemory(0x1008, 3<<26|0x2000>>2)
+		profiler := NewProfiler(meta)
+		runProfiler(t, state, profiler, 11)
+		require.Len(t, profiler.frames, 3, "root and one frame per call site")
+
+		var buf bytes.Buffer
+		require.NoError(t, profiler.WriteFolded(&buf))
+		require.Equal(t, "main 5\nmain;fn 6\n", buf.String())
+	})
+}
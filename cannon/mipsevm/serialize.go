@@ -0,0 +1,384 @@
+package mipsevm
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"os"
+	"sort"
+)
+
+// StateBinaryMagic starts every state in the binary format, to tell it apart from JSON.
+var StateBinaryMagic = [4]byte{'c', 'n', 'n', 's'}
+
+// StateBinaryVersion is the version of the binary state format written by [State.Serialize].
+const StateBinaryVersion uint8 = 1
+
+// gzipMagic starts every gzip stream.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// maxLastHintSize bounds the size of the last hint read from a binary state, to not allocate arbitrary amounts of memory.
+const maxLastHintSize = 1 << 24
+
+// Serialize writes the state to w in the binary state format:
+//
+//	magic     [4]byte "cnns"
+//	version   uint8
+//	registers PreimageKey, PreimageOffset, PC, NextPC, LO, HI, Heap, ExitCode, Exited, Step, Registers
+//	lastHint  uint32 length, followed by the hint bytes
+//	memory    see [Memory.Serialize]
+//
+// All integers are big-endian. The pages are written one at a time, so the state is never held in memory twice.
+func (s *State) Serialize(w io.Writer) error {
+	out := make([]byte, 0, 4+1+32+4*6+1+1+8+32*4+4)
+	out = append(out, StateBinaryMagic[:]...)
+	out = append(out, StateBinaryVersion)
+	out = append(out, s.PreimageKey[:]...)
+	out = binary.BigEndian.AppendUint32(out, s.PreimageOffset)
+	out = binary.BigEndian.AppendUint32(out, s.PC)
+	out = binary.BigEndian.AppendUint32(out, s.NextPC)
+	out = binary.BigEndian.AppendUint32(out, s.LO)
+	out = binary.BigEndian.AppendUint32(out, s.HI)
+	out = binary.BigEndian.AppendUint32(out, s.Heap)
+	out = append(out, s.ExitCode)
+	if s.Exited {
+		out = append(out, 1)
+	} else {
+		out = append(out, 0)
+	}
+	out = binary.BigEndian.AppendUint64(out, s.Step)
+	for _, r := range s.Registers {
+		out = binary.BigEndian.AppendUint32(out, r)
+	}
+	out = binary.BigEndian.AppendUint32(out, uint32(len(s.LastHint)))
+	if _, err := w.Write(out); err != nil {
+		return err
+	}
+	if _, err := w.Write(s.LastHint); err != nil {
+		return err
+	}
+	return s.Memory.Serialize(w)
+}
+
+// Deserialize reads a state in the binary state format written by [State.Serialize] from r.
+func (s *State) Deserialize(r io.Reader) error {
+	if err := s.deserializeFields(r); err != nil {
+		return err
+	}
+	s.Memory = NewMemory()
+	return s.Memory.Deserialize(r)
+}
+
+// deserializeFields reads the state in the binary state format up to the memory from r.
+func (s *State) deserializeFields(r io.Reader) error {
+	var header [5]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return fmt.Errorf("failed to read header: %w", err)
+	}
+	if !bytes.Equal(header[:4], StateBinaryMagic[:]) {
+		return errors.New("not a binary state")
+	}
+	if header[4] != StateBinaryVersion {
+		return fmt.Errorf("unsupported binary state version %d, expected %d", header[4], StateBinaryVersion)
+	}
+	var fields [32 + 4*6 + 1 + 1 + 8 + 32*4 + 4]byte
+	if _, err := io.ReadFull(r, fields[:]); err != nil {
+		return fmt.Errorf("failed to read state fields: %w", err)
+	}
+	in := fields[:]
+	next := func(n int) []byte {
+		v := in[:n]
+		in = in[n:]
+		return v
+	}
+	copy(s.PreimageKey[:], next(32))
+	s.PreimageOffset = binary.BigEndian.Uint32(next(4))
+	s.PC = binary.BigEndian.Uint32(next(4))
+	s.NextPC = binary.BigEndian.Uint32(next(4))
+	s.LO = binary.BigEndian.Uint32(next(4))
+	s.HI = binary.BigEndian.Uint32(next(4))
+	s.Heap = binary.BigEndian.Uint32(next(4))
+	s.ExitCode = next(1)[0]
+	switch exited := next(1)[0]; exited {
+	case 0:
+		s.Exited = false
+	case 1:
+		s.Exited = true
+	default:
+		return fmt.Errorf("invalid exited flag %d", exited)
+	}
+	s.Step = binary.BigEndian.Uint64(next(8))
+	for i := range s.Registers {
+		s.Registers[i] = binary.BigEndian.Uint32(next(4))
+	}
+	hintLen := binary.BigEndian.Uint32(next(4))
+	if hintLen > maxLastHintSize {
+		return fmt.Errorf("last hint of %d bytes is too large", hintLen)
+	}
+	s.LastHint = nil
+	if hintLen > 0 {
+		s.LastHint = make([]byte, hintLen)
+		if _, err := io.ReadFull(r, s.LastHint); err != nil {
+			return fmt.Errorf("failed to read last hint: %w", err)
+		}
+	}
+	return nil
+}
+
+// ReadStateWitness reads a state in the binary state format written by [State.Serialize] from r,
+// and returns its encoded witness. The memory is merkleized while it is read, see [ReadMemoryMerkleRoot].
+func ReadStateWitness(r io.Reader) ([]byte, error) {
+	var s State
+	if err := s.deserializeFields(r); err != nil {
+		return nil, err
+	}
+	memRoot, err := ReadMemoryMerkleRoot(r)
+	if err != nil {
+		return nil, err
+	}
+	return s.encodeWitness(memRoot), nil
+}
+
+// Serialize writes the memory pages to w, as the big-endian uint32 page count,
+// followed by the big-endian uint32 index and the data of each page, in order of page index.
+func (m *Memory) Serialize(w io.Writer) error {
+	indices := make([]uint32, 0, len(m.pages))
+	for k := range m.pages {
+		indices = append(indices, k)
+	}
+	sort.Slice(indices, func(i, j int) bool {
+		return indices[i] < indices[j]
+	})
+	if _, err := w.Write(binary.BigEndian.AppendUint32(nil, uint32(len(indices)))); err != nil {
+		return err
+	}
+	for _, k := range indices {
+		if _, err := w.Write(binary.BigEndian.AppendUint32(nil, k)); err != nil {
+			return err
+		}
+		if _, err := w.Write(m.pages[k].Data[:]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Deserialize replaces the memory with the pages written by [Memory.Serialize], read from r.
+// Each page is read directly into its allocated page.
+func (m *Memory) Deserialize(r io.Reader) error {
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return fmt.Errorf("failed to read page count: %w", err)
+	}
+	count := binary.BigEndian.Uint32(buf[:])
+	if count > MaxPageCount {
+		return fmt.Errorf("page count %d exceeds max page count %d", count, MaxPageCount)
+	}
+	m.nodes = make(map[uint64]*[32]byte)
+	m.pages = make(map[uint32]*CachedPage)
+	m.lastPageKeys = [2]uint32{^uint32(0), ^uint32(0)}
+	m.lastPage = [2]*CachedPage{nil, nil}
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return fmt.Errorf("failed to read index of page entry %d: %w", i, err)
+		}
+		pageIndex := binary.BigEndian.Uint32(buf[:])
+		if pageIndex >= MaxPageCount {
+			return fmt.Errorf("invalid page index %d, entry %d", pageIndex, i)
+		}
+		if _, ok := m.pages[pageIndex]; ok {
+			return fmt.Errorf("cannot load duplicate page, entry %d, page index %d", i, pageIndex)
+		}
+		if _, err := io.ReadFull(r, m.AllocPage(pageIndex).Data[:]); err != nil {
+			return fmt.Errorf("failed to read page %d: %w", pageIndex, err)
+		}
+	}
+	return nil
+}
+
+// ReadMemoryMerkleRoot reads the pages written by [Memory.Serialize] from r, and returns the merkle root of the memory.
+// The pages are merkleized as they are read, so only a single page is held in memory.
+func ReadMemoryMerkleRoot(r io.Reader) ([32]byte, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return [32]byte{}, fmt.Errorf("failed to read page count: %w", err)
+	}
+	count := binary.BigEndian.Uint32(buf[:])
+	if count > MaxPageCount {
+		return [32]byte{}, fmt.Errorf("page count %d exceeds max page count %d", count, MaxPageCount)
+	}
+	var merkleizer memoryMerkleizer
+	page := &CachedPage{Data: new(Page)}
+	for i := uint32(0); i < count; i++ {
+		if _, err := io.ReadFull(r, buf[:]); err != nil {
+			return [32]byte{}, fmt.Errorf("failed to read index of page entry %d: %w", i, err)
+		}
+		pageIndex := binary.BigEndian.Uint32(buf[:])
+		if pageIndex >= MaxPageCount {
+			return [32]byte{}, fmt.Errorf("invalid page index %d, entry %d", pageIndex, i)
+		}
+		if uint64(pageIndex) < merkleizer.next {
+			return [32]byte{}, fmt.Errorf("page index %d out of order, entry %d", pageIndex, i)
+		}
+		if _, err := io.ReadFull(r, page.Data[:]); err != nil {
+			return [32]byte{}, fmt.Errorf("failed to read page %d: %w", pageIndex, err)
+		}
+		page.InvalidateFull()
+		merkleizer.add(pageIndex, page.MerkleRoot())
+	}
+	return merkleizer.root(), nil
+}
+
+// memoryMerkleizer computes the merkle root of the memory from the roots of its pages, added in order of page index.
+// It only holds the left siblings of the path to the next page.
+type memoryMerkleizer struct {
+	// next is the index of the next page, the pages before it are merkleized
+	next uint64
+	// branch[l] is the root of the left sibling at l levels above the pages, of the path to the next page
+	branch [PageKeySize][32]byte
+	// memRoot is the merkle root of the memory, once all pages are merkleized
+	memRoot [32]byte
+}
+
+// add merkleizes the page with the given root at pageIndex, after the empty pages skipped before it.
+func (m *memoryMerkleizer) add(pageIndex uint32, pageRoot [32]byte) {
+	m.skipTo(uint64(pageIndex))
+	m.push(0, pageRoot)
+}
+
+// skipTo merkleizes the pages up to pageIndex as empty pages, in the largest empty subtrees that fit.
+func (m *memoryMerkleizer) skipTo(pageIndex uint64) {
+	for m.next < pageIndex {
+		level := bits.TrailingZeros64(m.next)
+		if level > PageKeySize {
+			level = PageKeySize
+		}
+		for m.next+(1<<level) > pageIndex {
+			level--
+		}
+		m.push(level, zeroHashes[PageAddrSize-5+level])
+	}
+}
+
+// push merkleizes the subtree of 2**level pages with the given root at the next page, which must be aligned to it.
+func (m *memoryMerkleizer) push(level int, node [32]byte) {
+	index := m.next >> level
+	m.next += 1 << level
+	for ; level < PageKeySize; level++ {
+		if index&1 == 0 {
+			m.branch[level] = node
+			return
+		}
+		node = HashPair(m.branch[level], node)
+		index >>= 1
+	}
+	m.memRoot = node
+}
+
+// root returns the merkle root of the memory, with the pages after the last added page empty.
+func (m *memoryMerkleizer) root() [32]byte {
+	m.skipTo(MaxPageCount)
+	return m.memRoot
+}
+
+// stateFile is a state file opened for reading, with the gzip compression of the content detected.
+type stateFile struct {
+	f *os.File
+	g *gzip.Reader
+	r *bufio.Reader
+}
+
+func openStateFile(path string) (*stateFile, error) {
+	if path == "" {
+		return nil, errors.New("no path specified")
+	}
+	f, err := os.OpenFile(path, os.O_RDONLY, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", path, err)
+	}
+	file := &stateFile{f: f, r: bufio.NewReaderSize(f, 1<<20)}
+	if file.isPrefixed(gzipMagic) {
+		file.g, err = gzip.NewReader(file.r)
+		if err != nil {
+			_ = f.Close()
+			return nil, fmt.Errorf("create gzip reader: %w", err)
+		}
+		file.r = bufio.NewReaderSize(file.g, 1<<20)
+	}
+	return file, nil
+}
+
+// isBinary returns true if the state is in the binary state format.
+func (s *stateFile) isBinary() bool {
+	return s.isPrefixed(StateBinaryMagic[:])
+}
+
+func (s *stateFile) isPrefixed(prefix []byte) bool {
+	dat, err := s.r.Peek(len(prefix))
+	return err == nil && bytes.Equal(dat, prefix)
+}
+
+func (s *stateFile) Close() error {
+	if s.g != nil {
+		_ = s.g.Close()
+	}
+	return s.f.Close()
+}
+
+// readState reads the state, in the binary state format or as JSON.
+func (s *stateFile) readState() (*State, error) {
+	var state State
+	if s.isBinary() {
+		if err := state.Deserialize(s.r); err != nil {
+			return nil, err
+		}
+		return &state, nil
+	}
+	if err := json.NewDecoder(s.r).Decode(&state); err != nil {
+		return nil, err
+	}
+	return &state, nil
+}
+
+// LoadState reads a state from the file at path, in the binary state format or as JSON, optionally gzip compressed.
+// The format is detected from the content of the file, not its name.
+func LoadState(path string) (*State, error) {
+	file, err := openStateFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	state, err := file.readState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file %q: %w", path, err)
+	}
+	return state, nil
+}
+
+// LoadStateWitness reads a state from the file at path like [LoadState], and returns its encoded witness.
+// States in the binary state format are merkleized while they are read, without loading the memory.
+func LoadStateWitness(path string) ([]byte, error) {
+	file, err := openStateFile(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if file.isBinary() {
+		witness, err := ReadStateWitness(file.r)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode file %q: %w", path, err)
+		}
+		return witness, nil
+	}
+	state, err := file.readState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode file %q: %w", path, err)
+	}
+	return state.EncodeWitness(), nil
+}
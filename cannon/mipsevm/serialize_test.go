@@ -0,0 +1,246 @@
+package mipsevm
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func serializeTestState() *State {
+	state := &State{
+		Memory:         NewMemory(),
+		PreimageKey:    common.Hash{0xaa},
+		PreimageOffset: 12,
+		PC:             0x1000,
+		NextPC:         0x1004,
+		LO:             1,
+		HI:             2,
+		Heap:           0x2000_0000,
+		ExitCode:       3,
+		Exited:         true,
+		Step:           987654321,
+		LastHint:       []byte{0, 0, 0, 2, 0xbb, 0xcc},
+	}
+	for i := range state.Registers {
+		state.Registers[i] = uint32(i * 7)
+	}
+	state.Memory.SetMemory(8, 123)
+	state.Memory.SetMemory(0x7fff_fff0, 456)
+	state.Memory.SetMemory(0xffff_fffc, 789)
+	return state
+}
+
+func TestStateSerialize(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		state := serializeTestState()
+		var buf bytes.Buffer
+		require.NoError(t, state.Serialize(&buf))
+
+		var result State
+		require.NoError(t, result.Deserialize(&buf))
+		require.Zero(t, buf.Len(), "should read the whole state")
+		require.Equal(t, state.EncodeWitness(), result.EncodeWitness())
+		require.Equal(t, state.Memory.MerkleRoot(), result.Memory.MerkleRoot())
+		require.Equal(t, state.Registers, result.Registers)
+		require.Equal(t, state.LastHint, result.LastHint)
+		require.Equal(t, uint32(456), result.Memory.GetMemory(0x7fff_fff0))
+	})
+
+	t.Run("MatchesJSON", func(t *testing.T) {
+		state := serializeTestState()
+		var buf bytes.Buffer
+		require.NoError(t, state.Serialize(&buf))
+		var fromBinary State
+		require.NoError(t, fromBinary.Deserialize(&buf))
+
+		expected, err := json.Marshal(state)
+		require.NoError(t, err)
+		actual, err := json.Marshal(&fromBinary)
+		require.NoError(t, err)
+		require.JSONEq(t, string(expected), string(actual))
+	})
+
+	t.Run("Deterministic", func(t *testing.T) {
+		var a, b bytes.Buffer
+		require.NoError(t, serializeTestState().Serialize(&a))
+		require.NoError(t, serializeTestState().Serialize(&b))
+		require.Equal(t, a.Bytes(), b.Bytes())
+	})
+
+	t.Run("EmptyLastHint", func(t *testing.T) {
+		state := serializeTestState()
+		state.LastHint = nil
+		var buf bytes.Buffer
+		require.NoError(t, state.Serialize(&buf))
+		var result State
+		require.NoError(t, result.Deserialize(&buf))
+		require.Nil(t, result.LastHint)
+	})
+
+	t.Run("RejectsJSON", func(t *testing.T) {
+		dat, err := json.Marshal(serializeTestState())
+		require.NoError(t, err)
+		var result State
+		require.ErrorContains(t, result.Deserialize(bytes.NewReader(dat)), "not a binary state")
+	})
+
+	t.Run("RejectsUnknownVersion", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, serializeTestState().Serialize(&buf))
+		dat := buf.Bytes()
+		dat[4] = StateBinaryVersion + 1
+		var result State
+		require.ErrorContains(t, result.Deserialize(bytes.NewReader(dat)), "unsupported binary state version")
+	})
+
+	t.Run("Truncated", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, serializeTestState().Serialize(&buf))
+		dat := buf.Bytes()
+		var result State
+		require.Error(t, result.Deserialize(bytes.NewReader(dat[:len(dat)-1])))
+	})
+
+	t.Run("DuplicatePage", func(t *testing.T) {
+		m := NewMemory()
+		m.SetMemory(8, 123)
+		var buf bytes.Buffer
+		require.NoError(t, m.Serialize(&buf))
+		dat := buf.Bytes()
+		// Repeat the single page entry and bump the page count
+		dat = append(dat, dat[4:]...)
+		dat[3] = 2
+		var result Memory
+		require.ErrorContains(t, result.Deserialize(bytes.NewReader(dat)), "duplicate page")
+	})
+}
+
+func TestReadMemoryMerkleRoot(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		m := NewMemory()
+		var buf bytes.Buffer
+		require.NoError(t, m.Serialize(&buf))
+		root, err := ReadMemoryMerkleRoot(&buf)
+		require.NoError(t, err)
+		require.Equal(t, m.MerkleRoot(), root)
+	})
+
+	t.Run("FirstAndLastPage", func(t *testing.T) {
+		m := NewMemory()
+		m.SetMemory(0, 1)
+		m.SetMemory(0xffff_fffc, 2)
+		var buf bytes.Buffer
+		require.NoError(t, m.Serialize(&buf))
+		root, err := ReadMemoryMerkleRoot(&buf)
+		require.NoError(t, err)
+		require.Equal(t, m.MerkleRoot(), root)
+	})
+
+	t.Run("RandomPages", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1234))
+		for i := 0; i < 20; i++ {
+			m := NewMemory()
+			for j := rng.Intn(50); j > 0; j-- {
+				// Cluster some of the pages, to merkleize both neighbouring and distant pages
+				addr := rng.Uint32() &^ 3
+				if rng.Intn(2) == 0 {
+					addr &= 0x0003_fffc
+				}
+				m.SetMemory(addr, rng.Uint32())
+			}
+			var buf bytes.Buffer
+			require.NoError(t, m.Serialize(&buf))
+			root, err := ReadMemoryMerkleRoot(&buf)
+			require.NoError(t, err)
+			require.Equal(t, m.MerkleRoot(), root)
+			require.Zero(t, buf.Len(), "should read the whole memory")
+		}
+	})
+
+	t.Run("PageOutOfOrder", func(t *testing.T) {
+		m := NewMemory()
+		m.SetMemory(8, 123)
+		m.SetMemory(0x1008, 456)
+		var buf bytes.Buffer
+		require.NoError(t, m.Serialize(&buf))
+		dat := buf.Bytes()
+		// Swap the two page entries
+		entry := 4 + PageSize
+		swapped := append(append(append([]byte{}, dat[:4]...), dat[4+entry:]...), dat[4:4+entry]...)
+		_, err := ReadMemoryMerkleRoot(bytes.NewReader(swapped))
+		require.ErrorContains(t, err, "out of order")
+	})
+}
+
+func TestReadStateWitness(t *testing.T) {
+	state := serializeTestState()
+	var buf bytes.Buffer
+	require.NoError(t, state.Serialize(&buf))
+	witness, err := ReadStateWitness(&buf)
+	require.NoError(t, err)
+	require.Equal(t, state.EncodeWitness(), witness)
+}
+
+func TestLoadState(t *testing.T) {
+	writeBinary := func(w io.Writer, state *State) error { return state.Serialize(w) }
+	writeJSON := func(w io.Writer, state *State) error { return json.NewEncoder(w).Encode(state) }
+	tests := []struct {
+		name  string
+		write func(w io.Writer, state *State) error
+		gzip  bool
+	}{
+		{name: "JSON", write: writeJSON},
+		{name: "GzipJSON", write: writeJSON, gzip: true},
+		{name: "Binary", write: writeBinary},
+		{name: "GzipBinary", write: writeBinary, gzip: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			// The format is detected from the content, so the name of the file does not matter
+			path := filepath.Join(t.TempDir(), "state")
+			var buf bytes.Buffer
+			if test.gzip {
+				g := gzip.NewWriter(&buf)
+				require.NoError(t, test.write(g, serializeTestState()))
+				require.NoError(t, g.Close())
+			} else {
+				require.NoError(t, test.write(&buf, serializeTestState()))
+			}
+			require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o644))
+
+			state, err := LoadState(path)
+			require.NoError(t, err)
+			require.Equal(t, serializeTestState().EncodeWitness(), state.EncodeWitness())
+			require.Equal(t, serializeTestState().LastHint, state.LastHint)
+
+			witness, err := LoadStateWitness(path)
+			require.NoError(t, err)
+			require.Equal(t, serializeTestState().EncodeWitness(), witness)
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state.json")
+		require.NoError(t, os.WriteFile(path, []byte("{invalid"), 0o644))
+		_, err := LoadState(path)
+		require.ErrorContains(t, err, "failed to decode file")
+		_, err = LoadStateWitness(path)
+		require.ErrorContains(t, err, "failed to decode file")
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := LoadState(filepath.Join(t.TempDir(), "state.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+		_, err = LoadStateWitness(filepath.Join(t.TempDir(), "state.json"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
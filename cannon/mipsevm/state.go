@@ -38,8 +38,11 @@ type State struct {
 }
 
 func (s *State) EncodeWitness() []byte {
+	return s.encodeWitness(s.Memory.MerkleRoot())
+}
+
+func (s *State) encodeWitness(memRoot [32]byte) []byte {
 	out := make([]byte, 0)
-	memRoot := s.Memory.MerkleRoot()
 	out = append(out, memRoot[:]...)
 	out = append(out, s.PreimageKey[:]...)
 	out = binary.BigEndian.AppendUint32(out, s.PreimageOffset)
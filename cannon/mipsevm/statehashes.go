@@ -0,0 +1,113 @@
+package mipsevm
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// stateHashesHeaderSize is the size of the header of a state hashes file, the big-endian uint64 first step.
+const stateHashesHeaderSize = 8
+
+// StateHashWriter writes the post-state hash of each executed step to a compact file.
+// The file starts with the first step as a big-endian uint64, followed by the 32 byte post-state hash of each step,
+// so the hash of any step can be read at a fixed offset.
+type StateHashWriter struct {
+	f *os.File
+	w *bufio.Writer
+}
+
+// NewStateHashWriter creates the state hashes file at path, for the steps starting at firstStep.
+func NewStateHashWriter(path string, firstStep uint64) (*StateHashWriter, error) {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state hashes file: %w", err)
+	}
+	w := bufio.NewWriterSize(f, 1<<20)
+	if _, err := w.Write(binary.BigEndian.AppendUint64(nil, firstStep)); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to write state hashes header: %w", err)
+	}
+	return &StateHashWriter{f: f, w: w}, nil
+}
+
+// Write appends the post-state hash of the next step.
+func (s *StateHashWriter) Write(hash common.Hash) error {
+	_, err := s.w.Write(hash[:])
+	return err
+}
+
+func (s *StateHashWriter) Close() error {
+	if err := s.w.Flush(); err != nil {
+		_ = s.f.Close()
+		return fmt.Errorf("failed to flush state hashes: %w", err)
+	}
+	return s.f.Close()
+}
+
+// StateHashes reads a state hashes file written by [StateHashWriter].
+type StateHashes struct {
+	f         *os.File
+	firstStep uint64
+	count     uint64
+}
+
+// OpenStateHashes opens the state hashes file at path.
+// A partially written trailing hash, e.g. of an interrupted run, is ignored.
+func OpenStateHashes(path string) (*StateHashes, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state hashes file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to stat state hashes file: %w", err)
+	}
+	var header [stateHashesHeaderSize]byte
+	if _, err := io.ReadFull(f, header[:]); err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to read state hashes header: %w", err)
+	}
+	return &StateHashes{
+		f:         f,
+		firstStep: binary.BigEndian.Uint64(header[:]),
+		count:     uint64(info.Size()-stateHashesHeaderSize) / 32,
+	}, nil
+}
+
+// FirstStep returns the first step with a recorded post-state hash.
+func (s *StateHashes) FirstStep() uint64 {
+	return s.firstStep
+}
+
+// Count returns the number of steps with a recorded post-state hash.
+func (s *StateHashes) Count() uint64 {
+	return s.count
+}
+
+// Contains returns true if the post-state hash of step is recorded.
+func (s *StateHashes) Contains(step uint64) bool {
+	return step >= s.firstStep && step-s.firstStep < s.count
+}
+
+// PostStateHash returns the recorded hash of the state after executing step.
+func (s *StateHashes) PostStateHash(step uint64) (common.Hash, error) {
+	if !s.Contains(step) {
+		return common.Hash{}, errors.New("step not recorded")
+	}
+	var hash common.Hash
+	if _, err := s.f.ReadAt(hash[:], stateHashesHeaderSize+int64(step-s.firstStep)*32); err != nil {
+		return common.Hash{}, fmt.Errorf("failed to read post-state hash of step %d: %w", step, err)
+	}
+	return hash, nil
+}
+
+func (s *StateHashes) Close() error {
+	return s.f.Close()
+}
@@ -0,0 +1,54 @@
+package mipsevm
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateHashes(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashes.bin")
+	w, err := NewStateHashWriter(path, 100)
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		require.NoError(t, w.Write(common.Hash{byte(i + 1)}))
+	}
+	require.NoError(t, w.Close())
+
+	hashes, err := OpenStateHashes(path)
+	require.NoError(t, err)
+	defer hashes.Close()
+	require.Equal(t, uint64(100), hashes.FirstStep())
+	require.Equal(t, uint64(5), hashes.Count())
+	require.False(t, hashes.Contains(99))
+	require.False(t, hashes.Contains(105))
+	for i := uint64(0); i < 5; i++ {
+		require.True(t, hashes.Contains(100+i))
+		hash, err := hashes.PostStateHash(100 + i)
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{byte(i + 1)}, hash)
+	}
+	_, err = hashes.PostStateHash(105)
+	require.Error(t, err)
+}
+
+func TestStateHashesIgnorePartialHash(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashes.bin")
+	w, err := NewStateHashWriter(path, 0)
+	require.NoError(t, err)
+	require.NoError(t, w.Write(common.Hash{0xaa}))
+	require.NoError(t, w.Close())
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0xbb, 0xbb})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	hashes, err := OpenStateHashes(path)
+	require.NoError(t, err)
+	defer hashes.Close()
+	require.Equal(t, uint64(1), hashes.Count())
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/opio"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
 	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 // Main is the entrypoint into the Batch Submitter. This method returns a
@@ -88,6 +89,9 @@ func Main(version string, cliCtx *cli.Context) error {
 		})
 		l.Info("Admin RPC enabled")
 	}
+	if r, ok := batchSubmitter.TxManager.(txmgr.PendingTxReporter); ok {
+		server.AddAPI(txmgr.NewDebugAPI(r))
+	}
 	if err := server.Start(); err != nil {
 		cancel()
 		return fmt.Errorf("error starting RPC server: %w", err)
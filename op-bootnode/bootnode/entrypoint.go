@@ -25,6 +25,10 @@ func (g *gossipNoop) OnUnsafeL2Payload(_ context.Context, _ peer.ID, _ *eth.Exec
 	return nil
 }
 
+func (g *gossipNoop) OnTxPreconfirmation(_ context.Context, _ peer.ID, _ *eth.TxPreconfirmation) error {
+	return nil
+}
+
 type gossipConfig struct{}
 
 func (g *gossipConfig) P2PSequencerAddress() common.Address {
@@ -37,6 +41,10 @@ func (l *l2Chain) PayloadByNumber(_ context.Context, _ uint64) (*eth.ExecutionPa
 	return nil, nil
 }
 
+func (l *l2Chain) PayloadByHash(_ context.Context, _ common.Hash) (*eth.ExecutionPayload, error) {
+	return nil, nil
+}
+
 func Main(cliCtx *cli.Context) error {
 	log.Info("Initializing bootnode")
 	logCfg := oplog.ReadCLIConfig(cliCtx)
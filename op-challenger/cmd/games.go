@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+var ListGamesCommand = &cli.Command{
+	Name:        "list-games",
+	Usage:       "Lists the games recorded in the challenger datadir",
+	Description: "Lists the games recorded in the challenger datadir. A running challenger holds the lock of its datadir, so use --challenger-rpc to read the games from a challenger started with --rpc.enabled instead.",
+	Flags:       []cli.Flag{flags.DatadirFlag, flags.ChallengerRpcFlag},
+	Action:      listGames,
+}
+
+var ShowGameCommand = &cli.Command{
+	Name:        "show-game",
+	Usage:       "Shows the recorded history of a game",
+	Description: "Shows the claims, actions and proofs recorded for a game in the challenger datadir. A running challenger holds the lock of its datadir, so use --challenger-rpc to read the game from a challenger started with --rpc.enabled instead.",
+	Flags:       []cli.Flag{flags.DatadirFlag, flags.ChallengerRpcFlag, flags.DGFAddressFlag},
+	Action:      showGame,
+}
+
+// historySource reads the recorded history of the games, from the datadir or from a running challenger.
+type historySource interface {
+	Games() ([]db.Game, error)
+	History(addr common.Address) (db.GameHistory, error)
+	Close() error
+}
+
+func listGames(ctx *cli.Context) error {
+	source, err := openHistory(ctx)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	games, err := source.Games()
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(ctx.App.Writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Game\tFirst Seen\tAgree With Output\tStatus\tClaims\tActions")
+	for _, game := range games {
+		history, err := source.History(game.Address)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%d\t%d\n", game.Address, formatTime(game.FirstSeen), game.AgreeWithProposedOutput,
+			fault.GameStatusString(game.Status), len(history.Claims), len(history.Actions))
+	}
+	return w.Flush()
+}
+
+func showGame(ctx *cli.Context) error {
+	if !ctx.IsSet(flags.DGFAddressFlag.Name) {
+		return fmt.Errorf("flag %s is required", flags.DGFAddressFlag.Name)
+	}
+	addr, err := opservice.ParseAddress(ctx.String(flags.DGFAddressFlag.Name))
+	if err != nil {
+		return err
+	}
+	source, err := openHistory(ctx)
+	if err != nil {
+		return err
+	}
+	defer source.Close()
+	history, err := source.History(addr)
+	if errors.Is(err, db.ErrNotFound) {
+		return fmt.Errorf("game %v not found", addr)
+	} else if err != nil {
+		return err
+	}
+	game, claims, actions, proofs := history.Game, history.Claims, history.Actions, history.Proofs
+
+	out := ctx.App.Writer
+	fmt.Fprintf(out, "Game:              %v\n", game.Address)
+	fmt.Fprintf(out, "Root claim:        %v\n", game.RootClaim)
+	fmt.Fprintf(out, "First seen:        %v\n", formatTime(game.FirstSeen))
+	fmt.Fprintf(out, "Agree with output: %v\n", game.AgreeWithProposedOutput)
+	fmt.Fprintf(out, "Status:            %v\n", fault.GameStatusString(game.Status))
+
+	fmt.Fprintf(out, "\nClaims (%d):\n", len(claims))
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Idx\tParent\tGIndex\tValue\tCountered\tClock Duration\tClock Timestamp")
+	for _, claim := range claims {
+		fmt.Fprintf(w, "%d\t%d\t%d\t%v\t%v\t%ds\t%v\n", claim.ContractIndex, claim.ParentContractIndex, claim.GIndex, claim.Value,
+			claim.Countered, claim.ClockDuration, formatTime(claim.ClockTimestamp))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nActions (%d):\n", len(actions))
+	if err := writeActions(out, actions); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\nProofs (%d):\n", len(proofs))
+	w = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Time\tTrace Index\tClaim Value\tDir")
+	for _, proof := range proofs {
+		fmt.Fprintf(w, "%v\t%d\t%v\t%v\n", formatTime(proof.Time), proof.TraceIndex, proof.ClaimValue, proof.Dir)
+	}
+	return w.Flush()
+}
+
+func writeActions(out io.Writer, actions []db.Action) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Time\tType\tDetails\tTx Hash\tSuccess")
+	for _, action := range actions {
+		var details string
+		switch action.Type {
+		case db.ActionAttack, db.ActionDefend:
+			details = fmt.Sprintf("claim=%d value=%v bond=%v", action.ClaimIndex, action.Value, action.Bond.ToInt())
+		case db.ActionStep:
+			details = fmt.Sprintf("claim=%d", action.ClaimIndex)
+		case db.ActionPreimage:
+			details = fmt.Sprintf("key=%v offset=%d", action.OracleKey, action.OracleOffset)
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\n", formatTime(action.Time), action.Type, details, action.TxHash, action.Success)
+	}
+	return w.Flush()
+}
+
+// openHistory connects to the running challenger if its RPC is set,
+// or otherwise opens the challenger database in the datadir read-only.
+func openHistory(ctx *cli.Context) (historySource, error) {
+	if ctx.IsSet(flags.ChallengerRpcFlag.Name) {
+		client, err := rpc.DialContext(ctx.Context, ctx.String(flags.ChallengerRpcFlag.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial challenger rpc: %w", err)
+		}
+		return &rpcHistory{client: client}, nil
+	}
+	if !ctx.IsSet(flags.DatadirFlag.Name) {
+		return nil, fmt.Errorf("flag %s or %s is required", flags.DatadirFlag.Name, flags.ChallengerRpcFlag.Name)
+	}
+	return db.Open(filepath.Join(ctx.String(flags.DatadirFlag.Name), fault.DBDir), true)
+}
+
+// rpcHistory reads the history of the games from the RPC server of a running challenger.
+type rpcHistory struct {
+	client *rpc.Client
+}
+
+func (h *rpcHistory) Games() ([]db.Game, error) {
+	var games []db.Game
+	if err := h.client.Call(&games, db.Namespace+"_games"); err != nil {
+		return nil, fmt.Errorf("failed to fetch games: %w", err)
+	}
+	return games, nil
+}
+
+func (h *rpcHistory) History(addr common.Address) (db.GameHistory, error) {
+	var history db.GameHistory
+	if err := h.client.Call(&history, db.Namespace+"_gameHistory", addr); err != nil {
+		return db.GameHistory{}, fmt.Errorf("failed to fetch history of game %v: %w", addr, err)
+	}
+	return history, nil
+}
+
+func (h *rpcHistory) Close() error {
+	h.client.Close()
+	return nil
+}
+
+func formatTime(unix uint64) string {
+	return time.Unix(int64(unix), 0).UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestListGames(t *testing.T) {
+	t.Run("RequireDatadir", func(t *testing.T) {
+		_, err := runCommand(t, "list-games")
+		require.ErrorContains(t, err, "flag datadir or challenger-rpc is required")
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		datadir := setupGamesDB(t)
+		out, err := runCommand(t, "list-games", "--datadir", datadir)
+		require.NoError(t, err)
+		require.Contains(t, out, common.HexToAddress(gameAddressValue).Hex())
+		require.Contains(t, out, "Challenger Won")
+	})
+
+	t.Run("RunningChallenger", func(t *testing.T) {
+		datadir, rpcURL := setupRunningChallenger(t)
+		_, err := runCommand(t, "list-games", "--datadir", datadir)
+		require.Error(t, err, "db is locked by the running challenger")
+
+		out, err := runCommand(t, "list-games", "--challenger-rpc", rpcURL)
+		require.NoError(t, err)
+		require.Contains(t, out, common.HexToAddress(gameAddressValue).Hex())
+		require.Contains(t, out, "Challenger Won")
+	})
+}
+
+func TestShowGame(t *testing.T) {
+	datadir := setupGamesDB(t)
+
+	t.Run("RequireGameAddress", func(t *testing.T) {
+		_, err := runCommand(t, "show-game", "--datadir", datadir)
+		require.ErrorContains(t, err, "flag game-address is required")
+	})
+
+	t.Run("UnknownGame", func(t *testing.T) {
+		_, err := runCommand(t, "show-game", "--datadir", datadir, "--game-address", preimageOracleAddressValue)
+		require.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		out, err := runCommand(t, "show-game", "--datadir", datadir, "--game-address", gameAddressValue)
+		require.NoError(t, err)
+		require.Contains(t, out, "Claims (2)")
+		require.Contains(t, out, common.Hash{0xbb}.Hex())
+		require.Contains(t, out, "Actions (1)")
+		require.Contains(t, out, common.Hash{0x01}.Hex())
+		require.Contains(t, out, "Proofs (1)")
+	})
+
+	t.Run("RunningChallenger", func(t *testing.T) {
+		_, rpcURL := setupRunningChallenger(t)
+		out, err := runCommand(t, "show-game", "--challenger-rpc", rpcURL, "--game-address", gameAddressValue)
+		require.NoError(t, err)
+		require.Contains(t, out, "Claims (2)")
+		require.Contains(t, out, common.Hash{0xbb}.Hex())
+		require.Contains(t, out, "Actions (1)")
+		require.Contains(t, out, "Proofs (1)")
+
+		_, err = runCommand(t, "show-game", "--challenger-rpc", rpcURL, "--game-address", preimageOracleAddressValue)
+		require.ErrorContains(t, err, "not found")
+	})
+}
+
+// setupRunningChallenger opens the games db like a running challenger, which holds its lock,
+// and serves its history over RPC.
+func setupRunningChallenger(t *testing.T) (string, string) {
+	datadir := setupGamesDB(t)
+	store, err := db.Open(filepath.Join(datadir, fault.DBDir), false)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, store.Close()) })
+	server := rpc.NewServer()
+	api := db.NewAPI(store)
+	require.NoError(t, server.RegisterName(api.Namespace, api.Service))
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(httpServer.Close)
+	t.Cleanup(server.Stop)
+	return datadir, httpServer.URL
+}
+
+func setupGamesDB(t *testing.T) string {
+	datadir := t.TempDir()
+	store, err := db.Open(filepath.Join(datadir, fault.DBDir), false)
+	require.NoError(t, err)
+	defer store.Close()
+	addr := common.HexToAddress(gameAddressValue)
+	require.NoError(t, store.PutGame(db.Game{Address: addr, RootClaim: common.Hash{0xaa}, FirstSeen: 1000, Status: 1}))
+	require.NoError(t, store.PutClaims(addr, []db.Claim{
+		{ContractIndex: 0, Value: common.Hash{0xaa}},
+		{ContractIndex: 1, Value: common.Hash{0xbb}, GIndex: 2},
+	}))
+	require.NoError(t, store.AddAction(addr, db.Action{Type: db.ActionAttack, Value: common.Hash{0xbb}, TxHash: common.Hash{0x01}, Success: true}))
+	require.NoError(t, store.AddProof(addr, db.Proof{TraceIndex: 4, ClaimValue: common.Hash{0xcc}}))
+	return datadir
+}
+
+func runCommand(t *testing.T, args ...string) (string, error) {
+	var out bytes.Buffer
+	app := cli.NewApp()
+	app.Writer = &out
+	app.Flags = flags.Flags
+	app.Commands = []*cli.Command{ListGamesCommand, ShowGameCommand, SimulateCommand}
+	err := app.Run(append([]string{"op-challenger"}, args...))
+	return out.String(), err
+}
@@ -90,7 +90,7 @@ func TestTraceType(t *testing.T) {
 
 func TestGameAddress(t *testing.T) {
 	t.Run("Required", func(t *testing.T) {
-		verifyArgsInvalid(t, "flag game-address is required", addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-address"))
+		verifyArgsInvalid(t, "flag game-address or game-factory-address is required", addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-address"))
 	})
 
 	t.Run("Valid", func(t *testing.T) {
@@ -104,6 +104,46 @@ func TestGameAddress(t *testing.T) {
 	})
 }
 
+func TestGameFactoryAddress(t *testing.T) {
+	factoryAddr := common.Address{0xdd}
+	factoryArgs := func(args ...string) []string {
+		return addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-address",
+			append([]string{"--game-factory-address=" + factoryAddr.Hex(), "--datadir=./challenger"}, args...)...)
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, factoryArgs())
+		require.Equal(t, factoryAddr, cfg.GameFactoryAddress)
+		require.Equal(t, common.Address{}, cfg.GameAddress)
+		require.Equal(t, "./challenger", cfg.Datadir)
+		require.Equal(t, config.DefaultMaxConcurrency, cfg.MaxConcurrency)
+		require.Equal(t, uint64(0), cfg.GameFactoryStartBlock)
+		require.Equal(t, config.DefaultGameFactoryConfirmations, cfg.GameFactoryConfs)
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		cfg := configForArgs(t, factoryArgs("--max-concurrency=8", "--game-factory-start-block=1234", "--game-factory-confs=3"))
+		require.Equal(t, uint(8), cfg.MaxConcurrency)
+		require.Equal(t, uint64(1234), cfg.GameFactoryStartBlock)
+		require.Equal(t, uint64(3), cfg.GameFactoryConfs)
+	})
+
+	t.Run("NotWithGameAddress", func(t *testing.T) {
+		verifyArgsInvalid(t, "flags game-address and game-factory-address are mutually exclusive",
+			addRequiredArgs(config.TraceTypeAlphabet, "--game-factory-address="+factoryAddr.Hex(), "--datadir=./challenger"))
+	})
+
+	t.Run("DatadirRequired", func(t *testing.T) {
+		verifyArgsInvalid(t, "flag datadir is required",
+			addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-address", "--game-factory-address="+factoryAddr.Hex()))
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		verifyArgsInvalid(t, "invalid address: foo",
+			addRequiredArgsExcept(config.TraceTypeAlphabet, "--game-address", "--game-factory-address=foo", "--datadir=./challenger"))
+	})
+}
+
 func TestTxManagerFlagsSupported(t *testing.T) {
 	// Not a comprehensive list of flags, just enough to sanity check the txmgr.CLIFlags were defined
 	cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--"+txmgr.NumConfirmationsFlagName, "7"))
@@ -112,7 +152,11 @@ func TestTxManagerFlagsSupported(t *testing.T) {
 
 func TestAgreeWithProposedOutput(t *testing.T) {
 	t.Run("MustBeProvided", func(t *testing.T) {
-		verifyArgsInvalid(t, "flag agree-with-proposed-output is required", addRequiredArgsExcept(config.TraceTypeAlphabet, "--agree-with-proposed-output"))
+		verifyArgsInvalid(t, "flag agree-with-proposed-output or rollup-rpc is required", addRequiredArgsExcept(config.TraceTypeAlphabet, "--agree-with-proposed-output"))
+	})
+	t.Run("NotRequiredWithRollupRpc", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgsExcept(config.TraceTypeAlphabet, "--agree-with-proposed-output", "--rollup-rpc=http://localhost:9546"))
+		require.Equal(t, "http://localhost:9546", cfg.RollupRpc)
 	})
 	t.Run("Enabled", func(t *testing.T) {
 		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--agree-with-proposed-output"))
@@ -247,6 +291,42 @@ func TestCannonSnapshotFreq(t *testing.T) {
 	})
 }
 
+func TestCannonCacheSize(t *testing.T) {
+	t.Run("UsesDefault", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon))
+		require.Equal(t, config.DefaultCannonCacheSize, cfg.CannonCacheSize)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeCannon, "--cannon-cache-size=512"))
+		require.Equal(t, uint64(512), cfg.CannonCacheSize)
+	})
+}
+
+func TestDryRun(t *testing.T) {
+	t.Run("DefaultsToFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.DryRun)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--dry-run"))
+		require.True(t, cfg.DryRun)
+	})
+}
+
+func TestRPCEnabled(t *testing.T) {
+	t.Run("DefaultsToFalse", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet))
+		require.False(t, cfg.RPCEnabled)
+	})
+
+	t.Run("Enabled", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs(config.TraceTypeAlphabet, "--rpc.enabled"))
+		require.True(t, cfg.RPCEnabled)
+	})
+}
+
 func verifyArgsInvalid(t *testing.T, messageContains string, cliArgs []string) {
 	_, _, err := runWithArgs(cliArgs)
 	require.ErrorContains(t, err, messageContains)
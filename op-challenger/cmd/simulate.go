@@ -0,0 +1,256 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/simulate"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+var (
+	SimulateTraceTypeFlag = &cli.StringFlag{
+		Name:  "trace-type",
+		Usage: "Trace type of the VM to play the game on. Valid options: " + openum.EnumString(config.TraceTypes),
+		Value: config.TraceTypeAlphabet.String(),
+	}
+	SimulateDevRpcFlag = &cli.StringFlag{
+		Name:  "dev-rpc",
+		Usage: "RPC URL of a geth dev node to play the game on, instead of an in-process simulated L1",
+	}
+	SimulatePrivateKeyFlag = &cli.StringFlag{
+		Name:  "private-key",
+		Usage: "Private key of a funded account of the geth dev node, used to deploy the contracts and fund the challengers (dev-rpc only)",
+	}
+	SimulateForkRpcFlag = &cli.StringFlag{
+		Name: "fork-rpc",
+		Usage: "RPC URL of an L1 node to fork the in-process simulated L1 from. " +
+			"The game disputes an output proposed to the L2 output oracle of the L1 chain",
+	}
+	SimulateForkBlockFlag = &cli.Uint64Flag{
+		Name:  "fork-block",
+		Usage: "L1 block to fork at, which is the L1 head of the game. Defaults to the parent of the latest block (fork-rpc only)",
+	}
+	SimulateL2OOAddressFlag = &cli.StringFlag{
+		Name:  "l2oo-address",
+		Usage: "Address of the L2 output oracle the disputed output was proposed to (fork-rpc only)",
+	}
+	SimulateL2BlockNumberFlag = &cli.Uint64Flag{
+		Name:  "l2-block-number",
+		Usage: "Disputes the first output proposed at or after this L2 block. Defaults to the latest proposed output (fork-rpc only)",
+	}
+	SimulateGameDepthFlag = &cli.IntFlag{
+		Name:  "game-depth",
+		Usage: "Depth of the game tree",
+		Value: 4,
+	}
+	SimulateGameDurationFlag = &cli.DurationFlag{
+		Name:  "game-duration",
+		Usage: "Duration of the game. The game is resolved once the clocks expire, which takes this long on a geth dev node",
+		Value: 10 * time.Minute,
+	}
+	SimulateRootAlphabetFlag = &cli.StringFlag{
+		Name:  "root-alphabet",
+		Usage: "Alphabet trace of the defender, which makes the root claim",
+		Value: "abcdexyzabcdexyz",
+	}
+	SimulateAlphabetFlag = &cli.StringFlag{
+		Name:  "alphabet",
+		Usage: "Alphabet trace of the challenger, which counters the root claim",
+		Value: "abcdefghijklmnop",
+	}
+	SimulateRootDivergeAtFlag = &cli.Uint64Flag{
+		Name: "root-diverge-at",
+		Usage: "Trace index from which the trace of the defender, which makes the root claim, diverges from the cannon trace. " +
+			"Defaults to the last trace index, so only the root claim is invalid (cannon trace type only)",
+	}
+	SimulateDivergeAtFlag = &cli.Uint64Flag{
+		Name:  "diverge-at",
+		Usage: "Trace index from which the trace of the challenger diverges from the cannon trace. Defaults to the cannon trace (cannon trace type only)",
+	}
+)
+
+var SimulateCommand = &cli.Command{
+	Name:  "simulate",
+	Usage: "Plays a game between two challengers with opposing traces",
+	Description: "Deploys the dispute game contracts to an in-process simulated L1, which may be forked from an L1 node, " +
+		"or to a geth dev node, and plays a full game between a defender of the root claim and a challenger countering it.",
+	Flags: []cli.Flag{
+		SimulateTraceTypeFlag,
+		SimulateDevRpcFlag,
+		SimulatePrivateKeyFlag,
+		SimulateForkRpcFlag,
+		SimulateForkBlockFlag,
+		SimulateL2OOAddressFlag,
+		SimulateL2BlockNumberFlag,
+		SimulateGameDepthFlag,
+		SimulateGameDurationFlag,
+		SimulateRootAlphabetFlag,
+		SimulateAlphabetFlag,
+		flags.CannonBinFlag,
+		flags.CannonServerFlag,
+		flags.CannonPreStateFlag,
+		flags.CannonDatadirFlag,
+		flags.CannonL2Flag,
+		flags.CannonSnapshotFreqFlag,
+		SimulateRootDivergeAtFlag,
+		SimulateDivergeAtFlag,
+	},
+	Action: runSimulation,
+}
+
+func checkSimulateFlags(ctx *cli.Context, traceType config.TraceType) error {
+	if ctx.IsSet(SimulateDevRpcFlag.Name) {
+		if ctx.IsSet(SimulateForkRpcFlag.Name) {
+			return fmt.Errorf("flags %s and %s are mutually exclusive", SimulateDevRpcFlag.Name, SimulateForkRpcFlag.Name)
+		}
+		if !ctx.IsSet(SimulatePrivateKeyFlag.Name) {
+			return fmt.Errorf("flag %s is required with %s", SimulatePrivateKeyFlag.Name, SimulateDevRpcFlag.Name)
+		}
+	}
+	if ctx.IsSet(SimulateForkRpcFlag.Name) && !ctx.IsSet(SimulateL2OOAddressFlag.Name) {
+		return fmt.Errorf("flag %s is required with %s", SimulateL2OOAddressFlag.Name, SimulateForkRpcFlag.Name)
+	}
+	switch traceType {
+	case config.TraceTypeAlphabet:
+	case config.TraceTypeCannon:
+		// cannon executes the L2 blocks of an output proposed to an L1 chain, so the game must dispute a forked output
+		if !ctx.IsSet(SimulateForkRpcFlag.Name) {
+			return fmt.Errorf("flag %s is required with trace type %v", SimulateForkRpcFlag.Name, traceType)
+		}
+		for _, flag := range []cli.Flag{flags.CannonBinFlag, flags.CannonServerFlag, flags.CannonPreStateFlag, flags.CannonDatadirFlag, flags.CannonL2Flag} {
+			if !ctx.IsSet(flag.Names()[0]) {
+				return fmt.Errorf("flag %s is required with trace type %v", flag.Names()[0], traceType)
+			}
+		}
+	}
+	return nil
+}
+
+func runSimulation(ctx *cli.Context) error {
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	var traceType config.TraceType
+	if err := traceType.Set(strings.ToLower(ctx.String(SimulateTraceTypeFlag.Name))); err != nil {
+		return err
+	}
+	if err := checkSimulateFlags(ctx, traceType); err != nil {
+		return err
+	}
+	depth := ctx.Int(SimulateGameDepthFlag.Name)
+	cfg := simulate.Config{TraceType: traceType, GameDepth: depth, GameDuration: ctx.Duration(SimulateGameDurationFlag.Name)}
+	var backend simulate.Backend
+	var funder *ecdsa.PrivateKey
+	if ctx.IsSet(SimulateDevRpcFlag.Name) {
+		funder, err = crypto.HexToECDSA(strings.TrimPrefix(ctx.String(SimulatePrivateKeyFlag.Name), "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid private key: %w", err)
+		}
+		backend, err = simulate.DialDevNode(ctx.Context, ctx.String(SimulateDevRpcFlag.Name))
+		if err != nil {
+			return err
+		}
+	} else {
+		funder, err = crypto.GenerateKey()
+		if err != nil {
+			return err
+		}
+		funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+		alloc := core.GenesisAlloc{crypto.PubkeyToAddress(funder.PublicKey): {Balance: funds}}
+		if ctx.IsSet(SimulateForkRpcFlag.Name) {
+			backend, cfg.Fork, err = forkBackend(ctx, alloc)
+			if err != nil {
+				return err
+			}
+		} else {
+			backend = simulate.NewSimulatedBackend(alloc)
+		}
+	}
+	defender, challenger, err := simulationTraces(ctx, logger, cfg)
+	if err != nil {
+		return err
+	}
+	result, err := simulate.Run(ctx.Context, logger, backend, funder, cfg,
+		simulate.Challenger{Name: "Defender", Trace: defender},
+		simulate.Challenger{Name: "Challenger", Trace: challenger})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(ctx.App.Writer, "Game: %v\nStatus: %v\nClaims: %d\n", result.Game, fault.GameStatusString(result.Status), result.Claims)
+	return nil
+}
+
+func forkBackend(ctx *cli.Context, alloc core.GenesisAlloc) (simulate.Backend, *simulate.Fork, error) {
+	l2oo, err := opservice.ParseAddress(ctx.String(SimulateL2OOAddressFlag.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	forkCfg := simulate.ForkConfig{L2OutputOracle: l2oo}
+	if ctx.IsSet(SimulateForkBlockFlag.Name) {
+		forkCfg.BlockNumber = new(big.Int).SetUint64(ctx.Uint64(SimulateForkBlockFlag.Name))
+	}
+	if ctx.IsSet(SimulateL2BlockNumberFlag.Name) {
+		forkCfg.L2BlockNumber = new(big.Int).SetUint64(ctx.Uint64(SimulateL2BlockNumberFlag.Name))
+	}
+	source, err := simulate.DialForkSource(ctx.Context, ctx.String(SimulateForkRpcFlag.Name))
+	if err != nil {
+		return nil, nil, err
+	}
+	return simulate.NewForkedBackend(ctx.Context, source, forkCfg, alloc)
+}
+
+// simulationTraces creates the traces of the defender and the challenger for the trace type of cfg.
+// The cannon traces diverge from the trace of the output disputed on the forked L1 at the configured trace indices.
+func simulationTraces(ctx *cli.Context, logger log.Logger, cfg simulate.Config) (types.TraceProvider, types.TraceProvider, error) {
+	depth := uint64(cfg.GameDepth)
+	switch cfg.TraceType {
+	case config.TraceTypeCannon:
+		cannonCfg := config.Config{
+			L1EthRpc:               ctx.String(SimulateForkRpcFlag.Name),
+			TraceType:              config.TraceTypeCannon,
+			GameDepth:              cfg.GameDepth,
+			CannonBin:              ctx.String(flags.CannonBinFlag.Name),
+			CannonServer:           ctx.String(flags.CannonServerFlag.Name),
+			CannonAbsolutePreState: ctx.String(flags.CannonPreStateFlag.Name),
+			CannonDatadir:          ctx.String(flags.CannonDatadirFlag.Name),
+			CannonL2:               ctx.String(flags.CannonL2Flag.Name),
+			CannonSnapshotFreq:     ctx.Uint(flags.CannonSnapshotFreqFlag.Name),
+			CannonCacheSize:        config.DefaultCannonCacheSize,
+		}
+		trace, err := cannon.NewTraceProviderFromInputs(ctx.Context, logger, &cannonCfg, cfg.Fork, db.NoopGameRecorder)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create cannon trace provider: %w", err)
+		}
+		rootDivergeAt := uint64(1)<<depth - 1
+		if ctx.IsSet(SimulateRootDivergeAtFlag.Name) {
+			rootDivergeAt = ctx.Uint64(SimulateRootDivergeAtFlag.Name)
+		}
+		var challenger types.TraceProvider = trace
+		if ctx.IsSet(SimulateDivergeAtFlag.Name) {
+			challenger = simulate.NewDivergentTrace(trace, ctx.Uint64(SimulateDivergeAtFlag.Name))
+		}
+		return simulate.NewDivergentTrace(trace, rootDivergeAt), challenger, nil
+	default:
+		return alphabet.NewTraceProvider(ctx.String(SimulateRootAlphabetFlag.Name), depth),
+			alphabet.NewTraceProvider(ctx.String(SimulateAlphabetFlag.Name), depth), nil
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate(t *testing.T) {
+	t.Run("RequirePrivateKeyForDevNode", func(t *testing.T) {
+		_, err := runCommand(t, "simulate", "--dev-rpc", "http://localhost:8545")
+		require.ErrorContains(t, err, "flag private-key is required")
+	})
+
+	t.Run("ChallengerWins", func(t *testing.T) {
+		out, err := runCommand(t, "--log.level", "error", "simulate")
+		require.NoError(t, err)
+		require.Contains(t, out, "Status: Challenger Won")
+	})
+
+	t.Run("DefenderWins", func(t *testing.T) {
+		out, err := runCommand(t, "--log.level", "error", "simulate", "--root-alphabet", "abcdefghijklmnop", "--alphabet", "abcdexyzabcdexyz")
+		require.NoError(t, err)
+		require.Contains(t, out, "Status: Defender Won")
+	})
+}
+
+func TestSimulateFlags(t *testing.T) {
+	t.Run("InvalidTraceType", func(t *testing.T) {
+		_, err := runCommand(t, "simulate", "--trace-type", "foo")
+		require.ErrorContains(t, err, "unknown trace type")
+	})
+
+	t.Run("DevRpcAndForkRpcExclusive", func(t *testing.T) {
+		_, err := runCommand(t, "simulate", "--dev-rpc", "http://localhost:8545", "--fork-rpc", "http://localhost:8545")
+		require.ErrorContains(t, err, "flags dev-rpc and fork-rpc are mutually exclusive")
+	})
+
+	t.Run("RequireL2OutputOracleForFork", func(t *testing.T) {
+		_, err := runCommand(t, "simulate", "--fork-rpc", "http://localhost:8545")
+		require.ErrorContains(t, err, "flag l2oo-address is required with fork-rpc")
+	})
+
+	t.Run("RequireForkForCannon", func(t *testing.T) {
+		_, err := runCommand(t, "simulate", "--trace-type", "cannon")
+		require.ErrorContains(t, err, "flag fork-rpc is required with trace type cannon")
+	})
+
+	t.Run("RequireCannonFlags", func(t *testing.T) {
+		_, err := runCommand(t, "simulate", "--trace-type", "cannon", "--fork-rpc", "http://localhost:8545",
+			"--l2oo-address", "0x1234567890123456789012345678901234567890", "--cannon-bin", "./bin/cannon")
+		require.ErrorContains(t, err, "flag cannon-server is required with trace type cannon")
+	})
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -18,6 +20,9 @@ var (
 	ErrMissingAlphabetTrace          = errors.New("missing alphabet trace")
 	ErrMissingL1EthRPC               = errors.New("missing l1 eth rpc url")
 	ErrMissingGameAddress            = errors.New("missing game address")
+	ErrGameAddressAndFactoryAddress  = errors.New("game address and game factory address are mutually exclusive")
+	ErrMissingDatadir                = errors.New("missing datadir")
+	ErrMaxConcurrencyZero            = errors.New("max concurrency must not be 0")
 	ErrMissingPreimageOracleAddress  = errors.New("missing pre-image oracle address")
 	ErrMissingCannonSnapshotFreq     = errors.New("missing cannon snapshot freq")
 )
@@ -55,6 +60,14 @@ func ValidTraceType(value TraceType) bool {
 
 const DefaultCannonSnapshotFreq = uint(10_000)
 
+// DefaultCannonCacheSize is the default max size in MiB of the proofs, snapshots and state hashes kept in the cannon datadir.
+const DefaultCannonCacheSize = uint64(10 * 1024)
+
+const DefaultMaxConcurrency = uint(4)
+
+// DefaultGameFactoryConfirmations is the default number of L1 blocks that game creations must be confirmed by to be discovered.
+const DefaultGameFactoryConfirmations = uint64(10)
+
 // Config is a well typed config that is parsed from the CLI params.
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
@@ -62,9 +75,19 @@ type Config struct {
 	L1EthRpc                string         // L1 RPC Url
 	GameAddress             common.Address // Address of the fault game
 	PreimageOracleAddress   common.Address // Address of the pre-image oracle
-	AgreeWithProposedOutput bool           // Temporary config if we agree or disagree with the posted output
+	AgreeWithProposedOutput bool           // Temporary config if we agree or disagree with the posted output, unless RollupRpc is set
+	RollupRpc               string         // Trusted rollup node RPC Url to determine agreement with the posted output of each game
 	GameDepth               int            // Depth of the game tree
 
+	// Specific to monitoring all games of a dispute game factory, instead of a single game
+	GameFactoryAddress    common.Address // Address of the dispute game factory to discover games from
+	GameFactoryStartBlock uint64         // L1 block to start discovering games from
+	GameFactoryConfs      uint64         // Number of L1 blocks that game creations must be confirmed by to be discovered
+	Datadir               string         // Directory to persist the known games and the history of each game in
+	MaxConcurrency        uint           // Max number of games to progress concurrently
+
+	DryRun bool // Log the transactions the challenger would send, instead of sending them
+
 	TraceType TraceType // Type of trace
 
 	// Specific to the alphabet trace provider
@@ -77,8 +100,12 @@ type Config struct {
 	CannonDatadir          string // Cannon Data Directory
 	CannonL2               string // L2 RPC Url
 	CannonSnapshotFreq     uint   // Frequency of snapshots to create when executing cannon (in VM instructions)
+	CannonCacheSize        uint64 // Max size in MiB of the proofs, snapshots and state hashes kept in the cannon datadir, 0 for unlimited
 
-	TxMgrConfig txmgr.CLIConfig
+	TxMgrConfig   txmgr.CLIConfig
+	RPCEnabled    bool // Serve the RPC server, disabled by default as it listens on all interfaces
+	RPCConfig     oprpc.CLIConfig
+	MetricsConfig opmetrics.CLIConfig
 }
 
 func NewConfig(
@@ -99,17 +126,38 @@ func NewConfig(
 
 		TraceType: traceType,
 
-		TxMgrConfig: txmgr.NewCLIConfig(l1EthRpc),
+		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc),
+		RPCConfig:     oprpc.DefaultCLIConfig(),
+		MetricsConfig: opmetrics.DefaultCLIConfig(),
 
 		CannonSnapshotFreq: DefaultCannonSnapshotFreq,
+		CannonCacheSize:    DefaultCannonCacheSize,
+		MaxConcurrency:     DefaultMaxConcurrency,
+		GameFactoryConfs:   DefaultGameFactoryConfirmations,
 	}
 }
 
+// MonitorFactory returns true if the challenger discovers games from the dispute game factory,
+// instead of monitoring a single game.
+func (c Config) MonitorFactory() bool {
+	return c.GameFactoryAddress != (common.Address{})
+}
+
 func (c Config) Check() error {
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
 	}
-	if c.GameAddress == (common.Address{}) {
+	if c.MonitorFactory() {
+		if c.GameAddress != (common.Address{}) {
+			return ErrGameAddressAndFactoryAddress
+		}
+		if c.Datadir == "" {
+			return ErrMissingDatadir
+		}
+		if c.MaxConcurrency == 0 {
+			return ErrMaxConcurrencyZero
+		}
+	} else if c.GameAddress == (common.Address{}) {
 		return ErrMissingGameAddress
 	}
 	if c.TraceType == "" {
@@ -144,5 +192,11 @@ func (c Config) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.RPCConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.MetricsConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
@@ -68,6 +68,34 @@ func TestGameAddressRequired(t *testing.T) {
 	require.ErrorIs(t, config.Check(), ErrMissingGameAddress)
 }
 
+func TestGameFactory(t *testing.T) {
+	factoryConfig := func() Config {
+		cfg := validConfig(TraceTypeAlphabet)
+		cfg.GameAddress = common.Address{}
+		cfg.GameFactoryAddress = common.Address{0xaa}
+		cfg.Datadir = "/tmp/challenger"
+		return cfg
+	}
+	t.Run("Valid", func(t *testing.T) {
+		require.NoError(t, factoryConfig().Check())
+	})
+	t.Run("NotWithGameAddress", func(t *testing.T) {
+		cfg := factoryConfig()
+		cfg.GameAddress = validGameAddress
+		require.ErrorIs(t, cfg.Check(), ErrGameAddressAndFactoryAddress)
+	})
+	t.Run("DatadirRequired", func(t *testing.T) {
+		cfg := factoryConfig()
+		cfg.Datadir = ""
+		require.ErrorIs(t, cfg.Check(), ErrMissingDatadir)
+	})
+	t.Run("MaxConcurrencyMustNotBeZero", func(t *testing.T) {
+		cfg := factoryConfig()
+		cfg.MaxConcurrency = 0
+		require.ErrorIs(t, cfg.Check(), ErrMaxConcurrencyZero)
+	})
+}
+
 func TestAlphabetTraceRequired(t *testing.T) {
 	config := validConfig(TraceTypeAlphabet)
 	config.AlphabetTrace = ""
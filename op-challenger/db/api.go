@@ -0,0 +1,39 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// Namespace is the RPC namespace the history of the games is served in.
+const Namespace = "challenger"
+
+// NewAPI serves the history recorded in store over RPC. The challenger holds the lock of its database
+// while it runs, so this is how the history is read without stopping the challenger.
+func NewAPI(store *Store) rpc.API {
+	return rpc.API{
+		Namespace: Namespace,
+		Service:   &api{store: store},
+	}
+}
+
+type api struct {
+	store *Store
+}
+
+// Games returns all games seen, in order of first seen.
+func (a *api) Games(_ context.Context) ([]Game, error) {
+	return a.store.Games()
+}
+
+// GameHistory returns everything recorded of the game at addr.
+func (a *api) GameHistory(_ context.Context, addr common.Address) (*GameHistory, error) {
+	history, err := a.store.History(addr)
+	if err != nil {
+		return nil, fmt.Errorf("game %v: %w", addr, err)
+	}
+	return &history, nil
+}
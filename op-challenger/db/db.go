@@ -0,0 +1,270 @@
+package db
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+)
+
+const (
+	// cacheSize is the memory in megabytes used for caching the on-disk database.
+	cacheSize = 16
+	// handles is the number of open file handles of the on-disk database.
+	handles = 16
+)
+
+var ErrNotFound = errors.New("not found")
+
+// Key prefixes of the records in the database.
+// All records of a game are keyed by the prefix, followed by the game address and the sequence number of the record.
+var (
+	gamePrefix     = []byte("g")
+	claimPrefix    = []byte("c")
+	actionPrefix   = []byte("a")
+	proofPrefix    = []byte("p")
+	sequencePrefix = []byte("s")
+)
+
+// Store is the persistent state of the challenger. It records the games seen, their claims,
+// the actions taken in each game and the proofs generated for them.
+type Store struct {
+	db ethdb.KeyValueStore
+	// lock serialises the updates that read existing records, such as allocating record sequence numbers.
+	lock sync.Mutex
+}
+
+// Open opens the on-disk database in dir, creating it if it does not exist yet.
+func Open(dir string, readonly bool) (*Store, error) {
+	db, err := leveldb.New(dir, cacheSize, handles, "", readonly)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open challenger db (%v): %w", dir, err)
+	}
+	return NewStore(db), nil
+}
+
+// NewStore creates a new [Store] backed by the key-value store db.
+func NewStore(db ethdb.KeyValueStore) *Store {
+	return &Store{db: db}
+}
+
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// PutGame records the game. The time the game was first seen is kept if the game is already known.
+func (s *Store) PutGame(game Game) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	existing, err := s.Game(game.Address)
+	if err == nil {
+		game.FirstSeen = existing.FirstSeen
+	} else if !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return s.put(gameKey(game.Address), &game)
+}
+
+// Game returns the game at addr, or [ErrNotFound] if the game has not been seen.
+func (s *Store) Game(addr common.Address) (Game, error) {
+	var game Game
+	if err := s.get(gameKey(addr), &game); err != nil {
+		return Game{}, err
+	}
+	return game, nil
+}
+
+// Games returns all games seen, in order of first seen.
+func (s *Store) Games() ([]Game, error) {
+	it := s.db.NewIterator(gamePrefix, nil)
+	defer it.Release()
+	var games []Game
+	for it.Next() {
+		var game Game
+		if err := json.Unmarshal(it.Value(), &game); err != nil {
+			return nil, fmt.Errorf("failed to decode game %x: %w", it.Key(), err)
+		}
+		games = append(games, game)
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read games: %w", err)
+	}
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].FirstSeen < games[j].FirstSeen
+	})
+	return games, nil
+}
+
+// History returns everything recorded of the game at addr, or [ErrNotFound] if the game has not been seen.
+func (s *Store) History(addr common.Address) (GameHistory, error) {
+	game, err := s.Game(addr)
+	if err != nil {
+		return GameHistory{}, err
+	}
+	claims, err := s.Claims(addr)
+	if err != nil {
+		return GameHistory{}, err
+	}
+	actions, err := s.Actions(addr)
+	if err != nil {
+		return GameHistory{}, err
+	}
+	proofs, err := s.Proofs(addr)
+	if err != nil {
+		return GameHistory{}, err
+	}
+	return GameHistory{Game: game, Claims: claims, Actions: actions, Proofs: proofs}, nil
+}
+
+// PutClaims records the current claims of the game at addr, replacing the claims recorded before.
+// Claims are only ever added to a game, so the claims recorded before are always a prefix of the new claims.
+func (s *Store) PutClaims(addr common.Address, claims []Claim) error {
+	batch := s.db.NewBatch()
+	for _, claim := range claims {
+		data, err := json.Marshal(&claim)
+		if err != nil {
+			return fmt.Errorf("failed to encode claim %v: %w", claim.ContractIndex, err)
+		}
+		if err := batch.Put(recordKey(claimPrefix, addr, uint64(claim.ContractIndex)), data); err != nil {
+			return err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return fmt.Errorf("failed to write claims of game %v: %w", addr, err)
+	}
+	return nil
+}
+
+// Claims returns the claims of the game at addr, in order of their index in the contract.
+func (s *Store) Claims(addr common.Address) ([]Claim, error) {
+	var claims []Claim
+	err := s.iterate(claimPrefix, addr, func(data []byte) error {
+		var claim Claim
+		if err := json.Unmarshal(data, &claim); err != nil {
+			return err
+		}
+		claims = append(claims, claim)
+		return nil
+	})
+	return claims, err
+}
+
+// AddAction records an action taken in the game at addr.
+func (s *Store) AddAction(addr common.Address, action Action) error {
+	return s.add(actionPrefix, addr, &action)
+}
+
+// Actions returns the actions taken in the game at addr, in the order they were taken.
+func (s *Store) Actions(addr common.Address) ([]Action, error) {
+	var actions []Action
+	err := s.iterate(actionPrefix, addr, func(data []byte) error {
+		var action Action
+		if err := json.Unmarshal(data, &action); err != nil {
+			return err
+		}
+		actions = append(actions, action)
+		return nil
+	})
+	return actions, err
+}
+
+// AddProof records a proof generated for the game at addr.
+func (s *Store) AddProof(addr common.Address, proof Proof) error {
+	return s.add(proofPrefix, addr, &proof)
+}
+
+// Proofs returns the proofs generated for the game at addr, in the order they were generated.
+func (s *Store) Proofs(addr common.Address) ([]Proof, error) {
+	var proofs []Proof
+	err := s.iterate(proofPrefix, addr, func(data []byte) error {
+		var proof Proof
+		if err := json.Unmarshal(data, &proof); err != nil {
+			return err
+		}
+		proofs = append(proofs, proof)
+		return nil
+	})
+	return proofs, err
+}
+
+// add records v under the next sequence number of the records with prefix of the game at addr.
+func (s *Store) add(prefix []byte, addr common.Address, v any) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	seqKey := append(append(append([]byte{}, sequencePrefix...), prefix...), addr.Bytes()...)
+	var seq uint64
+	if data, err := s.read(seqKey); err == nil {
+		seq = binary.BigEndian.Uint64(data)
+	} else if !errors.Is(err, ErrNotFound) {
+		return fmt.Errorf("failed to read sequence number: %w", err)
+	}
+	value, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+	batch := s.db.NewBatch()
+	if err := batch.Put(recordKey(prefix, addr, seq), value); err != nil {
+		return err
+	}
+	if err := batch.Put(seqKey, binary.BigEndian.AppendUint64(nil, seq+1)); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+// iterate calls fn with each record with prefix of the game at addr, in order of their sequence number.
+func (s *Store) iterate(prefix []byte, addr common.Address, fn func(data []byte) error) error {
+	it := s.db.NewIterator(append(append([]byte{}, prefix...), addr.Bytes()...), nil)
+	defer it.Release()
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return fmt.Errorf("failed to decode record %x: %w", it.Key(), err)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return fmt.Errorf("failed to read records of game %v: %w", addr, err)
+	}
+	return nil
+}
+
+func (s *Store) put(key []byte, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+	return s.db.Put(key, data)
+}
+
+func (s *Store) get(key []byte, v any) error {
+	data, err := s.read(key)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// read returns the value at key, or [ErrNotFound] if there is no value at key.
+func (s *Store) read(key []byte) ([]byte, error) {
+	if ok, err := s.db.Has(key); err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrNotFound
+	}
+	return s.db.Get(key)
+}
+
+func gameKey(addr common.Address) []byte {
+	return append(append([]byte{}, gamePrefix...), addr.Bytes()...)
+}
+
+// recordKey returns the key of a record of a game. The sequence number is big-endian so records iterate in order.
+func recordKey(prefix []byte, addr common.Address, seq uint64) []byte {
+	key := append(append([]byte{}, prefix...), addr.Bytes()...)
+	return binary.BigEndian.AppendUint64(key, seq)
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	game1 = common.Address{0x01}
+	game2 = common.Address{0x02}
+)
+
+func TestGames(t *testing.T) {
+	store := NewStore(memorydb.New())
+	_, err := store.Game(game1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, store.PutGame(Game{Address: game2, FirstSeen: 200}))
+	require.NoError(t, store.PutGame(Game{Address: game1, RootClaim: common.Hash{0xaa}, FirstSeen: 100}))
+	games, err := store.Games()
+	require.NoError(t, err)
+	require.Equal(t, []Game{
+		{Address: game1, RootClaim: common.Hash{0xaa}, FirstSeen: 100},
+		{Address: game2, FirstSeen: 200},
+	}, games, "should be ordered by first seen")
+
+	t.Run("KeepFirstSeen", func(t *testing.T) {
+		require.NoError(t, store.PutGame(Game{Address: game1, RootClaim: common.Hash{0xaa}, FirstSeen: 300, Status: types.GameStatusDefenderWon}))
+		game, err := store.Game(game1)
+		require.NoError(t, err)
+		require.Equal(t, uint64(100), game.FirstSeen)
+		require.Equal(t, types.GameStatusDefenderWon, game.Status)
+	})
+}
+
+func TestClaims(t *testing.T) {
+	store := NewStore(memorydb.New())
+	claims, err := store.Claims(game1)
+	require.NoError(t, err)
+	require.Empty(t, claims)
+
+	require.NoError(t, store.PutClaims(game1, []Claim{{ContractIndex: 0, Value: common.Hash{0xaa}}}))
+	expected := []Claim{
+		{ContractIndex: 0, Value: common.Hash{0xaa}, Countered: true},
+		{ContractIndex: 1, Value: common.Hash{0xbb}, GIndex: 2, ClockDuration: 10, ClockTimestamp: 1000},
+	}
+	require.NoError(t, store.PutClaims(game1, expected))
+	claims, err = store.Claims(game1)
+	require.NoError(t, err)
+	require.Equal(t, expected, claims, "should replace existing claims")
+
+	claims, err = store.Claims(game2)
+	require.NoError(t, err)
+	require.Empty(t, claims, "should not include claims of other games")
+}
+
+func TestActions(t *testing.T) {
+	store := NewStore(memorydb.New())
+	actions := []Action{
+		{Time: 1, Type: ActionAttack, ClaimIndex: 0, Value: common.Hash{0xaa}, Bond: (*hexutil.Big)(big.NewInt(1000)), TxHash: common.Hash{0x01}, Success: true},
+		{Time: 2, Type: ActionPreimage, OracleKey: []byte{0x02, 0xcc}, OracleOffset: 8, TxHash: common.Hash{0x02}, Success: true},
+		{Time: 3, Type: ActionStep, ClaimIndex: 1, TxHash: common.Hash{0x03}},
+	}
+	for _, action := range actions {
+		require.NoError(t, store.AddAction(game1, action))
+	}
+	require.NoError(t, store.AddAction(game2, Action{Type: ActionResolve}))
+
+	recorded, err := store.Actions(game1)
+	require.NoError(t, err)
+	require.Equal(t, actions, recorded)
+	recorded, err = store.Actions(game2)
+	require.NoError(t, err)
+	require.Equal(t, []Action{{Type: ActionResolve}}, recorded)
+}
+
+func TestActionsKeepOrderAfterReopen(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open(dir, false)
+	require.NoError(t, err)
+	for i := 0; i < 300; i++ {
+		require.NoError(t, store.AddAction(game1, Action{Time: uint64(i)}))
+	}
+	require.NoError(t, store.Close())
+
+	store, err = Open(dir, false)
+	require.NoError(t, err)
+	defer store.Close()
+	require.NoError(t, store.AddAction(game1, Action{Time: 300}))
+	actions, err := store.Actions(game1)
+	require.NoError(t, err)
+	require.Len(t, actions, 301)
+	for i, action := range actions {
+		require.Equal(t, uint64(i), action.Time)
+	}
+}
+
+func TestProofs(t *testing.T) {
+	store := NewStore(memorydb.New())
+	recorder := store.GameRecorder(testlog.Logger(t, log.LvlInfo), game1)
+	proofs := []Proof{
+		{Time: 1, TraceIndex: 5, ClaimValue: common.Hash{0xaa}, Dir: "/cannon"},
+		{Time: 2, TraceIndex: 3, ClaimValue: common.Hash{0xbb}, Dir: "/cannon"},
+	}
+	for _, proof := range proofs {
+		recorder.RecordProof(proof)
+	}
+	recorded, err := store.Proofs(game1)
+	require.NoError(t, err)
+	require.Equal(t, proofs, recorded)
+}
+
+func TestHistory(t *testing.T) {
+	store := NewStore(memorydb.New())
+	_, err := store.History(game1)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	game := Game{Address: game1, RootClaim: common.Hash{0xaa}, FirstSeen: 100}
+	claims := []Claim{{ContractIndex: 0, Value: common.Hash{0xaa}}, {ContractIndex: 1, ParentContractIndex: 0, Value: common.Hash{0xbb}, GIndex: 2}}
+	action := Action{Time: 1, Type: ActionAttack, ClaimIndex: 0, Value: common.Hash{0xbb}, Success: true}
+	proof := Proof{Time: 2, TraceIndex: 5, ClaimValue: common.Hash{0xcc}, Dir: "/cannon"}
+	require.NoError(t, store.PutGame(game))
+	require.NoError(t, store.PutClaims(game1, claims))
+	require.NoError(t, store.AddAction(game1, action))
+	require.NoError(t, store.AddProof(game1, proof))
+	require.NoError(t, store.AddAction(game2, Action{Time: 3, Type: ActionStep}))
+
+	history, err := store.History(game1)
+	require.NoError(t, err)
+	require.Equal(t, GameHistory{Game: game, Claims: claims, Actions: []Action{action}, Proofs: []Proof{proof}}, history)
+}
+
+func TestNewClaim(t *testing.T) {
+	claim := types.Claim{
+		ClaimData: types.ClaimData{
+			Value:    common.Hash{0xaa},
+			Position: types.NewPosition(2, 1),
+		},
+		Countered:           true,
+		Clock:               types.Clock{Duration: 10, Timestamp: 1000},
+		ContractIndex:       3,
+		ParentContractIndex: 1,
+	}
+	require.Equal(t, Claim{
+		ContractIndex:       3,
+		ParentContractIndex: 1,
+		Value:               common.Hash{0xaa},
+		GIndex:              5,
+		Countered:           true,
+		ClockDuration:       10,
+		ClockTimestamp:      1000,
+	}, NewClaim(claim))
+}
@@ -0,0 +1,53 @@
+package db
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// GameRecorder records the history of a single game.
+// Failing to record the history must not stop the challenger from playing the game,
+// so errors are logged rather than returned.
+type GameRecorder interface {
+	RecordClaims(claims []Claim)
+	RecordAction(action Action)
+	RecordProof(proof Proof)
+}
+
+type noopGameRecorder struct{}
+
+// NoopGameRecorder is a [GameRecorder] that records nothing.
+var NoopGameRecorder GameRecorder = noopGameRecorder{}
+
+func (noopGameRecorder) RecordClaims(claims []Claim) {}
+func (noopGameRecorder) RecordAction(action Action)  {}
+func (noopGameRecorder) RecordProof(proof Proof)     {}
+
+type storeGameRecorder struct {
+	logger log.Logger
+	store  *Store
+	addr   common.Address
+}
+
+// GameRecorder returns a [GameRecorder] recording the history of the game at addr in the store.
+func (s *Store) GameRecorder(logger log.Logger, addr common.Address) GameRecorder {
+	return &storeGameRecorder{logger: logger, store: s, addr: addr}
+}
+
+func (r *storeGameRecorder) RecordClaims(claims []Claim) {
+	if err := r.store.PutClaims(r.addr, claims); err != nil {
+		r.logger.Warn("Failed to record claims", "err", err)
+	}
+}
+
+func (r *storeGameRecorder) RecordAction(action Action) {
+	if err := r.store.AddAction(r.addr, action); err != nil {
+		r.logger.Warn("Failed to record action", "type", action.Type, "tx_hash", action.TxHash, "err", err)
+	}
+}
+
+func (r *storeGameRecorder) RecordProof(proof Proof) {
+	if err := r.store.AddProof(r.addr, proof); err != nil {
+		r.logger.Warn("Failed to record proof", "trace_index", proof.TraceIndex, "err", err)
+	}
+}
@@ -0,0 +1,90 @@
+package db
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// ActionType is the type of an action taken by the challenger in a game.
+type ActionType string
+
+const (
+	ActionAttack   ActionType = "attack"
+	ActionDefend   ActionType = "defend"
+	ActionStep     ActionType = "step"
+	ActionResolve  ActionType = "resolve"
+	ActionPreimage ActionType = "preimage"
+)
+
+// Game is a dispute game seen by the challenger.
+type Game struct {
+	Address   common.Address `json:"address"`
+	RootClaim common.Hash    `json:"rootClaim"`
+	// FirstSeen is the unix time the challenger first saw the game.
+	FirstSeen uint64 `json:"firstSeen"`
+	// AgreeWithProposedOutput is true if the challenger agrees with the output proposed by the game.
+	AgreeWithProposedOutput bool             `json:"agreeWithProposedOutput"`
+	Status                  types.GameStatus `json:"status"`
+}
+
+// Claim is a claim of a game, as last loaded from the contract.
+type Claim struct {
+	ContractIndex       int         `json:"contractIndex"`
+	ParentContractIndex int         `json:"parentContractIndex"`
+	Value               common.Hash `json:"value"`
+	GIndex              uint64      `json:"gindex"`
+	Countered           bool        `json:"countered"`
+	ClockDuration       uint64      `json:"clockDuration"`
+	ClockTimestamp      uint64      `json:"clockTimestamp"`
+}
+
+// NewClaim creates the record of a claim loaded from the contract.
+func NewClaim(claim types.Claim) Claim {
+	return Claim{
+		ContractIndex:       claim.ContractIndex,
+		ParentContractIndex: claim.ParentContractIndex,
+		Value:               claim.Value,
+		GIndex:              claim.ToGIndex(),
+		Countered:           claim.Countered,
+		ClockDuration:       claim.Clock.Duration,
+		ClockTimestamp:      claim.Clock.Timestamp,
+	}
+}
+
+// Action is a transaction sent by the challenger to progress a game.
+type Action struct {
+	// Time is the unix time the transaction was confirmed.
+	Time uint64     `json:"time"`
+	Type ActionType `json:"type"`
+	// ClaimIndex is the index of the countered claim for moves and steps.
+	ClaimIndex int `json:"claimIndex,omitempty"`
+	// Value is the value of the claim posted by a move.
+	Value common.Hash `json:"value,omitempty"`
+	// Bond is the bond in wei posted with the transaction.
+	Bond *hexutil.Big `json:"bond,omitempty"`
+	// OracleKey and OracleOffset identify the preimage part uploaded to the preimage oracle.
+	OracleKey    hexutil.Bytes `json:"oracleKey,omitempty"`
+	OracleOffset uint32        `json:"oracleOffset,omitempty"`
+	TxHash       common.Hash   `json:"txHash"`
+	// Success is false if the transaction reverted.
+	Success bool `json:"success"`
+}
+
+// Proof is a proof generated by cannon for a game.
+type Proof struct {
+	// Time is the unix time the proof was generated.
+	Time       uint64      `json:"time"`
+	TraceIndex uint64      `json:"traceIndex"`
+	ClaimValue common.Hash `json:"claimValue"`
+	// Dir is the directory of the cannon trace the proof was generated in.
+	Dir string `json:"dir"`
+}
+
+// GameHistory is everything recorded of a game.
+type GameHistory struct {
+	Game    Game     `json:"game"`
+	Claims  []Claim  `json:"claims"`
+	Actions []Action `json:"actions"`
+	Proofs  []Proof  `json:"proofs"`
+}
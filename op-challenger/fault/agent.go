@@ -4,9 +4,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
 	"github.com/ethereum-optimism/optimism/op-challenger/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -19,26 +24,51 @@ type Responder interface {
 	Step(ctx context.Context, stepData types.StepCallData) error
 }
 
+// balanceFetcher returns the funds available to the challenger to post bonds, in wei.
+type balanceFetcher func(ctx context.Context) (*big.Int, error)
+
 type Agent struct {
 	solver                  *solver.Solver
 	loader                  Loader
 	responder               Responder
+	bonds                   BondSource
+	fetchBalance            balanceFetcher
+	gameDuration            uint64
+	clock                   clock.Clock
+	metrics                 metrics.Metricer
+	recorder                db.GameRecorder
 	maxDepth                int
 	agreeWithProposedOutput bool
 	log                     log.Logger
 }
 
-func NewAgent(loader Loader, maxDepth int, trace types.TraceProvider, responder Responder, agreeWithProposedOutput bool, log log.Logger) *Agent {
+// NewAgent creates a new [Agent]. The gameDuration is the GAME_DURATION of the game in seconds,
+// each team has half of it to make their moves. The claims loaded from the contract are recorded with the recorder.
+func NewAgent(loader Loader, maxDepth int, solver *solver.Solver, responder Responder, bonds BondSource, fetchBalance balanceFetcher, gameDuration uint64, clock clock.Clock, m metrics.Metricer, recorder db.GameRecorder, agreeWithProposedOutput bool, log log.Logger) *Agent {
 	return &Agent{
-		solver:                  solver.NewSolver(maxDepth, trace),
+		solver:                  solver,
 		loader:                  loader,
 		responder:               responder,
+		bonds:                   bonds,
+		fetchBalance:            fetchBalance,
+		gameDuration:            gameDuration,
+		clock:                   clock,
+		metrics:                 m,
+		recorder:                recorder,
 		maxDepth:                maxDepth,
 		agreeWithProposedOutput: agreeWithProposedOutput,
 		log:                     log,
 	}
 }
 
+// plannedMove is a move the agent intends to make against a claim,
+// along with the time in seconds left on the chess clock to make it.
+type plannedMove struct {
+	claim    types.Claim
+	move     types.Claim
+	timeLeft int64
+}
+
 // Act iterates the game & performs all of the next actions.
 func (a *Agent) Act(ctx context.Context) error {
 	if a.tryResolve(ctx) {
@@ -48,12 +78,8 @@ func (a *Agent) Act(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("create game from contracts: %w", err)
 	}
-	// Create counter claims
-	for _, claim := range game.Claims() {
-		if err := a.move(ctx, claim, game); err != nil && !errors.Is(err, types.ErrGameDepthReached) {
-			log.Error("Failed to move", "err", err)
-		}
-	}
+	// Create counter claims, most urgent first
+	a.performMoves(ctx, a.planMoves(ctx, game))
 	// Step on all leaf claims
 	for _, claim := range game.Claims() {
 		if err := a.step(ctx, claim, game); err != nil {
@@ -86,6 +112,11 @@ func (a *Agent) newGameFromContracts(ctx context.Context) (types.Game, error) {
 	if len(claims) == 0 {
 		return nil, errors.New("no claims")
 	}
+	records := make([]db.Claim, 0, len(claims))
+	for _, claim := range claims {
+		records = append(records, db.NewClaim(claim))
+	}
+	a.recorder.RecordClaims(records)
 	game := types.NewGameState(a.agreeWithProposedOutput, claims[0], uint64(a.maxDepth))
 	if err := game.PutAll(claims[1:]); err != nil {
 		return nil, fmt.Errorf("failed to load claims into the local state: %w", err)
@@ -93,26 +124,116 @@ func (a *Agent) newGameFromContracts(ctx context.Context) (types.Game, error) {
 	return game, nil
 }
 
-// move determines & executes the next move given a claim
-func (a *Agent) move(ctx context.Context, claim types.Claim, game types.Game) error {
+// planMoves determines the next moves to make against the claims of the game, ordered by the time left on their clocks.
+// Moves whose clock has expired can no longer be made and are skipped.
+func (a *Agent) planMoves(ctx context.Context, game types.Game) []plannedMove {
+	claims := game.Claims()
+	clocks := make(map[int]types.Clock, len(claims))
+	for _, claim := range claims {
+		clocks[claim.ContractIndex] = claim.Clock
+	}
+	now := uint64(a.clock.Now().Unix())
+	var moves []plannedMove
+	for _, claim := range claims {
+		move, err := a.nextMove(ctx, claim, game)
+		if err != nil {
+			if !errors.Is(err, types.ErrGameDepthReached) {
+				a.log.Error("Failed to move", "err", err)
+			}
+			continue
+		}
+		if move == nil {
+			continue
+		}
+		timeLeft := a.timeLeft(claim, clocks, now)
+		if timeLeft < 0 {
+			a.log.Warn("Clock expired, skipping move", "depth", move.Depth(), "index_at_depth", move.IndexAtDepth(),
+				"parent_value", claim.Value, "time_left", timeLeft)
+			a.metrics.RecordMoveSkipped("clock_expired")
+			continue
+		}
+		moves = append(moves, plannedMove{claim: claim, move: *move, timeLeft: timeLeft})
+	}
+	sort.SliceStable(moves, func(i, j int) bool {
+		return moves[i].timeLeft < moves[j].timeLeft
+	})
+	return moves
+}
+
+// timeLeft returns the time in seconds left to counter the claim, which may be negative once the clock has expired.
+// The clock of a counter claim accumulates the duration of the claim's parent, the previous move of the same team,
+// and the time since the claim was made. It may not exceed half of the game duration.
+func (a *Agent) timeLeft(claim types.Claim, clocks map[int]types.Clock, now uint64) int64 {
+	var used uint64
+	if !claim.IsRoot() {
+		used = clocks[claim.ParentContractIndex].Duration
+	}
+	if now > claim.Clock.Timestamp {
+		used += now - claim.Clock.Timestamp
+	}
+	return int64(a.gameDuration/2) - int64(used)
+}
+
+// performMoves executes the planned moves in order, skipping moves whose bond can't be afforded.
+// If the available funds are unknown, only the moves that require no bond are executed.
+func (a *Agent) performMoves(ctx context.Context, moves []plannedMove) {
+	if len(moves) == 0 {
+		return
+	}
+	funds, err := a.fetchBalance(ctx)
+	if err != nil {
+		a.log.Error("Failed to fetch available funds, only performing moves that require no bond", "err", err)
+		funds = nil
+	}
+	for _, planned := range moves {
+		move := planned.move
+		log := a.log.New("is_defend", move.DefendsParent(), "depth", move.Depth(), "index_at_depth", move.IndexAtDepth(),
+			"value", move.Value, "trace_index", move.TraceIndex(a.maxDepth),
+			"parent_value", planned.claim.Value, "parent_trace_index", planned.claim.TraceIndex(a.maxDepth),
+			"time_left", planned.timeLeft)
+		bond, err := a.bonds.RequiredBond(ctx, move.Position)
+		if err != nil {
+			log.Error("Failed to fetch required bond", "err", err)
+			continue
+		}
+		if bond.Sign() > 0 {
+			if funds == nil {
+				log.Warn("Unknown funds for bond, skipping move", "bond", bond)
+				a.metrics.RecordMoveSkipped("unknown_funds")
+				continue
+			}
+			if bond.Cmp(funds) > 0 {
+				log.Warn("Insufficient funds for bond, skipping move", "bond", bond, "funds", funds)
+				a.metrics.RecordMoveSkipped("insufficient_funds")
+				continue
+			}
+		}
+		log.Info("Performing move", "bond", bond)
+		if err := a.responder.Respond(ctx, move); err != nil {
+			log.Error("Failed to move", "err", err)
+			continue
+		}
+		if funds != nil {
+			funds = new(big.Int).Sub(funds, bond)
+		}
+	}
+}
+
+// nextMove determines the next move given a claim, or nil if there is no move to make.
+func (a *Agent) nextMove(ctx context.Context, claim types.Claim, game types.Game) (*types.Claim, error) {
 	nextMove, err := a.solver.NextMove(ctx, claim, game.AgreeWithClaimLevel(claim))
 	if err != nil {
-		return fmt.Errorf("execute next move: %w", err)
+		return nil, fmt.Errorf("execute next move: %w", err)
 	}
 	if nextMove == nil {
 		a.log.Debug("No next move")
-		return nil
+		return nil, nil
 	}
-	move := *nextMove
-	log := a.log.New("is_defend", move.DefendsParent(), "depth", move.Depth(), "index_at_depth", move.IndexAtDepth(),
-		"value", move.Value, "trace_index", move.TraceIndex(a.maxDepth),
-		"parent_value", claim.Value, "parent_trace_index", claim.TraceIndex(a.maxDepth))
-	if game.IsDuplicate(move) {
-		log.Debug("Skipping duplicate move")
-		return nil
+	if game.IsDuplicate(*nextMove) {
+		a.log.Debug("Skipping duplicate move", "depth", nextMove.Depth(), "index_at_depth", nextMove.IndexAtDepth(), "value", nextMove.Value)
+		return nil, nil
 	}
-	log.Info("Performing move")
-	return a.responder.Respond(ctx, move)
+	return nextMove, nil
 }
 
 // step determines & executes the next step against a leaf claim through the responder
@@ -0,0 +1,132 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	agentTestGameDepth    = 4
+	agentTestGameDuration = uint64(1000)
+	agentTestNow          = int64(2000)
+)
+
+func TestAgentPrioritisesMovesByClock(t *testing.T) {
+	// The move against the root has 500-(2000-1900)=400 seconds left,
+	// the move against the depth 2 claim has 500-100-(2000-1850)=250 seconds left.
+	agent, responder, _ := setupTestAgent(t, 1900, 1850, big.NewInt(1_000_000))
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.responses, 2)
+	require.Equal(t, 3, responder.responses[0].Depth(), "should counter the claim with the least time left first")
+	require.Equal(t, 1, responder.responses[1].Depth())
+}
+
+func TestAgentSkipsMovesWithExpiredClock(t *testing.T) {
+	// The move against the root has 500-(2000-1400)=-100 seconds left.
+	agent, responder, m := setupTestAgent(t, 1400, 1850, big.NewInt(1_000_000))
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.responses, 1)
+	require.Equal(t, 3, responder.responses[0].Depth())
+	require.Equal(t, []string{"clock_expired"}, m.movesSkipped)
+}
+
+func TestAgentSkipsMovesItCannotAfford(t *testing.T) {
+	// Bonds are 3000 wei for the move at depth 3 and 1000 wei for the move at depth 1.
+	agent, responder, m := setupTestAgent(t, 1900, 1850, big.NewInt(3500))
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.responses, 1)
+	require.Equal(t, 3, responder.responses[0].Depth())
+	require.Equal(t, []string{"insufficient_funds"}, m.movesSkipped)
+}
+
+func TestAgentOnlyMovesWithoutBondWhenBalanceUnavailable(t *testing.T) {
+	agent, responder, m := setupTestAgent(t, 1900, 1850, big.NewInt(1_000_000))
+	agent.fetchBalance = func(ctx context.Context) (*big.Int, error) {
+		return nil, errors.New("boom")
+	}
+	// The move at depth 3 requires no bond, the move at depth 1 does.
+	agent.bonds = &stubBondSource{free: map[int]bool{3: true}}
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, responder.responses, 1)
+	require.Equal(t, 3, responder.responses[0].Depth())
+	require.Equal(t, []string{"unknown_funds"}, m.movesSkipped)
+}
+
+func TestAgentRecordsClaims(t *testing.T) {
+	agent, _, _ := setupTestAgent(t, 1900, 1850, big.NewInt(1_000_000))
+	recorder := &stubGameRecorder{}
+	agent.recorder = recorder
+	require.NoError(t, agent.Act(context.Background()))
+	require.Len(t, recorder.claims, 3)
+	require.Equal(t, 2, recorder.claims[2].ContractIndex)
+	require.Equal(t, 1, recorder.claims[2].ParentContractIndex)
+	require.Equal(t, uint64(200), recorder.claims[2].ClockDuration)
+}
+
+// setupTestAgent creates an agent disagreeing with an incorrect root claim, which has been attacked at depth 1
+// and incorrectly countered again at depth 2, leaving the root and the depth 2 claim for the agent to counter.
+func setupTestAgent(t *testing.T, rootTimestamp uint64, depth2Timestamp uint64, funds *big.Int) (*Agent, *stubResponder, *stubBondMetrics) {
+	builder := test.NewAlphabetClaimBuilder(t, agentTestGameDepth)
+	root := builder.CreateRootClaim(false)
+	root.Clock = types.Clock{Timestamp: rootTimestamp}
+	// The opponent attacks the root with a value that differs from the agent's own attack.
+	depth1 := builder.AttackClaim(root, false)
+	depth1.ContractIndex = 1
+	depth1.Clock = types.Clock{Duration: 100, Timestamp: rootTimestamp}
+	depth2 := builder.AttackClaim(depth1, false)
+	depth2.ContractIndex = 2
+	depth2.ParentContractIndex = 1
+	depth2.Clock = types.Clock{Duration: 200, Timestamp: depth2Timestamp}
+
+	responder := &stubResponder{}
+	m := &stubBondMetrics{Metricer: metrics.NoopMetrics}
+	fetchBalance := func(ctx context.Context) (*big.Int, error) {
+		return funds, nil
+	}
+	agent := NewAgent(&stubLoader{claims: []types.Claim{root, depth1, depth2}}, agentTestGameDepth,
+		solver.NewSolver(agentTestGameDepth, builder.CorrectTraceProvider()), responder, &stubBondSource{}, fetchBalance,
+		agentTestGameDuration, clock.NewDeterministicClock(time.Unix(agentTestNow, 0)), m, db.NoopGameRecorder, true, testlog.Logger(t, log.LvlInfo))
+	return agent, responder, m
+}
+
+type stubLoader struct {
+	claims []types.Claim
+}
+
+func (s *stubLoader) FetchClaims(ctx context.Context) ([]types.Claim, error) {
+	return s.claims, nil
+}
+
+type stubResponder struct {
+	responses []types.Claim
+}
+
+func (s *stubResponder) CanResolve(ctx context.Context) bool {
+	return false
+}
+
+func (s *stubResponder) Resolve(ctx context.Context) error {
+	return nil
+}
+
+func (s *stubResponder) Respond(ctx context.Context, response types.Claim) error {
+	s.responses = append(s.responses, response)
+	return nil
+}
+
+func (s *stubResponder) Step(ctx context.Context, stepData types.StepCallData) error {
+	return nil
+}
@@ -0,0 +1,61 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// OutputRootSource is a minimal interface around [sources.RollupClient], the trusted rollup node.
+type OutputRootSource interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// GameClaimCaller is a minimal interface around [bindings.FaultDisputeGameCaller].
+type GameClaimCaller interface {
+	RootClaim(opts *bind.CallOpts) ([32]byte, error)
+	L2BlockNumber(opts *bind.CallOpts) (*big.Int, error)
+}
+
+// DetermineAgreement compares the root claim of the game with the output root of the trusted rollup node at the
+// L2 block number of the game, and returns whether the challenger agrees with the proposed output.
+// The root claim of a fault dispute game disputes the proposed output: if the root claim matches the
+// trusted output root, the challenger agrees with the root claim, and so disagrees with the proposed output.
+func DetermineAgreement(ctx context.Context, logger log.Logger, game GameClaimCaller, rollupClient OutputRootSource, m metrics.Metricer) (bool, error) {
+	agree, err := determineAgreement(ctx, logger, game, rollupClient)
+	if err != nil {
+		m.RecordGameAgreementFailure()
+		return false, err
+	}
+	m.RecordGameAgreement(!agree)
+	return agree, nil
+}
+
+func determineAgreement(ctx context.Context, logger log.Logger, game GameClaimCaller, rollupClient OutputRootSource) (bool, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	rootClaim, err := game.RootClaim(opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch root claim: %w", err)
+	}
+	l2BlockNumber, err := game.L2BlockNumber(opts)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch L2 block number: %w", err)
+	}
+	if !l2BlockNumber.IsUint64() {
+		return false, fmt.Errorf("L2 block number %v out of range", l2BlockNumber)
+	}
+	output, err := rollupClient.OutputAtBlock(ctx, l2BlockNumber.Uint64())
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch output at L2 block %v: %w", l2BlockNumber, err)
+	}
+	agreeWithRootClaim := common.Hash(output.OutputRoot) == common.Hash(rootClaim)
+	logger.Info("Determined agreement with root claim from rollup node", "agree_with_root_claim", agreeWithRootClaim,
+		"root_claim", common.Hash(rootClaim), "output_root", output.OutputRoot, "l2_block_number", l2BlockNumber)
+	return !agreeWithRootClaim, nil
+}
@@ -0,0 +1,102 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetermineAgreement(t *testing.T) {
+	rootClaim := common.Hash{0xaa}
+
+	t.Run("RootClaimMatchesOutput", func(t *testing.T) {
+		game, rollup, m := setupAgreementTest(rootClaim, eth.Bytes32(rootClaim))
+		agree, err := DetermineAgreement(context.Background(), testlog.Logger(t, log.LvlInfo), game, rollup, m)
+		require.NoError(t, err)
+		require.False(t, agree, "should disagree with the proposed output when agreeing with the root claim")
+		require.Equal(t, uint64(1234), rollup.requested)
+		require.Equal(t, []bool{true}, m.agreements)
+	})
+
+	t.Run("RootClaimDiffersFromOutput", func(t *testing.T) {
+		game, rollup, m := setupAgreementTest(rootClaim, eth.Bytes32{0xbb})
+		agree, err := DetermineAgreement(context.Background(), testlog.Logger(t, log.LvlInfo), game, rollup, m)
+		require.NoError(t, err)
+		require.True(t, agree, "should agree with the proposed output when disagreeing with the root claim")
+		require.Equal(t, []bool{false}, m.agreements)
+	})
+
+	t.Run("RollupNodeError", func(t *testing.T) {
+		game, rollup, m := setupAgreementTest(rootClaim, eth.Bytes32(rootClaim))
+		rollup.err = errors.New("boom")
+		_, err := DetermineAgreement(context.Background(), testlog.Logger(t, log.LvlInfo), game, rollup, m)
+		require.ErrorIs(t, err, rollup.err)
+		require.Empty(t, m.agreements)
+		require.Equal(t, 1, m.failures)
+	})
+
+	t.Run("GameContractError", func(t *testing.T) {
+		game, rollup, m := setupAgreementTest(rootClaim, eth.Bytes32(rootClaim))
+		game.err = errors.New("boom")
+		_, err := DetermineAgreement(context.Background(), testlog.Logger(t, log.LvlInfo), game, rollup, m)
+		require.ErrorIs(t, err, game.err)
+		require.Equal(t, 1, m.failures)
+	})
+}
+
+func setupAgreementTest(rootClaim common.Hash, outputRoot eth.Bytes32) (*stubGameClaimCaller, *stubOutputRootSource, *stubAgreementMetrics) {
+	game := &stubGameClaimCaller{rootClaim: rootClaim, l2BlockNumber: big.NewInt(1234)}
+	rollup := &stubOutputRootSource{outputRoot: outputRoot}
+	return game, rollup, &stubAgreementMetrics{Metricer: metrics.NoopMetrics}
+}
+
+type stubGameClaimCaller struct {
+	rootClaim     common.Hash
+	l2BlockNumber *big.Int
+	err           error
+}
+
+func (s *stubGameClaimCaller) RootClaim(opts *bind.CallOpts) ([32]byte, error) {
+	return s.rootClaim, s.err
+}
+
+func (s *stubGameClaimCaller) L2BlockNumber(opts *bind.CallOpts) (*big.Int, error) {
+	return s.l2BlockNumber, s.err
+}
+
+type stubOutputRootSource struct {
+	outputRoot eth.Bytes32
+	requested  uint64
+	err        error
+}
+
+func (s *stubOutputRootSource) OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	s.requested = blockNum
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &eth.OutputResponse{OutputRoot: s.outputRoot}, nil
+}
+
+type stubAgreementMetrics struct {
+	metrics.Metricer
+	agreements []bool
+	failures   int
+}
+
+func (s *stubAgreementMetrics) RecordGameAgreement(agreeWithRootClaim bool) {
+	s.agreements = append(s.agreements, agreeWithRootClaim)
+}
+
+func (s *stubAgreementMetrics) RecordGameAgreementFailure() {
+	s.failures++
+}
@@ -0,0 +1,105 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// requiredBondMethod is the method of the fault dispute game that returns the bond required to post a claim at a position.
+const requiredBondMethod = "getRequiredBond"
+
+// BondSource provides the bond required to post a claim in the game.
+type BondSource interface {
+	RequiredBond(ctx context.Context, position types.Position) (*big.Int, error)
+}
+
+// noBonds is the [BondSource] of games that do not require bonds to post claims.
+type noBonds struct{}
+
+func (noBonds) RequiredBond(ctx context.Context, position types.Position) (*big.Int, error) {
+	return big.NewInt(0), nil
+}
+
+// contractBondSource reads the required bonds from the fault dispute game contract.
+type contractBondSource struct {
+	caller  bind.ContractCaller
+	fdgAddr common.Address
+	fdgAbi  *abi.ABI
+}
+
+// NewBondSource creates a [BondSource] for the fault dispute game at fdgAddr.
+// Bonds are disabled, requiring no bond for any claim, while the fault dispute game contract does not expose the required bonds.
+// Otherwise the required bonds are read from the contract once per position, as they do not change during the game.
+func NewBondSource(fdgAddr common.Address, caller bind.ContractCaller) (BondSource, error) {
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fdgAbi.Methods[requiredBondMethod]; !ok {
+		return noBonds{}, nil
+	}
+	return newCachingBondSource(&contractBondSource{
+		caller:  caller,
+		fdgAddr: fdgAddr,
+		fdgAbi:  fdgAbi,
+	}), nil
+}
+
+// RequiredBond returns the bond in wei required to post a claim at the position.
+func (b *contractBondSource) RequiredBond(ctx context.Context, position types.Position) (*big.Int, error) {
+	data, err := b.fdgAbi.Pack(requiredBondMethod, new(big.Int).SetUint64(position.ToGIndex()))
+	if err != nil {
+		return nil, err
+	}
+	result, err := b.caller.CallContract(ctx, ethereum.CallMsg{To: &b.fdgAddr, Data: data}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("fetch required bond at position %v: %w", position.ToGIndex(), err)
+	}
+	out, err := b.fdgAbi.Unpack(requiredBondMethod, result)
+	if err != nil {
+		return nil, fmt.Errorf("decode required bond: %w", err)
+	}
+	return abi.ConvertType(out[0], new(big.Int)).(*big.Int), nil
+}
+
+// cachingBondSource caches the required bonds of a game by position.
+type cachingBondSource struct {
+	source BondSource
+
+	mu    sync.Mutex
+	bonds map[uint64]*big.Int
+}
+
+func newCachingBondSource(source BondSource) *cachingBondSource {
+	return &cachingBondSource{
+		source: source,
+		bonds:  make(map[uint64]*big.Int),
+	}
+}
+
+func (c *cachingBondSource) RequiredBond(ctx context.Context, position types.Position) (*big.Int, error) {
+	gindex := position.ToGIndex()
+	c.mu.Lock()
+	bond, ok := c.bonds[gindex]
+	c.mu.Unlock()
+	if ok {
+		return bond, nil
+	}
+	bond, err := c.source.RequiredBond(ctx, position)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.bonds[gindex] = bond
+	c.mu.Unlock()
+	return bond, nil
+}
@@ -0,0 +1,44 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequiredBondNotSupportedByGame(t *testing.T) {
+	// The current fault dispute game does not expose required bonds, so bonds are disabled and no contract call is made.
+	bonds, err := NewBondSource(common.Address{0xaa}, nil)
+	require.NoError(t, err)
+	require.IsType(t, noBonds{}, bonds)
+	bond, err := bonds.RequiredBond(context.Background(), types.NewPosition(3, 2))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(0), bond)
+}
+
+func TestCachingBondSource(t *testing.T) {
+	source := &stubBondSource{}
+	bonds := newCachingBondSource(source)
+	for i := 0; i < 3; i++ {
+		bond, err := bonds.RequiredBond(context.Background(), types.NewPosition(3, 2))
+		require.NoError(t, err)
+		require.Equal(t, big.NewInt(3000), bond)
+	}
+	require.Equal(t, 1, source.calls, "should fetch the bond of a position once")
+
+	bond, err := bonds.RequiredBond(context.Background(), types.NewPosition(3, 1))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(3000), bond)
+	require.Equal(t, 2, source.calls, "bonds are cached by position")
+
+	source.err = errors.New("boom")
+	_, err = bonds.RequiredBond(context.Background(), types.NewPosition(1, 0))
+	require.ErrorIs(t, err, source.err)
+	_, err = bonds.RequiredBond(context.Background(), types.NewPosition(1, 0))
+	require.ErrorIs(t, err, source.err, "errors are not cached")
+}
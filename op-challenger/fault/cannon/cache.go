@@ -0,0 +1,227 @@
+package cannon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	hashesDir      = "hashes"
+	stateHashesExt = ".bin"
+)
+
+var (
+	stateHashesNameRegexp = regexp.MustCompile(`^[0-9]+\.bin$`)
+	proofNameRegexp       = regexp.MustCompile(`^[0-9]+\.json$`)
+	// preimageNameRegexp matches the pre-images stored by the op-program server, which fetches them again if they are evicted.
+	preimageNameRegexp = regexp.MustCompile(`^0x[0-9a-f]{64}\.txt$`)
+)
+
+// stateHashesFile is a file of state hashes recorded by cannon, for the count steps from firstStep on.
+type stateHashesFile struct {
+	path      string
+	firstStep uint64
+	count     uint64
+}
+
+// traceCache serves the post-state hashes recorded by cannon for every step it executed,
+// so the claims at trace indices that have been executed before are read from disk instead of running cannon again.
+// It also bounds the total size of the proofs, snapshots, state hashes and pre-images kept in the cannon datadir.
+//
+// The step ranges of the state hashes files are indexed once, and the index is updated as files are evicted,
+// or invalidated once cannon recorded new state hashes.
+type traceCache struct {
+	logger log.Logger
+	dir    string
+	// maxSize is the maximum size of the cached files in bytes, 0 if the cache is unbounded.
+	maxSize int64
+
+	mu sync.Mutex
+	// hashes indexes the state hashes files, nil if they have to be indexed again.
+	hashes []stateHashesFile
+}
+
+// newTraceCache creates a new [traceCache] for the cannon datadir, holding at most maxSizeMiB MiB of files.
+func newTraceCache(logger log.Logger, dir string, maxSizeMiB uint64) *traceCache {
+	return &traceCache{
+		logger:  logger,
+		dir:     dir,
+		maxSize: int64(maxSizeMiB) * 1024 * 1024,
+	}
+}
+
+// StateHash returns the recorded post-state hash of the step at trace index i, or false if it is not recorded.
+func (c *traceCache) StateHash(i uint64) (common.Hash, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.hashes == nil {
+		if err := c.indexStateHashes(); err != nil {
+			return common.Hash{}, false, err
+		}
+	}
+	for _, file := range c.hashes {
+		if i < file.firstStep || i-file.firstStep >= file.count {
+			continue
+		}
+		hash, ok, err := readStateHash(file.path, i)
+		if err != nil {
+			c.logger.Warn("Failed to read state hashes", "path", file.path, "err", err)
+			continue
+		}
+		if ok {
+			markUsed(file.path)
+			return hash, true, nil
+		}
+	}
+	return common.Hash{}, false, nil
+}
+
+// StateHashesRecorded invalidates the index of the state hashes files, after cannon recorded new state hashes.
+func (c *traceCache) StateHashesRecorded() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.hashes = nil
+}
+
+// indexStateHashes indexes the step ranges of the state hashes files. The caller must hold the lock.
+func (c *traceCache) indexStateHashes() error {
+	dir := filepath.Join(c.dir, hashesDir)
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		c.hashes = []stateHashesFile{}
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("list state hashes in %v: %w", dir, err)
+	}
+	hashes := []stateHashesFile{}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !stateHashesNameRegexp.MatchString(name) {
+			continue
+		}
+		if _, err := strconv.ParseUint(strings.TrimSuffix(name, stateHashesExt), 10, 64); err != nil {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		file, err := mipsevm.OpenStateHashes(path)
+		if err != nil {
+			c.logger.Warn("Failed to read state hashes", "path", path, "err", err)
+			continue
+		}
+		hashes = append(hashes, stateHashesFile{path: path, firstStep: file.FirstStep(), count: file.Count()})
+		_ = file.Close()
+	}
+	c.hashes = hashes
+	return nil
+}
+
+// evicted removes an evicted state hashes file from the index.
+func (c *traceCache) evicted(path string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for i, file := range c.hashes {
+		if file.path == path {
+			c.hashes = append(c.hashes[:i], c.hashes[i+1:]...)
+			return
+		}
+	}
+}
+
+func readStateHash(path string, i uint64) (common.Hash, bool, error) {
+	hashes, err := mipsevm.OpenStateHashes(path)
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	defer hashes.Close()
+	if !hashes.Contains(i) {
+		return common.Hash{}, false, nil
+	}
+	hash, err := hashes.PostStateHash(i)
+	if err != nil {
+		return common.Hash{}, false, err
+	}
+	return hash, true, nil
+}
+
+type cachedFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// Evict deletes the least recently used proofs, snapshots, state hashes and pre-images until the cache fits in its maximum size.
+// Pre-images are evicted in the order they were fetched in.
+func (c *traceCache) Evict() error {
+	if c.maxSize == 0 {
+		return nil
+	}
+	var files []cachedFile
+	var total int64
+	for _, dir := range []struct {
+		name    string
+		pattern *regexp.Regexp
+	}{
+		{proofsDir, proofNameRegexp},
+		{snapsDir, snapshotNameRegexp},
+		{hashesDir, stateHashesNameRegexp},
+		{preimagesDir, preimageNameRegexp},
+	} {
+		entries, err := os.ReadDir(filepath.Join(c.dir, dir.name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("list cached files in %v: %w", dir.name, err)
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !dir.pattern.MatchString(entry.Name()) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil {
+				continue // Deleted concurrently
+			}
+			files = append(files, cachedFile{
+				path:    filepath.Join(c.dir, dir.name, entry.Name()),
+				size:    info.Size(),
+				modTime: info.ModTime(),
+			})
+			total += info.Size()
+		}
+	}
+	if total <= c.maxSize {
+		return nil
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, file := range files {
+		if total <= c.maxSize {
+			break
+		}
+		if err := os.Remove(file.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("evict %v: %w", file.path, err)
+		}
+		c.logger.Debug("Evicted cached trace file", "path", file.path, "size", file.size)
+		c.evicted(file.path)
+		total -= file.size
+	}
+	return nil
+}
+
+// markUsed updates the modification time of a cached file, so it is evicted after the files that were used less recently.
+func markUsed(path string) {
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+}
@@ -0,0 +1,138 @@
+package cannon
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCacheStateHash(t *testing.T) {
+	dir := t.TempDir()
+	writeStateHashes(t, dir, 0, 10)
+	writeStateHashes(t, dir, 100, 10)
+	cache := newTraceCache(testlog.Logger(t, log.LvlInfo), dir, 0)
+
+	t.Run("Recorded", func(t *testing.T) {
+		for _, i := range []uint64{0, 9, 100, 109} {
+			hash, ok, err := cache.StateHash(i)
+			require.NoError(t, err)
+			require.True(t, ok)
+			require.Equal(t, stateHash(i), hash)
+		}
+	})
+
+	t.Run("NotRecorded", func(t *testing.T) {
+		for _, i := range []uint64{10, 99, 110} {
+			_, ok, err := cache.StateHash(i)
+			require.NoError(t, err)
+			require.False(t, ok)
+		}
+	})
+
+	t.Run("NoHashesDir", func(t *testing.T) {
+		cache := newTraceCache(testlog.Logger(t, log.LvlInfo), t.TempDir(), 0)
+		_, ok, err := cache.StateHash(0)
+		require.NoError(t, err)
+		require.False(t, ok)
+	})
+}
+
+func TestTraceCacheStateHashesIndex(t *testing.T) {
+	dir := t.TempDir()
+	writeStateHashes(t, dir, 0, 10)
+	cache := newTraceCache(testlog.Logger(t, log.LvlInfo), dir, 0)
+	_, ok, err := cache.StateHash(5)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, []stateHashesFile{{path: filepath.Join(dir, hashesDir, "0.bin"), firstStep: 0, count: 10}}, cache.hashes)
+
+	// New files are only indexed once cannon recorded new state hashes
+	writeStateHashes(t, dir, 100, 10)
+	_, ok, err = cache.StateHash(105)
+	require.NoError(t, err)
+	require.False(t, ok, "should not list the state hashes files on each lookup")
+	cache.StateHashesRecorded()
+	hash, ok, err := cache.StateHash(105)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, stateHash(105), hash)
+
+	// Evicted files are removed from the index
+	cache.maxSize = 1
+	require.NoError(t, os.Chtimes(filepath.Join(dir, hashesDir, "0.bin"), time.Now(), time.Now().Add(-time.Hour)))
+	require.NoError(t, cache.Evict())
+	require.Empty(t, cache.hashes)
+	_, ok, err = cache.StateHash(5)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestProviderServesRecordedStateHashes(t *testing.T) {
+	dataDir, prestate := setupTestData(t)
+	writeStateHashes(t, dataDir, 1000, 10)
+	provider, generator := setupWithTestData(dataDir, prestate)
+	value, err := provider.Get(context.Background(), 1005)
+	require.NoError(t, err)
+	require.Equal(t, stateHash(1005), value)
+	require.Empty(t, generator.generated)
+}
+
+func TestTraceCacheEvict(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	writeCacheFile := func(subdir string, name string, size int, age time.Duration) string {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, subdir), 0o755))
+		path := filepath.Join(dir, subdir, name)
+		require.NoError(t, os.WriteFile(path, make([]byte, size), 0o644))
+		require.NoError(t, os.Chtimes(path, now.Add(-age), now.Add(-age)))
+		return path
+	}
+	oldProof := writeCacheFile(proofsDir, "1.json", 1024*1024, 4*time.Hour)
+	oldPreimage := writeCacheFile(preimagesDir, common.Hash{0xaa}.String()+".txt", 1024*1024, 4*time.Hour)
+	oldSnapshot := writeCacheFile(snapsDir, "100.json.gz", 1024*1024, 3*time.Hour)
+	hashes := writeCacheFile(hashesDir, "0.bin", 1024*1024, 2*time.Hour)
+	proof := writeCacheFile(proofsDir, "2.json", 1024*1024, time.Hour)
+	preimage := writeCacheFile(preimagesDir, common.Hash{0xbb}.String()+".txt", 1024*1024, time.Hour)
+	unrelated := writeCacheFile(snapsDir, "other", 4*1024*1024, 5*time.Hour)
+
+	cache := newTraceCache(testlog.Logger(t, log.LvlInfo), dir, 3)
+	require.NoError(t, cache.Evict())
+	require.NoFileExists(t, oldProof)
+	require.NoFileExists(t, oldPreimage, "should evict pre-images")
+	require.NoFileExists(t, oldSnapshot)
+	require.FileExists(t, hashes)
+	require.FileExists(t, proof)
+	require.FileExists(t, preimage)
+	require.FileExists(t, unrelated, "should only evict cache files")
+
+	t.Run("Unbounded", func(t *testing.T) {
+		cache := newTraceCache(testlog.Logger(t, log.LvlInfo), dir, 0)
+		require.NoError(t, cache.Evict())
+		require.FileExists(t, hashes)
+		require.FileExists(t, proof)
+	})
+}
+
+func writeStateHashes(t *testing.T, dir string, firstStep uint64, count uint64) {
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, hashesDir), 0o755))
+	w, err := mipsevm.NewStateHashWriter(filepath.Join(dir, hashesDir, fmt.Sprintf("%d.bin", firstStep)), firstStep)
+	require.NoError(t, err)
+	for i := firstStep; i < firstStep+count; i++ {
+		require.NoError(t, w.Write(stateHash(i)))
+	}
+	require.NoError(t, w.Close())
+}
+
+func stateHash(i uint64) common.Hash {
+	return common.BigToHash(new(big.Int).SetUint64(i + 1))
+}
@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -17,10 +18,12 @@ import (
 
 const (
 	snapsDir     = "snapshots"
-	preimagesDir = "snapshots"
+	preimagesDir = "preimages"
+	// snapshotExt is the extension of the snapshots written by the executor, in the compressed binary state format to save disk space.
+	snapshotExt = ".bin.gz"
 )
 
-var snapshotNameRegexp = regexp.MustCompile(`^[0-9]+\.json$`)
+var snapshotNameRegexp = regexp.MustCompile(`^[0-9]+\.(json|bin)(\.gz)?$`)
 
 type snapshotSelect func(logger log.Logger, dir string, absolutePreState string, i uint64) (string, error)
 type cmdExecutor func(ctx context.Context, l log.Logger, binary string, args ...string) error
@@ -60,6 +63,14 @@ func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) erro
 	if err != nil {
 		return fmt.Errorf("find starting snapshot: %w", err)
 	}
+	if start != e.absolutePreState {
+		markUsed(start)
+	}
+	for _, outDir := range []string{filepath.Join(dir, proofsDir), filepath.Join(e.dataDir, snapsDir), filepath.Join(e.dataDir, hashesDir)} {
+		if err := os.MkdirAll(outDir, 0o755); err != nil {
+			return fmt.Errorf("create output dir %v: %w", outDir, err)
+		}
+	}
 	args := []string{
 		"run",
 		"--input", start,
@@ -67,7 +78,8 @@ func (e *Executor) GenerateProof(ctx context.Context, dir string, i uint64) erro
 		"--stop-at", "=" + strconv.FormatUint(i+1, 10),
 		"--proof-fmt", filepath.Join(dir, proofsDir, "%d.json"),
 		"--snapshot-at", "%" + strconv.FormatUint(uint64(e.snapshotFreq), 10),
-		"--snapshot-fmt", filepath.Join(e.dataDir, snapsDir, "%d.json"),
+		"--snapshot-fmt", filepath.Join(e.dataDir, snapsDir, "%d"+snapshotExt),
+		"--state-hashes-fmt", filepath.Join(e.dataDir, hashesDir, "%d"+stateHashesExt),
 		"--",
 		e.server,
 		"--l1", e.l1,
@@ -108,6 +120,7 @@ func findStartingSnapshot(logger log.Logger, snapDir string, absolutePreState st
 		return "", fmt.Errorf("list snapshots in %v: %w", snapDir, err)
 	}
 	bestSnap := uint64(0)
+	bestName := ""
 	for _, entry := range entries {
 		if entry.IsDir() {
 			logger.Warn("Unexpected directory in snapshots dir: %v/%v", snapDir, entry.Name())
@@ -118,19 +131,18 @@ func findStartingSnapshot(logger log.Logger, snapDir string, absolutePreState st
 			logger.Warn("Unexpected file in snapshots dir: %v/%v", snapDir, entry.Name())
 			continue
 		}
-		index, err := strconv.ParseUint(name[0:len(name)-len(".json")], 10, 64)
+		index, err := strconv.ParseUint(name[:strings.Index(name, ".")], 10, 64)
 		if err != nil {
 			logger.Error("Unable to parse trace index of snapshot file: %v/%v", snapDir, entry.Name())
 			continue
 		}
 		if index > bestSnap && index < traceIndex {
 			bestSnap = index
+			bestName = name
 		}
 	}
 	if bestSnap == 0 {
 		return absolutePreState, nil
 	}
-	startFrom := fmt.Sprintf("%v/%v.json", snapDir, bestSnap)
-
-	return startFrom, nil
+	return filepath.Join(snapDir, bestName), nil
 }
@@ -65,7 +65,11 @@ func TestGenerateProof(t *testing.T) {
 	require.Equal(t, cfg.CannonL2, args["--l2"])
 	require.Equal(t, filepath.Join(cfg.CannonDatadir, preimagesDir), args["--datadir"])
 	require.Equal(t, filepath.Join(cfg.CannonDatadir, proofsDir, "%d.json"), args["--proof-fmt"])
-	require.Equal(t, filepath.Join(cfg.CannonDatadir, snapsDir, "%d.json"), args["--snapshot-fmt"])
+	require.Equal(t, filepath.Join(cfg.CannonDatadir, snapsDir, "%d.bin.gz"), args["--snapshot-fmt"])
+	require.Equal(t, filepath.Join(cfg.CannonDatadir, hashesDir, "%d.bin"), args["--state-hashes-fmt"])
+	require.DirExists(t, filepath.Join(cfg.CannonDatadir, proofsDir))
+	require.DirExists(t, filepath.Join(cfg.CannonDatadir, snapsDir))
+	require.DirExists(t, filepath.Join(cfg.CannonDatadir, hashesDir))
 
 	// Local game inputs
 	require.Equal(t, inputs.l1Head.Hex(), args["--l1.head"])
@@ -146,6 +150,23 @@ func TestFindStartingSnapshot(t *testing.T) {
 		require.Equal(t, filepath.Join(dir, "250.json"), snapshot)
 	})
 
+	t.Run("CompressedSnapshots", func(t *testing.T) {
+		dir := withSnapshots(t, "100.json", "200.json.gz", "300.json.gz")
+		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 250)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "200.json.gz"), snapshot)
+	})
+
+	t.Run("BinarySnapshots", func(t *testing.T) {
+		dir := withSnapshots(t, "100.json.gz", "200.bin.gz", "300.bin")
+		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 350)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "300.bin"), snapshot)
+		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 250)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "200.bin.gz"), snapshot)
+	})
+
 	t.Run("IgnoreDirectories", func(t *testing.T) {
 		dir := withSnapshots(t, "100.json")
 		require.NoError(t, os.Mkdir(filepath.Join(dir, "120.json"), 0o777))
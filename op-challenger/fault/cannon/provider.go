@@ -7,10 +7,12 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
 	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -38,29 +40,46 @@ type ProofGenerator interface {
 }
 
 type CannonTraceProvider struct {
+	logger    log.Logger
 	dir       string
 	prestate  string
 	generator ProofGenerator
+	cache     *traceCache
+	recorder  db.GameRecorder
 }
 
-func NewTraceProvider(ctx context.Context, logger log.Logger, cfg *config.Config, l1Client bind.ContractCaller) (*CannonTraceProvider, error) {
+// NewTraceProvider creates a trace provider for the game of cfg. The proofs it generates are recorded with the recorder.
+func NewTraceProvider(ctx context.Context, logger log.Logger, cfg *config.Config, l1Client bind.ContractCaller, recorder db.GameRecorder) (*CannonTraceProvider, error) {
+	gameCaller, err := bindings.NewFaultDisputeGameCaller(cfg.GameAddress, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("create caller for game %v: %w", cfg.GameAddress, err)
+	}
+	return newTraceProvider(ctx, logger, cfg, gameCaller, recorder)
+}
+
+// NewTraceProviderFromInputs creates a trace provider for a game with the given inputs, so the trace can be created
+// before the game is. The game address of cfg is only used to report errors.
+func NewTraceProviderFromInputs(ctx context.Context, logger log.Logger, cfg *config.Config, inputs GameInputsSource, recorder db.GameRecorder) (*CannonTraceProvider, error) {
+	return newTraceProvider(ctx, logger, cfg, inputs, recorder)
+}
+
+func newTraceProvider(ctx context.Context, logger log.Logger, cfg *config.Config, gameCaller GameInputsSource, recorder db.GameRecorder) (*CannonTraceProvider, error) {
 	l2Client, err := ethclient.DialContext(ctx, cfg.CannonL2)
 	if err != nil {
 		return nil, fmt.Errorf("dial l2 cleint %v: %w", cfg.CannonL2, err)
 	}
 	defer l2Client.Close() // Not needed after fetching the inputs
-	gameCaller, err := bindings.NewFaultDisputeGameCaller(cfg.GameAddress, l1Client)
-	if err != nil {
-		return nil, fmt.Errorf("create caller for game %v: %w", cfg.GameAddress, err)
-	}
 	l1Head, err := fetchLocalInputs(ctx, cfg.GameAddress, gameCaller, l2Client)
 	if err != nil {
 		return nil, fmt.Errorf("fetch local game inputs: %w", err)
 	}
 	return &CannonTraceProvider{
+		logger:    logger,
 		dir:       cfg.CannonDatadir,
 		prestate:  cfg.CannonAbsolutePreState,
 		generator: NewExecutor(logger, cfg, l1Head),
+		cache:     newTraceCache(logger, cfg.CannonDatadir, cfg.CannonCacheSize),
+		recorder:  recorder,
 	}, nil
 }
 
@@ -73,7 +92,14 @@ func (p *CannonTraceProvider) GetOracleData(ctx context.Context, i uint64) (*typ
 	return &data, nil
 }
 
+// Get returns the post-state hash of the step at trace index i. Steps executed by cannon before are served from
+// the recorded state hashes, other steps are executed from the closest snapshot.
 func (p *CannonTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	if hash, ok, err := p.cache.StateHash(i); err != nil {
+		p.logger.Warn("Failed to read cached state hash", "index", i, "err", err)
+	} else if ok {
+		return hash, nil
+	}
 	proof, err := p.loadProof(ctx, i)
 	if err != nil {
 		return common.Hash{}, err
@@ -103,27 +129,31 @@ func (p *CannonTraceProvider) GetPreimage(ctx context.Context, i uint64) ([]byte
 }
 
 func (p *CannonTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
-	path := filepath.Join(p.dir, p.prestate)
-	file, err := os.Open(path)
-	if err != nil {
-		return []byte{}, fmt.Errorf("cannot open state file (%v): %w", path, err)
+	path := p.prestate
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(p.dir, path)
 	}
-	defer file.Close()
-	var state mipsevm.State
-	err = json.NewDecoder(file).Decode(&state)
-	if err != nil {
+	witness, err := mipsevm.LoadStateWitness(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []byte{}, fmt.Errorf("cannot open state file (%v): %w", path, err)
+	} else if err != nil {
 		return []byte{}, fmt.Errorf("invalid mipsevm state (%v): %w", path, err)
 	}
-	return state.EncodeWitness(), nil
+	return witness, nil
 }
 
 func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofData, error) {
 	path := filepath.Join(p.dir, proofsDir, fmt.Sprintf("%d.json", i))
 	file, err := os.Open(path)
+	generated := false
 	if errors.Is(err, os.ErrNotExist) {
-		if err := p.generator.GenerateProof(ctx, p.dir, i); err != nil {
-			return nil, fmt.Errorf("generate cannon trace with proof at %v: %w", i, err)
+		genErr := p.generator.GenerateProof(ctx, p.dir, i)
+		// cannon records state hashes for the steps it executed, even if it failed to generate the proof
+		p.cache.StateHashesRecorded()
+		if genErr != nil {
+			return nil, fmt.Errorf("generate cannon trace with proof at %v: %w", i, genErr)
 		}
+		generated = true
 		// Try opening the file again now and it should exist.
 		file, err = os.Open(path)
 	}
@@ -131,10 +161,25 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofDa
 		return nil, fmt.Errorf("cannot open proof file (%v): %w", path, err)
 	}
 	defer file.Close()
+	if generated {
+		if err := p.cache.Evict(); err != nil {
+			p.logger.Warn("Failed to evict cached trace files", "err", err)
+		}
+	} else {
+		markUsed(path)
+	}
 	var proof proofData
 	err = json.NewDecoder(file).Decode(&proof)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read proof (%v): %w", path, err)
 	}
+	if generated {
+		p.recorder.RecordProof(db.Proof{
+			Time:       uint64(time.Now().Unix()),
+			TraceIndex: i,
+			ClaimValue: common.BytesToHash(proof.ClaimValue),
+			Dir:        p.dir,
+		})
+	}
 	return &proof, nil
 }
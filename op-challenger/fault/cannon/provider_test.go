@@ -1,15 +1,21 @@
 package cannon
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"embed"
 	_ "embed"
+	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
@@ -50,6 +56,26 @@ func TestGet(t *testing.T) {
 	})
 }
 
+func TestRecordGeneratedProofs(t *testing.T) {
+	dataDir, prestate := setupTestData(t)
+	provider, generator := setupWithTestData(dataDir, prestate)
+	recorder := &stubGameRecorder{GameRecorder: db.NoopGameRecorder}
+	provider.recorder = recorder
+	generator.proof = &proofData{ClaimValue: common.Hash{0xaa}.Bytes()}
+
+	_, err := provider.Get(context.Background(), 0)
+	require.NoError(t, err)
+	require.Empty(t, recorder.proofs, "should not record existing proofs")
+
+	value, err := provider.Get(context.Background(), 8)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0xaa}, value)
+	require.Len(t, recorder.proofs, 1)
+	require.Equal(t, uint64(8), recorder.proofs[0].TraceIndex)
+	require.Equal(t, common.Hash{0xaa}, recorder.proofs[0].ClaimValue)
+	require.Equal(t, dataDir, recorder.proofs[0].Dir)
+}
+
 func TestGetOracleData(t *testing.T) {
 	dataDir, prestate := setupTestData(t)
 	t.Run("ExistingProof", func(t *testing.T) {
@@ -163,6 +189,25 @@ func TestAbsolutePreState(t *testing.T) {
 		}
 		require.Equal(t, state.EncodeWitness(), preState)
 	})
+
+	t.Run("BinaryAbsolutePreState", func(t *testing.T) {
+		state := mipsevm.State{
+			Memory: mipsevm.NewMemory(),
+			PC:     0x1000,
+			NextPC: 0x1004,
+		}
+		state.Memory.SetMemory(0x1000, 0x24020001)
+		var buf bytes.Buffer
+		g := gzip.NewWriter(&buf)
+		require.NoError(t, state.Serialize(g))
+		require.NoError(t, g.Close())
+		require.NoError(t, os.WriteFile(filepath.Join(dataDir, "state.bin.gz"), buf.Bytes(), 0o644))
+
+		provider, _ := setupWithTestData(dataDir, "state.bin.gz")
+		preState, err := provider.AbsolutePreState(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, state.EncodeWitness(), preState)
+	})
 }
 
 func setupPreState(t *testing.T, dataDir string, filename string) {
@@ -192,18 +237,45 @@ func setupTestData(t *testing.T) (string, string) {
 
 func setupWithTestData(dataDir string, prestate string) (*CannonTraceProvider, *stubGenerator) {
 	generator := &stubGenerator{}
+	logger := log.New()
 	return &CannonTraceProvider{
+		logger:    logger,
 		dir:       dataDir,
 		generator: generator,
 		prestate:  prestate,
+		cache:     newTraceCache(logger, dataDir, 0),
+		recorder:  db.NoopGameRecorder,
 	}, generator
 }
 
 type stubGenerator struct {
 	generated []int // Using int makes assertions easier
+	// proof is written as the generated proof file, if set.
+	proof *proofData
 }
 
 func (e *stubGenerator) GenerateProof(ctx context.Context, dir string, i uint64) error {
 	e.generated = append(e.generated, int(i))
-	return nil
+	if e.proof == nil {
+		return nil
+	}
+	data, err := json.Marshal(e.proof)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json", i)), data, 0o644)
+}
+
+type stubGameRecorder struct {
+	db.GameRecorder
+	proofs  []db.Proof
+	actions []db.Action
+}
+
+func (s *stubGameRecorder) RecordAction(action db.Action) {
+	s.actions = append(s.actions, action)
+}
+
+func (s *stubGameRecorder) RecordProof(proof db.Proof) {
+	s.proofs = append(s.proofs, proof)
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
 	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
@@ -18,8 +20,9 @@ import (
 // cannonUpdater is a [types.OracleUpdater] that exposes a method
 // to update onchain cannon oracles with required data.
 type cannonUpdater struct {
-	log   log.Logger
-	txMgr txmgr.TxManager
+	log      log.Logger
+	txMgr    txmgr.TxManager
+	recorder db.GameRecorder
 
 	fdgAbi  abi.ABI
 	fdgAddr common.Address
@@ -28,12 +31,13 @@ type cannonUpdater struct {
 	preimageOracleAddr common.Address
 }
 
-// NewOracleUpdater returns a new updater.
+// NewOracleUpdater returns a new updater. The preimages it uploads are recorded with the recorder.
 func NewOracleUpdater(
 	logger log.Logger,
 	txMgr txmgr.TxManager,
 	fdgAddr common.Address,
 	preimageOracleAddr common.Address,
+	recorder db.GameRecorder,
 ) (*cannonUpdater, error) {
 	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
 	if err != nil {
@@ -45,8 +49,9 @@ func NewOracleUpdater(
 	}
 
 	return &cannonUpdater{
-		log:   logger,
-		txMgr: txMgr,
+		log:      logger,
+		txMgr:    txMgr,
+		recorder: recorder,
 
 		fdgAbi:  *fdgAbi,
 		fdgAddr: fdgAddr,
@@ -70,7 +75,7 @@ func (u *cannonUpdater) sendLocalOracleData(ctx context.Context, data types.Prei
 	if err != nil {
 		return fmt.Errorf("local oracle tx data build: %w", err)
 	}
-	return u.sendTxAndWait(ctx, u.fdgAddr, txData)
+	return u.sendTxAndWait(ctx, u.fdgAddr, txData, data)
 }
 
 // sendGlobalOracleData sends the global oracle data to the [txmgr].
@@ -79,7 +84,7 @@ func (u *cannonUpdater) sendGlobalOracleData(ctx context.Context, data types.Pre
 	if err != nil {
 		return fmt.Errorf("global oracle tx data build: %w", err)
 	}
-	return u.sendTxAndWait(ctx, u.fdgAddr, txData)
+	return u.sendTxAndWait(ctx, u.preimageOracleAddr, txData, data)
 }
 
 // BuildLocalOracleData takes the local preimage key and data
@@ -106,7 +111,8 @@ func (u *cannonUpdater) BuildGlobalOracleData(data types.PreimageOracleData) ([]
 
 // sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
-func (u *cannonUpdater) sendTxAndWait(ctx context.Context, addr common.Address, txData []byte) error {
+// Once the tx is included, the upload of the preimage data is recorded along with the tx hash.
+func (u *cannonUpdater) sendTxAndWait(ctx context.Context, addr common.Address, txData []byte, data types.PreimageOracleData) error {
 	receipt, err := u.txMgr.Send(ctx, txmgr.TxCandidate{
 		To:       &addr,
 		TxData:   txData,
@@ -115,6 +121,14 @@ func (u *cannonUpdater) sendTxAndWait(ctx context.Context, addr common.Address,
 	if err != nil {
 		return err
 	}
+	u.recorder.RecordAction(db.Action{
+		Time:         uint64(time.Now().Unix()),
+		Type:         db.ActionPreimage,
+		OracleKey:    data.OracleKey,
+		OracleOffset: data.OracleOffset,
+		TxHash:       receipt.TxHash,
+		Success:      receipt.Status != ethtypes.ReceiptStatusFailed,
+	})
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
 		u.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
 	} else {
@@ -6,6 +6,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
 	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 
@@ -29,6 +30,7 @@ var (
 type mockTxManager struct {
 	from        common.Address
 	sends       int
+	sentTo      []common.Address
 	failedSends int
 	sendFails   bool
 }
@@ -39,6 +41,7 @@ func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (
 		return nil, mockSendError
 	}
 	m.sends++
+	m.sentTo = append(m.sentTo, *candidate.To)
 	return ethtypes.NewReceipt(
 		[]byte{},
 		false,
@@ -64,7 +67,7 @@ func newTestCannonUpdater(t *testing.T, sendFails bool) (*cannonUpdater, *mockTx
 		from:      mockFdgAddress,
 		sendFails: sendFails,
 	}
-	updater, err := NewOracleUpdater(logger, txMgr, mockFdgAddress, mockPreimageOracleAddress)
+	updater, err := NewOracleUpdater(logger, txMgr, mockFdgAddress, mockPreimageOracleAddress, db.NoopGameRecorder)
 	require.NoError(t, err)
 	return updater, txMgr
 }
@@ -80,6 +83,33 @@ func TestCannonUpdater_UpdateOracle(t *testing.T) {
 		require.Equal(t, 1, mockTxMgr.sends)
 	})
 
+	t.Run("sends global data to the preimage oracle", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		data := types.NewPreimageOracleData(common.Hex2Bytes("02aa"), common.Hex2Bytes("0000000000000002cccc"), 4)
+		require.NoError(t, updater.UpdateOracle(context.Background(), data))
+		require.Equal(t, []common.Address{mockPreimageOracleAddress}, mockTxMgr.sentTo)
+	})
+
+	t.Run("sends local data to the game", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		data := types.NewPreimageOracleData(common.Hex2Bytes("01aa"), common.Hex2Bytes("0000000000000002cccc"), 4)
+		require.NoError(t, updater.UpdateOracle(context.Background(), data))
+		require.Equal(t, []common.Address{mockFdgAddress}, mockTxMgr.sentTo)
+	})
+
+	t.Run("records upload", func(t *testing.T) {
+		updater, _ := newTestCannonUpdater(t, false)
+		recorder := &stubGameRecorder{GameRecorder: db.NoopGameRecorder}
+		updater.recorder = recorder
+		data := types.NewPreimageOracleData(common.Hex2Bytes("02aa"), common.Hex2Bytes("0000000000000002cccc"), 4)
+		require.NoError(t, updater.UpdateOracle(context.Background(), data))
+		require.Len(t, recorder.actions, 1)
+		require.Equal(t, db.ActionPreimage, recorder.actions[0].Type)
+		require.Equal(t, common.Hex2Bytes("02aa"), []byte(recorder.actions[0].OracleKey))
+		require.Equal(t, uint32(4), recorder.actions[0].OracleOffset)
+		require.True(t, recorder.actions[0].Success)
+	})
+
 	t.Run("send fails", func(t *testing.T) {
 		updater, mockTxMgr := newTestCannonUpdater(t, true)
 		require.Error(t, updater.UpdateOracle(context.Background(), types.PreimageOracleData{
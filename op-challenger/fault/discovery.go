@@ -0,0 +1,146 @@
+package fault
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// faultGameType is the game type of fault dispute games in the dispute game factory (GameTypes.FAULT).
+const faultGameType uint8 = 0
+
+// GameMetadata describes a dispute game created by the dispute game factory.
+type GameMetadata struct {
+	Address   common.Address `json:"address"`
+	RootClaim common.Hash    `json:"rootClaim"`
+	// L1Block is the number of the L1 block the game was created in.
+	L1Block  uint64 `json:"l1Block"`
+	Resolved bool   `json:"resolved"`
+}
+
+// GameSource discovers the fault dispute games created in a range of L1 blocks.
+type GameSource interface {
+	FetchGames(ctx context.Context, from uint64, to uint64) ([]GameMetadata, error)
+}
+
+type factoryGameSource struct {
+	filterer *bindings.DisputeGameFactoryFilterer
+}
+
+// NewFactoryGameSource creates a GameSource that discovers games from the DisputeGameCreated events of the factory.
+func NewFactoryGameSource(factoryAddr common.Address, client bind.ContractFilterer) (*factoryGameSource, error) {
+	filterer, err := bindings.NewDisputeGameFactoryFilterer(factoryAddr, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind the dispute game factory: %w", err)
+	}
+	return &factoryGameSource{filterer: filterer}, nil
+}
+
+func (f *factoryGameSource) FetchGames(ctx context.Context, from uint64, to uint64) ([]GameMetadata, error) {
+	it, err := f.filterer.FilterDisputeGameCreated(&bind.FilterOpts{Start: from, End: &to, Context: ctx}, nil, []uint8{faultGameType}, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter games created in blocks %d - %d: %w", from, to, err)
+	}
+	defer it.Close()
+	var games []GameMetadata
+	for it.Next() {
+		games = append(games, GameMetadata{
+			Address:   it.Event.DisputeProxy,
+			RootClaim: it.Event.RootClaim,
+			L1Block:   it.Event.Raw.BlockNumber,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to read games created in blocks %d - %d: %w", from, to, err)
+	}
+	return games, nil
+}
+
+// knownGames is the persisted state of the game discovery.
+type knownGames struct {
+	// NextBlock is the next L1 block to scan for new games.
+	NextBlock uint64         `json:"nextBlock"`
+	Games     []GameMetadata `json:"games"`
+}
+
+// gameStore persists the games discovered from the factory, so they are still tracked after a restart.
+type gameStore struct {
+	path  string
+	state knownGames
+	index map[common.Address]int
+}
+
+// loadGameStore loads the known games from path. If the file does not exist yet, discovery starts at startBlock.
+func loadGameStore(path string, startBlock uint64) (*gameStore, error) {
+	s := &gameStore{
+		path:  path,
+		state: knownGames{NextBlock: startBlock},
+		index: make(map[common.Address]int),
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read known games: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to decode known games (%v): %w", path, err)
+	}
+	for i, game := range s.state.Games {
+		s.index[game.Address] = i
+	}
+	return s, nil
+}
+
+// add adds the game, and returns false if the game was already known.
+func (s *gameStore) add(game GameMetadata) bool {
+	if _, ok := s.index[game.Address]; ok {
+		return false
+	}
+	s.index[game.Address] = len(s.state.Games)
+	s.state.Games = append(s.state.Games, game)
+	return true
+}
+
+func (s *gameStore) markResolved(addr common.Address) {
+	if i, ok := s.index[addr]; ok {
+		s.state.Games[i].Resolved = true
+	}
+}
+
+// inProgress returns the games that are not resolved yet, in order of discovery.
+func (s *gameStore) inProgress() []GameMetadata {
+	var games []GameMetadata
+	for _, game := range s.state.Games {
+		if !game.Resolved {
+			games = append(games, game)
+		}
+	}
+	return games
+}
+
+// save writes the known games to a temporary file first, so a crash never leaves a partially written file behind.
+func (s *gameStore) save() error {
+	data, err := json.Marshal(&s.state)
+	if err != nil {
+		return fmt.Errorf("failed to encode known games: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create known games directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write known games: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to replace known games: %w", err)
+	}
+	return nil
+}
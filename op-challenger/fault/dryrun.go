@@ -0,0 +1,114 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// dryRunTxManager is a [txmgr.TxManager] that logs the decoded calldata of the transactions it is asked to send,
+// instead of sending them. Calls are still made against the underlying transaction manager.
+// Since nothing is sent, the game does not progress and the same transactions are requested again on each update,
+// so each distinct transaction is only logged at info level the first time it is requested.
+type dryRunTxManager struct {
+	txmgr.TxManager
+	logger log.Logger
+	abis   []*abi.ABI
+
+	lock sync.Mutex
+	seen map[common.Hash]bool
+}
+
+// NewDryRunTxManager creates a [txmgr.TxManager] that logs transactions to the fault dispute game and
+// preimage oracle contracts instead of sending them through txMgr.
+func NewDryRunTxManager(logger log.Logger, txMgr txmgr.TxManager) (txmgr.TxManager, error) {
+	var abis []*abi.ABI
+	for _, metadata := range []*bind.MetaData{bindings.FaultDisputeGameMetaData, bindings.PreimageOracleMetaData} {
+		contractAbi, err := metadata.GetAbi()
+		if err != nil {
+			return nil, err
+		}
+		abis = append(abis, contractAbi)
+	}
+	return &dryRunTxManager{
+		TxManager: txMgr,
+		logger:    logger,
+		abis:      abis,
+		seen:      make(map[common.Hash]bool),
+	}, nil
+}
+
+// Send logs the decoded candidate transaction and returns a successful receipt without sending it.
+func (d *dryRunTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
+	var to common.Address
+	if candidate.To != nil {
+		to = *candidate.To
+	}
+	value := candidate.Value
+	if value == nil {
+		value = big.NewInt(0)
+	}
+	// The hash identifies the transaction, it is returned as the transaction hash of the receipt
+	id := crypto.Keccak256Hash(to[:], value.Bytes(), candidate.TxData)
+	method, args := d.decode(candidate.TxData)
+
+	d.lock.Lock()
+	seen := d.seen[id]
+	d.seen[id] = true
+	d.lock.Unlock()
+	logFn := d.logger.Info
+	if seen {
+		logFn = d.logger.Debug
+	}
+	logFn("Dry run: not sending transaction", "to", to, "value", value, "method", method, "args", args)
+	return &ethtypes.Receipt{
+		Status: ethtypes.ReceiptStatusSuccessful,
+		TxHash: id,
+	}, nil
+}
+
+// decode returns the name and the formatted arguments of the contract method called with data.
+func (d *dryRunTxManager) decode(data []byte) (string, string) {
+	if len(data) < 4 {
+		return "unknown", hexutil.Encode(data)
+	}
+	for _, contractAbi := range d.abis {
+		method, err := contractAbi.MethodById(data[:4])
+		if err != nil {
+			continue
+		}
+		values, err := method.Inputs.Unpack(data[4:])
+		if err != nil {
+			return method.Name, hexutil.Encode(data[4:])
+		}
+		args := make([]string, len(values))
+		for i, value := range values {
+			args[i] = fmt.Sprintf("%v=%v", method.Inputs[i].Name, formatArg(value))
+		}
+		return method.Name, strings.Join(args, " ")
+	}
+	return "unknown", hexutil.Encode(data)
+}
+
+func formatArg(value any) string {
+	switch v := value.(type) {
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Encode(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,80 @@
+package fault
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRunTxManager(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlDebug)
+	mockTxMgr := &mockTxManager{from: common.Address{0xaa}}
+	dryRun, err := NewDryRunTxManager(logger, mockTxMgr)
+	require.NoError(t, err)
+
+	t.Run("DoesNotSend", func(t *testing.T) {
+		responder, err := NewFaultResponder(logger, dryRun, mockFdgAddress, &stubBondSource{}, metrics.NoopMetrics, db.NoopGameRecorder)
+		require.NoError(t, err)
+		err = responder.Respond(context.Background(), types.Claim{
+			ClaimData: types.ClaimData{
+				Value:    common.Hash{0xbb},
+				Position: types.NewPosition(1, 0),
+			},
+		})
+		require.NoError(t, err)
+		require.NoError(t, responder.Resolve(context.Background()))
+		require.Zero(t, mockTxMgr.sends)
+	})
+
+	t.Run("ReturnsSuccessfulReceipt", func(t *testing.T) {
+		receipt, err := dryRun.Send(context.Background(), txmgr.TxCandidate{To: &mockFdgAddress, TxData: []byte{1, 2, 3}})
+		require.NoError(t, err)
+		require.Equal(t, ethtypes.ReceiptStatusSuccessful, receipt.Status)
+		require.Zero(t, mockTxMgr.sends)
+	})
+
+	t.Run("DelegatesCalls", func(t *testing.T) {
+		_, err := dryRun.Call(context.Background(), ethereum.CallMsg{To: &mockFdgAddress}, nil)
+		require.NoError(t, err)
+		require.Equal(t, 1, mockTxMgr.calls)
+		require.Equal(t, mockTxMgr.from, dryRun.From())
+	})
+}
+
+func TestDryRunTxManagerDecode(t *testing.T) {
+	txMgr, err := NewDryRunTxManager(testlog.Logger(t, log.LvlDebug), &mockTxManager{})
+	require.NoError(t, err)
+	dryRun := txMgr.(*dryRunTxManager)
+
+	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err := fdgAbi.Pack("attack", big.NewInt(3), common.Hash{0xcc})
+	require.NoError(t, err)
+	method, args := dryRun.decode(data)
+	require.Equal(t, "attack", method)
+	require.Equal(t, "_parentIndex=3 _claim="+common.Hash{0xcc}.Hex(), args)
+
+	oracleAbi, err := bindings.PreimageOracleMetaData.GetAbi()
+	require.NoError(t, err)
+	data, err = oracleAbi.Pack("loadKeccak256PreimagePart", big.NewInt(8), []byte{0xdd, 0xee})
+	require.NoError(t, err)
+	method, args = dryRun.decode(data)
+	require.Equal(t, "loadKeccak256PreimagePart", method)
+	require.Equal(t, "_partOffset=8 _preimage=0xddee", args)
+
+	method, args = dryRun.decode([]byte{1, 2})
+	require.Equal(t, "unknown", method)
+	require.Equal(t, "0x0102", args)
+}
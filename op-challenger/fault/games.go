@@ -0,0 +1,197 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// maxDiscoveryBlockRange is the max number of L1 blocks to fetch game creation events for in a single request.
+const maxDiscoveryBlockRange = 10_000
+
+// gamePlayer progresses a single game.
+type gamePlayer interface {
+	// ProgressGame performs the next actions in the game, and returns true once the game is resolved.
+	ProgressGame(ctx context.Context) bool
+}
+
+type playerCreator func(ctx context.Context, addr common.Address) (gamePlayer, error)
+
+type blockNumberFetcher func(ctx context.Context) (uint64, error)
+
+// gameMonitor discovers the games of the dispute game factory, and progresses all games that are in progress.
+//
+// Each game is progressed by its own worker, so a game that is slow to progress does not hold up the others.
+// At most maxConcurrency games are progressed at a time.
+type gameMonitor struct {
+	logger           log.Logger
+	source           GameSource
+	fetchBlockNumber blockNumberFetcher
+	store            *gameStore
+	createPlayer     playerCreator
+	confs            uint64
+
+	// sem limits the number of games that are progressed at a time.
+	sem chan struct{}
+	// progressInterval is the interval at which each game is progressed, and new games are discovered.
+	progressInterval time.Duration
+	// maxCreateBackoff is the max delay between attempts to create the player of a game.
+	maxCreateBackoff time.Duration
+
+	// workers are the games that have a worker, accessed by the monitor loop only.
+	workers map[common.Address]bool
+	// resolved receives the games that the workers resolved.
+	resolved chan common.Address
+	wg       sync.WaitGroup
+}
+
+func newGameMonitor(logger log.Logger, source GameSource, fetchBlockNumber blockNumberFetcher, store *gameStore, createPlayer playerCreator, maxConcurrency int, confs uint64) *gameMonitor {
+	return &gameMonitor{
+		logger:           logger,
+		source:           source,
+		fetchBlockNumber: fetchBlockNumber,
+		store:            store,
+		createPlayer:     createPlayer,
+		confs:            confs,
+		sem:              make(chan struct{}, maxConcurrency),
+		progressInterval: 300 * time.Millisecond,
+		maxCreateBackoff: 5 * time.Minute,
+		workers:          make(map[common.Address]bool),
+		resolved:         make(chan common.Address),
+	}
+}
+
+func (m *gameMonitor) MonitorGames(ctx context.Context) error {
+	m.logger.Info("Monitoring dispute games", "known", len(m.store.state.Games), "in_progress", len(m.store.inProgress()))
+	defer m.wg.Wait()
+	ticker := time.NewTicker(m.progressInterval)
+	defer ticker.Stop()
+	for {
+		if err := m.discoverGames(ctx); err != nil {
+			m.logger.Warn("Failed to discover new games", "err", err)
+		}
+		m.startWorkers(ctx)
+		select {
+		case <-ticker.C:
+		case addr := <-m.resolved:
+			m.onResolved(addr)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// discoverGames adds the games created since the last scanned L1 block to the known games.
+// Only blocks with at least confs confirmations are scanned, so that games created in blocks that are reorged out are never tracked.
+func (m *gameMonitor) discoverGames(ctx context.Context) error {
+	head, err := m.fetchBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	if head < m.confs {
+		return nil
+	}
+	confirmed := head - m.confs
+	for from := m.store.state.NextBlock; from <= confirmed; {
+		to := from + maxDiscoveryBlockRange - 1
+		if to > confirmed {
+			to = confirmed
+		}
+		games, err := m.source.FetchGames(ctx, from, to)
+		if err != nil {
+			return err
+		}
+		for _, game := range games {
+			if m.store.add(game) {
+				m.logger.Info("Discovered new dispute game", "game", game.Address, "root_claim", game.RootClaim, "l1_block", game.L1Block)
+			}
+		}
+		m.store.state.NextBlock = to + 1
+		if err := m.store.save(); err != nil {
+			return err
+		}
+		from = to + 1
+	}
+	return nil
+}
+
+// startWorkers starts a worker for each game in progress that does not have one yet.
+func (m *gameMonitor) startWorkers(ctx context.Context) {
+	for _, game := range m.store.inProgress() {
+		if m.workers[game.Address] {
+			continue
+		}
+		m.workers[game.Address] = true
+		m.wg.Add(1)
+		go m.runWorker(ctx, game.Address)
+	}
+}
+
+// onResolved stops tracking the resolved game.
+func (m *gameMonitor) onResolved(addr common.Address) {
+	m.logger.Info("Game resolved, no longer tracking", "game", addr)
+	m.store.markResolved(addr)
+	delete(m.workers, addr)
+	if err := m.store.save(); err != nil {
+		m.logger.Error("Failed to persist resolved games", "err", err)
+	}
+}
+
+// runWorker creates the player of the game, and progresses the game until it is resolved or ctx is done.
+func (m *gameMonitor) runWorker(ctx context.Context, addr common.Address) {
+	defer m.wg.Done()
+	player, ok := m.createPlayerWithBackoff(ctx, addr)
+	if !ok {
+		return
+	}
+	for {
+		if m.progressGame(ctx, player) {
+			select {
+			case m.resolved <- addr:
+			case <-ctx.Done():
+			}
+			return
+		}
+		select {
+		case <-time.After(m.progressInterval):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// createPlayerWithBackoff creates the player of the game, retrying with exponential backoff until it succeeds or ctx is done.
+func (m *gameMonitor) createPlayerWithBackoff(ctx context.Context, addr common.Address) (gamePlayer, bool) {
+	backoff := m.progressInterval
+	for {
+		player, err := m.createPlayer(ctx, addr)
+		if err == nil {
+			return player, true
+		}
+		m.logger.Error("Failed to create game player", "game", addr, "retry_in", backoff, "err", err)
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, false
+		}
+		backoff *= 2
+		if backoff > m.maxCreateBackoff {
+			backoff = m.maxCreateBackoff
+		}
+	}
+}
+
+// progressGame progresses the game, waiting while maxConcurrency games are progressing, and returns true if the game is resolved.
+func (m *gameMonitor) progressGame(ctx context.Context, player gamePlayer) bool {
+	select {
+	case m.sem <- struct{}{}:
+	case <-ctx.Done():
+		return false
+	}
+	defer func() { <-m.sem }()
+	return player.ProgressGame(ctx)
+}
@@ -0,0 +1,242 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiscoverGames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.json")
+	store, err := loadGameStore(path, 100)
+	require.NoError(t, err)
+	source := &stubGameSource{games: []GameMetadata{
+		{Address: common.Address{0xaa}, L1Block: 50}, // before the start block, never fetched
+		{Address: common.Address{0xbb}, L1Block: 120},
+		{Address: common.Address{0xcc}, L1Block: 100 + maxDiscoveryBlockRange + 5},
+	}}
+	head := uint64(100 + maxDiscoveryBlockRange + 10)
+	monitor, _ := setupGameMonitor(t, store, source, head, 1)
+
+	require.NoError(t, monitor.discoverGames(context.Background()))
+	require.Equal(t, [][2]uint64{
+		{100, 100 + maxDiscoveryBlockRange - 1},
+		{100 + maxDiscoveryBlockRange, head},
+	}, source.ranges, "block range should be split into multiple requests")
+	require.Equal(t, []GameMetadata{source.games[1], source.games[2]}, store.inProgress())
+
+	// known games are persisted, discovery resumes after the last scanned block
+	store, err = loadGameStore(path, 0)
+	require.NoError(t, err)
+	require.Equal(t, head+1, store.state.NextBlock)
+	require.Equal(t, []GameMetadata{source.games[1], source.games[2]}, store.inProgress())
+
+	source.ranges = nil
+	monitor, _ = setupGameMonitor(t, store, source, head, 1)
+	require.NoError(t, monitor.discoverGames(context.Background()))
+	require.Empty(t, source.ranges, "no new blocks to scan")
+}
+
+func TestDiscoverGamesDoesNotAdvanceOnError(t *testing.T) {
+	store, err := loadGameStore(filepath.Join(t.TempDir(), "games.json"), 1)
+	require.NoError(t, err)
+	source := &stubGameSource{err: errors.New("boom")}
+	monitor, _ := setupGameMonitor(t, store, source, 10, 1)
+	require.ErrorIs(t, monitor.discoverGames(context.Background()), source.err)
+	require.Equal(t, uint64(1), store.state.NextBlock)
+}
+
+func TestDiscoverGamesWaitsForConfirmations(t *testing.T) {
+	store, err := loadGameStore(filepath.Join(t.TempDir(), "games.json"), 100)
+	require.NoError(t, err)
+	source := &stubGameSource{games: []GameMetadata{
+		{Address: common.Address{0xaa}, L1Block: 105},
+		{Address: common.Address{0xbb}, L1Block: 108},
+	}}
+	monitor, _ := setupGameMonitor(t, store, source, 110, 1)
+	monitor.confs = 3
+
+	require.NoError(t, monitor.discoverGames(context.Background()))
+	require.Equal(t, [][2]uint64{{100, 107}}, source.ranges, "should only scan confirmed blocks")
+	require.Equal(t, []GameMetadata{source.games[0]}, store.inProgress())
+	require.Equal(t, uint64(108), store.state.NextBlock)
+
+	monitor.confs = 200
+	source.ranges = nil
+	require.NoError(t, monitor.discoverGames(context.Background()))
+	require.Empty(t, source.ranges, "no confirmed blocks yet")
+}
+
+func TestMonitorGames(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "games.json")
+	store, err := loadGameStore(path, 0)
+	require.NoError(t, err)
+	for i := byte(1); i <= 5; i++ {
+		store.add(GameMetadata{Address: common.Address{i}})
+	}
+	monitor, players := setupGameMonitor(t, store, &stubGameSource{}, 0, 2)
+	players.resolveAfter = map[common.Address]int{{1}: 1, {3}: 2}
+	players.failCreate = map[common.Address]int{{5}: 2}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- monitor.MonitorGames(ctx)
+	}()
+	// resolved games are persisted
+	persistedInProgress := func() []common.Address {
+		store, err := loadGameStore(path, 0)
+		require.NoError(t, err)
+		var inProgress []common.Address
+		for _, game := range store.inProgress() {
+			inProgress = append(inProgress, game.Address)
+		}
+		return inProgress
+	}
+	require.Eventually(t, func() bool {
+		players.mu.Lock()
+		defer players.mu.Unlock()
+		return players.progressed[common.Address{5}] >= 1 && len(persistedInProgress()) == 3
+	}, 10*time.Second, 10*time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+
+	require.Equal(t, []common.Address{{2}, {4}, {5}}, persistedInProgress())
+	require.Equal(t, 2, players.maxActive, "should progress games concurrently, up to max concurrency")
+	require.Equal(t, 1, players.progressed[common.Address{1}], "resolved game is no longer progressed")
+	require.Equal(t, 2, players.progressed[common.Address{3}], "resolved game is no longer progressed")
+	require.Equal(t, map[common.Address]int{{1}: 1, {2}: 1, {3}: 1, {4}: 1, {5}: 3}, players.created,
+		"players are created once per game, player creation is retried")
+}
+
+func TestMonitorGamesDoesNotWaitForSlowGames(t *testing.T) {
+	store, err := loadGameStore(filepath.Join(t.TempDir(), "games.json"), 0)
+	require.NoError(t, err)
+	store.add(GameMetadata{Address: common.Address{1}})
+	store.add(GameMetadata{Address: common.Address{2}})
+	monitor, players := setupGameMonitor(t, store, &stubGameSource{}, 0, 2)
+	release := make(chan struct{})
+	players.block = map[common.Address]chan struct{}{{1}: release}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = monitor.MonitorGames(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		players.mu.Lock()
+		defer players.mu.Unlock()
+		return players.progressed[common.Address{2}] >= 3
+	}, 10*time.Second, 10*time.Millisecond, "should keep progressing other games while a game is slow")
+	close(release)
+}
+
+func TestCreatePlayerBackoff(t *testing.T) {
+	store, err := loadGameStore(filepath.Join(t.TempDir(), "games.json"), 0)
+	require.NoError(t, err)
+	monitor, players := setupGameMonitor(t, store, &stubGameSource{}, 0, 1)
+	monitor.maxCreateBackoff = 80 * time.Millisecond
+	players.failCreate = map[common.Address]int{{1}: 1000}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+	_, ok := monitor.createPlayerWithBackoff(ctx, common.Address{1})
+	require.False(t, ok)
+	// Attempts at 0, 10, 30, 70, 150, 230, 310 and 390ms, instead of every 10ms
+	attempts := players.created[common.Address{1}]
+	require.Greater(t, attempts, 3)
+	require.Less(t, attempts, 12, "should back off")
+}
+
+func setupGameMonitor(t *testing.T, store *gameStore, source GameSource, head uint64, maxConcurrency int) (*gameMonitor, *stubPlayers) {
+	players := &stubPlayers{
+		progressed: make(map[common.Address]int),
+		created:    make(map[common.Address]int),
+	}
+	fetchBlockNumber := func(ctx context.Context) (uint64, error) {
+		return head, nil
+	}
+	monitor := newGameMonitor(testlog.Logger(t, log.LvlDebug), source, fetchBlockNumber, store, players.create, maxConcurrency, 0)
+	monitor.progressInterval = 10 * time.Millisecond
+	monitor.maxCreateBackoff = 40 * time.Millisecond
+	return monitor, players
+}
+
+type stubGameSource struct {
+	games  []GameMetadata
+	ranges [][2]uint64
+	err    error
+}
+
+func (s *stubGameSource) FetchGames(ctx context.Context, from uint64, to uint64) ([]GameMetadata, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.ranges = append(s.ranges, [2]uint64{from, to})
+	var games []GameMetadata
+	for _, game := range s.games {
+		if game.L1Block >= from && game.L1Block <= to {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
+type stubPlayers struct {
+	mu           sync.Mutex
+	active       int
+	maxActive    int
+	progressed   map[common.Address]int
+	created      map[common.Address]int
+	resolveAfter map[common.Address]int
+	// failCreate is the number of times to fail creating the player of each game
+	failCreate map[common.Address]int
+	// block blocks progressing each game until the channel is closed
+	block map[common.Address]chan struct{}
+}
+
+func (s *stubPlayers) create(ctx context.Context, addr common.Address) (gamePlayer, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.created[addr]++
+	if s.failCreate[addr] >= s.created[addr] {
+		return nil, errors.New("failed to create player")
+	}
+	return &stubPlayer{addr: addr, players: s}, nil
+}
+
+type stubPlayer struct {
+	addr    common.Address
+	players *stubPlayers
+}
+
+func (p *stubPlayer) ProgressGame(ctx context.Context) bool {
+	s := p.players
+	s.mu.Lock()
+	s.active++
+	if s.active > s.maxActive {
+		s.maxActive = s.active
+	}
+	s.progressed[p.addr]++
+	resolved := s.resolveAfter[p.addr] == s.progressed[p.addr]
+	block := s.block[p.addr]
+	s.mu.Unlock()
+
+	if block != nil {
+		<-block
+	}
+
+	time.Sleep(10 * time.Millisecond) // overlap with other players that run concurrently
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.active--
+	return resolved
+}
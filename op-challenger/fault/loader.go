@@ -55,7 +55,7 @@ func (l *loader) fetchClaim(ctx context.Context, arrIndex uint64) (types.Claim,
 			Position: types.NewPositionFromGIndex(fetchedClaim.Position.Uint64()),
 		},
 		Countered:           fetchedClaim.Countered,
-		Clock:               fetchedClaim.Clock.Uint64(),
+		Clock:               types.NewClockFromPacked(fetchedClaim.Clock),
 		ContractIndex:       int(arrIndex),
 		ParentContractIndex: int(fetchedClaim.ParentIndex),
 	}
@@ -0,0 +1,180 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// GameStore persists the history of the games played by the challenger.
+type GameStore interface {
+	PutGame(game db.Game) error
+	GameRecorder(logger log.Logger, addr common.Address) db.GameRecorder
+}
+
+// noopGameStore is the [GameStore] used when the challenger has no datadir to persist its state in.
+type noopGameStore struct{}
+
+func (noopGameStore) PutGame(game db.Game) error {
+	return nil
+}
+
+func (noopGameStore) GameRecorder(logger log.Logger, addr common.Address) db.GameRecorder {
+	return db.NoopGameRecorder
+}
+
+// GamePlayer progresses a single fault dispute game with its own Agent.
+type GamePlayer struct {
+	agent                   Actor
+	agreeWithProposedOutput bool
+	caller                  GameInfo
+	logger                  log.Logger
+}
+
+// NewGamePlayer creates the trace provider, oracle updater and agent for the game at addr.
+// The game address of cfg is ignored. When monitoring the games of a factory, each game
+// generates its cannon traces in its own subdirectory of the cannon datadir.
+// If rollupClient is not nil, it determines whether the challenger agrees with the proposed output of the game,
+// instead of the AgreeWithProposedOutput config. The game and its history are recorded in the store.
+func NewGamePlayer(ctx context.Context, logger log.Logger, m metrics.Metricer, store GameStore, cfg *config.Config, addr common.Address, client *ethclient.Client, txMgr txmgr.TxManager, rollupClient OutputRootSource) (*GamePlayer, error) {
+	logger = logger.New("game", addr)
+	gameCfg := *cfg
+	gameCfg.GameAddress = addr
+	if rollupClient != nil {
+		contract, err := bindings.NewFaultDisputeGameCaller(addr, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to bind the fault dispute game contract: %w", err)
+		}
+		gameCfg.AgreeWithProposedOutput, err = DetermineAgreement(ctx, logger, contract, rollupClient, m)
+		if err != nil {
+			return nil, fmt.Errorf("failed to determine agreement with proposed output: %w", err)
+		}
+	}
+	if cfg.MonitorFactory() && cfg.TraceType == config.TraceTypeCannon {
+		gameCfg.CannonDatadir = filepath.Join(cfg.CannonDatadir, "game-"+addr.Hex())
+		if !filepath.IsAbs(cfg.CannonAbsolutePreState) {
+			// the absolute pre-state is shared by all games
+			prestate, err := filepath.Abs(filepath.Join(cfg.CannonDatadir, cfg.CannonAbsolutePreState))
+			if err != nil {
+				return nil, fmt.Errorf("resolve cannon absolute pre-state: %w", err)
+			}
+			gameCfg.CannonAbsolutePreState = prestate
+		}
+	}
+
+	recorder := store.GameRecorder(logger, addr)
+	var gameSolver *solver.Solver
+	var updater types.OracleUpdater
+	switch cfg.TraceType {
+	case config.TraceTypeCannon:
+		trace, err := cannon.NewTraceProvider(ctx, logger, &gameCfg, client, recorder)
+		if err != nil {
+			return nil, fmt.Errorf("create cannon trace provider: %w", err)
+		}
+		gameSolver = solver.NewSolver(cfg.GameDepth, trace)
+		updater, err = cannon.NewOracleUpdater(logger, txMgr, addr, cfg.PreimageOracleAddress, recorder)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the cannon updater: %w", err)
+		}
+	case config.TraceTypeAlphabet:
+		trace := alphabet.NewTraceProvider(cfg.AlphabetTrace, uint64(cfg.GameDepth))
+		gameSolver = solver.NewSolver(cfg.GameDepth, trace)
+		updater = alphabet.NewOracleUpdater(logger)
+	default:
+		return nil, fmt.Errorf("unsupported trace type: %v", cfg.TraceType)
+	}
+	return newTypedGamePlayer(ctx, logger, m, store, &gameCfg, client, gameSolver, updater, txMgr)
+}
+
+// newTypedGamePlayer creates a new GamePlayer from a provided solver.
+func newTypedGamePlayer(ctx context.Context, logger log.Logger, m metrics.Metricer, store GameStore, cfg *config.Config, client *ethclient.Client, gameSolver *solver.Solver, uploader types.OracleUpdater, txMgr txmgr.TxManager) (*GamePlayer, error) {
+	contract, err := bindings.NewFaultDisputeGameCaller(cfg.GameAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind the fault dispute game contract: %w", err)
+	}
+	gameDuration, err := contract.GAMEDURATION(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the game duration: %w", err)
+	}
+	rootClaim, err := contract.RootClaim(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the root claim: %w", err)
+	}
+	game := db.Game{
+		Address:                 cfg.GameAddress,
+		RootClaim:               rootClaim,
+		FirstSeen:               uint64(time.Now().Unix()),
+		AgreeWithProposedOutput: cfg.AgreeWithProposedOutput,
+	}
+	if err := store.PutGame(game); err != nil {
+		logger.Warn("Failed to record game", "err", err)
+	}
+	recorder := store.GameRecorder(logger, cfg.GameAddress)
+
+	loader := NewLoader(contract)
+	bonds, err := NewBondSource(cfg.GameAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the bond source: %w", err)
+	}
+	responder, err := NewFaultResponder(logger, txMgr, cfg.GameAddress, bonds, m, recorder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the responder: %w", err)
+	}
+	fetchBalance := func(ctx context.Context) (*big.Int, error) {
+		return client.BalanceAt(ctx, txMgr.From(), nil)
+	}
+
+	caller, err := NewFaultCallerFromBindings(cfg.GameAddress, client, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind the fault contract: %w", err)
+	}
+
+	return &GamePlayer{
+		agent: NewAgent(loader, cfg.GameDepth, gameSolver, responder, bonds, fetchBalance, gameDuration, clock.SystemClock, m,
+			recorder, cfg.AgreeWithProposedOutput, logger),
+		agreeWithProposedOutput: cfg.AgreeWithProposedOutput,
+		caller:                  &recordingGameInfo{GameInfo: caller, logger: logger, store: store, game: game},
+		logger:                  logger,
+	}, nil
+}
+
+// recordingGameInfo is a [GameInfo] that records the status of the game in the store once it is resolved.
+type recordingGameInfo struct {
+	GameInfo
+	logger log.Logger
+	store  GameStore
+	game   db.Game
+}
+
+func (r *recordingGameInfo) GetGameStatus(ctx context.Context) (types.GameStatus, error) {
+	status, err := r.GameInfo.GetGameStatus(ctx)
+	if err == nil && status != r.game.Status {
+		r.game.Status = status
+		if err := r.store.PutGame(r.game); err != nil {
+			r.logger.Warn("Failed to record game status", "err", err)
+		}
+	}
+	return status, err
+}
+
+// ProgressGame performs the next actions in the game, and returns true once the game is resolved.
+func (g *GamePlayer) ProgressGame(ctx context.Context) bool {
+	return progressGame(ctx, g.logger, g.agreeWithProposedOutput, g.agent, g.caller)
+}
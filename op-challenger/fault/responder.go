@@ -3,14 +3,18 @@ package fault
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
 	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -19,23 +23,29 @@ import (
 type faultResponder struct {
 	log log.Logger
 
-	txMgr txmgr.TxManager
+	txMgr    txmgr.TxManager
+	bonds    BondSource
+	metrics  metrics.Metricer
+	recorder db.GameRecorder
 
 	fdgAddr common.Address
 	fdgAbi  *abi.ABI
 }
 
-// NewFaultResponder returns a new [faultResponder].
-func NewFaultResponder(logger log.Logger, txManagr txmgr.TxManager, fdgAddr common.Address) (*faultResponder, error) {
+// NewFaultResponder returns a new [faultResponder]. The transactions it sends are recorded with the recorder.
+func NewFaultResponder(logger log.Logger, txManagr txmgr.TxManager, fdgAddr common.Address, bonds BondSource, m metrics.Metricer, recorder db.GameRecorder) (*faultResponder, error) {
 	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
 	if err != nil {
 		return nil, err
 	}
 	return &faultResponder{
-		log:     logger,
-		txMgr:   txManagr,
-		fdgAddr: fdgAddr,
-		fdgAbi:  fdgAbi,
+		log:      logger,
+		txMgr:    txManagr,
+		bonds:    bonds,
+		metrics:  m,
+		recorder: recorder,
+		fdgAddr:  fdgAddr,
+		fdgAbi:   fdgAbi,
 	}, nil
 }
 
@@ -100,35 +110,66 @@ func (r *faultResponder) Resolve(ctx context.Context) error {
 		return err
 	}
 
-	return r.sendTxAndWait(ctx, txData)
+	_, err = r.sendTxAndWait(ctx, txData, nil, db.Action{Type: db.ActionResolve})
+	return err
 }
 
-// Respond takes a [Claim] and executes the response action.
+// Respond takes a [Claim] and executes the response action, posting the bond required for the claim.
+// If the response wins, the challenger expects to be paid its own bond and the bond of the countered claim.
 func (r *faultResponder) Respond(ctx context.Context, response types.Claim) error {
 	txData, err := r.BuildTx(ctx, response)
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, txData)
+	bond, err := r.bonds.RequiredBond(ctx, response.Position)
+	if err != nil {
+		return err
+	}
+	counteredBond, err := r.bonds.RequiredBond(ctx, response.Parent.Position)
+	if err != nil {
+		return err
+	}
+	action := db.Action{
+		Type:       db.ActionAttack,
+		ClaimIndex: response.ParentContractIndex,
+		Value:      response.Value,
+		Bond:       (*hexutil.Big)(bond),
+	}
+	if response.DefendsParent() {
+		action.Type = db.ActionDefend
+	}
+	success, err := r.sendTxAndWait(ctx, txData, bond, action)
+	if err != nil || !success {
+		return err
+	}
+	r.metrics.RecordBondPosted(bond)
+	r.metrics.RecordExpectedPayout(new(big.Int).Add(bond, counteredBond))
+	return nil
 }
 
-// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
+// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt, returning true if the tx succeeded.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
-func (r *faultResponder) sendTxAndWait(ctx context.Context, txData []byte) error {
+// Once the tx is included, the action it performs is recorded along with the tx hash.
+func (r *faultResponder) sendTxAndWait(ctx context.Context, txData []byte, value *big.Int, action db.Action) (bool, error) {
 	receipt, err := r.txMgr.Send(ctx, txmgr.TxCandidate{
 		To:       &r.fdgAddr,
 		TxData:   txData,
 		GasLimit: 0,
+		Value:    value,
 	})
 	if err != nil {
-		return err
+		return false, err
 	}
+	action.Time = uint64(time.Now().Unix())
+	action.TxHash = receipt.TxHash
+	action.Success = receipt.Status != ethtypes.ReceiptStatusFailed
+	r.recorder.RecordAction(action)
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
 		r.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
-	} else {
-		r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
+		return false, nil
 	}
-	return nil
+	r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
+	return true, nil
 }
 
 // buildStepTxData creates the transaction data for the step function.
@@ -148,5 +189,7 @@ func (r *faultResponder) Step(ctx context.Context, stepData types.StepCallData)
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, txData)
+	action := db.Action{Type: db.ActionStep, ClaimIndex: int(stepData.ClaimIndex)}
+	_, err = r.sendTxAndWait(ctx, txData, nil, action)
+	return err
 }
@@ -7,7 +7,9 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
 	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
@@ -29,6 +31,7 @@ type mockTxManager struct {
 	sends     int
 	calls     int
 	sendFails bool
+	sent      []txmgr.TxCandidate
 }
 
 func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
@@ -36,6 +39,7 @@ func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (
 		return nil, mockSendError
 	}
 	m.sends++
+	m.sent = append(m.sent, candidate)
 	return ethtypes.NewReceipt(
 		[]byte{},
 		false,
@@ -60,12 +64,56 @@ func (m *mockTxManager) From() common.Address {
 }
 
 func newTestFaultResponder(t *testing.T, sendFails bool) (*faultResponder, *mockTxManager) {
+	responder, mockTxMgr, _ := newTestFaultResponderWithBonds(t, sendFails, &stubBondSource{})
+	return responder, mockTxMgr
+}
+
+func newTestFaultResponderWithBonds(t *testing.T, sendFails bool, bonds BondSource) (*faultResponder, *mockTxManager, *stubBondMetrics) {
 	log := testlog.Logger(t, log.LvlError)
 	mockTxMgr := &mockTxManager{}
 	mockTxMgr.sendFails = sendFails
-	responder, err := NewFaultResponder(log, mockTxMgr, mockFdgAddress)
+	m := &stubBondMetrics{Metricer: metrics.NoopMetrics}
+	responder, err := NewFaultResponder(log, mockTxMgr, mockFdgAddress, bonds, m, db.NoopGameRecorder)
 	require.NoError(t, err)
-	return responder, mockTxMgr
+	return responder, mockTxMgr, m
+}
+
+// stubBondSource requires a bond of 1000 wei per depth of the position.
+// stubBondSource requires a bond of 1000 wei per depth, except at the free depths.
+type stubBondSource struct {
+	err   error
+	free  map[int]bool
+	calls int
+}
+
+func (s *stubBondSource) RequiredBond(ctx context.Context, position types.Position) (*big.Int, error) {
+	s.calls++
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.free[position.Depth()] {
+		return big.NewInt(0), nil
+	}
+	return big.NewInt(int64(position.Depth()) * 1000), nil
+}
+
+type stubBondMetrics struct {
+	metrics.Metricer
+	bondsPosted     []*big.Int
+	expectedPayouts []*big.Int
+	movesSkipped    []string
+}
+
+func (s *stubBondMetrics) RecordBondPosted(bond *big.Int) {
+	s.bondsPosted = append(s.bondsPosted, bond)
+}
+
+func (s *stubBondMetrics) RecordExpectedPayout(payout *big.Int) {
+	s.expectedPayouts = append(s.expectedPayouts, payout)
+}
+
+func (s *stubBondMetrics) RecordMoveSkipped(reason string) {
+	s.movesSkipped = append(s.movesSkipped, reason)
 }
 
 // TestResponder_CanResolve_CallFails tests the [Responder.CanResolve] method
@@ -144,6 +192,74 @@ func TestResponder_Respond_Success(t *testing.T) {
 	require.Equal(t, 1, mockTxMgr.sends)
 }
 
+// TestResponder_Respond_PostsBond tests the [Responder.Respond] method
+// sends the required bond with the move and records the expected payout.
+func TestResponder_Respond_PostsBond(t *testing.T) {
+	responder, mockTxMgr, m := newTestFaultResponderWithBonds(t, false, &stubBondSource{})
+	err := responder.Respond(context.Background(), types.Claim{
+		ClaimData: types.ClaimData{
+			Value:    common.Hash{0x01},
+			Position: types.NewPosition(2, 0),
+		},
+		Parent: types.ClaimData{
+			Value:    common.Hash{0x02},
+			Position: types.NewPosition(1, 0),
+		},
+	})
+	require.NoError(t, err)
+	require.Len(t, mockTxMgr.sent, 1)
+	require.Equal(t, big.NewInt(2000), mockTxMgr.sent[0].Value)
+	require.Equal(t, []*big.Int{big.NewInt(2000)}, m.bondsPosted)
+	require.Equal(t, []*big.Int{big.NewInt(3000)}, m.expectedPayouts, "should expect own bond and countered bond")
+}
+
+// TestResponder_RecordsActions tests the [Responder] records the moves, steps and resolves it sends.
+func TestResponder_RecordsActions(t *testing.T) {
+	responder, _, _ := newTestFaultResponderWithBonds(t, false, &stubBondSource{})
+	recorder := &stubGameRecorder{}
+	responder.recorder = recorder
+	err := responder.Respond(context.Background(), types.Claim{
+		ClaimData: types.ClaimData{
+			Value:    common.Hash{0x01},
+			Position: types.NewPosition(2, 0),
+		},
+		Parent: types.ClaimData{
+			Value:    common.Hash{0x02},
+			Position: types.NewPosition(1, 0),
+		},
+		ParentContractIndex: 1,
+	})
+	require.NoError(t, err)
+	require.NoError(t, responder.Step(context.Background(), types.StepCallData{ClaimIndex: 3}))
+	require.NoError(t, responder.Resolve(context.Background()))
+
+	require.Len(t, recorder.actions, 3)
+	require.Equal(t, db.ActionAttack, recorder.actions[0].Type)
+	require.Equal(t, 1, recorder.actions[0].ClaimIndex)
+	require.Equal(t, common.Hash{0x01}, recorder.actions[0].Value)
+	require.Equal(t, big.NewInt(2000), recorder.actions[0].Bond.ToInt())
+	require.True(t, recorder.actions[0].Success)
+	require.Equal(t, db.ActionStep, recorder.actions[1].Type)
+	require.Equal(t, 3, recorder.actions[1].ClaimIndex)
+	require.Equal(t, db.ActionResolve, recorder.actions[2].Type)
+}
+
+// TestResponder_Respond_BondError tests the [Responder.Respond] method
+// does not send the move if the required bond can't be determined.
+func TestResponder_Respond_BondError(t *testing.T) {
+	bonds := &stubBondSource{err: errors.New("boom")}
+	responder, mockTxMgr, m := newTestFaultResponderWithBonds(t, false, bonds)
+	err := responder.Respond(context.Background(), types.Claim{
+		ClaimData: types.ClaimData{
+			Value:    common.Hash{0x01},
+			Position: types.NewPosition(1, 0),
+		},
+	})
+	require.ErrorIs(t, err, bonds.err)
+	require.Equal(t, 0, mockTxMgr.sends)
+	require.Empty(t, m.bondsPosted)
+}
+
 // TestResponder_BuildTx_Attack tests the [Responder.BuildTx] method
 // returns a tx candidate with the correct data for an attack tx.
 func TestResponder_BuildTx_Attack(t *testing.T) {
@@ -205,3 +321,21 @@ func TestResponder_BuildTx_Defend(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expected, tx)
 }
+
+type stubGameRecorder struct {
+	claims  []db.Claim
+	actions []db.Action
+	proofs  []db.Proof
+}
+
+func (s *stubGameRecorder) RecordClaims(claims []db.Claim) {
+	s.claims = claims
+}
+
+func (s *stubGameRecorder) RecordAction(action db.Action) {
+	s.actions = append(s.actions, action)
+}
+
+func (s *stubGameRecorder) RecordProof(proof db.Proof) {
+	s.proofs = append(s.proofs, proof)
+}
@@ -3,97 +3,171 @@ package fault
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 
-	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
-	"github.com/ethereum-optimism/optimism/op-challenger/fault/alphabet"
-	"github.com/ethereum-optimism/optimism/op-challenger/fault/cannon"
-	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-challenger/version"
+	opclient "github.com/ethereum-optimism/optimism/op-service/client"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+const (
+	// knownGamesFile is the file in the datadir that persists the games discovered from the factory.
+	knownGamesFile = "games.json"
+	// DBDir is the directory in the datadir of the database recording the history of the games played.
+	DBDir = "challenger.db"
+)
+
 // Service provides a clean interface for the challenger to interact
 // with the fault package.
 type Service interface {
-	// MonitorGame monitors the fault dispute game and attempts to progress it.
+	// MonitorGame monitors the fault dispute game, or all games of the dispute game factory, and attempts to progress them.
 	MonitorGame(context.Context) error
 }
 
 type service struct {
-	agent                   *Agent
+	// player progresses the single configured game, nil when monitoring the games of a factory.
+	player *GamePlayer
+	// monitor discovers and progresses the games of the factory, nil when monitoring a single game.
+	monitor *gameMonitor
+
+	// store records the history of the games played, nil if there is no datadir.
+	store *db.Store
+
 	agreeWithProposedOutput bool
-	caller                  *FaultCaller
 	logger                  log.Logger
+	metrics                 *metrics.Metrics
+	metricsCfg              opmetrics.CLIConfig
+	rpcServer               *oprpc.Server
 }
 
 // NewService creates a new Service.
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*service, error) {
-	txMgr, err := txmgr.NewSimpleTxManager("challenger", logger, &metrics.NoopTxMetrics{}, cfg.TxMgrConfig)
+	m := metrics.NewMetrics()
+	simpleTxMgr, err := txmgr.NewSimpleTxManager("challenger", logger, m, cfg.TxMgrConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
 	}
+	var txMgr txmgr.TxManager = simpleTxMgr
+	if cfg.DryRun {
+		logger.Warn("Dry run: transactions are logged instead of sent")
+		txMgr, err = NewDryRunTxManager(logger, simpleTxMgr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the dry run transaction manager: %w", err)
+		}
+	}
 
 	client, err := ethclient.Dial(cfg.L1EthRpc)
 	if err != nil {
 		return nil, fmt.Errorf("failed to dial L1: %w", err)
 	}
 
-	var trace types.TraceProvider
-	var updater types.OracleUpdater
-	switch cfg.TraceType {
-	case config.TraceTypeCannon:
-		trace, err = cannon.NewTraceProvider(ctx, logger, cfg, client)
+	var rollupClient OutputRootSource
+	if cfg.RollupRpc != "" {
+		rollupClient, err = opclient.DialRollupClientWithTimeout(ctx, cfg.RollupRpc, opclient.DefaultDialTimeout)
 		if err != nil {
-			return nil, fmt.Errorf("create cannon trace provider: %w", err)
+			return nil, fmt.Errorf("failed to dial rollup node: %w", err)
 		}
-		updater, err = cannon.NewOracleUpdater(logger, txMgr, cfg.GameAddress, cfg.PreimageOracleAddress)
+	}
+
+	s := &service{
+		agreeWithProposedOutput: cfg.AgreeWithProposedOutput,
+		logger:                  logger,
+		metrics:                 m,
+		metricsCfg:              cfg.MetricsConfig,
+	}
+	var store GameStore = noopGameStore{}
+	// Nothing is sent in a dry run, so it is not recorded in the history of the games
+	if cfg.Datadir != "" && !cfg.DryRun {
+		s.store, err = db.Open(filepath.Join(cfg.Datadir, DBDir), false)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create the cannon updater: %w", err)
+			return nil, err
 		}
-	case config.TraceTypeAlphabet:
-		trace = alphabet.NewTraceProvider(cfg.AlphabetTrace, uint64(cfg.GameDepth))
-		updater = alphabet.NewOracleUpdater(logger)
-	default:
-		return nil, fmt.Errorf("unsupported trace type: %v", cfg.TraceType)
+		store = s.store
 	}
-
-	return newTypedService(ctx, logger, cfg, client, trace, updater, txMgr)
+	if err := s.initGames(ctx, logger, m, store, cfg, client, txMgr, rollupClient); err != nil {
+		s.closeStore()
+		return nil, err
+	}
+	if cfg.RPCEnabled {
+		s.rpcServer = oprpc.NewServer(cfg.RPCConfig.ListenAddr, cfg.RPCConfig.ListenPort, version.Version, oprpc.WithLogger(logger))
+		s.rpcServer.AddAPI(txmgr.NewDebugAPI(simpleTxMgr))
+		if s.store != nil {
+			s.rpcServer.AddAPI(db.NewAPI(s.store))
+		}
+	}
+	return s, nil
 }
 
-// newTypedService creates a new Service from a provided trace provider.
-func newTypedService(ctx context.Context, logger log.Logger, cfg *config.Config, client *ethclient.Client, provider types.TraceProvider, uploader types.OracleUpdater, txMgr txmgr.TxManager) (*service, error) {
-
-	contract, err := bindings.NewFaultDisputeGameCaller(cfg.GameAddress, client)
-	if err != nil {
-		return nil, fmt.Errorf("failed to bind the fault dispute game contract: %w", err)
+// initGames creates the monitor of the games of the factory, or the player of the single configured game.
+func (s *service) initGames(ctx context.Context, logger log.Logger, m *metrics.Metrics, store GameStore, cfg *config.Config, client *ethclient.Client, txMgr txmgr.TxManager, rollupClient OutputRootSource) error {
+	if cfg.MonitorFactory() {
+		source, err := NewFactoryGameSource(cfg.GameFactoryAddress, client)
+		if err != nil {
+			return err
+		}
+		known, err := loadGameStore(filepath.Join(cfg.Datadir, knownGamesFile), cfg.GameFactoryStartBlock)
+		if err != nil {
+			return err
+		}
+		createPlayer := func(ctx context.Context, addr common.Address) (gamePlayer, error) {
+			return NewGamePlayer(ctx, logger, m, store, cfg, addr, client, txMgr, rollupClient)
+		}
+		s.monitor = newGameMonitor(logger.New("factory", cfg.GameFactoryAddress), source, client.BlockNumber, known, createPlayer, int(cfg.MaxConcurrency), cfg.GameFactoryConfs)
+		return nil
 	}
-
-	loader := NewLoader(contract)
-	gameLogger := logger.New("game", cfg.GameAddress)
-	responder, err := NewFaultResponder(gameLogger, txMgr, cfg.GameAddress)
+	player, err := NewGamePlayer(ctx, logger, m, store, cfg, cfg.GameAddress, client, txMgr, rollupClient)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create the responder: %w", err)
+		return err
 	}
+	s.player = player
+	s.agreeWithProposedOutput = player.agreeWithProposedOutput
+	s.logger = player.logger
+	return nil
+}
 
-	caller, err := NewFaultCallerFromBindings(cfg.GameAddress, client, gameLogger)
-	if err != nil {
-		return nil, fmt.Errorf("failed to bind the fault contract: %w", err)
+func (s *service) closeStore() {
+	if s.store == nil {
+		return
+	}
+	if err := s.store.Close(); err != nil {
+		s.logger.Error("Failed to close challenger db", "err", err)
 	}
-
-	agent := NewAgent(loader, cfg.GameDepth, provider, responder, cfg.AgreeWithProposedOutput, gameLogger)
-
-	return &service{
-		agent:                   agent,
-		agreeWithProposedOutput: cfg.AgreeWithProposedOutput,
-		caller:                  caller,
-		logger:                  gameLogger,
-	}, nil
 }
 
-// MonitorGame monitors the fault dispute game and attempts to progress it.
+// MonitorGame monitors the fault dispute game, or all games of the dispute game factory, and attempts to progress them.
 func (s *service) MonitorGame(ctx context.Context) error {
-	return MonitorGame(ctx, s.logger, s.agreeWithProposedOutput, s.agent, s.caller)
+	defer s.closeStore()
+	if s.rpcServer != nil {
+		s.logger.Info("Starting RPC server", "addr", s.rpcServer.Endpoint())
+		if err := s.rpcServer.Start(); err != nil {
+			return fmt.Errorf("failed to start RPC server: %w", err)
+		}
+		defer func() {
+			if err := s.rpcServer.Stop(); err != nil {
+				s.logger.Error("Failed to stop RPC server", "err", err)
+			}
+		}()
+	}
+	if s.metricsCfg.Enabled {
+		s.logger.Info("Starting metrics server", "addr", s.metricsCfg.ListenAddr, "port", s.metricsCfg.ListenPort)
+		go func() {
+			if err := s.metrics.Serve(ctx, s.metricsCfg.ListenAddr, s.metricsCfg.ListenPort); err != nil {
+				s.logger.Error("Error starting metrics server", "err", err)
+			}
+		}()
+	}
+	s.metrics.RecordInfo(version.Version)
+	s.metrics.RecordUp()
+	if s.monitor != nil {
+		return s.monitor.MonitorGames(ctx)
+	}
+	return MonitorGame(ctx, s.logger, s.agreeWithProposedOutput, s.player.agent, s.player.caller)
 }
@@ -23,8 +23,8 @@ type Solver struct {
 // NewSolver creates a new [Solver] using the provided [TraceProvider].
 func NewSolver(gameDepth int, traceProvider types.TraceProvider) *Solver {
 	return &Solver{
-		traceProvider,
-		gameDepth,
+		trace:     traceProvider,
+		gameDepth: gameDepth,
 	}
 }
 
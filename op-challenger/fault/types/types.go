@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"errors"
+	"math"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -100,6 +101,23 @@ func (c *ClaimData) ValueBytes() [32]byte {
 	return responseArr
 }
 
+// Clock is the chess clock of a claim, packed in the contract as the duration in the upper 64 bits
+// and the timestamp in the lower 64 bits of a uint128.
+type Clock struct {
+	// Duration is the time in seconds used by the team of the claim, up to and including the claim.
+	Duration uint64
+	// Timestamp is the time in seconds at which the claim was made.
+	Timestamp uint64
+}
+
+// NewClockFromPacked unpacks a [Clock] from its contract representation.
+func NewClockFromPacked(packed *big.Int) Clock {
+	return Clock{
+		Duration:  new(big.Int).Rsh(packed, 64).Uint64(),
+		Timestamp: new(big.Int).And(packed, new(big.Int).SetUint64(math.MaxUint64)).Uint64(),
+	}
+}
+
 // Claim extends ClaimData with information about the relationship between two claims.
 // It uses ClaimData to break cyclicity without using pointers.
 // If the position of the game is Depth 0, IndexAtDepth 0 it is the root claim
@@ -111,7 +129,7 @@ type Claim struct {
 	//       When caching is implemented for the Challenger, this will need
 	//       to be changed/removed to avoid invalid/stale contract state.
 	Countered bool
-	Clock     uint64
+	Clock     Clock
 	Parent    ClaimData
 	// Location of the claim & it's parent inside the contract. Does not exist
 	// for claims that have not made it to the contract.
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -23,3 +24,10 @@ func TestNewPreimageOracleData(t *testing.T) {
 		require.Equal(t, uint32(7), data.OracleOffset)
 	})
 }
+
+func TestNewClockFromPacked(t *testing.T) {
+	packed := new(big.Int).Lsh(big.NewInt(300), 64)
+	packed.Or(packed, big.NewInt(1690000000))
+	require.Equal(t, Clock{Duration: 300, Timestamp: 1690000000}, NewClockFromPacked(packed))
+	require.Equal(t, Clock{}, NewClockFromPacked(big.NewInt(0)))
+}
@@ -8,6 +8,8 @@ import (
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -34,6 +36,11 @@ var (
 		Usage:   "Address of the Fault Game contract.",
 		EnvVars: prefixEnvVars("GAME_ADDRESS"),
 	}
+	GameFactoryAddressFlag = &cli.StringFlag{
+		Name:    "game-factory-address",
+		Usage:   "Address of the Dispute Game Factory contract. Monitors all fault dispute games created by the factory, instead of a single game.",
+		EnvVars: prefixEnvVars("GAME_FACTORY_ADDRESS"),
+	}
 	TraceTypeFlag = &cli.GenericFlag{
 		Name:    "trace-type",
 		Usage:   "The trace type. Valid options: " + openum.EnumString(config.TraceTypes),
@@ -45,15 +52,58 @@ var (
 	}
 	AgreeWithProposedOutputFlag = &cli.BoolFlag{
 		Name:    "agree-with-proposed-output",
-		Usage:   "Temporary hardcoded flag if we agree or disagree with the proposed output.",
+		Usage:   "Temporary hardcoded flag if we agree or disagree with the proposed output. Not used if rollup-rpc is set.",
 		EnvVars: prefixEnvVars("AGREE_WITH_PROPOSED_OUTPUT"),
 	}
+	RollupRpcFlag = &cli.StringFlag{
+		Name: "rollup-rpc",
+		Usage: "HTTP provider URL for a trusted rollup node. Used to determine whether the challenger agrees with " +
+			"the proposed output of each game, instead of agree-with-proposed-output.",
+		EnvVars: prefixEnvVars("ROLLUP_RPC"),
+	}
 	GameDepthFlag = &cli.IntFlag{
 		Name:    "game-depth",
 		Usage:   "Depth of the game tree.",
 		EnvVars: prefixEnvVars("GAME_DEPTH"),
 	}
 	// Optional Flags
+	GameFactoryStartBlockFlag = &cli.Uint64Flag{
+		Name:    "game-factory-start-block",
+		Usage:   "L1 block to start discovering games from, if no games are known yet (game factory only)",
+		EnvVars: prefixEnvVars("GAME_FACTORY_START_BLOCK"),
+	}
+	GameFactoryConfsFlag = &cli.Uint64Flag{
+		Name:    "game-factory-confs",
+		Usage:   "Number of L1 blocks that game creations must be confirmed by before the games are discovered, so that reorged games are never tracked (game factory only)",
+		EnvVars: prefixEnvVars("GAME_FACTORY_CONFS"),
+		Value:   config.DefaultGameFactoryConfirmations,
+	}
+	ChallengerRpcFlag = &cli.StringFlag{
+		Name:    "challenger-rpc",
+		Usage:   "RPC of a running challenger with --rpc.enabled to read the history of the games from, instead of its datadir",
+		EnvVars: prefixEnvVars("CHALLENGER_RPC"),
+	}
+	DatadirFlag = &cli.StringFlag{
+		Name:    "datadir",
+		Usage:   "Directory to persist the known games and the history of each game in (required for game factory)",
+		EnvVars: prefixEnvVars("DATADIR"),
+	}
+	MaxConcurrencyFlag = &cli.UintFlag{
+		Name:    "max-concurrency",
+		Usage:   "Maximum number of games to progress concurrently (game factory only)",
+		EnvVars: prefixEnvVars("MAX_CONCURRENCY"),
+		Value:   config.DefaultMaxConcurrency,
+	}
+	DryRunFlag = &cli.BoolFlag{
+		Name:    "dry-run",
+		Usage:   "Log the decoded moves, steps and preimage uploads the challenger would make, instead of sending them",
+		EnvVars: prefixEnvVars("DRY_RUN"),
+	}
+	RPCEnabledFlag = &cli.BoolFlag{
+		Name:    "rpc.enabled",
+		Usage:   "Enable the RPC server, which serves the debug API of the transaction manager and the history of the games recorded in the datadir",
+		EnvVars: prefixEnvVars("RPC_ENABLED"),
+	}
 	AlphabetFlag = &cli.StringFlag{
 		Name:    "alphabet",
 		Usage:   "Correct Alphabet Trace (alphabet trace type only)",
@@ -76,7 +126,7 @@ var (
 	}
 	CannonPreStateFlag = &cli.StringFlag{
 		Name:    "cannon-prestate",
-		Usage:   "Path to absolute prestate to use when generating trace data (cannon trace type only)",
+		Usage:   "Path to absolute prestate to use when generating trace data, as JSON or in the binary state format (cannon trace type only)",
 		EnvVars: prefixEnvVars("CANNON_PRESTATE"),
 	}
 	CannonDatadirFlag = &cli.StringFlag{
@@ -95,19 +145,33 @@ var (
 		EnvVars: prefixEnvVars("CANNON_SNAPSHOT_FREQ"),
 		Value:   config.DefaultCannonSnapshotFreq,
 	}
+	CannonCacheSizeFlag = &cli.Uint64Flag{
+		Name:    "cannon-cache-size",
+		Usage:   "Max size in MiB of the proofs, snapshots and state hashes to keep in the cannon datadir, 0 for unlimited (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_CACHE_SIZE"),
+		Value:   config.DefaultCannonCacheSize,
+	}
 )
 
 // requiredFlags are checked by [CheckRequired]
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
-	DGFAddressFlag,
 	TraceTypeFlag,
-	AgreeWithProposedOutputFlag,
 	GameDepthFlag,
 }
 
 // optionalFlags is a list of unchecked cli flags
 var optionalFlags = []cli.Flag{
+	AgreeWithProposedOutputFlag,
+	RollupRpcFlag,
+	DGFAddressFlag,
+	GameFactoryAddressFlag,
+	GameFactoryStartBlockFlag,
+	GameFactoryConfsFlag,
+	DatadirFlag,
+	MaxConcurrencyFlag,
+	DryRunFlag,
+	RPCEnabledFlag,
 	AlphabetFlag,
 	PreimageOracleAddressFlag,
 	CannonBinFlag,
@@ -116,11 +180,14 @@ var optionalFlags = []cli.Flag{
 	CannonDatadirFlag,
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
+	CannonCacheSizeFlag,
 }
 
 func init() {
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -134,6 +201,19 @@ func CheckRequired(ctx *cli.Context) error {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
 	}
+	if ctx.IsSet(GameFactoryAddressFlag.Name) {
+		if ctx.IsSet(DGFAddressFlag.Name) {
+			return fmt.Errorf("flags %s and %s are mutually exclusive", DGFAddressFlag.Name, GameFactoryAddressFlag.Name)
+		}
+		if !ctx.IsSet(DatadirFlag.Name) {
+			return fmt.Errorf("flag %s is required", DatadirFlag.Name)
+		}
+	} else if !ctx.IsSet(DGFAddressFlag.Name) {
+		return fmt.Errorf("flag %s or %s is required", DGFAddressFlag.Name, GameFactoryAddressFlag.Name)
+	}
+	if !ctx.IsSet(AgreeWithProposedOutputFlag.Name) && !ctx.IsSet(RollupRpcFlag.Name) {
+		return fmt.Errorf("flag %s or %s is required", AgreeWithProposedOutputFlag.Name, RollupRpcFlag.Name)
+	}
 	gameType := config.TraceType(strings.ToLower(ctx.String(TraceTypeFlag.Name)))
 	switch gameType {
 	case config.TraceTypeCannon:
@@ -170,7 +250,13 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	if err := CheckRequired(ctx); err != nil {
 		return nil, err
 	}
-	dgfAddress, err := opservice.ParseAddress(ctx.String(DGFAddressFlag.Name))
+	var dgfAddress, gameFactoryAddress common.Address
+	var err error
+	if ctx.IsSet(GameFactoryAddressFlag.Name) {
+		gameFactoryAddress, err = opservice.ParseAddress(ctx.String(GameFactoryAddressFlag.Name))
+	} else {
+		dgfAddress, err = opservice.ParseAddress(ctx.String(DGFAddressFlag.Name))
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -193,6 +279,12 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		L1EthRpc:                ctx.String(L1EthRpcFlag.Name),
 		TraceType:               traceTypeFlag,
 		GameAddress:             dgfAddress,
+		GameFactoryAddress:      gameFactoryAddress,
+		GameFactoryStartBlock:   ctx.Uint64(GameFactoryStartBlockFlag.Name),
+		GameFactoryConfs:        ctx.Uint64(GameFactoryConfsFlag.Name),
+		Datadir:                 ctx.String(DatadirFlag.Name),
+		MaxConcurrency:          ctx.Uint(MaxConcurrencyFlag.Name),
+		DryRun:                  ctx.Bool(DryRunFlag.Name),
 		PreimageOracleAddress:   preimageOracleAddress,
 		AlphabetTrace:           ctx.String(AlphabetFlag.Name),
 		CannonBin:               ctx.String(CannonBinFlag.Name),
@@ -201,8 +293,13 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 		CannonDatadir:           ctx.String(CannonDatadirFlag.Name),
 		CannonL2:                ctx.String(CannonL2Flag.Name),
 		CannonSnapshotFreq:      ctx.Uint(CannonSnapshotFreqFlag.Name),
+		CannonCacheSize:         ctx.Uint64(CannonCacheSizeFlag.Name),
 		AgreeWithProposedOutput: ctx.Bool(AgreeWithProposedOutputFlag.Name),
+		RollupRpc:               ctx.String(RollupRpcFlag.Name),
 		GameDepth:               ctx.Int(GameDepthFlag.Name),
 		TxMgrConfig:             txMgrConfig,
+		RPCEnabled:              ctx.Bool(RPCEnabledFlag.Name),
+		RPCConfig:               oprpc.ReadCLIConfig(ctx),
+		MetricsConfig:           opmetrics.ReadCLIConfig(ctx),
 	}, nil
 }
@@ -0,0 +1,158 @@
+package metrics
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/prometheus/client_golang/prometheus"
+
+	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+const Namespace = "op_challenger"
+
+type Metricer interface {
+	RecordInfo(version string)
+	RecordUp()
+
+	// Record Tx metrics
+	txmetrics.TxMetricer
+
+	RecordGameAgreement(agreeWithRootClaim bool)
+	RecordGameAgreementFailure()
+
+	RecordBondPosted(bond *big.Int)
+	RecordExpectedPayout(payout *big.Int)
+	RecordMoveSkipped(reason string)
+}
+
+type Metrics struct {
+	ns       string
+	registry *prometheus.Registry
+	factory  opmetrics.Factory
+
+	txmetrics.TxMetrics
+
+	info prometheus.GaugeVec
+	up   prometheus.Gauge
+
+	gameAgreements        *prometheus.CounterVec
+	gameAgreementFailures prometheus.Counter
+
+	bondsPosted     prometheus.Counter
+	expectedPayouts prometheus.Counter
+	movesSkipped    *prometheus.CounterVec
+}
+
+var _ Metricer = (*Metrics)(nil)
+
+func NewMetrics() *Metrics {
+	registry := opmetrics.NewRegistry()
+	factory := opmetrics.With(registry)
+
+	return &Metrics{
+		ns:       Namespace,
+		registry: registry,
+		factory:  factory,
+
+		TxMetrics: txmetrics.MakeTxMetrics(Namespace, factory),
+
+		info: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "info",
+			Help:      "Pseudo-metric tracking version and config info",
+		}, []string{
+			"version",
+		}),
+		up: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "up",
+			Help:      "1 if the op-challenger has finished starting up",
+		}),
+		gameAgreements: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_agreements_total",
+			Help:      "Number of games the challenger decided to agree or disagree with the root claim of, based on the trusted rollup node",
+		}, []string{
+			"root_claim",
+		}),
+		gameAgreementFailures: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "game_agreement_failures_total",
+			Help:      "Number of times the challenger failed to decide whether it agrees with the root claim of a game",
+		}),
+		bondsPosted: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "bonds_posted_eth_total",
+			Help:      "Total bonds posted by the challenger with its claims, in ETH",
+		}),
+		expectedPayouts: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "expected_payouts_eth_total",
+			Help:      "Total payouts the challenger expects if its claims win, its own bonds and the bonds of the countered claims, in ETH",
+		}),
+		movesSkipped: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "moves_skipped_total",
+			Help:      "Number of moves the challenger skipped, by reason",
+		}, []string{
+			"reason",
+		}),
+	}
+}
+
+func (m *Metrics) Serve(ctx context.Context, host string, port int) error {
+	return opmetrics.ListenAndServe(ctx, m.registry, host, port)
+}
+
+func (m *Metrics) StartBalanceMetrics(ctx context.Context, l log.Logger, client *ethclient.Client, account common.Address) {
+	opmetrics.LaunchBalanceMetrics(ctx, l, m.registry, m.ns, client, account)
+}
+
+// RecordInfo sets a pseudo-metric that contains versioning and
+// config info for the op-challenger.
+func (m *Metrics) RecordInfo(version string) {
+	m.info.WithLabelValues(version).Set(1)
+}
+
+// RecordUp sets the up metric to 1.
+func (m *Metrics) RecordUp() {
+	m.up.Set(1)
+}
+
+// RecordGameAgreement should be called when the challenger decided whether it agrees with the root claim of a game.
+func (m *Metrics) RecordGameAgreement(agreeWithRootClaim bool) {
+	label := "disagree"
+	if agreeWithRootClaim {
+		label = "agree"
+	}
+	m.gameAgreements.WithLabelValues(label).Inc()
+}
+
+// RecordGameAgreementFailure should be called when the challenger failed to decide whether it agrees with the root claim of a game.
+func (m *Metrics) RecordGameAgreementFailure() {
+	m.gameAgreementFailures.Inc()
+}
+
+// RecordBondPosted should be called when the challenger posted a claim with a bond, in wei.
+func (m *Metrics) RecordBondPosted(bond *big.Int) {
+	m.bondsPosted.Add(opmetrics.WeiToEther(bond))
+}
+
+// RecordExpectedPayout should be called with the payout, in wei, the challenger expects if a posted claim wins.
+func (m *Metrics) RecordExpectedPayout(payout *big.Int) {
+	m.expectedPayouts.Add(opmetrics.WeiToEther(payout))
+}
+
+// RecordMoveSkipped should be called when the challenger did not make a move it disagrees with a claim for.
+func (m *Metrics) RecordMoveSkipped(reason string) {
+	m.movesSkipped.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) Document() []opmetrics.DocumentedMetric {
+	return m.factory.Document()
+}
@@ -0,0 +1,23 @@
+package metrics
+
+import (
+	"math/big"
+
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+type noopMetrics struct {
+	txmetrics.NoopTxMetrics
+}
+
+var NoopMetrics Metricer = new(noopMetrics)
+
+func (*noopMetrics) RecordInfo(version string) {}
+func (*noopMetrics) RecordUp()                 {}
+
+func (*noopMetrics) RecordGameAgreement(agreeWithRootClaim bool) {}
+func (*noopMetrics) RecordGameAgreementFailure()                 {}
+
+func (*noopMetrics) RecordBondPosted(bond *big.Int)       {}
+func (*noopMetrics) RecordExpectedPayout(payout *big.Int) {}
+func (*noopMetrics) RecordMoveSkipped(reason string)      {}
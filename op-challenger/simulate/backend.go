@@ -0,0 +1,89 @@
+package simulate
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Backend is the L1 chain a simulated game is played on.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	ChainID(ctx context.Context) (*big.Int, error)
+
+	// Mine includes the pending transactions in a block.
+	Mine()
+
+	// AdvanceTime moves the time of the chain forward by d.
+	AdvanceTime(ctx context.Context, d time.Duration) error
+}
+
+// simulatedBackend is an in-process [backends.SimulatedBackend] that only mines blocks when requested
+// and moves its clock forward without waiting.
+type simulatedBackend struct {
+	*backends.SimulatedBackend
+}
+
+// NewSimulatedBackend creates an in-process L1 chain with the accounts of alloc.
+func NewSimulatedBackend(alloc core.GenesisAlloc) Backend {
+	return newSimulatedBackend(core.Genesis{Alloc: alloc})
+}
+
+// newSimulatedBackend creates an in-process L1 chain with the accounts and timestamp of the genesis.
+func newSimulatedBackend(genesis core.Genesis) *simulatedBackend {
+	genesis.Config = params.AllEthashProtocolChanges
+	genesis.GasLimit = 50_000_000
+	return &simulatedBackend{SimulatedBackend: backends.NewSimulatedBackendWithOpts(backends.WithGenesis(genesis))}
+}
+
+func (b *simulatedBackend) ChainID(ctx context.Context) (*big.Int, error) {
+	return b.Blockchain().Config().ChainID, nil
+}
+
+func (b *simulatedBackend) Mine() {
+	b.Commit()
+}
+
+func (b *simulatedBackend) AdvanceTime(ctx context.Context, d time.Duration) error {
+	if err := b.AdjustTime(d); err != nil {
+		return fmt.Errorf("adjust time: %w", err)
+	}
+	b.Commit()
+	return nil
+}
+
+// devNodeBackend is a geth node in dev mode, which mines a block for each transaction it receives.
+// Its clock follows the wall clock, so advancing the time waits for it to pass.
+type devNodeBackend struct {
+	*ethclient.Client
+}
+
+// DialDevNode connects to the geth dev node at rpcUrl.
+func DialDevNode(ctx context.Context, rpcUrl string) (Backend, error) {
+	client, err := ethclient.DialContext(ctx, rpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("dial dev node: %w", err)
+	}
+	return &devNodeBackend{Client: client}, nil
+}
+
+func (b *devNodeBackend) Mine() {}
+
+func (b *devNodeBackend) AdvanceTime(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
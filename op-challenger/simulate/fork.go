@@ -0,0 +1,260 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// forkedBlockOracleAddr is the address of the block oracle of a forked backend, which has the L1 head of the fork checkpointed.
+var forkedBlockOracleAddr = common.HexToAddress("0x000000000000000000000000000000000000b10c")
+
+// ForkSource is the L1 chain a simulated backend is forked from.
+type ForkSource interface {
+	bind.ContractCaller
+	ethereum.ChainStateReader
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+
+	// CreateAccessList returns the accounts and storage slots accessed by the call at the given block.
+	CreateAccessList(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (types.AccessList, error)
+}
+
+// rpcForkSource is a [ForkSource] served by an L1 RPC.
+type rpcForkSource struct {
+	*ethclient.Client
+	rpc *rpc.Client
+}
+
+// DialForkSource connects to the L1 RPC at rpcUrl to fork from.
+func DialForkSource(ctx context.Context, rpcUrl string) (ForkSource, error) {
+	client, err := rpc.DialContext(ctx, rpcUrl)
+	if err != nil {
+		return nil, fmt.Errorf("dial fork source: %w", err)
+	}
+	return &rpcForkSource{Client: ethclient.NewClient(client), rpc: client}, nil
+}
+
+func (s *rpcForkSource) CreateAccessList(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (types.AccessList, error) {
+	var result struct {
+		AccessList types.AccessList `json:"accessList"`
+		Error      string           `json:"error,omitempty"`
+	}
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+		"data": hexutil.Bytes(msg.Data),
+	}
+	if err := s.rpc.CallContext(ctx, &result, "eth_createAccessList", arg, hexutil.EncodeBig(blockNumber)); err != nil {
+		return nil, err
+	}
+	if result.Error != "" {
+		return nil, fmt.Errorf("call failed: %v", result.Error)
+	}
+	return result.AccessList, nil
+}
+
+// ForkConfig selects the output proposal that a game played on a forked backend disputes.
+type ForkConfig struct {
+	// BlockNumber is the L1 block to fork at, which is the L1 head of the game.
+	// Nil for the parent of the latest block, as the child of the L1 head must exist to checkpoint it.
+	BlockNumber *big.Int
+	// L2OutputOracle is the L2 output oracle the disputed output was proposed to.
+	L2OutputOracle common.Address
+	// L2BlockNumber selects the first output proposed at or after it as the disputed output.
+	// Nil for the latest output proposed before the L1 head.
+	L2BlockNumber *big.Int
+}
+
+// Fork is the L1 state a backend was forked from.
+// It provides the inputs of the game disputing the output, so cannon traces can be created before the game.
+type Fork struct {
+	L2OutputOracle common.Address // Forked L2 output oracle
+	BlockOracle    common.Address // Block oracle with the L1 head checkpointed
+	L1HeadNumber   uint64         // Number of the L1 block the state was forked at
+
+	l1Head   common.Hash
+	starting bindings.IFaultDisputeGameOutputProposal
+	disputed bindings.IFaultDisputeGameOutputProposal
+}
+
+// L1Head returns the hash of the L1 block the state was forked at.
+func (f *Fork) L1Head(opts *bind.CallOpts) ([32]byte, error) {
+	return f.l1Head, nil
+}
+
+// Proposals returns the disputed output and the output proposed before it.
+func (f *Fork) Proposals(opts *bind.CallOpts) (struct {
+	Starting bindings.IFaultDisputeGameOutputProposal
+	Disputed bindings.IFaultDisputeGameOutputProposal
+}, error) {
+	return struct {
+		Starting bindings.IFaultDisputeGameOutputProposal
+		Disputed bindings.IFaultDisputeGameOutputProposal
+	}{Starting: f.starting, Disputed: f.disputed}, nil
+}
+
+// NewForkedBackend creates an in-process L1 chain with the accounts of alloc and the state of the source
+// that a game disputing the output selected by cfg reads: the accounts and storage slots of the L2 output oracle
+// accessed when the game is initialized. The chain starts at the time of the child of the L1 head.
+// Other state of the source is not available on the forked chain.
+func NewForkedBackend(ctx context.Context, source ForkSource, cfg ForkConfig, alloc core.GenesisAlloc) (Backend, *Fork, error) {
+	var child *types.Header
+	var err error
+	if cfg.BlockNumber == nil {
+		child, err = source.HeaderByNumber(ctx, nil)
+	} else {
+		child, err = source.HeaderByNumber(ctx, new(big.Int).Add(cfg.BlockNumber, common.Big1))
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("fetch child of l1 head: %w", err)
+	}
+	if child.Number.Sign() == 0 {
+		return nil, nil, errors.New("cannot fork at the parent of the genesis block")
+	}
+	l1Head := new(big.Int).Sub(child.Number, common.Big1)
+	fork := &Fork{
+		L2OutputOracle: cfg.L2OutputOracle,
+		BlockOracle:    forkedBlockOracleAddr,
+		L1HeadNumber:   l1Head.Uint64(),
+		l1Head:         child.ParentHash,
+	}
+
+	calls, err := fork.fetchProposals(ctx, source, l1Head, cfg.L2BlockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	genesis := make(core.GenesisAlloc)
+	for _, call := range calls {
+		accessList, err := source.CreateAccessList(ctx, call, l1Head)
+		if err != nil {
+			return nil, nil, fmt.Errorf("create access list: %w", err)
+		}
+		accessList = append(accessList, types.AccessTuple{Address: *call.To})
+		for _, tuple := range accessList {
+			if err := forkAccount(ctx, source, l1Head, genesis, tuple); err != nil {
+				return nil, nil, fmt.Errorf("fork account %v: %w", tuple.Address, err)
+			}
+		}
+	}
+	if _, ok := genesis[forkedBlockOracleAddr]; ok {
+		return nil, nil, fmt.Errorf("block oracle address %v is used by the forked chain", forkedBlockOracleAddr)
+	}
+	genesis[forkedBlockOracleAddr] = checkpointedBlockOracle(fork.L1HeadNumber, fork.l1Head, child.Time)
+	for addr, account := range alloc {
+		genesis[addr] = account
+	}
+	backend := newSimulatedBackend(core.Genesis{Alloc: genesis, Timestamp: child.Time})
+	return backend, fork, nil
+}
+
+// fetchProposals fetches the disputed output and the output proposed before it from the source,
+// and returns the calls the game makes to the L2 output oracle to fetch them.
+func (f *Fork) fetchProposals(ctx context.Context, source ForkSource, l1Head *big.Int, l2BlockNumber *big.Int) ([]ethereum.CallMsg, error) {
+	l2oo, err := bindings.NewL2OutputOracleCaller(f.L2OutputOracle, source)
+	if err != nil {
+		return nil, err
+	}
+	l2ooAbi, err := bindings.L2OutputOracleMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: l1Head}
+	if l2BlockNumber == nil {
+		l2BlockNumber, err = l2oo.LatestBlockNumber(opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetch latest proposed l2 block: %w", err)
+		}
+	}
+	idx, err := l2oo.GetL2OutputIndexAfter(opts, l2BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("fetch index of output after l2 block %v: %w", l2BlockNumber, err)
+	}
+	if idx.Sign() == 0 {
+		return nil, errors.New("cannot dispute the first output proposal")
+	}
+	startingIdx := new(big.Int).Sub(idx, common.Big1)
+	starting, err := l2oo.GetL2Output(opts, startingIdx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch starting output: %w", err)
+	}
+	disputed, err := l2oo.GetL2Output(opts, idx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch disputed output: %w", err)
+	}
+	f.starting = bindings.IFaultDisputeGameOutputProposal{Index: startingIdx, L2BlockNumber: starting.L2BlockNumber, OutputRoot: starting.OutputRoot}
+	f.disputed = bindings.IFaultDisputeGameOutputProposal{Index: idx, L2BlockNumber: disputed.L2BlockNumber, OutputRoot: disputed.OutputRoot}
+
+	var calls []ethereum.CallMsg
+	for _, args := range [][]interface{}{
+		{"getL2OutputIndexAfter", disputed.L2BlockNumber},
+		{"getL2Output", startingIdx},
+		{"getL2Output", idx},
+	} {
+		data, err := l2ooAbi.Pack(args[0].(string), args[1:]...)
+		if err != nil {
+			return nil, err
+		}
+		calls = append(calls, ethereum.CallMsg{To: &f.L2OutputOracle, Data: data})
+	}
+	return calls, nil
+}
+
+// forkAccount copies the account and the storage slots of the tuple at the block from the source to the genesis.
+func forkAccount(ctx context.Context, source ForkSource, blockNumber *big.Int, genesis core.GenesisAlloc, tuple types.AccessTuple) error {
+	account, ok := genesis[tuple.Address]
+	if !ok {
+		code, err := source.CodeAt(ctx, tuple.Address, blockNumber)
+		if err != nil {
+			return fmt.Errorf("fetch code: %w", err)
+		}
+		balance, err := source.BalanceAt(ctx, tuple.Address, blockNumber)
+		if err != nil {
+			return fmt.Errorf("fetch balance: %w", err)
+		}
+		nonce, err := source.NonceAt(ctx, tuple.Address, blockNumber)
+		if err != nil {
+			return fmt.Errorf("fetch nonce: %w", err)
+		}
+		account = core.GenesisAccount{Code: code, Balance: balance, Nonce: nonce, Storage: make(map[common.Hash]common.Hash)}
+	}
+	for _, slot := range tuple.StorageKeys {
+		if _, ok := account.Storage[slot]; ok {
+			continue
+		}
+		value, err := source.StorageAt(ctx, tuple.Address, slot, blockNumber)
+		if err != nil {
+			return fmt.Errorf("fetch storage slot %v: %w", slot, err)
+		}
+		account.Storage[slot] = common.BytesToHash(value)
+	}
+	genesis[tuple.Address] = account
+	return nil
+}
+
+// checkpointedBlockOracle returns a block oracle account with the block checkpointed, as if the child of the block
+// called checkpoint. The blocks mapping of the oracle is in slot 0, with the hash and the child timestamp of each block
+// in consecutive slots.
+func checkpointedBlockOracle(number uint64, hash common.Hash, childTimestamp uint64) core.GenesisAccount {
+	slot := crypto.Keccak256Hash(common.BigToHash(new(big.Int).SetUint64(number)).Bytes(), common.Hash{}.Bytes())
+	timestampSlot := common.BigToHash(new(big.Int).Add(slot.Big(), common.Big1))
+	return core.GenesisAccount{
+		Code:    common.FromHex(bindings.BlockOracleDeployedBin),
+		Balance: new(big.Int),
+		Storage: map[common.Hash]common.Hash{
+			slot:          hash,
+			timestampSlot: common.BigToHash(new(big.Int).SetUint64(childTimestamp)),
+		},
+	}
+}
@@ -0,0 +1,147 @@
+package simulate
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/state"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/tracers/logger"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForkedBackend(t *testing.T) {
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+	alloc := core.GenesisAlloc{crypto.PubkeyToAddress(key.PublicKey): {Balance: funds}}
+
+	// Propose the outputs to the source chain, and mine the child of the L1 head to fork at
+	source := &simulatedForkSource{SimulatedBackend: backends.NewSimulatedBackend(alloc, 50_000_000)}
+	deployer, err := newDeployer(ctx, &simulatedBackend{SimulatedBackend: source.SimulatedBackend}, key)
+	require.NoError(t, err)
+	l2ooAddr, _, _, err := deployer.proposeOutputs(ctx)
+	require.NoError(t, err)
+	l1Head := source.Blockchain().CurrentBlock()
+	source.Commit()
+
+	// Play with another key, as the contracts deployed by the same key would collide with the forked contracts
+	forkKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	forkAlloc := core.GenesisAlloc{crypto.PubkeyToAddress(forkKey.PublicKey): {Balance: funds}}
+	backend, fork, err := NewForkedBackend(ctx, source, ForkConfig{L2OutputOracle: l2ooAddr}, forkAlloc)
+	require.NoError(t, err)
+	require.Equal(t, l1Head.Number.Uint64(), fork.L1HeadNumber)
+	proposals, err := fork.Proposals(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1), proposals.Starting.L2BlockNumber)
+	require.Equal(t, common.Hash{0x01}, common.Hash(proposals.Starting.OutputRoot))
+	require.Equal(t, big.NewInt(2), proposals.Disputed.L2BlockNumber)
+	require.Equal(t, common.Hash{0x02}, common.Hash(proposals.Disputed.OutputRoot))
+
+	result, err := Run(ctx, testlog.Logger(t, log.LvlInfo), backend, forkKey,
+		Config{TraceType: config.TraceTypeAlphabet, GameDepth: 4, GameDuration: 24 * time.Hour, Fork: fork},
+		Challenger{Name: "Defender", Trace: alphabet.NewTraceProvider("abcdexyz", 4)},
+		Challenger{Name: "Challenger", Trace: alphabet.NewTraceProvider(correctAlphabet, 4)})
+	require.NoError(t, err)
+	require.Equal(t, types.GameStatusChallengerWon, result.Status)
+
+	game, err := bindings.NewFaultDisputeGameCaller(result.Game, backend)
+	require.NoError(t, err)
+	gameL1Head, err := game.L1Head(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, l1Head.Hash(), common.Hash(gameL1Head), "should checkpoint the forked l1 head")
+	gameProposals, err := game.Proposals(&bind.CallOpts{})
+	require.NoError(t, err)
+	require.Equal(t, proposals.Starting, gameProposals.Starting, "should dispute the forked outputs")
+	require.Equal(t, proposals.Disputed, gameProposals.Disputed, "should dispute the forked outputs")
+}
+
+// simulatedForkSource is a [ForkSource] that serves the state of a simulated backend at any block.
+type simulatedForkSource struct {
+	*backends.SimulatedBackend
+}
+
+func (s *simulatedForkSource) stateAt(blockNumber *big.Int) (*ethtypes.Header, *state.StateDB, error) {
+	chain := s.Blockchain()
+	header := chain.CurrentBlock()
+	if blockNumber != nil {
+		header = chain.GetHeaderByNumber(blockNumber.Uint64())
+	}
+	statedb, err := chain.StateAt(header.Root)
+	return header, statedb, err
+}
+
+func (s *simulatedForkSource) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	_, statedb, err := s.stateAt(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return statedb.GetCode(account), nil
+}
+
+func (s *simulatedForkSource) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	_, statedb, err := s.stateAt(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return statedb.GetBalance(account), nil
+}
+
+func (s *simulatedForkSource) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	_, statedb, err := s.stateAt(blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	return statedb.GetNonce(account), nil
+}
+
+func (s *simulatedForkSource) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	_, statedb, err := s.stateAt(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	value := statedb.GetState(account, key)
+	return value.Bytes(), nil
+}
+
+func (s *simulatedForkSource) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	ret, _, err := s.call(msg, blockNumber)
+	return ret, err
+}
+
+func (s *simulatedForkSource) CreateAccessList(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (ethtypes.AccessList, error) {
+	_, accessList, err := s.call(msg, blockNumber)
+	return accessList, err
+}
+
+func (s *simulatedForkSource) call(msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, ethtypes.AccessList, error) {
+	header, statedb, err := s.stateAt(blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	chain := s.Blockchain()
+	rules := chain.Config().Rules(header.Number, true, header.Time)
+	tracer := logger.NewAccessListTracer(nil, msg.From, *msg.To, vm.ActivePrecompiles(rules))
+	blockCtx := core.NewEVMBlockContext(header, chain, nil, chain.Config(), statedb)
+	evm := vm.NewEVM(blockCtx, vm.TxContext{Origin: msg.From, GasPrice: new(big.Int)}, statedb, chain.Config(), vm.Config{Tracer: tracer, NoBaseFee: true})
+	ret, _, err := evm.Call(vm.AccountRef(msg.From), *msg.To, msg.Data, 10_000_000, new(big.Int))
+	return ret, tracer.AccessList(), err
+}
@@ -0,0 +1,412 @@
+package simulate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/db"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const faultGameType uint8 = 0
+
+// disputedL2BlockNumber is the L2 block number of the output disputed by the simulated game, unless it is forked.
+// The simulation proposes outputs for L2 blocks 1 and 2, so the game bisects the trace between them.
+const disputedL2BlockNumber = 2
+
+// challengerFunds is the amount of ETH each challenger is funded with to pay for its moves.
+var challengerFunds = new(big.Int).Mul(big.NewInt(100), big.NewInt(params.Ether))
+
+// Config configures the contracts of a simulated game.
+type Config struct {
+	TraceType    config.TraceType // Trace type of the VM the game is played on, which must match the traces of the challengers
+	GameDepth    int              // Depth of the game tree
+	GameDuration time.Duration    // Duration of the game, split between the chess clocks of both sides
+
+	// Fork is the L1 state the backend was forked from, if any.
+	// The game then disputes the output selected by the fork, instead of an output proposed by the simulation.
+	Fork *Fork
+}
+
+// Challenger is one side of a simulated game.
+type Challenger struct {
+	Name  string
+	Trace types.TraceProvider
+}
+
+// Result is the outcome of a simulated game.
+type Result struct {
+	Game   common.Address
+	Status types.GameStatus
+	Claims int
+}
+
+// Run deploys the dispute game contracts to the backend with the funded key, and plays a game between two challengers.
+// The game is played on the VM of the trace type of cfg, so the steps are only valid for traces of that VM.
+// The root claim is the last claim of the defender's trace. The defender supports the root claim, like a challenger
+// that disagrees with the proposed output, and the challenger counters it, like a challenger that agrees with it.
+// The game is resolved once neither side has any more moves to make and the clocks have expired.
+func Run(ctx context.Context, logger log.Logger, backend Backend, funder *ecdsa.PrivateKey, cfg Config, defender Challenger, challenger Challenger) (Result, error) {
+	deployer, err := newDeployer(ctx, backend, funder)
+	if err != nil {
+		return Result{}, err
+	}
+	prestate, err := defender.Trace.AbsolutePreState(ctx)
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch absolute prestate: %w", err)
+	}
+	factory, output, err := deployer.deployContracts(ctx, crypto.Keccak256Hash(prestate), cfg)
+	if err != nil {
+		return Result{}, fmt.Errorf("deploy contracts: %w", err)
+	}
+	rootClaim, err := defender.Trace.Get(ctx, 1<<cfg.GameDepth-1)
+	if err != nil {
+		return Result{}, fmt.Errorf("fetch root claim: %w", err)
+	}
+	gameAddr, err := deployer.createGame(ctx, factory, rootClaim, output)
+	if err != nil {
+		return Result{}, fmt.Errorf("create game: %w", err)
+	}
+	logger = logger.New("game", gameAddr)
+	logger.Info("Created game", "root_claim", common.Hash(rootClaim))
+
+	game, err := bindings.NewFaultDisputeGameCaller(gameAddr, backend)
+	if err != nil {
+		return Result{}, fmt.Errorf("bind game: %w", err)
+	}
+	var agents []*fault.Agent
+	for _, c := range []struct {
+		Challenger
+		agreeWithProposedOutput bool
+	}{
+		{defender, false},
+		{challenger, true},
+	} {
+		key, err := deployer.fundNewAccount(ctx)
+		if err != nil {
+			return Result{}, fmt.Errorf("fund %v: %w", c.Name, err)
+		}
+		agent, err := newAgent(ctx, logger.New("challenger", c.Name), backend, key, gameAddr, game, cfg, c.Trace, c.agreeWithProposedOutput)
+		if err != nil {
+			return Result{}, fmt.Errorf("create %v: %w", c.Name, err)
+		}
+		agents = append(agents, agent)
+	}
+	return play(ctx, logger, backend, gameAddr, game, cfg, agents)
+}
+
+// play acts with each agent in turn until the game is resolved.
+// Once the agents stop making moves, the chain time is moved past the end of the game so it can be resolved.
+func play(ctx context.Context, logger log.Logger, backend Backend, gameAddr common.Address, game *bindings.FaultDisputeGameCaller, cfg Config, agents []*fault.Agent) (Result, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	claims := func() (int, error) {
+		count, err := game.ClaimDataLen(opts)
+		if err != nil {
+			return 0, fmt.Errorf("fetch claim count: %w", err)
+		}
+		return int(count.Int64()), nil
+	}
+	expired := false
+	for {
+		before, err := claims()
+		if err != nil {
+			return Result{}, err
+		}
+		for _, agent := range agents {
+			if err := agent.Act(ctx); err != nil {
+				logger.Error("Failed to act", "err", err)
+			}
+		}
+		status, err := game.Status(opts)
+		if err != nil {
+			return Result{}, fmt.Errorf("fetch game status: %w", err)
+		}
+		after, err := claims()
+		if err != nil {
+			return Result{}, err
+		}
+		if types.GameStatus(status) != types.GameStatusInProgress {
+			logger.Info("Game resolved", "status", fault.GameStatusString(types.GameStatus(status)), "claims", after)
+			return Result{Game: gameAddr, Status: types.GameStatus(status), Claims: after}, nil
+		}
+		if after != before {
+			continue
+		}
+		if expired {
+			return Result{}, errors.New("game could not be resolved after the clocks expired")
+		}
+		logger.Info("No more moves, waiting for the clocks to expire", "claims", after)
+		if err := backend.AdvanceTime(ctx, cfg.GameDuration+time.Second); err != nil {
+			return Result{}, fmt.Errorf("advance time: %w", err)
+		}
+		expired = true
+	}
+}
+
+func newAgent(ctx context.Context, logger log.Logger, backend Backend, key *ecdsa.PrivateKey, gameAddr common.Address, game *bindings.FaultDisputeGameCaller, cfg Config, trace types.TraceProvider, agreeWithProposedOutput bool) (*fault.Agent, error) {
+	txMgr, err := newTxManager(ctx, backend, key)
+	if err != nil {
+		return nil, err
+	}
+	bonds, err := fault.NewBondSource(gameAddr, backend)
+	if err != nil {
+		return nil, err
+	}
+	responder, err := fault.NewFaultResponder(logger, txMgr, gameAddr, bonds, metrics.NoopMetrics, db.NoopGameRecorder)
+	if err != nil {
+		return nil, err
+	}
+	fetchBalance := func(ctx context.Context) (*big.Int, error) {
+		return backend.BalanceAt(ctx, txMgr.From(), nil)
+	}
+	return fault.NewAgent(fault.NewLoader(game), cfg.GameDepth, solver.NewSolver(cfg.GameDepth, trace), responder, bonds, fetchBalance,
+		uint64(cfg.GameDuration/time.Second), &chainClock{Clock: clock.SystemClock, backend: backend}, metrics.NoopMetrics,
+		db.NoopGameRecorder, agreeWithProposedOutput, logger), nil
+}
+
+// chainClock is a [clock.Clock] whose current time is the time of the latest block,
+// so the chess clocks of the game are measured against the time of the chain, which may run ahead of the wall clock.
+type chainClock struct {
+	clock.Clock
+	backend Backend
+}
+
+func (c *chainClock) Now() time.Time {
+	header, err := c.backend.HeaderByNumber(context.Background(), nil)
+	if err != nil {
+		return c.Clock.Now()
+	}
+	return time.Unix(int64(header.Time), 0)
+}
+
+// deployer deploys the contracts of the simulated game and funds the challengers from the funded key.
+type deployer struct {
+	backend Backend
+	opts    *bind.TransactOpts
+}
+
+func newDeployer(ctx context.Context, backend Backend, key *ecdsa.PrivateKey) (*deployer, error) {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch chain id: %w", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return nil, err
+	}
+	opts.Context = ctx
+	return &deployer{backend: backend, opts: opts}, nil
+}
+
+// disputedOutput is the output disputed by the simulated game, and the L1 head containing it.
+type disputedOutput struct {
+	l2BlockNumber *big.Int
+	l1Head        uint64
+}
+
+// deployContracts deploys the dispute game factory with the fault dispute game implementation on the VM of the trace
+// type. Unless the backend is forked, it proposes the outputs the game disputes, and checkpoints an L1 head containing
+// them. It returns the factory and the output the game disputes.
+func (d *deployer) deployContracts(ctx context.Context, absolutePrestate common.Hash, cfg Config) (*bindings.DisputeGameFactory, disputedOutput, error) {
+	_, tx, proxy, err := bindings.DeployProxy(d.opts, d.backend, d.opts.From)
+	if err != nil {
+		return nil, disputedOutput{}, fmt.Errorf("deploy factory proxy: %w", err)
+	}
+	proxyAddr, err := d.waitDeployed(ctx, tx)
+	if err != nil {
+		return nil, disputedOutput{}, err
+	}
+	_, tx, _, err = bindings.DeployDisputeGameFactory(d.opts, d.backend)
+	if err != nil {
+		return nil, disputedOutput{}, fmt.Errorf("deploy factory: %w", err)
+	}
+	factoryImplAddr, err := d.waitDeployed(ctx, tx)
+	if err != nil {
+		return nil, disputedOutput{}, err
+	}
+	factoryAbi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, disputedOutput{}, err
+	}
+	data, err := factoryAbi.Pack("initialize", d.opts.From)
+	if err != nil {
+		return nil, disputedOutput{}, err
+	}
+	if err := d.wait(ctx)(proxy.UpgradeToAndCall(d.opts, factoryImplAddr, data)); err != nil {
+		return nil, disputedOutput{}, fmt.Errorf("initialize factory: %w", err)
+	}
+	factory, err := bindings.NewDisputeGameFactory(proxyAddr, d.backend)
+	if err != nil {
+		return nil, disputedOutput{}, err
+	}
+
+	vmAddr, err := d.deployVM(ctx, cfg.TraceType, absolutePrestate)
+	if err != nil {
+		return nil, disputedOutput{}, err
+	}
+	var l2ooAddr, blockOracleAddr common.Address
+	var output disputedOutput
+	if cfg.Fork != nil {
+		l2ooAddr, blockOracleAddr = cfg.Fork.L2OutputOracle, cfg.Fork.BlockOracle
+		output = disputedOutput{l2BlockNumber: cfg.Fork.disputed.L2BlockNumber, l1Head: cfg.Fork.L1HeadNumber}
+	} else {
+		l2ooAddr, blockOracleAddr, output, err = d.proposeOutputs(ctx)
+		if err != nil {
+			return nil, disputedOutput{}, err
+		}
+	}
+	_, tx, _, err = bindings.DeployFaultDisputeGame(d.opts, d.backend, absolutePrestate, big.NewInt(int64(cfg.GameDepth)),
+		uint64(cfg.GameDuration/time.Second), vmAddr, l2ooAddr, blockOracleAddr)
+	if err != nil {
+		return nil, disputedOutput{}, fmt.Errorf("deploy fault dispute game: %w", err)
+	}
+	gameImplAddr, err := d.waitDeployed(ctx, tx)
+	if err != nil {
+		return nil, disputedOutput{}, err
+	}
+	if err := d.wait(ctx)(factory.SetImplementation(d.opts, faultGameType, gameImplAddr)); err != nil {
+		return nil, disputedOutput{}, fmt.Errorf("set fault dispute game implementation: %w", err)
+	}
+	return factory, output, nil
+}
+
+// deployVM deploys the VM that executes the steps of traces of the trace type.
+func (d *deployer) deployVM(ctx context.Context, traceType config.TraceType, absolutePrestate common.Hash) (common.Address, error) {
+	var tx *ethtypes.Transaction
+	var err error
+	switch traceType {
+	case config.TraceTypeAlphabet:
+		_, tx, _, err = bindings.DeployAlphabetVM(d.opts, d.backend, absolutePrestate)
+	case config.TraceTypeCannon:
+		_, tx, _, err = bindings.DeployMIPS(d.opts, d.backend)
+	default:
+		return common.Address{}, fmt.Errorf("unsupported trace type: %v", traceType)
+	}
+	if err != nil {
+		return common.Address{}, fmt.Errorf("deploy %v vm: %w", traceType, err)
+	}
+	return d.waitDeployed(ctx, tx)
+}
+
+// proposeOutputs deploys an L2 output oracle and a block oracle, proposes the outputs the game disputes,
+// and checkpoints an L1 head containing them.
+func (d *deployer) proposeOutputs(ctx context.Context) (common.Address, common.Address, disputedOutput, error) {
+	_, tx, blockOracle, err := bindings.DeployBlockOracle(d.opts, d.backend)
+	if err != nil {
+		return common.Address{}, common.Address{}, disputedOutput{}, fmt.Errorf("deploy block oracle: %w", err)
+	}
+	blockOracleAddr, err := d.waitDeployed(ctx, tx)
+	if err != nil {
+		return common.Address{}, common.Address{}, disputedOutput{}, err
+	}
+	_, tx, l2oo, err := bindings.DeployL2OutputOracle(d.opts, d.backend, big.NewInt(1), big.NewInt(1), big.NewInt(0), big.NewInt(0),
+		d.opts.From, d.opts.From, big.NewInt(1))
+	if err != nil {
+		return common.Address{}, common.Address{}, disputedOutput{}, fmt.Errorf("deploy l2 output oracle: %w", err)
+	}
+	l2ooAddr, err := d.waitDeployed(ctx, tx)
+	if err != nil {
+		return common.Address{}, common.Address{}, disputedOutput{}, err
+	}
+	for i := uint64(1); i <= disputedL2BlockNumber; i++ {
+		if err := d.wait(ctx)(l2oo.ProposeL2Output(d.opts, common.Hash{byte(i)}, new(big.Int).SetUint64(i), common.Hash{}, big.NewInt(0))); err != nil {
+			return common.Address{}, common.Address{}, disputedOutput{}, fmt.Errorf("propose output %v: %w", i, err)
+		}
+	}
+	// The block oracle records the hash of the parent block, which must include the proposed outputs.
+	tx, err = blockOracle.Checkpoint(d.opts)
+	if err != nil {
+		return common.Address{}, common.Address{}, disputedOutput{}, fmt.Errorf("checkpoint l1 head: %w", err)
+	}
+	receipt, err := d.waitReceipt(ctx, tx)
+	if err != nil {
+		return common.Address{}, common.Address{}, disputedOutput{}, fmt.Errorf("checkpoint l1 head: %w", err)
+	}
+	output := disputedOutput{l2BlockNumber: big.NewInt(disputedL2BlockNumber), l1Head: receipt.BlockNumber.Uint64() - 1}
+	return l2ooAddr, blockOracleAddr, output, nil
+}
+
+// createGame creates a fault dispute game disputing the output with the root claim.
+func (d *deployer) createGame(ctx context.Context, factory *bindings.DisputeGameFactory, rootClaim common.Hash, output disputedOutput) (common.Address, error) {
+	extraData, err := abi.Arguments{{Type: uint256Type}, {Type: uint256Type}}.Pack(output.l2BlockNumber, new(big.Int).SetUint64(output.l1Head))
+	if err != nil {
+		return common.Address{}, err
+	}
+	tx, err := factory.Create(d.opts, faultGameType, rootClaim, extraData)
+	if err != nil {
+		return common.Address{}, err
+	}
+	receipt, err := d.waitReceipt(ctx, tx)
+	if err != nil {
+		return common.Address{}, err
+	}
+	for _, l := range receipt.Logs {
+		if created, err := factory.ParseDisputeGameCreated(*l); err == nil {
+			return created.DisputeProxy, nil
+		}
+	}
+	return common.Address{}, errors.New("no dispute game created")
+}
+
+// fundNewAccount creates a new account and funds it with challengerFunds.
+func (d *deployer) fundNewAccount(ctx context.Context) (*ecdsa.PrivateKey, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	opts := *d.opts
+	opts.Value = challengerFunds
+	opts.GasLimit = params.TxGas
+	contract := bind.NewBoundContract(crypto.PubkeyToAddress(key.PublicKey), abi.ABI{}, d.backend, d.backend, d.backend)
+	if err := d.wait(ctx)(contract.Transfer(&opts)); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// wait returns a function that waits for the transaction returned by a contract binding to succeed.
+func (d *deployer) wait(ctx context.Context) func(tx *ethtypes.Transaction, err error) error {
+	return func(tx *ethtypes.Transaction, err error) error {
+		if err != nil {
+			return err
+		}
+		_, err = d.waitReceipt(ctx, tx)
+		return err
+	}
+}
+
+func (d *deployer) waitReceipt(ctx context.Context, tx *ethtypes.Transaction) (*ethtypes.Receipt, error) {
+	d.backend.Mine()
+	receipt, err := bind.WaitMined(ctx, d.backend, tx)
+	if err != nil {
+		return nil, err
+	}
+	if receipt.Status != ethtypes.ReceiptStatusSuccessful {
+		return nil, fmt.Errorf("transaction %v failed", tx.Hash())
+	}
+	return receipt, nil
+}
+
+func (d *deployer) waitDeployed(ctx context.Context, tx *ethtypes.Transaction) (common.Address, error) {
+	d.backend.Mine()
+	return bind.WaitDeployed(ctx, d.backend, tx)
+}
+
+var uint256Type, _ = abi.NewType("uint256", "", nil)
@@ -0,0 +1,80 @@
+package simulate
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+const correctAlphabet = "abcdefghijklmnop"
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name              string
+		rootClaimAlphabet string
+		otherAlphabet     string
+		expected          types.GameStatus
+	}{
+		{"ChallengerWins_DefenseStep", "abcdexyz", correctAlphabet, types.GameStatusChallengerWon},
+		{"DefenderWins_DefenseStep", correctAlphabet, "abcdexyz", types.GameStatusDefenderWon},
+		{"ChallengerWins_AttackStep", "abcdefghzyx", correctAlphabet, types.GameStatusChallengerWon},
+		{"DefenderIncorrectAtTraceZero", "zyxwvut", correctAlphabet, types.GameStatusChallengerWon},
+		{"ChallengerIncorrectAtLastTraceIndex", correctAlphabet, "abcdefghijklmnoz", types.GameStatusDefenderWon},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			key, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+			backend := NewSimulatedBackend(core.GenesisAlloc{crypto.PubkeyToAddress(key.PublicKey): {Balance: funds}})
+			result, err := Run(context.Background(), testlog.Logger(t, log.LvlInfo), backend, key,
+				Config{TraceType: config.TraceTypeAlphabet, GameDepth: 4, GameDuration: 24 * time.Hour},
+				Challenger{Name: "Defender", Trace: alphabet.NewTraceProvider(test.rootClaimAlphabet, 4)},
+				Challenger{Name: "Challenger", Trace: alphabet.NewTraceProvider(test.otherAlphabet, 4)})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, result.Status)
+			require.Greater(t, result.Claims, 4, "should play to the max depth")
+		})
+	}
+}
+
+func TestRunDivergentTrace(t *testing.T) {
+	tests := []struct {
+		name          string
+		defenderFrom  uint64
+		challengeFrom uint64
+		expected      types.GameStatus
+	}{
+		{"ChallengerWins", 5, 16, types.GameStatusChallengerWon},
+		{"ChallengerWins_FromTraceZero", 0, 16, types.GameStatusChallengerWon},
+		{"DefenderWins", 16, 9, types.GameStatusDefenderWon},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			key, err := crypto.GenerateKey()
+			require.NoError(t, err)
+			funds := new(big.Int).Mul(big.NewInt(1000), big.NewInt(params.Ether))
+			backend := NewSimulatedBackend(core.GenesisAlloc{crypto.PubkeyToAddress(key.PublicKey): {Balance: funds}})
+			honest := alphabet.NewTraceProvider(correctAlphabet, 4)
+			result, err := Run(context.Background(), testlog.Logger(t, log.LvlInfo), backend, key,
+				Config{TraceType: config.TraceTypeAlphabet, GameDepth: 4, GameDuration: 24 * time.Hour},
+				Challenger{Name: "Defender", Trace: NewDivergentTrace(honest, test.defenderFrom)},
+				Challenger{Name: "Challenger", Trace: NewDivergentTrace(honest, test.challengeFrom)})
+			require.NoError(t, err)
+			require.Equal(t, test.expected, result.Status)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package simulate
+
+import (
+	"context"
+	"errors"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var errDivergentClaim = errors.New("divergent claims have no pre-image")
+
+// divergentTrace is a [types.TraceProvider] that agrees with a trace before a trace index, and diverges from it on.
+type divergentTrace struct {
+	types.TraceProvider
+	from uint64
+}
+
+// NewDivergentTrace returns a trace that agrees with the claims of trace before the trace index from,
+// and makes different claims from it on, like a challenger running a faulty VM.
+// The claims from the index on have no pre-image, so they can not be stepped from.
+func NewDivergentTrace(trace types.TraceProvider, from uint64) types.TraceProvider {
+	return &divergentTrace{TraceProvider: trace, from: from}
+}
+
+func (t *divergentTrace) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	if i < t.from {
+		return t.TraceProvider.Get(ctx, i)
+	}
+	return crypto.Keccak256Hash([]byte("divergent"), new(big.Int).SetUint64(i).Bytes()), nil
+}
+
+func (t *divergentTrace) GetOracleData(ctx context.Context, i uint64) (*types.PreimageOracleData, error) {
+	if i < t.from {
+		return t.TraceProvider.GetOracleData(ctx, i)
+	}
+	return nil, errDivergentClaim
+}
+
+func (t *divergentTrace) GetPreimage(ctx context.Context, i uint64) ([]byte, []byte, error) {
+	if i < t.from {
+		return t.TraceProvider.GetPreimage(ctx, i)
+	}
+	return nil, nil, errDivergentClaim
+}
@@ -0,0 +1,74 @@
+package simulate
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// txManager is a [txmgr.TxManager] that sends the transactions of a challenger to the [Backend] of a simulation
+// and mines them immediately, instead of managing their fees and resubmitting them.
+type txManager struct {
+	backend Backend
+	opts    *bind.TransactOpts
+
+	// lock serializes the transactions of the account, as the nonce is read from the backend for each transaction
+	lock sync.Mutex
+}
+
+func newTxManager(ctx context.Context, backend Backend, key *ecdsa.PrivateKey) (*txManager, error) {
+	chainID, err := backend.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetch chain id: %w", err)
+	}
+	opts, err := bind.NewKeyedTransactorWithChainID(key, chainID)
+	if err != nil {
+		return nil, err
+	}
+	return &txManager{backend: backend, opts: opts}, nil
+}
+
+func (m *txManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	if candidate.To == nil {
+		return nil, errors.New("contract creation is not supported")
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	opts := *m.opts
+	opts.Context = ctx
+	opts.GasLimit = candidate.GasLimit
+	opts.Value = candidate.Value
+	contract := bind.NewBoundContract(*candidate.To, abi.ABI{}, m.backend, m.backend, m.backend)
+	tx, err := contract.RawTransact(&opts, candidate.TxData)
+	if err != nil {
+		return nil, err
+	}
+	m.backend.Mine()
+	return bind.WaitMined(ctx, m.backend, tx)
+}
+
+func (m *txManager) Call(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return m.backend.CallContract(ctx, msg, blockNumber)
+}
+
+func (m *txManager) From() common.Address {
+	return m.opts.From
+}
+
+func (m *txManager) BlockNumber(ctx context.Context) (uint64, error) {
+	header, err := m.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
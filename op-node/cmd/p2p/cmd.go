@@ -102,4 +102,22 @@ var Subcommands = cli.Commands{
 			return nil
 		},
 	},
+	{
+		Name:   "export-reputation",
+		Usage:  "Exports the peer scores and bans of an op-node as JSON",
+		Flags:  []cli.Flag{rpcFlag, outFlag},
+		Action: exportReputation,
+	},
+	{
+		Name:   "import-reputation",
+		Usage:  "Imports peer scores and bans, as exported with export-reputation, into the peerstore of an op-node",
+		Flags:  []cli.Flag{rpcFlag, inFlag},
+		Action: importReputation,
+	},
+	{
+		Name:   "sign-ban-list",
+		Usage:  "Signs a ban list, to share it with op-nodes that subscribe to it with --p2p.ban.list",
+		Flags:  []cli.Flag{privateKeyFlag, inFlag, outFlag},
+		Action: signBanList,
+	},
 }
@@ -0,0 +1,162 @@
+package p2p
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/urfave/cli/v2"
+
+	opp2p "github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/monitor"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+)
+
+type ReputationAPI interface {
+	ExportReputation(ctx context.Context) (*store.Reputation, error)
+	ImportReputation(ctx context.Context, rep *store.Reputation) error
+}
+
+// ExportReputation writes the peer scores and bans of the node as JSON.
+func ExportReputation(ctx context.Context, api ReputationAPI, w io.Writer) error {
+	rep, err := api.ExportReputation(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to export reputation: %w", err)
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(rep)
+}
+
+// ImportReputation reads peer scores and bans as JSON, and imports them into the peerstore of the node.
+func ImportReputation(ctx context.Context, api ReputationAPI, r io.Reader) error {
+	var rep store.Reputation
+	if err := json.NewDecoder(r).Decode(&rep); err != nil {
+		return fmt.Errorf("failed to decode reputation: %w", err)
+	}
+	if err := api.ImportReputation(ctx, &rep); err != nil {
+		return fmt.Errorf("failed to import reputation: %w", err)
+	}
+	return nil
+}
+
+// SignBanList reads a ban list as JSON, and writes it signed with the given key.
+// The list is timestamped with the given time if it has no timestamp yet.
+func SignBanList(r io.Reader, key *ecdsa.PrivateKey, now time.Time, w io.Writer) error {
+	var list monitor.BanList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return fmt.Errorf("failed to decode ban list: %w", err)
+	}
+	if list.Timestamp == 0 {
+		list.Timestamp = uint64(now.Unix())
+	}
+	signed, err := monitor.SignBanList(&list, key)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(signed)
+}
+
+var (
+	rpcFlag = &cli.StringFlag{
+		Name:     "rpc",
+		Usage:    "RPC endpoint of the op-node, with the admin API enabled",
+		Required: true,
+	}
+	inFlag = &cli.StringFlag{
+		Name:  "in",
+		Usage: "File to read from, STDIN if empty",
+	}
+	outFlag = &cli.StringFlag{
+		Name:  "out",
+		Usage: "File to write to, STDOUT if empty",
+	}
+	privateKeyFlag = &cli.StringFlag{
+		Name:     "private-key",
+		Usage:    "Hex encoded private key of the ban list signer",
+		Required: true,
+	}
+)
+
+func dialP2PClient(ctx *cli.Context) (*opp2p.Client, error) {
+	c, err := rpc.DialContext(ctx.Context, ctx.String(rpcFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial op-node RPC: %w", err)
+	}
+	return opp2p.NewClient(c), nil
+}
+
+func openInput(ctx *cli.Context) (io.ReadCloser, error) {
+	if path := ctx.String(inFlag.Name); path != "" {
+		return os.Open(path)
+	}
+	return io.NopCloser(os.Stdin), nil
+}
+
+func openOutput(ctx *cli.Context) (io.WriteCloser, error) {
+	if path := ctx.String(outFlag.Name); path != "" {
+		return os.Create(path)
+	}
+	return nopWriteCloser{os.Stdout}, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
+
+func exportReputation(ctx *cli.Context) error {
+	client, err := dialP2PClient(ctx)
+	if err != nil {
+		return err
+	}
+	out, err := openOutput(ctx)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return ExportReputation(ctx.Context, client, out)
+}
+
+func importReputation(ctx *cli.Context) error {
+	client, err := dialP2PClient(ctx)
+	if err != nil {
+		return err
+	}
+	in, err := openInput(ctx)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	return ImportReputation(ctx.Context, client, in)
+}
+
+func signBanList(ctx *cli.Context) error {
+	key, err := crypto.HexToECDSA(strings.TrimPrefix(ctx.String(privateKeyFlag.Name), "0x"))
+	if err != nil {
+		return fmt.Errorf("invalid private key: %w", err)
+	}
+	in, err := openInput(ctx)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := openOutput(ctx)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+	return SignBanList(in, key, time.Now(), out)
+}
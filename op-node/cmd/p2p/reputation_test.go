@@ -0,0 +1,66 @@
+package p2p
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	gcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/monitor"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+)
+
+type memReputationAPI struct {
+	rep *store.Reputation
+}
+
+func (m *memReputationAPI) ExportReputation(ctx context.Context) (*store.Reputation, error) {
+	return m.rep, nil
+}
+
+func (m *memReputationAPI) ImportReputation(ctx context.Context, rep *store.Reputation) error {
+	m.rep = rep
+	return nil
+}
+
+func TestExportImportReputation(t *testing.T) {
+	priv, _, err := crypto.GenerateKeyPair(crypto.Secp256k1, 32)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+	expiry := time.Unix(2484924, 0).UTC()
+	src := &memReputationAPI{rep: &store.Reputation{
+		Scores:   []store.PeerScoresEntry{{ID: id, Scores: store.PeerScores{Gossip: store.GossipScores{Total: -3}}}},
+		PeerBans: []store.PeerBan{{ID: id, Expiry: expiry}},
+		IPBans:   []store.IPBan{{IP: net.IPv4(1, 2, 3, 4), Expiry: expiry}},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, ExportReputation(context.Background(), src, &buf))
+
+	dst := &memReputationAPI{}
+	require.NoError(t, ImportReputation(context.Background(), dst, &buf))
+	require.Equal(t, src.rep, dst.rep)
+}
+
+func TestSignBanList(t *testing.T) {
+	key, err := gcrypto.GenerateKey()
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	var out bytes.Buffer
+	require.NoError(t, SignBanList(bytes.NewReader([]byte(`{"ipBans":[{"ip":"1.2.3.4","expiry":"2030-01-01T00:00:00Z"}]}`)), key, now, &out))
+
+	var signed monitor.SignedBanList
+	require.NoError(t, json.Unmarshal(out.Bytes(), &signed))
+	list, err := signed.Verify(gcrypto.PubkeyToAddress(key.PublicKey))
+	require.NoError(t, err)
+	require.Equal(t, uint64(1000), list.Timestamp, "unstamped list is stamped with the signing time")
+	require.Len(t, list.IPBans, 1)
+	require.True(t, list.IPBans[0].IP.Equal(net.IPv4(1, 2, 3, 4)))
+}
@@ -0,0 +1,50 @@
+package eth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// TxPreconfirmationSize is the size of a binary encoded TxPreconfirmation.
+const TxPreconfirmationSize = 8 + 32 + 8 + 8 + 32
+
+// TxPreconfirmation is a commitment of the sequencer to include a transaction in an L2 block,
+// published before the block itself is published.
+type TxPreconfirmation struct {
+	// BlockNumber is the number of the L2 block that includes the transaction
+	BlockNumber Uint64Quantity `json:"blockNumber"`
+	// ParentHash is the hash of the parent of the L2 block that includes the transaction
+	ParentHash common.Hash `json:"parentHash"`
+	// Timestamp is the timestamp of the L2 block that includes the transaction
+	Timestamp Uint64Quantity `json:"timestamp"`
+	// TxIndex is the index of the transaction in the L2 block
+	TxIndex Uint64Quantity `json:"txIndex"`
+	// TxHash is the hash of the included transaction
+	TxHash common.Hash `json:"txHash"`
+}
+
+// MarshalBinary encodes the preconfirmation as the fixed-size concatenation of its fields,
+// with the numbers encoded as big-endian uint64.
+func (p *TxPreconfirmation) MarshalBinary() ([]byte, error) {
+	out := make([]byte, TxPreconfirmationSize)
+	binary.BigEndian.PutUint64(out[0:8], uint64(p.BlockNumber))
+	copy(out[8:40], p.ParentHash[:])
+	binary.BigEndian.PutUint64(out[40:48], uint64(p.Timestamp))
+	binary.BigEndian.PutUint64(out[48:56], uint64(p.TxIndex))
+	copy(out[56:88], p.TxHash[:])
+	return out, nil
+}
+
+func (p *TxPreconfirmation) UnmarshalBinary(data []byte) error {
+	if len(data) != TxPreconfirmationSize {
+		return fmt.Errorf("invalid tx preconfirmation length: %d", len(data))
+	}
+	p.BlockNumber = Uint64Quantity(binary.BigEndian.Uint64(data[0:8]))
+	copy(p.ParentHash[:], data[8:40])
+	p.Timestamp = Uint64Quantity(binary.BigEndian.Uint64(data[40:48]))
+	p.TxIndex = Uint64Quantity(binary.BigEndian.Uint64(data[48:56]))
+	copy(p.TxHash[:], data[56:88])
+	return nil
+}
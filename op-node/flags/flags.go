@@ -17,6 +17,9 @@ import (
 
 const EnvVarPrefix = "OP_NODE"
 
+// defaultFinalizationPeriod is the finalization period of the L2OutputOracle on mainnet.
+const defaultFinalizationPeriod = 7 * 24 * time.Hour
+
 func prefixEnvVars(name string) []string {
 	return []string{EnvVarPrefix + "_" + name}
 }
@@ -34,6 +37,19 @@ var (
 		Usage:   "Address of L2 Engine JSON-RPC endpoints to use (engine and eth namespace required)",
 		EnvVars: prefixEnvVars("L2_ENGINE_RPC"),
 	}
+	L2StandbyEngineAddrs = &cli.StringSliceFlag{
+		Name: "l2.standby",
+		Usage: "Addresses of standby L2 Engine JSON-RPC endpoints, authenticated with the same JWT secret as the l2 engine. " +
+			"The standby engines follow the chain of the primary engine, and the first standby engine that is in sync " +
+			"is promoted to primary if the primary fails to process a payload or forkchoice update.",
+		EnvVars: prefixEnvVars("L2_STANDBY_ENGINE_RPCS"),
+	}
+	L2EngineRecordPath = &cli.StringFlag{
+		Name: "l2.engine-record",
+		Usage: "Path of a file to append all engine API calls to the l2 engine to, with their results. " +
+			"The recording can be replayed against another engine with op-wheel, to debug rejected payloads.",
+		EnvVars: prefixEnvVars("L2_ENGINE_RECORD"),
+	}
 	RollupConfig = &cli.StringFlag{
 		Name:    "rollup.config",
 		Usage:   "Rollup chain parameters",
@@ -132,6 +148,11 @@ var (
 		Required: false,
 		Value:    0,
 	}
+	SequencerTxPreconfsFlag = &cli.BoolFlag{
+		Name:    "sequencer.tx-preconfs",
+		Usage:   "Publish preconfirmations of the transactions of blocks being built on the p2p tx preconfirmations topic, as the engine includes them. Requires engine_getPayloadProgressV1 on the engine, otherwise the transactions are preconfirmed once blocks are sealed",
+		EnvVars: prefixEnvVars("SEQUENCER_TX_PRECONFS"),
+	}
 	SequencerL1Confs = &cli.Uint64Flag{
 		Name:     "sequencer.l1-confs",
 		Usage:    "Number of L1 blocks to keep distance from the L1 head as a sequencer for picking an L1 origin.",
@@ -139,6 +160,22 @@ var (
 		Required: false,
 		Value:    4,
 	}
+	SequencerConsensusRPCFlag = &cli.StringFlag{
+		Name:    "sequencer.consensus-rpc",
+		Usage:   "RPC of the sequencer consensus to join, served by a node with --sequencer.consensus-server. The sequencer only sequences while it is the leader. The consensus is not replicated: the serving node is a single point of failure.",
+		EnvVars: prefixEnvVars("SEQUENCER_CONSENSUS_RPC"),
+	}
+	SequencerConsensusServerFlag = &cli.BoolFlag{
+		Name:    "sequencer.consensus-server",
+		Usage:   "Serve the sequencer consensus in the consensus RPC namespace, from memory. If the sequencer is enabled, it joins in-process. No sequencer can sequence while this node is down.",
+		EnvVars: prefixEnvVars("SEQUENCER_CONSENSUS_SERVER"),
+	}
+	SequencerConsensusLeaseTimeoutFlag = &cli.DurationFlag{
+		Name:    "sequencer.consensus-lease-timeout",
+		Usage:   "Time after which the consensus server hands the leadership over to another sequencer, if the leader did not renew its lease.",
+		EnvVars: prefixEnvVars("SEQUENCER_CONSENSUS_LEASE_TIMEOUT"),
+		Value:   time.Second * 5,
+	}
 	L1EpochPollIntervalFlag = &cli.DurationFlag{
 		Name:     "l1.epoch-poll-interval",
 		Usage:    "Poll interval for retrieving new L1 epoch updates such as safe and finalized block changes. Disabled if 0 or negative.",
@@ -221,6 +258,49 @@ var (
 		Required: false,
 		Value:    false,
 	}
+	L2StateSyncRPC = &cli.StringFlag{
+		Name: "l2.state-sync.rpc",
+		Usage: "L2 RPC to retrieve the state sync anchor block from. If set, a fresh execution engine is synced to the " +
+			"L2 block of the latest output proposal of the finalized L1 chain, instead of executing all blocks from genesis.",
+		EnvVars:  prefixEnvVars("L2_STATE_SYNC_RPC"),
+		Required: false,
+	}
+	L2StateSyncL2OutputOracleAddr = &cli.StringFlag{
+		Name:     "l2.state-sync.l2oo-address",
+		Usage:    "Address of the L2OutputOracle contract to pick the state sync anchor block from.",
+		EnvVars:  prefixEnvVars("L2_STATE_SYNC_L2OO_ADDRESS"),
+		Required: false,
+	}
+	L2StateSyncFinalizationPeriod = &cli.DurationFlag{
+		Name: "l2.state-sync.finalization-period",
+		Usage: "Finalization period (FINALIZATION_PERIOD_SECONDS) of the L2OutputOracle contract. Only output proposals " +
+			"older than it are picked as state sync anchor, as younger proposals can still be deleted.",
+		EnvVars:  prefixEnvVars("L2_STATE_SYNC_FINALIZATION_PERIOD"),
+		Required: false,
+		Value:    defaultFinalizationPeriod,
+	}
+	LightL2RPC = &cli.StringFlag{
+		Name: "light.l2-rpc",
+		Usage: "Run in light mode, without execution engine: follow the blocks gossiped by the sequencer, and verify them " +
+			"against the finalized output proposals. The L2 RPC to retrieve blocks and output proofs from is not trusted. " +
+			"The l2 flag is not required in light mode.",
+		EnvVars:  prefixEnvVars("LIGHT_L2_RPC"),
+		Required: false,
+	}
+	LightL2OutputOracleAddr = &cli.StringFlag{
+		Name:     "light.l2oo-address",
+		Usage:    "Address of the L2OutputOracle contract to verify the L2 chain against in light mode.",
+		EnvVars:  prefixEnvVars("LIGHT_L2OO_ADDRESS"),
+		Required: false,
+	}
+	LightFinalizationPeriod = &cli.DurationFlag{
+		Name: "light.finalization-period",
+		Usage: "Finalization period (FINALIZATION_PERIOD_SECONDS) of the L2OutputOracle contract. Only output proposals " +
+			"older than it are verified against in light mode, as younger proposals can still be deleted.",
+		EnvVars:  prefixEnvVars("LIGHT_FINALIZATION_PERIOD"),
+		Required: false,
+		Value:    defaultFinalizationPeriod,
+	}
 	SkipSyncStartCheck = &cli.BoolFlag{
 		Name: "l2.skip-sync-start-check",
 		Usage: "Skip sanity check of consistency of L1 origins of the unsafe L2 blocks when determining the sync-starting point. " +
@@ -247,11 +327,17 @@ var optionalFlags = []cli.Flag{
 	L1RPCMaxBatchSize,
 	L1HTTPPollInterval,
 	L2EngineJWTSecret,
+	L2StandbyEngineAddrs,
+	L2EngineRecordPath,
 	VerifierL1Confs,
 	SequencerEnabledFlag,
 	SequencerStoppedFlag,
 	SequencerMaxSafeLagFlag,
+	SequencerTxPreconfsFlag,
 	SequencerL1Confs,
+	SequencerConsensusRPCFlag,
+	SequencerConsensusServerFlag,
+	SequencerConsensusLeaseTimeoutFlag,
 	L1EpochPollIntervalFlag,
 	RPCEnableAdmin,
 	RPCAdminPersistence,
@@ -268,6 +354,12 @@ var optionalFlags = []cli.Flag{
 	BackupL2UnsafeSyncRPC,
 	BackupL2UnsafeSyncRPCTrustRPC,
 	L2EngineSyncEnabled,
+	L2StateSyncRPC,
+	L2StateSyncL2OutputOracleAddr,
+	L2StateSyncFinalizationPeriod,
+	LightL2RPC,
+	LightL2OutputOracleAddr,
+	LightFinalizationPeriod,
 	SkipSyncStartCheck,
 }
 
@@ -282,6 +374,10 @@ func init() {
 
 func CheckRequired(ctx *cli.Context) error {
 	for _, f := range requiredFlags {
+		// the light mode does not run an execution engine
+		if f == L2EngineAddr && ctx.IsSet(LightL2RPC.Name) {
+			continue
+		}
 		if !ctx.IsSet(f.Names()[0]) {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
@@ -68,6 +68,25 @@ var (
 		Value:    1 * time.Hour,
 		EnvVars:  p2pEnv("PEER_BANNING_DURATION"),
 	}
+	BanListSource = &cli.StringFlag{
+		Name:     "p2p.ban.list",
+		Usage:    "Path or http(s) URL of a signed shared ban list, to periodically fetch and apply the peer and IP bans of. Disabled if empty.",
+		Required: false,
+		EnvVars:  p2pEnv("BAN_LIST"),
+	}
+	BanListSigner = &cli.StringFlag{
+		Name:     "p2p.ban.list.signer",
+		Usage:    "Address of the trusted signer of the shared ban list.",
+		Required: false,
+		EnvVars:  p2pEnv("BAN_LIST_SIGNER"),
+	}
+	BanListInterval = &cli.DurationFlag{
+		Name:     "p2p.ban.list.interval",
+		Usage:    "Interval to fetch the shared ban list at.",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVars:  p2pEnv("BAN_LIST_INTERVAL"),
+	}
 
 	TopicScoring = &cli.StringFlag{
 		Name:     "p2p.scoring.topics",
@@ -114,6 +133,25 @@ var (
 		Value:    0, // can simply match the TCP libp2p port
 		EnvVars:  p2pEnv("LISTEN_UDP_PORT"),
 	}
+	EnableQUIC = &cli.BoolFlag{
+		Name:     "p2p.quic",
+		Usage:    "Enable the QUIC transport for LibP2P, in addition to TCP. QUIC uses its own TLS security and multiplexing, regardless of p2p.security and p2p.mux.",
+		Required: false,
+		EnvVars:  p2pEnv("QUIC"),
+	}
+	ListenQUICPort = &cli.UintFlag{
+		Name:     "p2p.listen.quic",
+		Usage:    "UDP port to bind the LibP2P QUIC transport to, if enabled. Any available system port if set to 0. Must differ from p2p.listen.udp.",
+		Required: false,
+		Value:    0,
+		EnvVars:  p2pEnv("LISTEN_QUIC_PORT"),
+	}
+	EnableWebTransport = &cli.BoolFlag{
+		Name:     "p2p.webtransport",
+		Usage:    "Also accept WebTransport connections on the QUIC port, e.g. from browser peers. Requires p2p.quic.",
+		Required: false,
+		EnvVars:  p2pEnv("WEBTRANSPORT"),
+	}
 	AdvertiseIP = &cli.StringFlag{
 		Name:     "p2p.advertise.ip",
 		Usage:    "The IP address to advertise in Discv5, put into the ENR of the node. This may also be a hostname / domain name to resolve to an IP.",
@@ -137,6 +175,13 @@ var (
 		Value:    0,
 		EnvVars:  p2pEnv("ADVERTISE_UDP"),
 	}
+	AdvertiseQUICPort = &cli.UintFlag{
+		Name:     "p2p.advertise.quic",
+		Usage:    "The QUIC (UDP) port to advertise in Discv5, put into the ENR of the node, if QUIC is enabled. Set to p2p.listen.quic value if 0.",
+		Required: false,
+		Value:    0,
+		EnvVars:  p2pEnv("ADVERTISE_QUIC"),
+	}
 	Bootnodes = &cli.StringFlag{
 		Name:     "p2p.bootnodes",
 		Usage:    "Comma-separated base64-format ENR list. Bootnodes to start discovering other node records from.",
@@ -290,6 +335,43 @@ var (
 		Hidden:   true,
 		EnvVars:  p2pEnv("GOSSIP_FLOOD_PUBLISH"),
 	}
+	GossipPolicyMaxGasUsedFlag = &cli.Uint64Flag{
+		Name:     "p2p.gossip.policy.max-gas-used",
+		Usage:    "Ignore gossiped blocks that use more gas than this, without penalizing the peer. Disabled if 0.",
+		Required: false,
+		EnvVars:  p2pEnv("GOSSIP_POLICY_MAX_GAS_USED"),
+	}
+	GossipPolicyMaxTxsFlag = &cli.Uint64Flag{
+		Name:     "p2p.gossip.policy.max-txs",
+		Usage:    "Ignore gossiped blocks with more transactions than this, without penalizing the peer. Disabled if 0.",
+		Required: false,
+		EnvVars:  p2pEnv("GOSSIP_POLICY_MAX_TXS"),
+	}
+	GossipPolicyPeerRateLimitFlag = &cli.Float64Flag{
+		Name:     "p2p.gossip.policy.peer-rate-limit",
+		Usage:    "Max sustained number of gossiped blocks per second to validate from a single peer, blocks beyond the limit are ignored. Disabled if 0.",
+		Required: false,
+		EnvVars:  p2pEnv("GOSSIP_POLICY_PEER_RATE_LIMIT"),
+	}
+	GossipPolicyPeerRateBurstFlag = &cli.IntFlag{
+		Name:     "p2p.gossip.policy.peer-rate-burst",
+		Usage:    "Max burst of gossiped blocks to validate from a single peer, in addition to the peer rate limit.",
+		Required: false,
+		Value:    10,
+		EnvVars:  p2pEnv("GOSSIP_POLICY_PEER_RATE_BURST"),
+	}
+	GossipPolicyPeerRateLimitWeightFlag = &cli.Float64Flag{
+		Name:     "p2p.gossip.policy.peer-rate-limit.weight",
+		Usage:    "Application score weight of each gossiped block beyond the peer rate limit, should be negative. Defaults to the weight of the scoring level.",
+		Required: false,
+		EnvVars:  p2pEnv("GOSSIP_POLICY_PEER_RATE_LIMIT_WEIGHT"),
+	}
+	GossipPolicyPeerRateLimitDecayFlag = &cli.DurationFlag{
+		Name:     "p2p.gossip.policy.peer-rate-limit.decay",
+		Usage:    "Time for the application score penalty of gossiped blocks beyond the peer rate limit to decay to zero. Defaults to the decay of the scoring level.",
+		Required: false,
+		EnvVars:  p2pEnv("GOSSIP_POLICY_PEER_RATE_LIMIT_DECAY"),
+	}
 	SyncReqRespFlag = &cli.BoolFlag{
 		Name:     "p2p.sync.req-resp",
 		Usage:    "Enables P2P req-resp alternative sync method, on both server and client side.",
@@ -312,13 +394,20 @@ var p2pFlags = []cli.Flag{
 	Banning,
 	BanningThreshold,
 	BanningDuration,
+	BanListSource,
+	BanListSigner,
+	BanListInterval,
 	TopicScoring,
 	ListenIP,
 	ListenTCPPort,
 	ListenUDPPort,
+	EnableQUIC,
+	ListenQUICPort,
+	EnableWebTransport,
 	AdvertiseIP,
 	AdvertiseTCPPort,
 	AdvertiseUDPPort,
+	AdvertiseQUICPort,
 	Bootnodes,
 	StaticPeers,
 	HostMux,
@@ -339,5 +428,11 @@ var p2pFlags = []cli.Flag{
 	GossipMeshDhiFlag,
 	GossipMeshDlazyFlag,
 	GossipFloodPublishFlag,
+	GossipPolicyMaxGasUsedFlag,
+	GossipPolicyMaxTxsFlag,
+	GossipPolicyPeerRateLimitFlag,
+	GossipPolicyPeerRateBurstFlag,
+	GossipPolicyPeerRateLimitWeightFlag,
+	GossipPolicyPeerRateLimitDecayFlag,
 	SyncReqRespFlag,
 }
@@ -57,6 +57,7 @@ type Metricer interface {
 	RecordSequencerInconsistentL1Origin(from eth.BlockID, to eth.BlockID)
 	RecordSequencerReset()
 	RecordGossipEvent(evType int32)
+	RecordGossipPolicyViolation(policy string, result string)
 	IncPeerCount()
 	DecPeerCount()
 	IncStreamCount()
@@ -70,6 +71,8 @@ type Metricer interface {
 	SetPeerScores(allScores []store.PeerScores)
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 	RecordPeerUnban()
 	RecordIPUnban()
@@ -102,6 +105,7 @@ type Metrics struct {
 	P2PReqDurationSeconds *prometheus.HistogramVec
 	P2PReqTotal           *prometheus.CounterVec
 	P2PPayloadByNumber    *prometheus.GaugeVec
+	P2PPayloadsByRange    *prometheus.CounterVec
 
 	PayloadsQuarantineTotal prometheus.Gauge
 
@@ -133,15 +137,16 @@ type Metrics struct {
 	TransactionsSequencedTotal prometheus.Counter
 
 	// P2P Metrics
-	PeerCount         prometheus.Gauge
-	StreamCount       prometheus.Gauge
-	GossipEventsTotal *prometheus.CounterVec
-	BandwidthTotal    *prometheus.GaugeVec
-	PeerUnbans        prometheus.Counter
-	IPUnbans          prometheus.Counter
-	Dials             *prometheus.CounterVec
-	Accepts           *prometheus.CounterVec
-	PeerScores        *prometheus.HistogramVec
+	PeerCount                   prometheus.Gauge
+	StreamCount                 prometheus.Gauge
+	GossipEventsTotal           *prometheus.CounterVec
+	GossipPolicyViolationsTotal *prometheus.CounterVec
+	BandwidthTotal              *prometheus.GaugeVec
+	PeerUnbans                  prometheus.Counter
+	IPUnbans                    prometheus.Counter
+	Dials                       *prometheus.CounterVec
+	Accepts                     *prometheus.CounterVec
+	PeerScores                  *prometheus.HistogramVec
 
 	ChannelInputBytes prometheus.Counter
 
@@ -330,6 +335,15 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"type",
 		}),
+		GossipPolicyViolationsTotal: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "gossip_policy_violations_total",
+			Help:      "Count of gossip messages that violated a gossip policy, by policy and validation result",
+		}, []string{
+			"policy",
+			"result",
+		}),
 		BandwidthTotal: factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -400,6 +414,14 @@ func NewMetrics(procName string) *Metrics {
 		}, []string{
 			"p2p_role", // "client" or "server"
 		}),
+		P2PPayloadsByRange: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Subsystem: "p2p",
+			Name:      "payloads_by_range_blocks_total",
+			Help:      "Number of payloads transferred in payloads by range responses",
+		}, []string{
+			"p2p_role", // "client" or "server"
+		}),
 		PayloadsQuarantineTotal: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Subsystem: "p2p",
@@ -604,6 +626,10 @@ func (m *Metrics) RecordGossipEvent(evType int32) {
 	m.GossipEventsTotal.WithLabelValues(pb.TraceEvent_Type_name[evType]).Inc()
 }
 
+func (m *Metrics) RecordGossipPolicyViolation(policy string, result string) {
+	m.GossipPolicyViolationsTotal.WithLabelValues(policy, result).Inc()
+}
+
 func (m *Metrics) IncPeerCount() {
 	m.PeerCount.Inc()
 }
@@ -692,6 +718,23 @@ func (m *Metrics) ServerPayloadByNumberEvent(num uint64, resultCode byte, durati
 	m.P2PPayloadByNumber.WithLabelValues("server").Set(float64(num))
 }
 
+func (m *Metrics) ClientPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration) {
+	if resultCode > 4 { // summarize all high codes to reduce metrics overhead
+		resultCode = 5
+	}
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("client", "payloads_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("client", "payloads_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadsByRange.WithLabelValues("client").Add(float64(count))
+}
+
+func (m *Metrics) ServerPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration) {
+	code := strconv.FormatUint(uint64(resultCode), 10)
+	m.P2PReqTotal.WithLabelValues("server", "payloads_by_range", code).Inc()
+	m.P2PReqDurationSeconds.WithLabelValues("server", "payloads_by_range", code).Observe(float64(duration) / float64(time.Second))
+	m.P2PPayloadsByRange.WithLabelValues("server").Add(float64(count))
+}
+
 func (m *Metrics) PayloadsQuarantineSize(n int) {
 	m.PayloadsQuarantineTotal.Set(float64(n))
 }
@@ -790,6 +833,9 @@ func (n *noopMetricer) RecordSequencerReset() {
 func (n *noopMetricer) RecordGossipEvent(evType int32) {
 }
 
+func (n *noopMetricer) RecordGossipPolicyViolation(policy string, result string) {
+}
+
 func (n *noopMetricer) SetPeerScores(allScores []store.PeerScores) {
 }
 
@@ -824,6 +870,12 @@ func (n *noopMetricer) ClientPayloadByNumberEvent(num uint64, resultCode byte, d
 func (n *noopMetricer) ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration) {
 }
 
+func (n *noopMetricer) ClientPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration) {
+}
+
+func (n *noopMetricer) ServerPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration) {
+}
+
 func (n *noopMetricer) PayloadsQuarantineSize(int) {
 }
 
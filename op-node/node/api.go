@@ -6,7 +6,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -28,6 +30,7 @@ type driverClient interface {
 	StartSequencer(ctx context.Context, blockHash common.Hash) error
 	StopSequencer(context.Context) (common.Hash, error)
 	SequencerActive(context.Context) (bool, error)
+	TransferLeadership(context.Context) error
 }
 
 type rpcMetrics interface {
@@ -71,6 +74,103 @@ func (n *adminAPI) SequencerActive(ctx context.Context) (bool, error) {
 	return n.dr.SequencerActive(ctx)
 }
 
+func (n *adminAPI) TransferLeadership(ctx context.Context) error {
+	recordDur := n.m.RecordRPCServerRequest("admin_transferLeadership")
+	defer recordDur()
+	return n.dr.TransferLeadership(ctx)
+}
+
+type txPreconfsFeed interface {
+	SubscribeTxPreconfirmations(ch chan<- *eth.TxPreconfirmation) event.Subscription
+}
+
+type txPreconfsAPI struct {
+	feed txPreconfsFeed
+	m    rpcMetrics
+}
+
+func NewTxPreconfsAPI(feed txPreconfsFeed, m rpcMetrics) *txPreconfsAPI {
+	return &txPreconfsAPI{
+		feed: feed,
+		m:    m,
+	}
+}
+
+// TxPreconfirmations streams the tx preconfirmations that the sequencer publishes through p2p gossip.
+// It is used through optimism_subscribe("txPreconfirmations").
+func (api *txPreconfsAPI) TxPreconfirmations(ctx context.Context) (*rpc.Subscription, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_subscribeTxPreconfirmations")
+	defer recordDur()
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		preconfs := make(chan *eth.TxPreconfirmation, 128)
+		sub := api.feed.SubscribeTxPreconfirmations(preconfs)
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case preconf := <-preconfs:
+				_ = notifier.Notify(rpcSub.ID, preconf)
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+
+type lightClient interface {
+	VerifiedHeader(label eth.BlockLabel) (eth.L2BlockRef, error)
+	VerifiedHeaderByNumber(num uint64) (eth.L2BlockRef, error)
+}
+
+// lightAPI is the API of a node in light mode, which does not run an execution engine.
+type lightAPI struct {
+	config *rollup.Config
+	lc     lightClient
+	m      rpcMetrics
+}
+
+func NewLightAPI(config *rollup.Config, lc lightClient, m rpcMetrics) *lightAPI {
+	return &lightAPI{
+		config: config,
+		lc:     lc,
+		m:      m,
+	}
+}
+
+// VerifiedHeader returns the latest verified L2 block: the block of the latest finalized output proposal with
+// the "finalized" label, or the latest block signed by the sequencer that builds on it with the "unsafe" label.
+func (api *lightAPI) VerifiedHeader(_ context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_verifiedHeader")
+	defer recordDur()
+	return api.lc.VerifiedHeader(label)
+}
+
+// VerifiedHeaderByNumber returns the L2 block with the given number, if it is within the verified range of blocks.
+func (api *lightAPI) VerifiedHeaderByNumber(_ context.Context, number hexutil.Uint64) (eth.L2BlockRef, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_verifiedHeaderByNumber")
+	defer recordDur()
+	return api.lc.VerifiedHeaderByNumber(uint64(number))
+}
+
+func (api *lightAPI) RollupConfig(_ context.Context) (*rollup.Config, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_rollupConfig")
+	defer recordDur()
+	return api.config, nil
+}
+
+func (api *lightAPI) Version(ctx context.Context) (string, error) {
+	recordDur := api.m.RecordRPCServerRequest("optimism_version")
+	defer recordDur()
+	return version.Version + "-" + version.Meta, nil
+}
+
 type nodeAPI struct {
 	config *rollup.Config
 	client l2EthClient
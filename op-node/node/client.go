@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
@@ -18,6 +19,9 @@ import (
 type L2EndpointSetup interface {
 	// Setup a RPC client to a L2 execution engine to process rollup blocks with.
 	Setup(ctx context.Context, log log.Logger, rollupCfg *rollup.Config) (cl client.RPC, rpcCfg *sources.EngineClientConfig, err error)
+	// SetupStandbys sets up RPC clients to standby L2 execution engines, to fail over to if the primary engine fails.
+	// The standby engines use the same client config as the primary. There may be none.
+	SetupStandbys(ctx context.Context, log log.Logger) ([]client.RPC, error)
 	Check() error
 }
 
@@ -42,6 +46,12 @@ type L2EndpointConfig struct {
 	// JWT secrets for L2 Engine API authentication during HTTP or initial Websocket communication.
 	// Any value for an IPC connection.
 	L2EngineJWTSecret [32]byte
+
+	// Addresses of standby L2 Engine JSON-RPC endpoints, authenticated with the same JWT secret. Optional.
+	L2StandbyEngineAddrs []string
+
+	// Path of a file to record the engine API calls to the primary engine to, for debugging with op-wheel. Optional.
+	L2EngineRecordPath string
 }
 
 var _ L2EndpointSetup = (*L2EndpointConfig)(nil)
@@ -58,22 +68,50 @@ func (cfg *L2EndpointConfig) Setup(ctx context.Context, log log.Logger, rollupCf
 	if err := cfg.Check(); err != nil {
 		return nil, nil, err
 	}
-	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2EngineJWTSecret))
-	opts := []client.RPCOption{
-		client.WithGethRPCOptions(auth),
-		client.WithDialBackoff(10),
-	}
-	l2Node, err := client.NewRPC(ctx, log, cfg.L2EngineAddr, opts...)
+	l2Node, err := client.NewRPC(ctx, log, cfg.L2EngineAddr, cfg.rpcOptions()...)
 	if err != nil {
 		return nil, nil, err
 	}
+	if cfg.L2EngineRecordPath != "" {
+		f, err := os.OpenFile(cfg.L2EngineRecordPath, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+		if err != nil {
+			l2Node.Close()
+			return nil, nil, fmt.Errorf("failed to open engine API record file: %w", err)
+		}
+		log.Info("Recording engine API calls", "path", cfg.L2EngineRecordPath)
+		l2Node = sources.NewRecordingRPC(l2Node, f)
+	}
 
 	return l2Node, sources.EngineClientDefaultConfig(rollupCfg), nil
 }
 
+func (cfg *L2EndpointConfig) SetupStandbys(ctx context.Context, log log.Logger) ([]client.RPC, error) {
+	standbys := make([]client.RPC, 0, len(cfg.L2StandbyEngineAddrs))
+	for _, addr := range cfg.L2StandbyEngineAddrs {
+		l2Node, err := client.NewRPC(ctx, log, addr, cfg.rpcOptions()...)
+		if err != nil {
+			for _, standby := range standbys {
+				standby.Close()
+			}
+			return nil, fmt.Errorf("failed to dial standby engine %s: %w", addr, err)
+		}
+		standbys = append(standbys, l2Node)
+	}
+	return standbys, nil
+}
+
+func (cfg *L2EndpointConfig) rpcOptions() []client.RPCOption {
+	auth := rpc.WithHTTPAuth(gn.NewJWTAuth(cfg.L2EngineJWTSecret))
+	return []client.RPCOption{
+		client.WithGethRPCOptions(auth),
+		client.WithDialBackoff(10),
+	}
+}
+
 // PreparedL2Endpoints enables testing with in-process pre-setup RPC connections to L2 engines
 type PreparedL2Endpoints struct {
-	Client client.RPC
+	Client   client.RPC
+	Standbys []client.RPC
 }
 
 func (p *PreparedL2Endpoints) Check() error {
@@ -89,6 +127,10 @@ func (p *PreparedL2Endpoints) Setup(ctx context.Context, log log.Logger, rollupC
 	return p.Client, sources.EngineClientDefaultConfig(rollupCfg), nil
 }
 
+func (p *PreparedL2Endpoints) SetupStandbys(ctx context.Context, log log.Logger) ([]client.RPC, error) {
+	return p.Standbys, nil
+}
+
 // L2SyncEndpointConfig contains configuration for the fallback sync endpoint
 type L2SyncEndpointConfig struct {
 	// Address of the L2 RPC to use for backup sync, may be empty if RPC alt-sync is disabled.
@@ -46,6 +46,47 @@ type Config struct {
 	Heartbeat HeartbeatConfig
 
 	Sync sync.Config
+
+	// StateSync optionally bootstraps a fresh execution engine from a trusted L2 block.
+	StateSync StateSyncConfig
+
+	// SequencerConsensus optionally runs the sequencer with a consensus backend:
+	// the sequencer only sequences while it is the leader of the consensus.
+	// It takes precedence over SequencerConsensusRPC.
+	SequencerConsensus driver.SequencerConsensus
+
+	// SequencerConsensusRPC optionally runs the sequencer with a consensus shared with sequencers in other processes,
+	// and optionally serves such a consensus.
+	SequencerConsensusRPC SequencerConsensusRPCConfig
+
+	// Light optionally runs the node in light mode, without an execution engine.
+	Light LightConfig
+}
+
+// SequencerConsensusRPCConfig configures a set of sequencers in different processes that share a consensus.
+// One node serves the consensus of the set from memory, which the sequencers join over RPC.
+// The consensus is not replicated: the set fails over between sequencers, but the serving node
+// is a single point of failure, and the consensus log is lost when it restarts.
+type SequencerConsensusRPCConfig struct {
+	// ConsensusRPC is the RPC of the consensus to join. The sequencer does not join a consensus over RPC if this is empty.
+	ConsensusRPC string
+	// ConsensusServer serves the consensus of a set. The sequencer of the serving node, if enabled, joins the set in-process.
+	ConsensusServer bool
+	// LeaseTimeout is the time after which the served consensus hands the leadership over,
+	// if the leader did not renew its lease.
+	LeaseTimeout time.Duration
+}
+
+func (cfg *SequencerConsensusRPCConfig) Check() error {
+	if cfg.ConsensusServer {
+		if cfg.ConsensusRPC != "" {
+			return errors.New("a node serving the consensus joins it in-process, it cannot also join a consensus over RPC")
+		}
+		if cfg.LeaseTimeout <= 0 {
+			return errors.New("lease timeout must be positive")
+		}
+	}
+	return nil
 }
 
 type RPCConfig struct {
@@ -102,15 +143,34 @@ func (cfg *Config) LoadPersisted(log log.Logger) error {
 
 // Check verifies that the given configuration makes sense
 func (cfg *Config) Check() error {
-	if err := cfg.L2.Check(); err != nil {
+	if err := cfg.Light.Check(); err != nil {
+		return fmt.Errorf("light mode config error: %w", err)
+	}
+	if cfg.Light.Enabled() {
+		if cfg.Driver.SequencerEnabled {
+			return errors.New("light mode cannot run the sequencer")
+		}
+		if cfg.StateSync.Enabled() {
+			return errors.New("light mode does not run an execution engine to state sync")
+		}
+	} else if err := cfg.L2.Check(); err != nil {
 		return fmt.Errorf("l2 endpoint config error: %w", err)
 	}
 	if err := cfg.L2Sync.Check(); err != nil {
 		return fmt.Errorf("sync config error: %w", err)
 	}
+	if err := cfg.StateSync.Check(); err != nil {
+		return fmt.Errorf("state sync config error: %w", err)
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
+	if (cfg.SequencerConsensus != nil || cfg.SequencerConsensusRPC.ConsensusRPC != "") && !cfg.Driver.SequencerEnabled {
+		return errors.New("sequencer consensus requires the sequencer to be enabled")
+	}
+	if err := cfg.SequencerConsensusRPC.Check(); err != nil {
+		return fmt.Errorf("sequencer consensus RPC config error: %w", err)
+	}
 	if err := cfg.Metrics.Check(); err != nil {
 		return fmt.Errorf("metrics config error: %w", err)
 	}
@@ -0,0 +1,305 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+// lightMaxBackfill is the max number of blocks to retrieve from the L2 source,
+// to link a gossiped unsafe block to the verified chain.
+const lightMaxBackfill = 10_000
+
+var ErrNoVerifiedHeader = errors.New("no verified header available yet")
+
+// LightConfig configures the light mode of the node: instead of running an execution engine,
+// the node follows the blocks gossiped by the sequencer, and verifies them against output roots proposed to L1.
+type LightConfig struct {
+	// L2NodeAddr is the L2 RPC to retrieve blocks and output root proofs from.
+	// The RPC is not trusted, all data is verified against the L2OutputOracle.
+	// Light mode is disabled if this is empty.
+	L2NodeAddr string
+	// L2OutputOracleAddr is the L2OutputOracle L1 contract to verify the L2 chain against.
+	L2OutputOracleAddr common.Address
+	// FinalizationPeriod is the FINALIZATION_PERIOD_SECONDS of the L2OutputOracle.
+	// Only proposals older than it are verified against, as younger proposals can still be deleted.
+	FinalizationPeriod time.Duration
+}
+
+func (cfg *LightConfig) Enabled() bool {
+	return cfg.L2NodeAddr != ""
+}
+
+func (cfg *LightConfig) Check() error {
+	if cfg.Enabled() && cfg.L2OutputOracleAddr == (common.Address{}) {
+		return errors.New("light mode requires the L2OutputOracle address")
+	}
+	return nil
+}
+
+type LightL2Source interface {
+	StateSyncL2Source
+	L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error)
+}
+
+// LightClient tracks the L2 chain without executing it.
+//
+// The L2 block of the latest finalized output proposal as of the finalized L1 chain is verified:
+// the block is retrieved with a proof of the L2ToL1MessagePasser storage root, and must match the proposed output root.
+// Unsafe blocks, received from the sequencer gossip with a valid signature, are then linked to the verified block
+// through their parent hashes. Missing blocks in between are retrieved from the L2 source, and are verified by their hash.
+type LightClient struct {
+	log    log.Logger
+	cfg    *rollup.Config
+	oracle common.Address
+	// finalizationPeriod is the finalization period of the oracle, proposals younger than it are not trusted
+	finalizationPeriod time.Duration
+
+	l1 StateSyncL1Source
+	l2 LightL2Source
+
+	l1Finalized chan eth.L1BlockRef
+	unsafe      chan *eth.ExecutionPayload
+
+	mu sync.RWMutex
+	// chain holds the verified block, followed by the unsafe blocks that build on it. Empty until a block is verified.
+	chain []eth.L2BlockRef
+	// latestSigned is the latest unsafe block received from gossip, it may not be linked to the chain yet.
+	latestSigned eth.L2BlockRef
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func NewLightClient(log log.Logger, cfg *rollup.Config, oracle common.Address, finalizationPeriod time.Duration, l1 StateSyncL1Source, l2 LightL2Source) *LightClient {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &LightClient{
+		log:                log,
+		cfg:                cfg,
+		oracle:             oracle,
+		finalizationPeriod: finalizationPeriod,
+		l1:                 l1,
+		l2:                 l2,
+		l1Finalized:        make(chan eth.L1BlockRef, 1),
+		unsafe:             make(chan *eth.ExecutionPayload, 10),
+		ctx:                ctx,
+		cancel:             cancel,
+	}
+}
+
+func (lc *LightClient) Start() {
+	lc.wg.Add(1)
+	go lc.eventLoop()
+}
+
+func (lc *LightClient) Close() {
+	lc.cancel()
+	lc.wg.Wait()
+}
+
+// OnL1Finalized signals the light client to verify the latest output proposal as of the new finalized L1 block.
+func (lc *LightClient) OnL1Finalized(ctx context.Context, ref eth.L1BlockRef) error {
+	select {
+	case <-lc.l1Finalized: // replace any pending signal, only the latest finalized block matters
+	default:
+	}
+	select {
+	case lc.l1Finalized <- ref:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// OnUnsafeL2Payload queues a block received from the sequencer gossip. The gossip signature must be verified already.
+func (lc *LightClient) OnUnsafeL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	select {
+	case lc.unsafe <- payload:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (lc *LightClient) eventLoop() {
+	defer lc.wg.Done()
+	// The finalized L1 block changes infrequently, verify the chain as of the current one, instead of waiting for a change.
+	if ref, err := lc.l1.L1BlockRefByLabel(lc.ctx, eth.Finalized); err != nil {
+		lc.log.Warn("Failed to fetch finalized L1 block", "err", err)
+	} else if err := lc.verifyProposal(lc.ctx, ref); err != nil {
+		lc.log.Warn("Failed to verify latest output proposal", "l1_finalized", ref, "err", err)
+	}
+	for {
+		select {
+		case ref := <-lc.l1Finalized:
+			if err := lc.verifyProposal(lc.ctx, ref); err != nil {
+				lc.log.Warn("Failed to verify latest output proposal", "l1_finalized", ref, "err", err)
+			}
+		case payload := <-lc.unsafe:
+			ref, err := derive.PayloadToBlockRef(payload, &lc.cfg.Genesis)
+			if err != nil {
+				lc.log.Warn("Failed to decode unsafe block", "id", payload.ID(), "err", err)
+				continue
+			}
+			if err := lc.onUnsafe(lc.ctx, ref); err != nil {
+				lc.log.Warn("Failed to link unsafe block to verified chain", "block", ref, "err", err)
+			}
+		case <-lc.ctx.Done():
+			return
+		}
+	}
+}
+
+// verifyProposal verifies the L2 block of the latest finalized output proposal as of the given finalized L1 block,
+// and moves the verified block forward to it.
+func (lc *LightClient) verifyProposal(ctx context.Context, l1Finalized eth.L1BlockRef) error {
+	proposal, err := LatestFinalizedOutputProposal(ctx, lc.l1, lc.oracle, l1Finalized, lc.finalizationPeriod)
+	if err != nil {
+		return fmt.Errorf("failed to read latest finalized output proposal: %w", err)
+	}
+	if verified, ok := lc.verified(); ok && verified.Number == proposal.L2BlockNumber {
+		return nil
+	}
+	payload, err := VerifyProposedBlock(ctx, lc.l2, proposal)
+	if err != nil {
+		return err
+	}
+	ref, err := derive.PayloadToBlockRef(payload, &lc.cfg.Genesis)
+	if err != nil {
+		return fmt.Errorf("failed to decode verified block ref: %w", err)
+	}
+
+	lc.mu.Lock()
+	// keep the unsafe blocks that build on the newly verified block
+	if i, ok := lc.indexOf(ref); ok {
+		lc.chain = lc.chain[i:]
+	} else {
+		lc.chain = []eth.L2BlockRef{ref}
+	}
+	latestSigned := lc.latestSigned
+	lc.mu.Unlock()
+	lc.log.Info("Verified L2 block against output proposal", "block", ref, "l1_finalized", l1Finalized)
+
+	if latestSigned.Number > ref.Number {
+		return lc.onUnsafe(ctx, latestSigned)
+	}
+	return nil
+}
+
+// onUnsafe links the unsafe block to the verified chain, retrieving any missing blocks in between from the L2 source.
+func (lc *LightClient) onUnsafe(ctx context.Context, ref eth.L2BlockRef) error {
+	lc.mu.Lock()
+	lc.latestSigned = ref
+	chain := lc.chain
+	lc.mu.Unlock()
+	if len(chain) == 0 {
+		return nil // nothing to link to yet
+	}
+	verified := chain[0]
+	if ref.Number <= verified.Number {
+		return nil // already verified, or conflicting with the verified block, in which case it is irrelevant
+	}
+	// walk back from the new block until it connects to the chain, which may reorg the unsafe part of the chain
+	ext := []eth.L2BlockRef{ref}
+	cur := ref
+	for {
+		i := cur.Number - 1 - verified.Number
+		if i < uint64(len(chain)) && chain[i].Hash == cur.ParentHash {
+			chain = append(chain[:i+1:i+1], reverseRefs(ext)...)
+			break
+		}
+		if cur.Number-1 == verified.Number {
+			return fmt.Errorf("block %s does not build on verified block %s", ref, verified)
+		}
+		if len(ext) >= lightMaxBackfill {
+			return fmt.Errorf("block %s is more than %d blocks ahead of the verified chain", ref, lightMaxBackfill)
+		}
+		parent, err := lc.l2.L2BlockRefByHash(ctx, cur.ParentHash)
+		if err != nil {
+			return fmt.Errorf("failed to retrieve parent block %s: %w", cur.ParentHash, err)
+		}
+		if parent.Hash != cur.ParentHash || parent.Number+1 != cur.Number {
+			return fmt.Errorf("retrieved block %s is not the parent of %s", parent, cur)
+		}
+		ext = append(ext, parent)
+		cur = parent
+	}
+
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+	if len(lc.chain) == 0 || lc.chain[0] != verified {
+		return nil // the verified block changed while linking, the latest signed block is linked again by verifyProposal
+	}
+	lc.chain = chain
+	return nil
+}
+
+// indexOf returns the index of the block in the chain, if it is part of it. The caller must hold the lock.
+func (lc *LightClient) indexOf(ref eth.L2BlockRef) (uint64, bool) {
+	if len(lc.chain) == 0 || ref.Number < lc.chain[0].Number {
+		return 0, false
+	}
+	i := ref.Number - lc.chain[0].Number
+	if i >= uint64(len(lc.chain)) || lc.chain[i].Hash != ref.Hash {
+		return 0, false
+	}
+	return i, true
+}
+
+func (lc *LightClient) verified() (eth.L2BlockRef, bool) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	if len(lc.chain) == 0 {
+		return eth.L2BlockRef{}, false
+	}
+	return lc.chain[0], true
+}
+
+// VerifiedHeader returns the latest verified block of the given label:
+// eth.Finalized for the block verified against the output proposal, eth.Unsafe for the latest signed block linked to it.
+func (lc *LightClient) VerifiedHeader(label eth.BlockLabel) (eth.L2BlockRef, error) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	if len(lc.chain) == 0 {
+		return eth.L2BlockRef{}, ErrNoVerifiedHeader
+	}
+	switch label {
+	case eth.Finalized:
+		return lc.chain[0], nil
+	case eth.Unsafe:
+		return lc.chain[len(lc.chain)-1], nil
+	default:
+		return eth.L2BlockRef{}, fmt.Errorf("unsupported block label: %s", label)
+	}
+}
+
+// VerifiedHeaderByNumber returns the block with the given number from the verified chain.
+func (lc *LightClient) VerifiedHeaderByNumber(num uint64) (eth.L2BlockRef, error) {
+	lc.mu.RLock()
+	defer lc.mu.RUnlock()
+	if len(lc.chain) == 0 {
+		return eth.L2BlockRef{}, ErrNoVerifiedHeader
+	}
+	if num < lc.chain[0].Number || num-lc.chain[0].Number >= uint64(len(lc.chain)) {
+		return eth.L2BlockRef{}, fmt.Errorf("block %d is not in the verified range %d - %d", num, lc.chain[0].Number, lc.chain[len(lc.chain)-1].Number)
+	}
+	return lc.chain[num-lc.chain[0].Number], nil
+}
+
+func reverseRefs(refs []eth.L2BlockRef) []eth.L2BlockRef {
+	out := make([]eth.L2BlockRef, len(refs))
+	for i, ref := range refs {
+		out[len(refs)-1-i] = ref
+	}
+	return out
+}
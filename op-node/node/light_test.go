@@ -0,0 +1,148 @@
+package node
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+func (s *stateSyncTest) lightClient(t *testing.T) *LightClient {
+	return NewLightClient(testlog.Logger(t, log.LvlDebug), s.cfg, s.oracle, s.finalizationPeriod, s.l1, s.source)
+}
+
+// expectVerified sets up the output proposal of the test payload, and returns the verified block ref.
+func (s *stateSyncTest) expectVerified(t *testing.T) eth.L2BlockRef {
+	s.expectProposal(eth.OutputRoot(s.output), uint64(s.payload.BlockNumber), 5000)
+	s.source.ExpectPayloadByNumber(uint64(s.payload.BlockNumber), s.payload, nil)
+	s.source.ExpectOutputV0AtBlock(s.payload.BlockHash, s.output, nil)
+	ref, err := derive.PayloadToBlockRef(s.payload, &s.cfg.Genesis)
+	require.NoError(t, err)
+	return ref
+}
+
+func childRef(parent eth.L2BlockRef, hash common.Hash) eth.L2BlockRef {
+	return eth.L2BlockRef{
+		Hash:       hash,
+		Number:     parent.Number + 1,
+		ParentHash: parent.Hash,
+		Time:       parent.Time + 2,
+		L1Origin:   parent.L1Origin,
+	}
+}
+
+func TestLightClientVerifiesProposal(t *testing.T) {
+	s := newStateSyncTest(t)
+	lc := s.lightClient(t)
+	_, err := lc.VerifiedHeader(eth.Finalized)
+	require.ErrorIs(t, err, ErrNoVerifiedHeader)
+
+	verified := s.expectVerified(t)
+	require.NoError(t, lc.verifyProposal(context.Background(), s.l1Ref))
+
+	header, err := lc.VerifiedHeader(eth.Finalized)
+	require.NoError(t, err)
+	require.Equal(t, verified, header)
+	header, err = lc.VerifiedHeader(eth.Unsafe)
+	require.NoError(t, err)
+	require.Equal(t, verified, header, "no unsafe blocks yet")
+	s.l1.AssertExpectations(t)
+	s.source.AssertExpectations(t)
+}
+
+func TestLightClientRejectsUnprovenBlock(t *testing.T) {
+	s := newStateSyncTest(t)
+	s.expectProposal(eth.Bytes32{0xff}, uint64(s.payload.BlockNumber), 5000)
+	s.source.ExpectPayloadByNumber(uint64(s.payload.BlockNumber), s.payload, nil)
+	s.source.ExpectOutputV0AtBlock(s.payload.BlockHash, s.output, nil)
+
+	lc := s.lightClient(t)
+	require.ErrorContains(t, lc.verifyProposal(context.Background(), s.l1Ref), "does not match proposed output root")
+	_, err := lc.VerifiedHeader(eth.Finalized)
+	require.ErrorIs(t, err, ErrNoVerifiedHeader)
+}
+
+func TestLightClientSkipsChallengeableProposal(t *testing.T) {
+	s := newStateSyncTest(t)
+	s.expectProposals(
+		OutputProposal{OutputRoot: eth.OutputRoot(s.output), Timestamp: 5000, L2BlockNumber: uint64(s.payload.BlockNumber)},
+		// Proposed within the finalization period as of the finalized L1 block, so it can still be deleted
+		OutputProposal{OutputRoot: eth.Bytes32{0xff}, Timestamp: 9950, L2BlockNumber: uint64(s.payload.BlockNumber) + 100},
+	)
+	s.source.ExpectPayloadByNumber(uint64(s.payload.BlockNumber), s.payload, nil)
+	s.source.ExpectOutputV0AtBlock(s.payload.BlockHash, s.output, nil)
+
+	lc := s.lightClient(t)
+	require.NoError(t, lc.verifyProposal(context.Background(), s.l1Ref))
+	header, err := lc.VerifiedHeader(eth.Finalized)
+	require.NoError(t, err)
+	require.Equal(t, uint64(s.payload.BlockNumber), header.Number, "should verify the finalized proposal")
+	s.source.AssertExpectations(t)
+}
+
+func TestLightClientLinksUnsafeBlocks(t *testing.T) {
+	s := newStateSyncTest(t)
+	lc := s.lightClient(t)
+	verified := s.expectVerified(t)
+	require.NoError(t, lc.verifyProposal(context.Background(), s.l1Ref))
+
+	a := childRef(verified, common.Hash{0xa})
+	b := childRef(a, common.Hash{0xb})
+	c := childRef(b, common.Hash{0xc})
+
+	// a was not received through gossip, it is retrieved to link b to the verified block
+	s.source.ExpectL2BlockRefByHash(a.Hash, a, nil)
+	require.NoError(t, lc.onUnsafe(context.Background(), b))
+	require.NoError(t, lc.onUnsafe(context.Background(), c))
+
+	header, err := lc.VerifiedHeader(eth.Unsafe)
+	require.NoError(t, err)
+	require.Equal(t, c, header)
+	header, err = lc.VerifiedHeaderByNumber(a.Number)
+	require.NoError(t, err)
+	require.Equal(t, a, header)
+	_, err = lc.VerifiedHeaderByNumber(c.Number + 1)
+	require.ErrorContains(t, err, "not in the verified range")
+
+	// a reorg of the unsafe chain replaces the blocks after the common ancestor
+	b2 := childRef(a, common.Hash{0xb, 2})
+	require.NoError(t, lc.onUnsafe(context.Background(), b2))
+	header, err = lc.VerifiedHeader(eth.Unsafe)
+	require.NoError(t, err)
+	require.Equal(t, b2, header)
+	s.source.AssertExpectations(t)
+}
+
+func TestLightClientRejectsConflictingBlock(t *testing.T) {
+	s := newStateSyncTest(t)
+	lc := s.lightClient(t)
+	verified := s.expectVerified(t)
+	require.NoError(t, lc.verifyProposal(context.Background(), s.l1Ref))
+
+	conflicting := childRef(eth.L2BlockRef{Hash: common.Hash{0xde, 0xad}, Number: verified.Number}, common.Hash{0xa})
+	require.ErrorContains(t, lc.onUnsafe(context.Background(), conflicting), "does not build on verified block")
+	header, err := lc.VerifiedHeader(eth.Unsafe)
+	require.NoError(t, err)
+	require.Equal(t, verified, header)
+}
+
+func TestLightClientLinksUnsafeBlockAfterVerification(t *testing.T) {
+	s := newStateSyncTest(t)
+	lc := s.lightClient(t)
+	verified := s.expectVerified(t)
+
+	// the unsafe block is received before any block is verified, and is linked once the verified block is known
+	a := childRef(verified, common.Hash{0xa})
+	require.NoError(t, lc.onUnsafe(context.Background(), a))
+	require.NoError(t, lc.verifyProposal(context.Background(), s.l1Ref))
+
+	header, err := lc.VerifiedHeader(eth.Unsafe)
+	require.NoError(t, err)
+	require.Equal(t, a, header)
+}
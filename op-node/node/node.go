@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/hashicorp/go-multierror"
@@ -30,15 +32,28 @@ type OpNode struct {
 	l1SafeSub      ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 	l1FinalizedSub ethereum.Subscription // Subscription to get L1 safe blocks, a.k.a. justified data (polling)
 
-	l1Source  *sources.L1Client     // L1 Client to fetch data from
-	l2Driver  *driver.Driver        // L2 Engine to Sync
-	l2Source  *sources.EngineClient // L2 Execution Engine RPC bindings
-	rpcSync   *sources.SyncClient   // Alt-sync RPC client, optional (may be nil)
-	server    *rpcServer            // RPC server hosting the rollup-node API
-	p2pNode   *p2p.NodeP2P          // P2P node functionality
-	p2pSigner p2p.Signer            // p2p gogssip application messages will be signed with this signer
-	tracer    Tracer                // tracer to get events for testing/debugging
-	runCfg    *RuntimeConfig        // runtime configurables
+	l1Source  *sources.L1Client             // L1 Client to fetch data from
+	l2Driver  *driver.Driver                // L2 Engine to Sync
+	l2Source  *sources.FailoverEngineClient // L2 Execution Engine RPC bindings, with optional standby engines
+	rpcSync   *sources.SyncClient           // Alt-sync RPC client, optional (may be nil)
+	server    *rpcServer                    // RPC server hosting the rollup-node API
+	p2pNode   *p2p.NodeP2P                  // P2P node functionality
+	p2pSigner p2p.Signer                    // p2p gogssip application messages will be signed with this signer
+	tracer    Tracer                        // tracer to get events for testing/debugging
+	runCfg    *RuntimeConfig                // runtime configurables
+
+	txPreconfs event.Feed // feed of tx preconfirmations received from p2p gossip
+
+	stateSync       *StateSync        // bootstraps the engine state before the driver starts, optional (may be nil)
+	stateSyncSource *sources.L2Client // L2 RPC to retrieve the state sync anchor block from, optional (may be nil)
+	stateSyncWg     sync.WaitGroup
+	driverStarted   atomic.Bool
+
+	consensusAPI    *driver.ConsensusAPI // serves the consensus of a sequencer set, optional (may be nil)
+	consensusClient *driver.RPCConsensus // consensus of the sequencer set joined over RPC, optional (may be nil)
+
+	light       *LightClient      // follows the L2 chain without execution engine in light mode, optional (may be nil)
+	lightSource *sources.L2Client // L2 RPC to retrieve blocks and output proofs from in light mode, optional (may be nil)
 
 	// some resources cannot be stopped directly, like the p2p gossipsub router (not our design),
 	// and depend on this ctx to be closed.
@@ -84,11 +99,17 @@ func (n *OpNode) init(ctx context.Context, cfg *Config, snapshotLog log.Logger)
 	if err := n.initRuntimeConfig(ctx, cfg); err != nil {
 		return err
 	}
-	if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
-		return err
-	}
-	if err := n.initRPCSync(ctx, cfg); err != nil {
-		return err
+	if cfg.Light.Enabled() {
+		if err := n.initLight(ctx, cfg); err != nil {
+			return err
+		}
+	} else {
+		if err := n.initL2(ctx, cfg, snapshotLog); err != nil {
+			return err
+		}
+		if err := n.initRPCSync(ctx, cfg); err != nil {
+			return err
+		}
 	}
 	if err := n.initP2PSigner(ctx, cfg); err != nil {
 		return err
@@ -188,22 +209,87 @@ func (n *OpNode) initL2(ctx context.Context, cfg *Config, snapshotLog log.Logger
 		return fmt.Errorf("failed to setup L2 execution-engine RPC client: %w", err)
 	}
 
-	n.l2Source, err = sources.NewEngineClient(
+	primary, err := sources.NewEngineClient(
 		client.NewInstrumentedRPC(rpcClient, n.metrics), n.log, n.metrics.L2SourceCache, rpcCfg,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to create Engine client: %w", err)
 	}
+	standbyClients, err := cfg.L2.SetupStandbys(ctx, n.log)
+	if err != nil {
+		return fmt.Errorf("failed to setup standby L2 execution-engine RPC clients: %w", err)
+	}
+	standbys := make([]*sources.EngineClient, len(standbyClients))
+	for i, standbyClient := range standbyClients {
+		// standby engines are not instrumented, to not mix their RPC metrics with those of the primary
+		standbys[i], err = sources.NewEngineClient(standbyClient, n.log.New("standby", i+1), nil, rpcCfg)
+		if err != nil {
+			return fmt.Errorf("failed to create standby Engine client: %w", err)
+		}
+	}
+	n.l2Source = sources.NewFailoverEngineClient(n.log.New("role", "engine_failover"), primary, standbys...)
+	if len(standbys) > 0 {
+		n.log.Info("Configured standby execution engines", "count", len(standbys))
+	}
 
 	if err := cfg.Rollup.ValidateL2Config(ctx, n.l2Source); err != nil {
 		return err
 	}
+	for i, standby := range standbys {
+		if err := cfg.Rollup.ValidateL2Config(ctx, standby); err != nil {
+			return fmt.Errorf("invalid standby engine %d: %w", i+1, err)
+		}
+	}
+
+	consensus := cfg.SequencerConsensus
+	if cfg.SequencerConsensusRPC.ConsensusServer {
+		n.consensusAPI = driver.NewConsensusAPI(n.log.New("role", "consensus"), driver.NewLocalConsensus(), cfg.SequencerConsensusRPC.LeaseTimeout)
+		if consensus == nil && cfg.Driver.SequencerEnabled {
+			consensus = n.consensusAPI.JoinLocal()
+		}
+	} else if consensus == nil && cfg.SequencerConsensusRPC.ConsensusRPC != "" {
+		consensusRPC, err := client.NewRPC(ctx, n.log, cfg.SequencerConsensusRPC.ConsensusRPC)
+		if err != nil {
+			return fmt.Errorf("failed to setup sequencer consensus RPC client: %w", err)
+		}
+		n.consensusClient, err = driver.NewRPCConsensus(ctx, n.log.New("role", "consensus"), consensusRPC)
+		if err != nil {
+			consensusRPC.Close()
+			return err
+		}
+		consensus = n.consensusClient
+	}
+
+	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, consensus, &cfg.Sync)
 
-	n.l2Driver = driver.NewDriver(&cfg.Driver, &cfg.Rollup, n.l2Source, n.l1Source, n, n, n.log, snapshotLog, n.metrics, cfg.ConfigPersistence, &cfg.Sync)
+	if cfg.StateSync.Enabled() {
+		stateSyncRPC, err := client.NewRPC(ctx, n.log, cfg.StateSync.L2NodeAddr)
+		if err != nil {
+			return fmt.Errorf("failed to setup state sync L2 RPC client: %w", err)
+		}
+		n.stateSyncSource, err = sources.NewL2Client(stateSyncRPC, n.log, nil, sources.L2ClientDefaultConfig(&cfg.Rollup, false))
+		if err != nil {
+			return fmt.Errorf("failed to create state sync L2 source: %w", err)
+		}
+		n.stateSync = NewStateSync(n.log.New("role", "state_sync"), &cfg.Rollup, cfg.StateSync.L2OutputOracleAddr, cfg.StateSync.FinalizationPeriod, n.l1Source, n.stateSyncSource, n.l2Source)
+	}
 
 	return nil
 }
 
+func (n *OpNode) initLight(ctx context.Context, cfg *Config) error {
+	lightRPC, err := client.NewRPC(ctx, n.log, cfg.Light.L2NodeAddr)
+	if err != nil {
+		return fmt.Errorf("failed to setup light mode L2 RPC client: %w", err)
+	}
+	n.lightSource, err = sources.NewL2Client(client.NewInstrumentedRPC(lightRPC, n.metrics), n.log, n.metrics.L2SourceCache, sources.L2ClientDefaultConfig(&cfg.Rollup, false))
+	if err != nil {
+		return fmt.Errorf("failed to create light mode L2 source: %w", err)
+	}
+	n.light = NewLightClient(n.log.New("role", "light"), &cfg.Rollup, cfg.Light.L2OutputOracleAddr, cfg.Light.FinalizationPeriod, n.l1Source, n.lightSource)
+	return nil
+}
+
 func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 	rpcSyncClient, rpcCfg, err := cfg.L2Sync.Setup(ctx, n.log, &cfg.Rollup)
 	if err != nil {
@@ -221,15 +307,26 @@ func (n *OpNode) initRPCSync(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
-	server, err := newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source.L2Client, n.l2Driver, n.log, n.appVersion, n.metrics)
-	if err != nil {
-		return err
+	var server *rpcServer
+	if n.light != nil {
+		server = newLightRPCServer(&cfg.RPC, &cfg.Rollup, n.light, n.log, n.appVersion, n.metrics)
+	} else {
+		var err error
+		server, err = newRPCServer(ctx, &cfg.RPC, &cfg.Rollup, n.l2Source, n.driverClient(), n.log, n.appVersion, n.metrics)
+		if err != nil {
+			return err
+		}
 	}
 	if n.p2pNode != nil {
 		server.EnableP2P(p2p.NewP2PAPIBackend(n.p2pNode, n.log, n.metrics))
+		server.EnableTxPreconfsAPI(NewTxPreconfsAPI(n, n.metrics))
+	}
+	if n.consensusAPI != nil {
+		server.EnableConsensusAPI(n.consensusAPI)
+		n.log.Info("Sequencer consensus RPC enabled, this node is a single point of failure of the sequencers that join it")
 	}
-	if cfg.RPC.EnableAdmin {
-		server.EnableAdminAPI(NewAdminAPI(n.l2Driver, n.metrics))
+	if cfg.RPC.EnableAdmin && n.l2Driver != nil {
+		server.EnableAdminAPI(NewAdminAPI(n.driverClient(), n.metrics))
 		n.log.Info("Admin RPC enabled")
 	}
 	n.log.Info("Starting JSON-RPC server")
@@ -240,6 +337,15 @@ func (n *OpNode) initRPCServer(ctx context.Context, cfg *Config) error {
 	return nil
 }
 
+// driverClient returns the driver to serve RPCs with. If the driver starts after state sync,
+// the RPCs are refused until the driver is started.
+func (n *OpNode) driverClient() driverClient {
+	if n.stateSync == nil {
+		return n.l2Driver
+	}
+	return &startedDriver{dr: n.l2Driver, started: &n.driverStarted}
+}
+
 func (n *OpNode) initMetricsServer(ctx context.Context, cfg *Config) error {
 	if !cfg.Metrics.Enabled {
 		n.log.Info("metrics disabled")
@@ -256,7 +362,12 @@ func (n *OpNode) initMetricsServer(ctx context.Context, cfg *Config) error {
 
 func (n *OpNode) initP2P(ctx context.Context, cfg *Config) error {
 	if cfg.P2P != nil {
-		p2pNode, err := p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, n.l2Source, n.runCfg, n.metrics)
+		// Without execution engine there is no L2 chain to serve to peers.
+		var l2Chain p2p.L2Chain
+		if n.l2Source != nil {
+			l2Chain = n.l2Source
+		}
+		p2pNode, err := p2p.NewNodeP2P(n.resourcesCtx, &cfg.Rollup, n.log, cfg.P2P, n, l2Chain, n.runCfg, n.metrics)
 		if err != nil || p2pNode == nil {
 			return err
 		}
@@ -280,11 +391,16 @@ func (n *OpNode) initP2PSigner(ctx context.Context, cfg *Config) error {
 }
 
 func (n *OpNode) Start(ctx context.Context) error {
-	n.log.Info("Starting execution engine driver")
-
-	// start driving engine: sync blocks by deriving them from L1 and driving them into the engine
-	if err := n.l2Driver.Start(); err != nil {
-		n.log.Error("Could not start a rollup node", "err", err)
+	if n.light != nil {
+		n.log.Info("Starting light client")
+		n.light.Start()
+		return nil
+	}
+	if n.stateSync != nil {
+		// State sync may take a long time, the driver starts once the engine has synced to the anchor block.
+		n.stateSyncWg.Add(1)
+		go n.runStateSync()
+	} else if err := n.startDriver(); err != nil {
 		return err
 	}
 
@@ -300,10 +416,37 @@ func (n *OpNode) Start(ctx context.Context) error {
 	return nil
 }
 
+func (n *OpNode) startDriver() error {
+	n.log.Info("Starting execution engine driver")
+
+	// start driving engine: sync blocks by deriving them from L1 and driving them into the engine
+	if err := n.l2Driver.Start(); err != nil {
+		n.log.Error("Could not start a rollup node", "err", err)
+		return err
+	}
+	n.driverStarted.Store(true)
+	return nil
+}
+
+func (n *OpNode) runStateSync() {
+	defer n.stateSyncWg.Done()
+	anchor, synced, err := n.stateSync.Run(n.resourcesCtx)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			n.log.Error("State sync failed, not starting the driver", "err", err)
+		}
+		return
+	}
+	if synced {
+		n.log.Info("Completed state sync, starting derivation from anchor block", "anchor", anchor)
+	}
+	_ = n.startDriver()
+}
+
 func (n *OpNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 	n.tracer.OnNewL1Head(ctx, sig)
 
-	if n.l2Driver == nil {
+	if n.l2Driver == nil || !n.driverStarted.Load() {
 		return
 	}
 	// Pass on the event to the L2 Engine
@@ -315,7 +458,7 @@ func (n *OpNode) OnNewL1Head(ctx context.Context, sig eth.L1BlockRef) {
 }
 
 func (n *OpNode) OnNewL1Safe(ctx context.Context, sig eth.L1BlockRef) {
-	if n.l2Driver == nil {
+	if n.l2Driver == nil || !n.driverStarted.Load() {
 		return
 	}
 	// Pass on the event to the L2 Engine
@@ -327,7 +470,13 @@ func (n *OpNode) OnNewL1Safe(ctx context.Context, sig eth.L1BlockRef) {
 }
 
 func (n *OpNode) OnNewL1Finalized(ctx context.Context, sig eth.L1BlockRef) {
-	if n.l2Driver == nil {
+	if n.light != nil {
+		if err := n.light.OnL1Finalized(ctx, sig); err != nil {
+			n.log.Warn("failed to notify light client of L1 finalized block change", "err", err)
+		}
+		return
+	}
+	if n.l2Driver == nil || !n.driverStarted.Load() {
 		return
 	}
 	// Pass on the event to the L2 Engine
@@ -353,6 +502,27 @@ func (n *OpNode) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPay
 	return nil
 }
 
+func (n *OpNode) PublishTxPreconfirmation(ctx context.Context, preconf *eth.TxPreconfirmation) error {
+	if n.p2pNode == nil {
+		return nil
+	}
+	if n.p2pSigner == nil {
+		return fmt.Errorf("node has no p2p signer, preconfirmation of tx %s cannot be published", preconf.TxHash)
+	}
+	n.log.Debug("Publishing tx preconfirmation on p2p", "tx", preconf.TxHash, "block", uint64(preconf.BlockNumber), "index", uint64(preconf.TxIndex))
+	return n.p2pNode.GossipOut().PublishTxPreconfirmation(ctx, preconf, n.p2pSigner)
+}
+
+func (n *OpNode) OnTxPreconfirmation(ctx context.Context, from peer.ID, preconf *eth.TxPreconfirmation) error {
+	n.txPreconfs.Send(preconf)
+	return nil
+}
+
+// SubscribeTxPreconfirmations subscribes to the tx preconfirmations received from p2p gossip.
+func (n *OpNode) SubscribeTxPreconfirmations(ch chan<- *eth.TxPreconfirmation) event.Subscription {
+	return n.txPreconfs.Subscribe(ch)
+}
+
 func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
 	// ignore if it's from ourselves
 	if n.p2pNode != nil && from == n.p2pNode.Host().ID() {
@@ -363,9 +533,21 @@ func (n *OpNode) OnUnsafeL2Payload(ctx context.Context, from peer.ID, payload *e
 
 	n.log.Info("Received signed execution payload from p2p", "id", payload.ID(), "peer", from)
 
-	// Pass on the event to the L2 Engine
 	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
 	defer cancel()
+	if n.light != nil {
+		if err := n.light.OnUnsafeL2Payload(ctx, payload); err != nil {
+			n.log.Warn("failed to notify light client of new L2 payload", "err", err, "id", payload.ID())
+		}
+		return nil
+	}
+
+	if !n.driverStarted.Load() {
+		n.log.Debug("Ignoring L2 payload, driver has not started yet", "id", payload.ID())
+		return nil
+	}
+
+	// Pass on the event to the L2 Engine
 	if err := n.l2Driver.OnUnsafeL2Payload(ctx, payload); err != nil {
 		n.log.Warn("failed to notify engine driver of new L2 payload", "err", err, "id", payload.ID())
 	}
@@ -424,10 +606,30 @@ func (n *OpNode) Close() error {
 		n.l1HeadsSub.Unsubscribe()
 	}
 
+	if n.light != nil {
+		n.light.Close()
+	}
+	if n.lightSource != nil {
+		n.lightSource.Close()
+	}
+
+	// wait for state sync to stop, it may still start the driver
+	n.stateSyncWg.Wait()
+	if n.stateSyncSource != nil {
+		n.stateSyncSource.Close()
+	}
+
 	// close L2 driver
 	if n.l2Driver != nil {
-		if err := n.l2Driver.Close(); err != nil {
-			result = multierror.Append(result, fmt.Errorf("failed to close L2 engine driver cleanly: %w", err))
+		if n.driverStarted.Load() {
+			if err := n.l2Driver.Close(); err != nil {
+				result = multierror.Append(result, fmt.Errorf("failed to close L2 engine driver cleanly: %w", err))
+			}
+		}
+
+		// stop following the leadership of the sequencer consensus
+		if n.consensusClient != nil {
+			n.consensusClient.Close()
 		}
 
 		// If the L2 sync client is present & running, close it.
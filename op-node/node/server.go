@@ -6,6 +6,7 @@ import (
 	"net"
 	"net/http"
 	"strconv"
+	"strings"
 
 	ophttp "github.com/ethereum-optimism/optimism/op-node/http"
 	"github.com/ethereum/go-ethereum/log"
@@ -15,6 +16,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/driver"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 )
 
@@ -30,19 +32,34 @@ type rpcServer struct {
 
 func newRPCServer(ctx context.Context, rpcCfg *RPCConfig, rollupCfg *rollup.Config, l2Client l2EthClient, dr driverClient, log log.Logger, appVersion string, m metrics.Metricer) (*rpcServer, error) {
 	api := NewNodeAPI(rollupCfg, l2Client, dr, log.New("rpc", "node"), m)
+	r := newBaseRPCServer(rpcCfg, log, appVersion)
+	r.apis = append(r.apis, rpc.API{
+		Namespace:     "optimism",
+		Service:       api,
+		Authenticated: false,
+	})
+	return r, nil
+}
+
+// newLightRPCServer creates the RPC server of a node in light mode, which serves the light API instead of the node API.
+func newLightRPCServer(rpcCfg *RPCConfig, rollupCfg *rollup.Config, lc lightClient, log log.Logger, appVersion string, m metrics.Metricer) *rpcServer {
+	r := newBaseRPCServer(rpcCfg, log, appVersion)
+	r.apis = append(r.apis, rpc.API{
+		Namespace:     "optimism",
+		Service:       NewLightAPI(rollupCfg, lc, m),
+		Authenticated: false,
+	})
+	return r
+}
+
+func newBaseRPCServer(rpcCfg *RPCConfig, log log.Logger, appVersion string) *rpcServer {
 	// TODO: extend RPC config with options for WS, IPC and HTTP RPC connections
 	endpoint := net.JoinHostPort(rpcCfg.ListenAddr, strconv.Itoa(rpcCfg.ListenPort))
-	r := &rpcServer{
-		endpoint: endpoint,
-		apis: []rpc.API{{
-			Namespace:     "optimism",
-			Service:       api,
-			Authenticated: false,
-		}},
+	return &rpcServer{
+		endpoint:   endpoint,
 		appVersion: appVersion,
 		log:        log,
 	}
-	return r, nil
 }
 
 func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
@@ -54,6 +71,22 @@ func (s *rpcServer) EnableAdminAPI(api *adminAPI) {
 	})
 }
 
+func (s *rpcServer) EnableConsensusAPI(api *driver.ConsensusAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "consensus",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
+func (s *rpcServer) EnableTxPreconfsAPI(api *txPreconfsAPI) {
+	s.apis = append(s.apis, rpc.API{
+		Namespace:     "optimism",
+		Service:       api,
+		Authenticated: false,
+	})
+}
+
 func (s *rpcServer) EnableP2P(backend *p2p.APIBackend) {
 	s.apis = append(s.apis, rpc.API{
 		Namespace:     p2p.NamespaceRPC,
@@ -74,9 +107,17 @@ func (s *rpcServer) Start() error {
 	// defaults to localhost, which will prevent containers from
 	// calling into the opnode without an "invalid host" error.
 	nodeHandler := node.NewHTTPHandlerStack(srv, []string{"*"}, []string{"*"}, nil)
+	// Websocket connections are served on the same endpoint, for subscriptions.
+	wsHandler := node.NewWSHandlerStack(srv.WebsocketHandler([]string{"*"}), nil)
 
 	mux := http.NewServeMux()
-	mux.Handle("/", nodeHandler)
+	mux.Handle("/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isWebsocket(r) {
+			wsHandler.ServeHTTP(w, r)
+		} else {
+			nodeHandler.ServeHTTP(w, r)
+		}
+	}))
 	mux.HandleFunc("/healthz", healthzHandler(s.appVersion))
 
 	listener, err := net.Listen("tcp", s.endpoint)
@@ -102,6 +143,11 @@ func (r *rpcServer) Addr() net.Addr {
 	return r.listenAddr
 }
 
+func isWebsocket(r *http.Request) bool {
+	return strings.EqualFold(r.Header.Get("Upgrade"), "websocket") &&
+		strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade")
+}
+
 func healthzHandler(appVersion string) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(appVersion))
@@ -5,8 +5,11 @@ import (
 	"encoding/json"
 	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -192,6 +195,57 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+type testTxPreconfsFeed struct {
+	event.Feed
+}
+
+func (f *testTxPreconfsFeed) SubscribeTxPreconfirmations(ch chan<- *eth.TxPreconfirmation) event.Subscription {
+	return f.Subscribe(ch)
+}
+
+func TestTxPreconfirmationsSubscription(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, &rollup.Config{}, &testutils.MockL2Client{}, &mockDriverClient{}, log, "0.0", metrics.NoopMetrics)
+	require.NoError(t, err)
+	feed := &testTxPreconfsFeed{}
+	server.EnableTxPreconfsAPI(NewTxPreconfsAPI(feed, metrics.NoopMetrics))
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := gethrpc.DialContext(context.Background(), "ws://"+server.Addr().String())
+	require.NoError(t, err)
+	defer client.Close()
+
+	preconfs := make(chan *eth.TxPreconfirmation, 1)
+	sub, err := client.Subscribe(context.Background(), "optimism", preconfs, "txPreconfirmations")
+	require.NoError(t, err)
+	defer sub.Unsubscribe()
+
+	rng := rand.New(rand.NewSource(1234))
+	preconf := &eth.TxPreconfirmation{
+		BlockNumber: 42,
+		ParentHash:  testutils.RandomHash(rng),
+		Timestamp:   1000,
+		TxIndex:     3,
+		TxHash:      testutils.RandomHash(rng),
+	}
+	// the server-side subscription is set up asynchronously, keep sending until it is received
+	require.Eventually(t, func() bool {
+		feed.Send(preconf)
+		select {
+		case got := <-preconfs:
+			require.Equal(t, preconf, got)
+			return true
+		case <-time.After(10 * time.Millisecond):
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -224,3 +278,7 @@ func (c *mockDriverClient) StopSequencer(ctx context.Context) (common.Hash, erro
 func (c *mockDriverClient) SequencerActive(ctx context.Context) (bool, error) {
 	return c.Mock.MethodCalled("SequencerActive").Get(0).(bool), nil
 }
+
+func (c *mockDriverClient) TransferLeadership(ctx context.Context) error {
+	return c.Mock.MethodCalled("TransferLeadership").Get(0).(error)
+}
@@ -0,0 +1,348 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+)
+
+var (
+	// L2OutputOracleOutputsStorageSlot is the storage slot of the `l2Outputs` array in the L2OutputOracle L1 contract.
+	// The slot holds the length of the array, the elements are stored from keccak256(slot) onwards, two slots each.
+	L2OutputOracleOutputsStorageSlot = common.BigToHash(big.NewInt(3))
+)
+
+// stateSyncPollInterval is the interval to retry finding the anchor block, and to poll the engine sync status.
+const stateSyncPollInterval = time.Second * 12
+
+// StateSyncConfig configures bootstrapping a fresh execution engine by syncing its state to a trusted L2 block,
+// instead of executing every L2 block from genesis.
+type StateSyncConfig struct {
+	// L2NodeAddr is the L2 RPC to retrieve the anchor block and its output root proof from.
+	// The RPC is not trusted, the anchor block is verified against the L2OutputOracle.
+	// State sync is disabled if this is empty.
+	L2NodeAddr string
+	// L2OutputOracleAddr is the L2OutputOracle L1 contract to pick the anchor block from.
+	L2OutputOracleAddr common.Address
+	// FinalizationPeriod is the FINALIZATION_PERIOD_SECONDS of the L2OutputOracle.
+	// Only proposals older than it can be picked, as younger proposals can still be deleted.
+	FinalizationPeriod time.Duration
+}
+
+func (cfg *StateSyncConfig) Enabled() bool {
+	return cfg.L2NodeAddr != ""
+}
+
+func (cfg *StateSyncConfig) Check() error {
+	if cfg.Enabled() && cfg.L2OutputOracleAddr == (common.Address{}) {
+		return errors.New("state sync requires the L2OutputOracle address")
+	}
+	return nil
+}
+
+type StateSyncL1Source interface {
+	L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error)
+	ReadStorageAt(ctx context.Context, address common.Address, storageSlot common.Hash, blockHash common.Hash) (common.Hash, error)
+}
+
+type StateSyncL2Source interface {
+	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error)
+	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
+}
+
+type StateSyncEngine interface {
+	L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error)
+	NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error)
+	ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error)
+}
+
+// OutputProposal is an output root proposed to the L2OutputOracle.
+type OutputProposal struct {
+	OutputRoot    eth.Bytes32
+	Timestamp     uint64
+	L2BlockNumber uint64
+}
+
+// LatestFinalizedOutputProposal reads the latest finalized output proposal from the storage of the L2OutputOracle,
+// as of the given L1 block: the latest proposal that is older than the finalization period of the L2OutputOracle
+// at the time of the L1 block. Younger proposals can still be deleted by the challenger, and are not trusted.
+// The proposals are ordered by timestamp, so the proposal is found with a binary search.
+func LatestFinalizedOutputProposal(ctx context.Context, l1 StateSyncL1Source, oracle common.Address, l1Block eth.L1BlockRef, finalizationPeriod time.Duration) (*OutputProposal, error) {
+	length, err := l1.ReadStorageAt(ctx, oracle, L2OutputOracleOutputsStorageSlot, l1Block.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read number of output proposals: %w", err)
+	}
+	if length.Big().Sign() == 0 {
+		return nil, errors.New("no outputs have been proposed yet")
+	}
+	count := length.Big().Uint64()
+	period := uint64(finalizationPeriod / time.Second)
+	finalized := func(proposal *OutputProposal) bool {
+		return proposal.Timestamp+period <= l1Block.Time
+	}
+	// find the first proposal that is not finalized yet, in [lo, hi)
+	var latest *OutputProposal
+	lo, hi := uint64(0), count
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		proposal, err := readOutputProposalBlock(ctx, l1, oracle, l1Block.Hash, mid)
+		if err != nil {
+			return nil, err
+		}
+		if finalized(proposal) {
+			latest = proposal
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	if latest == nil {
+		return nil, fmt.Errorf("none of the %d output proposals is finalized yet", count)
+	}
+	outputRoot, err := l1.ReadStorageAt(ctx, oracle, common.BigToHash(outputProposalSlot(lo-1)), l1Block.Hash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output root: %w", err)
+	}
+	latest.OutputRoot = eth.Bytes32(outputRoot)
+	return latest, nil
+}
+
+// outputProposalSlot returns the first storage slot of the output proposal at index of the l2Outputs array.
+// OutputProposal{bytes32 outputRoot; uint128 timestamp; uint128 l2BlockNumber} takes two slots.
+func outputProposalSlot(index uint64) *big.Int {
+	slot := new(big.Int).SetBytes(crypto.Keccak256(L2OutputOracleOutputsStorageSlot[:]))
+	return slot.Add(slot, new(big.Int).Lsh(new(big.Int).SetUint64(index), 1))
+}
+
+// readOutputProposalBlock reads the timestamp and L2 block number of the output proposal at index, without its output root.
+func readOutputProposalBlock(ctx context.Context, l1 StateSyncL1Source, oracle common.Address, l1BlockHash common.Hash, index uint64) (*OutputProposal, error) {
+	slot := outputProposalSlot(index)
+	packed, err := l1.ReadStorageAt(ctx, oracle, common.BigToHash(slot.Add(slot, common.Big1)), l1BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read output block number of proposal %d: %w", index, err)
+	}
+	// the timestamp is packed into the lower 16 bytes, the L2 block number into the upper 16 bytes
+	return &OutputProposal{
+		Timestamp:     new(big.Int).SetBytes(packed[16:]).Uint64(),
+		L2BlockNumber: new(big.Int).SetBytes(packed[:16]).Uint64(),
+	}, nil
+}
+
+// StateSync bootstraps a fresh execution engine: it picks the L2 block of the latest finalized output proposal
+// as of the finalized L1 chain as anchor, and directs the engine to sync its state to that block through the engine API.
+// Once the anchor is the finalized head of the engine, the derivation process starts from there,
+// as sync.FindL2Heads does not traverse past the finalized head.
+type StateSync struct {
+	log    log.Logger
+	cfg    *rollup.Config
+	oracle common.Address
+	// finalizationPeriod is the finalization period of the oracle, proposals younger than it are not trusted
+	finalizationPeriod time.Duration
+
+	l1     StateSyncL1Source
+	source StateSyncL2Source
+	engine StateSyncEngine
+
+	pollInterval time.Duration
+}
+
+func NewStateSync(log log.Logger, cfg *rollup.Config, oracle common.Address, finalizationPeriod time.Duration, l1 StateSyncL1Source, source StateSyncL2Source, engine StateSyncEngine) *StateSync {
+	return &StateSync{
+		log:                log,
+		cfg:                cfg,
+		oracle:             oracle,
+		finalizationPeriod: finalizationPeriod,
+		l1:                 l1,
+		source:             source,
+		engine:             engine,
+		pollInterval:       stateSyncPollInterval,
+	}
+}
+
+// Run syncs the engine to the anchor block, and blocks until the engine has the state of the anchor block.
+// If the engine already has blocks beyond genesis, nothing is synced and false is returned.
+func (s *StateSync) Run(ctx context.Context) (anchor eth.L2BlockRef, synced bool, err error) {
+	head, err := s.engine.L2BlockRefByLabel(ctx, eth.Unsafe)
+	if err != nil {
+		return eth.L2BlockRef{}, false, fmt.Errorf("failed to fetch engine head: %w", err)
+	}
+	if head.Number > s.cfg.Genesis.L2.Number {
+		s.log.Info("Execution engine has blocks beyond genesis, skipping state sync", "head", head)
+		return head, false, nil
+	}
+
+	var payload *eth.ExecutionPayload
+	for {
+		payload, err = s.findAnchor(ctx)
+		if err == nil {
+			break
+		}
+		s.log.Warn("Failed to find state sync anchor block, retrying", "err", err)
+		if err := s.wait(ctx); err != nil {
+			return eth.L2BlockRef{}, false, err
+		}
+	}
+	anchor, err = derive.PayloadToBlockRef(payload, &s.cfg.Genesis)
+	if err != nil {
+		return eth.L2BlockRef{}, false, fmt.Errorf("failed to decode anchor block ref: %w", err)
+	}
+	s.log.Info("Found state sync anchor block", "anchor", anchor)
+
+	status, err := s.engine.NewPayload(ctx, payload)
+	if err != nil {
+		return anchor, false, fmt.Errorf("failed to insert anchor block: %w", err)
+	}
+	if status.Status == eth.ExecutionInvalid || status.Status == eth.ExecutionInvalidBlockHash {
+		return anchor, false, fmt.Errorf("engine rejected anchor block %s: %s", anchor, eth.NewPayloadErr(payload, status))
+	}
+
+	fc := &eth.ForkchoiceState{
+		HeadBlockHash:      anchor.Hash,
+		SafeBlockHash:      anchor.Hash,
+		FinalizedBlockHash: anchor.Hash,
+	}
+	for {
+		res, err := s.engine.ForkchoiceUpdate(ctx, fc, nil)
+		if err != nil {
+			s.log.Warn("Failed to update forkchoice to state sync anchor, retrying", "anchor", anchor, "err", err)
+		} else {
+			switch res.PayloadStatus.Status {
+			case eth.ExecutionValid:
+				s.log.Info("Execution engine synced to anchor block", "anchor", anchor)
+				return anchor, true, nil
+			case eth.ExecutionSyncing:
+				s.log.Info("Execution engine is syncing to anchor block", "anchor", anchor)
+			default:
+				return anchor, false, fmt.Errorf("engine failed to sync to anchor block %s: %s", anchor, eth.ForkchoiceUpdateErr(res.PayloadStatus))
+			}
+		}
+		if err := s.wait(ctx); err != nil {
+			return anchor, false, err
+		}
+	}
+}
+
+// findAnchor retrieves the L2 block of the latest finalized output proposal as of the finalized L1 chain,
+// and verifies it against the proposed output root.
+func (s *StateSync) findAnchor(ctx context.Context) (*eth.ExecutionPayload, error) {
+	l1Finalized, err := s.l1.L1BlockRefByLabel(ctx, eth.Finalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch finalized L1 block: %w", err)
+	}
+	proposal, err := LatestFinalizedOutputProposal(ctx, s.l1, s.oracle, l1Finalized, s.finalizationPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read latest finalized output proposal as of L1 block %s: %w", l1Finalized, err)
+	}
+	return VerifyProposedBlock(ctx, s.source, proposal)
+}
+
+// VerifyProposedBlock retrieves the L2 block of the output proposal from an untrusted source,
+// and verifies it against the proposed output root.
+func VerifyProposedBlock(ctx context.Context, source StateSyncL2Source, proposal *OutputProposal) (*eth.ExecutionPayload, error) {
+	payload, err := source.PayloadByNumber(ctx, proposal.L2BlockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L2 block %d: %w", proposal.L2BlockNumber, err)
+	}
+	if actual, ok := payload.CheckBlockHash(); !ok {
+		return nil, fmt.Errorf("L2 block %d has bad block hash %s, expected %s", proposal.L2BlockNumber, payload.BlockHash, actual)
+	}
+	output, err := source.OutputV0AtBlock(ctx, payload.BlockHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch output of L2 block %s: %w", payload.ID(), err)
+	}
+	if output.BlockHash != payload.BlockHash || output.StateRoot != payload.StateRoot {
+		return nil, fmt.Errorf("output of L2 block %s does not match the block", payload.ID())
+	}
+	if root := eth.OutputRoot(output); root != proposal.OutputRoot {
+		return nil, fmt.Errorf("output root %s of L2 block %s does not match proposed output root %s", root, payload.ID(), proposal.OutputRoot)
+	}
+	return payload, nil
+}
+
+func (s *StateSync) wait(ctx context.Context) error {
+	select {
+	case <-time.After(s.pollInterval):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ErrDriverNotStarted is returned by the driver RPCs while the engine is state syncing, before the driver starts.
+var ErrDriverNotStarted = errors.New("driver has not started yet, engine is state syncing")
+
+// startedDriver gates the driver RPCs until the driver is started.
+// The driver only serves requests once its event loop runs, so the requests would block until they time out.
+type startedDriver struct {
+	dr      driverClient
+	started *atomic.Bool
+}
+
+var _ driverClient = (*startedDriver)(nil)
+
+func (d *startedDriver) check() error {
+	if !d.started.Load() {
+		return ErrDriverNotStarted
+	}
+	return nil
+}
+
+func (d *startedDriver) SyncStatus(ctx context.Context) (*eth.SyncStatus, error) {
+	if err := d.check(); err != nil {
+		return nil, err
+	}
+	return d.dr.SyncStatus(ctx)
+}
+
+func (d *startedDriver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2BlockRef, *eth.SyncStatus, error) {
+	if err := d.check(); err != nil {
+		return eth.L2BlockRef{}, nil, err
+	}
+	return d.dr.BlockRefWithStatus(ctx, num)
+}
+
+func (d *startedDriver) ResetDerivationPipeline(ctx context.Context) error {
+	if err := d.check(); err != nil {
+		return err
+	}
+	return d.dr.ResetDerivationPipeline(ctx)
+}
+
+func (d *startedDriver) StartSequencer(ctx context.Context, blockHash common.Hash) error {
+	if err := d.check(); err != nil {
+		return err
+	}
+	return d.dr.StartSequencer(ctx, blockHash)
+}
+
+func (d *startedDriver) StopSequencer(ctx context.Context) (common.Hash, error) {
+	if err := d.check(); err != nil {
+		return common.Hash{}, err
+	}
+	return d.dr.StopSequencer(ctx)
+}
+
+func (d *startedDriver) SequencerActive(ctx context.Context) (bool, error) {
+	if err := d.check(); err != nil {
+		return false, err
+	}
+	return d.dr.SequencerActive(ctx)
+}
+
+func (d *startedDriver) TransferLeadership(ctx context.Context) error {
+	if err := d.check(); err != nil {
+		return err
+	}
+	return d.dr.TransferLeadership(ctx)
+}
@@ -0,0 +1,230 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+type stateSyncTest struct {
+	cfg    *rollup.Config
+	oracle common.Address
+	l1Ref  eth.L1BlockRef
+	// finalizationPeriod of the oracle, proposals after l1Ref.Time - finalizationPeriod are not finalized
+	finalizationPeriod time.Duration
+	payload            *eth.ExecutionPayload
+	output             *eth.OutputV0
+
+	l1     *testutils.MockL1Source
+	source *testutils.MockL2Client
+	engine *testutils.MockEngine
+}
+
+func newStateSyncTest(t *testing.T) *stateSyncTest {
+	rng := rand.New(rand.NewSource(1234))
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1: testutils.RandomBlockID(rng),
+			L2: testutils.RandomBlockID(rng),
+		},
+	}
+	l1Info, err := derive.L1InfoDepositBytes(0, testutils.RandomBlockInfo(rng), eth.SystemConfig{}, false)
+	require.NoError(t, err)
+	payload := &eth.ExecutionPayload{
+		ParentHash:   testutils.RandomHash(rng),
+		StateRoot:    eth.Bytes32(testutils.RandomHash(rng)),
+		BlockNumber:  100,
+		Timestamp:    2000,
+		Transactions: []eth.Data{l1Info},
+	}
+	payload.BlockHash, _ = payload.CheckBlockHash()
+	l1Ref := testutils.RandomBlockRef(rng)
+	l1Ref.Time = 10_000
+	return &stateSyncTest{
+		cfg:                cfg,
+		oracle:             testutils.RandomAddress(rng),
+		l1Ref:              l1Ref,
+		finalizationPeriod: 100 * time.Second,
+		payload:            payload,
+		output: &eth.OutputV0{
+			StateRoot:                payload.StateRoot,
+			MessagePasserStorageRoot: eth.Bytes32(testutils.RandomHash(rng)),
+			BlockHash:                payload.BlockHash,
+		},
+		l1:     &testutils.MockL1Source{},
+		source: &testutils.MockL2Client{},
+		engine: &testutils.MockEngine{},
+	}
+}
+
+// expectProposal sets up the L2OutputOracle storage with 3 finalized proposals, of which the last one is the given proposal.
+func (s *stateSyncTest) expectProposal(outputRoot eth.Bytes32, l2BlockNumber uint64, timestamp uint64) {
+	s.expectProposals(
+		OutputProposal{OutputRoot: eth.Bytes32{0x01}, Timestamp: 1000, L2BlockNumber: 10},
+		OutputProposal{OutputRoot: eth.Bytes32{0x02}, Timestamp: 2000, L2BlockNumber: 20},
+		OutputProposal{OutputRoot: outputRoot, Timestamp: timestamp, L2BlockNumber: l2BlockNumber},
+	)
+}
+
+// expectProposals sets up the L2OutputOracle storage with the proposals.
+// Not every proposal is read, as the latest finalized proposal is found with a binary search.
+func (s *stateSyncTest) expectProposals(proposals ...OutputProposal) {
+	s.l1.ExpectReadStorageAt(context.Background(), s.oracle, L2OutputOracleOutputsStorageSlot, s.l1Ref.Hash, common.BigToHash(big.NewInt(int64(len(proposals)))), nil)
+	base := new(big.Int).SetBytes(crypto.Keccak256(L2OutputOracleOutputsStorageSlot[:]))
+	for i, proposal := range proposals {
+		rootSlot := common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(2*i))))
+		packedSlot := common.BigToHash(new(big.Int).Add(base, big.NewInt(int64(2*i+1))))
+		var packed common.Hash
+		new(big.Int).SetUint64(proposal.L2BlockNumber).FillBytes(packed[:16])
+		new(big.Int).SetUint64(proposal.Timestamp).FillBytes(packed[16:])
+		var err error
+		s.l1.Mock.On("ReadStorageAt", s.oracle, rootSlot, s.l1Ref.Hash).Return(common.Hash(proposal.OutputRoot), &err).Maybe()
+		s.l1.Mock.On("ReadStorageAt", s.oracle, packedSlot, s.l1Ref.Hash).Return(packed, &err).Maybe()
+	}
+}
+
+func (s *stateSyncTest) stateSync(t *testing.T) *StateSync {
+	ss := NewStateSync(testlog.Logger(t, log.LvlDebug), s.cfg, s.oracle, s.finalizationPeriod, s.l1, s.source, s.engine)
+	ss.pollInterval = time.Millisecond
+	return ss
+}
+
+func TestLatestFinalizedOutputProposal(t *testing.T) {
+	// The L1 block is at time 10_000 and the finalization period is 100s, so proposals after 9_900 are not finalized
+	proposals := []OutputProposal{
+		{OutputRoot: eth.Bytes32{0x01}, Timestamp: 1000, L2BlockNumber: 10},
+		{OutputRoot: eth.Bytes32{0x02}, Timestamp: 2000, L2BlockNumber: 20},
+		{OutputRoot: eth.Bytes32{0x03}, Timestamp: 9900, L2BlockNumber: 30},
+		{OutputRoot: eth.Bytes32{0x04}, Timestamp: 9901, L2BlockNumber: 40},
+		{OutputRoot: eth.Bytes32{0x05}, Timestamp: 9990, L2BlockNumber: 50},
+	}
+	for n := 1; n <= len(proposals); n++ {
+		n := n
+		t.Run(fmt.Sprintf("Proposals%d", n), func(t *testing.T) {
+			s := newStateSyncTest(t)
+			s.expectProposals(proposals[:n]...)
+			proposal, err := LatestFinalizedOutputProposal(context.Background(), s.l1, s.oracle, s.l1Ref, s.finalizationPeriod)
+			require.NoError(t, err)
+			expected := proposals[n-1]
+			if n > 3 {
+				expected = proposals[2]
+			}
+			require.Equal(t, &expected, proposal)
+		})
+	}
+
+	t.Run("NoFinalizedProposal", func(t *testing.T) {
+		s := newStateSyncTest(t)
+		s.expectProposals(proposals[3:]...)
+		_, err := LatestFinalizedOutputProposal(context.Background(), s.l1, s.oracle, s.l1Ref, s.finalizationPeriod)
+		require.ErrorContains(t, err, "finalized")
+	})
+
+	t.Run("NoProposals", func(t *testing.T) {
+		s := newStateSyncTest(t)
+		s.expectProposals()
+		_, err := LatestFinalizedOutputProposal(context.Background(), s.l1, s.oracle, s.l1Ref, s.finalizationPeriod)
+		require.ErrorContains(t, err, "no outputs")
+	})
+}
+
+func TestStateSync(t *testing.T) {
+	s := newStateSyncTest(t)
+	s.engine.ExpectL2BlockRefByLabel(eth.Unsafe, eth.L2BlockRef{Hash: s.cfg.Genesis.L2.Hash, Number: s.cfg.Genesis.L2.Number}, nil)
+	s.l1.ExpectL1BlockRefByLabel(eth.Finalized, s.l1Ref, nil)
+	s.expectProposal(eth.OutputRoot(s.output), uint64(s.payload.BlockNumber), 5000)
+	s.source.ExpectPayloadByNumber(uint64(s.payload.BlockNumber), s.payload, nil)
+	s.source.ExpectOutputV0AtBlock(s.payload.BlockHash, s.output, nil)
+	s.engine.ExpectNewPayload(s.payload, &eth.PayloadStatusV1{Status: eth.ExecutionSyncing}, nil)
+	fc := &eth.ForkchoiceState{
+		HeadBlockHash:      s.payload.BlockHash,
+		SafeBlockHash:      s.payload.BlockHash,
+		FinalizedBlockHash: s.payload.BlockHash,
+	}
+	var attrs *eth.PayloadAttributes
+	s.engine.ExpectForkchoiceUpdate(fc, attrs, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionSyncing}}, nil)
+	s.engine.ExpectForkchoiceUpdate(fc, attrs, &eth.ForkchoiceUpdatedResult{PayloadStatus: eth.PayloadStatusV1{Status: eth.ExecutionValid}}, nil)
+
+	anchor, synced, err := s.stateSync(t).Run(context.Background())
+	require.NoError(t, err)
+	require.True(t, synced)
+	require.Equal(t, s.payload.BlockHash, anchor.Hash)
+	require.Equal(t, uint64(s.payload.BlockNumber), anchor.Number)
+	s.l1.AssertExpectations(t)
+	s.source.AssertExpectations(t)
+	s.engine.AssertExpectations(t)
+}
+
+func TestStateSyncSkipsUsedEngine(t *testing.T) {
+	s := newStateSyncTest(t)
+	head := eth.L2BlockRef{Hash: common.Hash{0x42}, Number: s.cfg.Genesis.L2.Number + 1}
+	s.engine.ExpectL2BlockRefByLabel(eth.Unsafe, head, nil)
+
+	anchor, synced, err := s.stateSync(t).Run(context.Background())
+	require.NoError(t, err)
+	require.False(t, synced)
+	require.Equal(t, head, anchor)
+	s.engine.AssertExpectations(t)
+}
+
+func TestStateSyncRejectsUnprovenAnchor(t *testing.T) {
+	s := newStateSyncTest(t)
+	s.l1.ExpectL1BlockRefByLabel(eth.Finalized, s.l1Ref, nil)
+	s.expectProposal(eth.Bytes32{0xff}, uint64(s.payload.BlockNumber), 5000)
+	s.source.ExpectPayloadByNumber(uint64(s.payload.BlockNumber), s.payload, nil)
+	s.source.ExpectOutputV0AtBlock(s.payload.BlockHash, s.output, nil)
+
+	_, err := s.stateSync(t).findAnchor(context.Background())
+	require.ErrorContains(t, err, "does not match proposed output root")
+}
+
+func TestStateSyncSkipsChallengeableProposal(t *testing.T) {
+	s := newStateSyncTest(t)
+	s.l1.ExpectL1BlockRefByLabel(eth.Finalized, s.l1Ref, nil)
+	s.expectProposals(
+		OutputProposal{OutputRoot: eth.OutputRoot(s.output), Timestamp: 5000, L2BlockNumber: uint64(s.payload.BlockNumber)},
+		// Proposed within the finalization period as of the finalized L1 block, so it can still be deleted
+		OutputProposal{OutputRoot: eth.Bytes32{0xff}, Timestamp: 9950, L2BlockNumber: uint64(s.payload.BlockNumber) + 100},
+	)
+	s.source.ExpectPayloadByNumber(uint64(s.payload.BlockNumber), s.payload, nil)
+	s.source.ExpectOutputV0AtBlock(s.payload.BlockHash, s.output, nil)
+
+	payload, err := s.stateSync(t).findAnchor(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, s.payload, payload, "should anchor to the finalized proposal")
+	s.source.AssertExpectations(t)
+}
+
+func TestStartedDriver(t *testing.T) {
+	var started atomic.Bool
+	m := &mockDriverClient{}
+	dr := &startedDriver{dr: m, started: &started}
+
+	_, err := dr.SyncStatus(context.Background())
+	require.ErrorIs(t, err, ErrDriverNotStarted)
+	require.ErrorIs(t, dr.StartSequencer(context.Background(), common.Hash{}), ErrDriverNotStarted)
+	m.AssertNotCalled(t, "SyncStatus")
+	m.AssertNotCalled(t, "StartSequencer")
+
+	started.Store(true)
+	status := &eth.SyncStatus{}
+	m.On("SyncStatus").Return(status)
+	actual, err := dr.SyncStatus(context.Background())
+	require.NoError(t, err)
+	require.Same(t, status, actual)
+}
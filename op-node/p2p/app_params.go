@@ -19,6 +19,10 @@ type ApplicationScoreParams struct {
 	RejectedPayloadWeight float64
 	RejectedPayloadDecay  float64
 
+	RateLimitedCap    float64
+	RateLimitedWeight float64
+	RateLimitedDecay  float64
+
 	DecayToZero   float64
 	DecayInterval time.Duration
 }
@@ -50,6 +54,12 @@ func LightApplicationScoreParams(cfg *rollup.Config) ApplicationScoreParams {
 		RejectedPayloadWeight: -20,
 		RejectedPayloadDecay:  ScoreDecay(tenEpochs, slot),
 
+		// Takes 10 blocks beyond the gossip policy peer rate limit to reach the default gossip threshold of -10,
+		// but at most we track 40, which stays above the default ban threshold of -100.
+		RateLimitedCap:    40,
+		RateLimitedWeight: -1,
+		RateLimitedDecay:  ScoreDecay(tenEpochs, slot),
+
 		DecayToZero:   DecayToZero,
 		DecayInterval: slot,
 	}
@@ -20,6 +20,7 @@ type ApplicationScorer interface {
 	onValidResponse(id peer.ID)
 	onResponseError(id peer.ID)
 	onRejectedPayload(id peer.ID)
+	onRateLimited(id peer.ID)
 	start()
 	stop()
 }
@@ -60,6 +61,7 @@ func (s *peerApplicationScorer) ApplicationScore(id peer.ID) float64 {
 	score := scores.ReqResp.ValidResponses * s.params.ValidResponseWeight
 	score += scores.ReqResp.ErrorResponses * s.params.ErrorResponseWeight
 	score += scores.ReqResp.RejectedPayloads * s.params.RejectedPayloadWeight
+	score += scores.GossipPolicy.RateLimitedBlocks * s.params.RateLimitedWeight
 	return score
 }
 
@@ -87,11 +89,20 @@ func (s *peerApplicationScorer) onRejectedPayload(id peer.ID) {
 	}
 }
 
+func (s *peerApplicationScorer) onRateLimited(id peer.ID) {
+	_, err := s.scorebook.SetScore(id, store.IncrementRateLimitedBlocks{Cap: s.params.RateLimitedCap})
+	if err != nil {
+		s.log.Error("Unable to update peer score", "peer", id, "err", err)
+		return
+	}
+}
+
 func (s *peerApplicationScorer) decayScores(id peer.ID) {
 	_, err := s.scorebook.SetScore(id, &store.DecayApplicationScores{
 		ValidResponseDecay:   s.params.ValidResponseDecay,
 		ErrorResponseDecay:   s.params.ErrorResponseDecay,
 		RejectedPayloadDecay: s.params.RejectedPayloadDecay,
+		RateLimitedDecay:     s.params.RateLimitedDecay,
 		DecayToZero:          s.params.DecayToZero,
 	})
 	if err != nil {
@@ -142,6 +153,9 @@ func (n *NoopApplicationScorer) onResponseError(_ peer.ID) {
 func (n *NoopApplicationScorer) onRejectedPayload(_ peer.ID) {
 }
 
+func (n *NoopApplicationScorer) onRateLimited(_ peer.ID) {
+}
+
 func (n *NoopApplicationScorer) start() {
 }
 
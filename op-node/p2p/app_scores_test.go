@@ -110,11 +110,23 @@ func TestIncrementRejectedPayloads(t *testing.T) {
 	require.Equal(t, stubScoreBookUpdate{peer.ID("aaa"), store.IncrementRejectedPayloads{Cap: 10}}, update)
 }
 
+func TestIncrementRateLimitedBlocks(t *testing.T) {
+	data, appScorer := setupPeerApplicationScorerTest(t, &ApplicationScoreParams{
+		RateLimitedCap: 10,
+	})
+
+	appScorer.onRateLimited("aaa")
+	require.Len(t, data.scorebook.updates, 1)
+	update := <-data.scorebook.updates
+	require.Equal(t, stubScoreBookUpdate{peer.ID("aaa"), store.IncrementRateLimitedBlocks{Cap: 10}}, update)
+}
+
 func TestApplicationScore(t *testing.T) {
 	data, appScorer := setupPeerApplicationScorerTest(t, &ApplicationScoreParams{
 		ValidResponseWeight:   0.8,
 		ErrorResponseWeight:   0.6,
 		RejectedPayloadWeight: 0.4,
+		RateLimitedWeight:     0.2,
 	})
 
 	peerScore := store.PeerScores{
@@ -123,10 +135,13 @@ func TestApplicationScore(t *testing.T) {
 			ErrorResponses:   2,
 			RejectedPayloads: 3,
 		},
+		GossipPolicy: store.GossipPolicyScores{
+			RateLimitedBlocks: 4,
+		},
 	}
 	data.scorebook.scores["aaa"] = peerScore
 	score := appScorer.ApplicationScore("aaa")
-	require.Equal(t, 1*0.8+2*0.6+3*0.4, score)
+	require.Equal(t, 1*0.8+2*0.6+3*0.4+4*0.2, score)
 }
 
 func TestApplicationScoreZeroWhenScoreDoesNotLoad(t *testing.T) {
@@ -142,6 +157,7 @@ func TestDecayScoresAfterDecayInterval(t *testing.T) {
 		ValidResponseDecay:   0.8,
 		ErrorResponseDecay:   0.7,
 		RejectedPayloadDecay: 0.3,
+		RateLimitedDecay:     0.2,
 		DecayToZero:          0.1,
 		DecayInterval:        90 * time.Second,
 	}
@@ -152,6 +168,7 @@ func TestDecayScoresAfterDecayInterval(t *testing.T) {
 		ValidResponseDecay:   0.8,
 		ErrorResponseDecay:   0.7,
 		RejectedPayloadDecay: 0.3,
+		RateLimitedDecay:     0.2,
 		DecayToZero:          0.1,
 	}
 
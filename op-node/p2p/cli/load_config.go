@@ -19,9 +19,11 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/monitor"
 
 	"github.com/urfave/cli/v2"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/p2p/enode"
 )
 
@@ -98,6 +100,23 @@ func loadScoringParams(conf *p2p.Config, ctx *cli.Context, rollupCfg *rollup.Con
 		}
 		conf.ScoringParams = params
 	}
+	if conf.ScoringParams != nil {
+		app := &conf.ScoringParams.ApplicationScoring
+		if ctx.IsSet(flags.GossipPolicyPeerRateLimitWeightFlag.Name) {
+			weight := ctx.Float64(flags.GossipPolicyPeerRateLimitWeightFlag.Name)
+			if weight > 0 {
+				return fmt.Errorf("peer rate limit score weight must not be positive: %f", weight)
+			}
+			app.RateLimitedWeight = weight
+		}
+		if ctx.IsSet(flags.GossipPolicyPeerRateLimitDecayFlag.Name) {
+			decay := ctx.Duration(flags.GossipPolicyPeerRateLimitDecayFlag.Name)
+			if decay < app.DecayInterval {
+				return fmt.Errorf("peer rate limit score decay must be at least the decay interval of %s: %s", app.DecayInterval, decay)
+			}
+			app.RateLimitedDecay = p2p.ScoreDecay(decay, app.DecayInterval)
+		}
+	}
 
 	return nil
 }
@@ -107,6 +126,17 @@ func loadBanningOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.BanningEnabled = ctx.Bool(flags.Banning.Name)
 	conf.BanningThreshold = ctx.Float64(flags.BanningThreshold.Name)
 	conf.BanningDuration = ctx.Duration(flags.BanningDuration.Name)
+	if source := ctx.String(flags.BanListSource.Name); source != "" {
+		signer := ctx.String(flags.BanListSigner.Name)
+		if !common.IsHexAddress(signer) {
+			return fmt.Errorf("invalid ban list signer address: %q", signer)
+		}
+		conf.BanListConfig = &monitor.BanListConfig{
+			Source:   source,
+			Signer:   common.HexToAddress(signer),
+			Interval: ctx.Duration(flags.BanListInterval.Name),
+		}
+	}
 	return nil
 }
 
@@ -127,6 +157,12 @@ func loadListenOpts(conf *p2p.Config, ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("bad listen UDP port: %w", err)
 	}
+	conf.EnableQUIC = ctx.Bool(flags.EnableQUIC.Name)
+	conf.ListenQUICPort, err = validatePort(ctx.Uint(flags.ListenQUICPort.Name))
+	if err != nil {
+		return fmt.Errorf("bad listen QUIC port: %w", err)
+	}
+	conf.EnableWebTransport = ctx.Bool(flags.EnableWebTransport.Name)
 	return nil
 }
 
@@ -144,6 +180,10 @@ func loadDiscoveryOpts(conf *p2p.Config, ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("bad advertised UDP port: %w", err)
 	}
+	conf.AdvertiseQUICPort, err = validatePort(ctx.Uint(flags.AdvertiseQUICPort.Name))
+	if err != nil {
+		return fmt.Errorf("bad advertised QUIC port: %w", err)
+	}
 	adIP := ctx.String(flags.AdvertiseIP.Name)
 	if adIP != "" { // optional
 		ips, err := net.LookupIP(adIP)
@@ -329,5 +369,11 @@ func loadGossipOptions(conf *p2p.Config, ctx *cli.Context) error {
 	conf.MeshDHi = ctx.Int(flags.GossipMeshDhiFlag.Name)
 	conf.MeshDLazy = ctx.Int(flags.GossipMeshDlazyFlag.Name)
 	conf.FloodPublish = ctx.Bool(flags.GossipFloodPublishFlag.Name)
+	conf.BlockPolicyConfig = p2p.BlockPolicyConfig{
+		MaxGasUsed:      ctx.Uint64(flags.GossipPolicyMaxGasUsedFlag.Name),
+		MaxTransactions: ctx.Uint64(flags.GossipPolicyMaxTxsFlag.Name),
+		PeerRateLimit:   ctx.Float64(flags.GossipPolicyPeerRateLimitFlag.Name),
+		PeerRateBurst:   ctx.Int(flags.GossipPolicyPeerRateBurstFlag.Name),
+	}
 	return nil
 }
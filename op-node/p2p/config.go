@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/p2p/gating"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/monitor"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/p2p/discover"
@@ -42,11 +43,14 @@ type SetupP2P interface {
 	// Host creates a libp2p host service. Returns nil, nil if p2p is disabled.
 	Host(log log.Logger, reporter metrics.Reporter, metrics HostMetrics) (host.Host, error)
 	// Discovery creates a disc-v5 service. Returns nil, nil, nil if discovery is disabled.
-	Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16) (*enode.LocalNode, *discover.UDPv5, error)
+	// The quicPort is advertised in the ENR if not 0.
+	Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16, quicPort uint16) (*enode.LocalNode, *discover.UDPv5, error)
 	TargetPeers() uint
 	BanPeers() bool
 	BanThreshold() float64
 	BanDuration() time.Duration
+	// BanList returns the config of the subscription to a shared ban list, nil if disabled.
+	BanList() *monitor.BanListConfig
 	GossipSetupConfigurables
 	ReqRespSyncEnabled() bool
 }
@@ -73,17 +77,27 @@ type Config struct {
 	BanningThreshold float64
 	BanningDuration  time.Duration
 
+	// Subscription to a shared ban list, nil if disabled.
+	BanListConfig *monitor.BanListConfig
+
 	ListenIP      net.IP
 	ListenTCPPort uint16
 
 	// Port to bind discv5 to
 	ListenUDPPort uint16
 
-	AdvertiseIP      net.IP
-	AdvertiseTCPPort uint16
-	AdvertiseUDPPort uint16
-	Bootnodes        []*enode.Node
-	DiscoveryDB      *enode.DB
+	// EnableQUIC enables the QUIC transport, in addition to TCP, on ListenQUICPort (UDP).
+	EnableQUIC     bool
+	ListenQUICPort uint16
+	// EnableWebTransport also accepts WebTransport connections on the QUIC port. Requires EnableQUIC.
+	EnableWebTransport bool
+
+	AdvertiseIP       net.IP
+	AdvertiseTCPPort  uint16
+	AdvertiseUDPPort  uint16
+	AdvertiseQUICPort uint16
+	Bootnodes         []*enode.Node
+	DiscoveryDB       *enode.DB
 
 	StaticPeers []core.Multiaddr
 
@@ -100,6 +114,9 @@ type Config struct {
 	MeshDHi   int // topic stable mesh high watermark
 	MeshDLazy int // gossip target
 
+	// BlockPolicyConfig holds the operator-configured gossip validation policies of blocks.
+	BlockPolicyConfig BlockPolicyConfig
+
 	// FloodPublish publishes messages from ourselves to peers outside of the gossip topic mesh but supporting the same topic.
 	FloodPublish bool
 
@@ -142,6 +159,10 @@ func (conf *Config) PeerScoringParams() *ScoringParams {
 	return conf.ScoringParams
 }
 
+func (conf *Config) BlockPolicy() *BlockPolicyConfig {
+	return &conf.BlockPolicyConfig
+}
+
 func (conf *Config) BanPeers() bool {
 	return conf.BanningEnabled
 }
@@ -154,6 +175,10 @@ func (conf *Config) BanDuration() time.Duration {
 	return conf.BanningDuration
 }
 
+func (conf *Config) BanList() *monitor.BanListConfig {
+	return conf.BanListConfig
+}
+
 func (conf *Config) ReqRespSyncEnabled() bool {
 	return conf.EnableReqRespSync
 }
@@ -172,6 +197,12 @@ func (conf *Config) Check() error {
 			return errors.New("discovery requires a persistent or in-memory discv5 db, but found none")
 		}
 	}
+	if conf.EnableWebTransport && !conf.EnableQUIC {
+		return errors.New("webtransport requires the QUIC transport to be enabled")
+	}
+	if conf.EnableQUIC && conf.ListenQUICPort != 0 && conf.ListenQUICPort == conf.ListenUDPPort && !conf.NoDiscovery {
+		return fmt.Errorf("QUIC and discv5 cannot share UDP port %d", conf.ListenQUICPort)
+	}
 	if conf.PeersLo == 0 || conf.PeersHi == 0 || conf.PeersLo > conf.PeersHi {
 		return fmt.Errorf("peers lo/hi tides are invalid: %d, %d", conf.PeersLo, conf.PeersHi)
 	}
@@ -187,5 +218,13 @@ func (conf *Config) Check() error {
 	if conf.MeshDLazy <= 0 || conf.MeshDLazy > maxMeshParam {
 		return fmt.Errorf("mesh Dlazy param must not be 0 or exceed %d, but got %d", maxMeshParam, conf.MeshDLazy)
 	}
+	if err := conf.BlockPolicyConfig.Check(); err != nil {
+		return fmt.Errorf("invalid gossip block policy: %w", err)
+	}
+	if conf.BanListConfig != nil {
+		if err := conf.BanListConfig.Check(); err != nil {
+			return fmt.Errorf("invalid ban list config: %w", err)
+		}
+	}
 	return nil
 }
@@ -50,7 +50,7 @@ const (
 	collectiveDialTimeout  = time.Second * 30
 )
 
-func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16) (*enode.LocalNode, *discover.UDPv5, error) {
+func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16, quicPort uint16) (*enode.LocalNode, *discover.UDPv5, error) {
 	if conf.NoDiscovery {
 		return nil, nil, nil
 	}
@@ -75,6 +75,17 @@ func (conf *Config) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort
 	} else {
 		return nil, nil, fmt.Errorf("no TCP port to put in discovery record")
 	}
+	if conf.EnableQUIC {
+		if conf.AdvertiseQUICPort != 0 { // same priority as the TCP port
+			localNode.Set(QUIC(conf.AdvertiseQUICPort))
+		} else if quicPort != 0 {
+			localNode.Set(QUIC(quicPort))
+		} else if conf.ListenQUICPort != 0 {
+			localNode.Set(QUIC(conf.ListenQUICPort))
+		} else {
+			return nil, nil, fmt.Errorf("no QUIC port to put in discovery record")
+		}
+	}
 	dat := OpStackENRData{
 		chainID: rollupCfg.L2ChainID.Uint64(),
 		version: 0,
@@ -152,6 +163,16 @@ func enrToAddrInfo(r *enode.Node) (*peer.AddrInfo, *crypto.Secp256k1PublicKey, e
 	if err != nil {
 		return nil, nil, fmt.Errorf("could not construct multi addr: %w", err)
 	}
+	addrs := []multiaddr.Multiaddr{mAddr}
+	// Nodes without QUIC support are still reachable over TCP, the QUIC address is optional.
+	var quicPort QUIC
+	if err := r.Load(&quicPort); err == nil && quicPort != 0 {
+		quicAddr, err := multiaddr.NewMultiaddr(fmt.Sprintf("/%s/%s/udp/%d/quic-v1", ipScheme, ip.String(), quicPort))
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not construct QUIC multi addr: %w", err)
+		}
+		addrs = append(addrs, quicAddr)
+	}
 	var enrPub Secp256k1
 	if err := r.Load(&enrPub); err != nil {
 		return nil, nil, fmt.Errorf("failed to load pubkey as libp2p pubkey type from ENR")
@@ -163,10 +184,17 @@ func enrToAddrInfo(r *enode.Node) (*peer.AddrInfo, *crypto.Secp256k1PublicKey, e
 	}
 	return &peer.AddrInfo{
 		ID:    peerID,
-		Addrs: []multiaddr.Multiaddr{mAddr},
+		Addrs: addrs,
 	}, pub, nil
 }
 
+// QUIC is the "quic" ENR entry, the UDP port of the libp2p QUIC transport, as also used by Ethereum consensus clients.
+type QUIC uint16
+
+func (v QUIC) ENRKey() string { return "quic" }
+
+var _ enr.Entry = QUIC(0)
+
 // The discovery ENRs are just key-value lists, and we filter them by records tagged with the "opstack" key,
 // and then check the chain ID and version.
 type OpStackENRData struct {
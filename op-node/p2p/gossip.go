@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/golang/snappy"
+	"github.com/hashicorp/go-multierror"
 	lru "github.com/hashicorp/golang-lru"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	pb "github.com/libp2p/go-libp2p-pubsub/pb"
@@ -54,6 +55,8 @@ type GossipSetupConfigurables interface {
 	PeerScoringParams() *ScoringParams
 	// ConfigureGossip creates configuration options to apply to the GossipSub setup
 	ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option
+	// BlockPolicy returns the operator-configured gossip validation policies of blocks, nil if none.
+	BlockPolicy() *BlockPolicyConfig
 }
 
 type GossipRuntimeConfig interface {
@@ -63,16 +66,23 @@ type GossipRuntimeConfig interface {
 //go:generate mockery --name GossipMetricer
 type GossipMetricer interface {
 	RecordGossipEvent(evType int32)
+	// RecordGossipPolicyViolation records a gossip message that violated the given gossip policy,
+	// and was rejected or ignored as result.
+	RecordGossipPolicyViolation(policy string, result string)
 }
 
 func blocksTopicV1(cfg *rollup.Config) string {
 	return fmt.Sprintf("/optimism/%s/0/blocks", cfg.L2ChainID.String())
 }
 
+func txPreconfsTopicV1(cfg *rollup.Config) string {
+	return fmt.Sprintf("/optimism/%s/0/tx_preconfs", cfg.L2ChainID.String())
+}
+
 // BuildSubscriptionFilter builds a simple subscription filter,
 // to help protect against peers spamming useless subscriptions.
 func BuildSubscriptionFilter(cfg *rollup.Config) pubsub.SubscriptionFilter {
-	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg)) // add more topics here in the future, if any.
+	return pubsub.NewAllowlistSubscriptionFilter(blocksTopicV1(cfg), txPreconfsTopicV1(cfg)) // add more topics here in the future, if any.
 }
 
 var msgBufPool = sync.Pool{New: func() any {
@@ -238,7 +248,9 @@ func (sb *seenBlocks) markSeen(h common.Hash) {
 	sb.blockHashes = append(sb.blockHashes, h)
 }
 
-func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig) pubsub.ValidatorEx {
+// BuildBlocksValidator builds the validator of gossiped blocks.
+// The operator-configured policies are applied after the protocol rules, and may be nil to apply none.
+func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, policies *BlockPolicies) pubsub.ValidatorEx {
 
 	// Seen block hashes per block height
 	// uint64 -> *seenBlocks
@@ -248,6 +260,11 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	}
 
 	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		// [IGNORE] if the peer exceeds its block rate limit
+		if !policies.Allow(id) {
+			return pubsub.ValidationIgnore
+		}
+
 		// [REJECT] if the compression is not valid
 		outLen, err := snappy.DecodedLen(message.Data)
 		if err != nil {
@@ -309,6 +326,11 @@ func BuildBlocksValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 			return pubsub.ValidationReject
 		}
 
+		// [IGNORE] if the block violates any of the operator-configured policies
+		if result := policies.Check(id, &payload); result != pubsub.ValidationAccept {
+			return result
+		}
+
 		seen, ok := blockHeightLRU.Get(uint64(payload.BlockNumber))
 		if !ok {
 			seen = new(seenBlocks)
@@ -341,7 +363,11 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 		log.Warn("failed to compute block signing hash", "err", err, "peer", id)
 		return pubsub.ValidationReject
 	}
+	return verifySequencerSignature(log, runCfg, id, signingHash, signatureBytes)
+}
 
+// verifySequencerSignature checks that the signing hash was signed by the configured p2p sequencer address.
+func verifySequencerSignature(log log.Logger, runCfg GossipRuntimeConfig, id peer.ID, signingHash common.Hash, signatureBytes []byte) pubsub.ValidationResult {
 	pub, err := crypto.SigToPub(signingHash[:], signatureBytes)
 	if err != nil {
 		log.Warn("invalid block signature", "err", err, "peer", id)
@@ -364,8 +390,66 @@ func verifyBlockSignature(log log.Logger, cfg *rollup.Config, runCfg GossipRunti
 	return pubsub.ValidationAccept
 }
 
+// BuildTxPreconfsValidator builds the validator of the tx preconfirmations topic.
+// Messages are the 65 byte sequencer signature, followed by the binary encoded eth.TxPreconfirmation, snappy compressed.
+func BuildTxPreconfsValidator(log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig) pubsub.ValidatorEx {
+	return func(ctx context.Context, id peer.ID, message *pubsub.Message) pubsub.ValidationResult {
+		// [REJECT] if the compression is not valid, or if the message has the wrong size
+		outLen, err := snappy.DecodedLen(message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression length data", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if outLen != 65+eth.TxPreconfirmationSize {
+			log.Warn("invalid tx preconfirmation size", "decoded_length", outLen, "peer", id)
+			return pubsub.ValidationReject
+		}
+		data, err := snappy.Decode(nil, message.Data)
+		if err != nil {
+			log.Warn("invalid snappy compression", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		signatureBytes, preconfBytes := data[:65], data[65:]
+
+		// [REJECT] if the signature by the sequencer is not valid
+		signingHash, err := TxPreconfSigningHash(cfg, preconfBytes)
+		if err != nil {
+			log.Warn("failed to compute tx preconfirmation signing hash", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+		if result := verifySequencerSignature(log, runCfg, id, signingHash, signatureBytes); result != pubsub.ValidationAccept {
+			return result
+		}
+
+		var preconf eth.TxPreconfirmation
+		if err := preconf.UnmarshalBinary(preconfBytes); err != nil {
+			log.Warn("invalid tx preconfirmation", "err", err, "peer", id)
+			return pubsub.ValidationReject
+		}
+
+		now := uint64(time.Now().Unix())
+
+		// [REJECT] if the block timestamp is older than 60 seconds in the past
+		if uint64(preconf.Timestamp) < now-60 {
+			log.Warn("tx preconfirmation is too old", "timestamp", uint64(preconf.Timestamp))
+			return pubsub.ValidationReject
+		}
+
+		// [REJECT] if the block timestamp is more than 5 seconds into the future
+		if uint64(preconf.Timestamp) > now+5 {
+			log.Warn("tx preconfirmation is too new", "timestamp", uint64(preconf.Timestamp))
+			return pubsub.ValidationReject
+		}
+
+		message.ValidatorData = &preconf
+		return pubsub.ValidationAccept
+	}
+}
+
 type GossipIn interface {
 	OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
+	OnTxPreconfirmation(ctx context.Context, from peer.ID, msg *eth.TxPreconfirmation) error
 }
 
 type GossipTopicInfo interface {
@@ -375,14 +459,16 @@ type GossipTopicInfo interface {
 type GossipOut interface {
 	GossipTopicInfo
 	PublishL2Payload(ctx context.Context, msg *eth.ExecutionPayload, signer Signer) error
+	PublishTxPreconfirmation(ctx context.Context, msg *eth.TxPreconfirmation, signer Signer) error
 	Close() error
 }
 
 type publisher struct {
-	log         log.Logger
-	cfg         *rollup.Config
-	blocksTopic *pubsub.Topic
-	runCfg      GossipRuntimeConfig
+	log           log.Logger
+	cfg           *rollup.Config
+	blocksTopic   *pubsub.Topic
+	preconfsTopic *pubsub.Topic
+	runCfg        GossipRuntimeConfig
 }
 
 var _ GossipOut = (*publisher)(nil)
@@ -418,12 +504,34 @@ func (p *publisher) PublishL2Payload(ctx context.Context, payload *eth.Execution
 	return p.blocksTopic.Publish(ctx, out)
 }
 
+func (p *publisher) PublishTxPreconfirmation(ctx context.Context, preconf *eth.TxPreconfirmation, signer Signer) error {
+	preconfData, err := preconf.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to encode tx preconfirmation to publish: %w", err)
+	}
+	sig, err := signer.Sign(ctx, SigningDomainTxPreconfsV1, p.cfg.L2ChainID, preconfData)
+	if err != nil {
+		return fmt.Errorf("failed to sign tx preconfirmation with signer: %w", err)
+	}
+	data := make([]byte, 0, 65+len(preconfData))
+	data = append(data, sig[:]...)
+	data = append(data, preconfData...)
+	return p.preconfsTopic.Publish(ctx, snappy.Encode(nil, data))
+}
+
 func (p *publisher) Close() error {
-	return p.blocksTopic.Close()
+	var result *multierror.Error
+	if err := p.blocksTopic.Close(); err != nil {
+		result = multierror.Append(result, fmt.Errorf("failed to close blocks topic: %w", err))
+	}
+	if err := p.preconfsTopic.Close(); err != nil {
+		result = multierror.Append(result, fmt.Errorf("failed to close tx preconfirmations topic: %w", err))
+	}
+	return result.ErrorOrNil()
 }
 
-func JoinGossip(p2pCtx context.Context, self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn) (GossipOut, error) {
-	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildBlocksValidator(log, cfg, runCfg)))
+func JoinGossip(p2pCtx context.Context, self peer.ID, ps *pubsub.PubSub, log log.Logger, cfg *rollup.Config, runCfg GossipRuntimeConfig, gossipIn GossipIn, policies *BlockPolicies) (GossipOut, error) {
+	val := guardGossipValidator(log, logValidationResult(self, "validated block", log, BuildBlocksValidator(log, cfg, runCfg, policies)))
 	blocksTopicName := blocksTopicV1(cfg)
 	err := ps.RegisterTopicValidator(blocksTopicName,
 		val,
@@ -450,7 +558,34 @@ func JoinGossip(p2pCtx context.Context, self peer.ID, ps *pubsub.PubSub, log log
 	subscriber := MakeSubscriber(log, BlocksHandler(gossipIn.OnUnsafeL2Payload))
 	go subscriber(p2pCtx, subscription)
 
-	return &publisher{log: log, cfg: cfg, blocksTopic: blocksTopic, runCfg: runCfg}, nil
+	preconfsVal := guardGossipValidator(log, logValidationResult(self, "validated tx preconfirmation", log, BuildTxPreconfsValidator(log, cfg, runCfg)))
+	preconfsTopicName := txPreconfsTopicV1(cfg)
+	err = ps.RegisterTopicValidator(preconfsTopicName,
+		preconfsVal,
+		pubsub.WithValidatorTimeout(time.Second),
+		pubsub.WithValidatorConcurrency(4))
+	if err != nil {
+		return nil, fmt.Errorf("failed to register tx preconfirmations gossip topic: %w", err)
+	}
+	preconfsTopic, err := ps.Join(preconfsTopicName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to join tx preconfirmations gossip topic: %w", err)
+	}
+	preconfsTopicEvents, err := preconfsTopic.EventHandler()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tx preconfirmations gossip topic handler: %w", err)
+	}
+	go LogTopicEvents(p2pCtx, log.New("topic", "tx_preconfs"), preconfsTopicEvents)
+
+	preconfsSubscription, err := preconfsTopic.Subscribe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to tx preconfirmations gossip topic: %w", err)
+	}
+
+	preconfsSubscriber := MakeSubscriber(log, TxPreconfsHandler(gossipIn.OnTxPreconfirmation))
+	go preconfsSubscriber(p2pCtx, preconfsSubscription)
+
+	return &publisher{log: log, cfg: cfg, blocksTopic: blocksTopic, preconfsTopic: preconfsTopic, runCfg: runCfg}, nil
 }
 
 type TopicSubscriber func(ctx context.Context, sub *pubsub.Subscription)
@@ -466,6 +601,16 @@ func BlocksHandler(onBlock func(ctx context.Context, from peer.ID, msg *eth.Exec
 	}
 }
 
+func TxPreconfsHandler(onPreconf func(ctx context.Context, from peer.ID, msg *eth.TxPreconfirmation) error) MessageHandler {
+	return func(ctx context.Context, from peer.ID, msg any) error {
+		preconf, ok := msg.(*eth.TxPreconfirmation)
+		if !ok {
+			return fmt.Errorf("expected topic validator to parse and validate data into tx preconfirmation, but got %T", msg)
+		}
+		return onPreconf(ctx, from, preconf)
+	}
+}
+
 func MakeSubscriber(log log.Logger, msgHandler MessageHandler) TopicSubscriber {
 	return func(ctx context.Context, sub *pubsub.Subscription) {
 		topicLog := log.New("topic", sub.Topic())
@@ -0,0 +1,185 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/hashicorp/golang-lru/v2/simplelru"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"golang.org/x/time/rate"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// peerRateLimitPolicy is the name of the per-peer block rate limit in logs and metrics.
+const peerRateLimitPolicy = "peer_rate_limit"
+
+// maxRateLimitedPeers is the number of peers to track the block rate of.
+const maxRateLimitedPeers = 1000
+
+// BlockPolicy is an operator-defined rule that gossiped blocks must satisfy,
+// in addition to the protocol rules of the blocks validator.
+type BlockPolicy struct {
+	// Name identifies the policy in logs and metrics.
+	Name string
+	// Check returns an error if the block, as received from the given peer, violates the policy.
+	Check func(from peer.ID, payload *eth.ExecutionPayload) error
+}
+
+// MaxGasUsedPolicy rejects blocks that use more than the given amount of gas.
+func MaxGasUsedPolicy(max uint64) BlockPolicy {
+	return BlockPolicy{
+		Name: "max_gas_used",
+		Check: func(from peer.ID, payload *eth.ExecutionPayload) error {
+			if uint64(payload.GasUsed) > max {
+				return fmt.Errorf("block uses %d gas, more than the max of %d", uint64(payload.GasUsed), max)
+			}
+			return nil
+		},
+	}
+}
+
+// MaxTransactionsPolicy rejects blocks with more than the given number of transactions.
+func MaxTransactionsPolicy(max uint64) BlockPolicy {
+	return BlockPolicy{
+		Name: "max_transactions",
+		Check: func(from peer.ID, payload *eth.ExecutionPayload) error {
+			if n := uint64(len(payload.Transactions)); n > max {
+				return fmt.Errorf("block has %d transactions, more than the max of %d", n, max)
+			}
+			return nil
+		},
+	}
+}
+
+// BlockPolicyConfig configures the gossip validation policies of blocks. Zero values disable the respective policy.
+type BlockPolicyConfig struct {
+	// MaxGasUsed is the max gas a gossiped block may use.
+	MaxGasUsed uint64
+	// MaxTransactions is the max number of transactions of a gossiped block.
+	MaxTransactions uint64
+	// PeerRateLimit is the max sustained number of blocks per second to validate from a single peer,
+	// with bursts of up to PeerRateBurst blocks. Blocks beyond the limit are ignored without validation.
+	PeerRateLimit float64
+	PeerRateBurst int
+	// Custom are additional policies, e.g. set by operators that embed the op-node.
+	Custom []BlockPolicy
+}
+
+func (c *BlockPolicyConfig) Check() error {
+	if c.PeerRateLimit < 0 {
+		return fmt.Errorf("invalid peer rate limit: %f", c.PeerRateLimit)
+	}
+	if c.PeerRateLimit > 0 && c.PeerRateBurst <= 0 {
+		return errors.New("peer rate limit requires a positive burst")
+	}
+	for i, p := range c.Custom {
+		if p.Name == "" || p.Check == nil {
+			return fmt.Errorf("custom block policy %d must have a name and check", i)
+		}
+	}
+	return nil
+}
+
+// rateLimitScorer is the part of the ApplicationScorer that tracks peers that exceed their block rate limit.
+type rateLimitScorer interface {
+	onRateLimited(id peer.ID)
+}
+
+// BlockPolicies applies the gossip validation policies of blocks.
+// Blocks that violate a policy are ignored, without penalizing the peer that sent it:
+// the policies only run on blocks signed by the sequencer, which honest peers forward regardless of local policies.
+// Blocks beyond the per-peer rate limit are ignored too, but do penalize the peer through the application score,
+// since the rate at which a peer sends blocks is up to the peer.
+// The zero/nil BlockPolicies accepts all blocks.
+type BlockPolicies struct {
+	log      log.Logger
+	policies []BlockPolicy
+	m        GossipMetricer
+	scorer   rateLimitScorer
+
+	limit    rate.Limit
+	burst    int
+	limiters *simplelru.LRU[peer.ID, *rate.Limiter]
+	mu       sync.Mutex
+}
+
+// NewBlockPolicies creates the policies of the given config. Violations are reported to the metrics,
+// and rate limit violations to the scorer, if not nil.
+// It returns nil if no policies are configured.
+func NewBlockPolicies(log log.Logger, cfg *BlockPolicyConfig, m GossipMetricer, scorer ApplicationScorer) *BlockPolicies {
+	if cfg == nil {
+		return nil
+	}
+	var policies []BlockPolicy
+	if cfg.MaxGasUsed != 0 {
+		policies = append(policies, MaxGasUsedPolicy(cfg.MaxGasUsed))
+	}
+	if cfg.MaxTransactions != 0 {
+		policies = append(policies, MaxTransactionsPolicy(cfg.MaxTransactions))
+	}
+	policies = append(policies, cfg.Custom...)
+	if len(policies) == 0 && cfg.PeerRateLimit == 0 {
+		return nil
+	}
+	p := &BlockPolicies{
+		log:      log,
+		policies: policies,
+		m:        m,
+		scorer:   scorer,
+		limit:    rate.Limit(cfg.PeerRateLimit),
+		burst:    cfg.PeerRateBurst,
+	}
+	if cfg.PeerRateLimit > 0 {
+		p.limiters, _ = simplelru.NewLRU[peer.ID, *rate.Limiter](maxRateLimitedPeers, nil)
+	}
+	return p
+}
+
+// Allow checks if the peer is within its block rate limit, and consumes from the limit if so.
+// Blocks beyond the limit should be ignored, not rejected: honest peers forward all blocks they receive.
+func (p *BlockPolicies) Allow(from peer.ID) bool {
+	if p == nil || p.limiters == nil {
+		return true
+	}
+	p.mu.Lock()
+	rl, ok := p.limiters.Get(from)
+	if !ok {
+		rl = rate.NewLimiter(p.limit, p.burst)
+		p.limiters.Add(from, rl)
+	}
+	p.mu.Unlock()
+	if rl.Allow() {
+		return true
+	}
+	p.log.Debug("peer exceeded block rate limit", "peer", from)
+	p.recordViolation(peerRateLimitPolicy, "ignore")
+	if p.scorer != nil {
+		p.scorer.onRateLimited(from)
+	}
+	return false
+}
+
+// Check applies the policies to the block, and ignores it if it violates any of them.
+func (p *BlockPolicies) Check(from peer.ID, payload *eth.ExecutionPayload) pubsub.ValidationResult {
+	if p == nil {
+		return pubsub.ValidationAccept
+	}
+	for _, policy := range p.policies {
+		if err := policy.Check(from, payload); err != nil {
+			p.log.Warn("block violates gossip policy", "policy", policy.Name, "block", payload.ID(), "peer", from, "err", err)
+			p.recordViolation(policy.Name, "ignore")
+			return pubsub.ValidationIgnore
+		}
+	}
+	return pubsub.ValidationAccept
+}
+
+func (p *BlockPolicies) recordViolation(policy string, result string) {
+	if p.m != nil {
+		p.m.RecordGossipPolicyViolation(policy, result)
+	}
+}
@@ -0,0 +1,84 @@
+package p2p
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/p2p/mocks"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type rateLimitRecorder struct {
+	NoopApplicationScorer
+	limited []peer.ID
+}
+
+func (r *rateLimitRecorder) onRateLimited(id peer.ID) {
+	r.limited = append(r.limited, id)
+}
+
+func TestNoBlockPolicies(t *testing.T) {
+	policies := NewBlockPolicies(testlog.Logger(t, log.LvlError), &BlockPolicyConfig{}, nil, nil)
+	require.Nil(t, policies, "no policies configured")
+	require.True(t, policies.Allow("a"))
+	require.Equal(t, pubsub.ValidationAccept, policies.Check("a", &eth.ExecutionPayload{GasUsed: 1 << 40}))
+}
+
+func TestBlockPolicies(t *testing.T) {
+	m := mocks.NewGossipMetricer(t)
+	policies := NewBlockPolicies(testlog.Logger(t, log.LvlError), &BlockPolicyConfig{
+		MaxGasUsed:      1000,
+		MaxTransactions: 2,
+		Custom: []BlockPolicy{{
+			Name: "no_block_7",
+			Check: func(from peer.ID, payload *eth.ExecutionPayload) error {
+				if payload.BlockNumber == 7 {
+					return errors.New("block 7 is not allowed")
+				}
+				return nil
+			},
+		}},
+	}, m, nil)
+
+	require.Equal(t, pubsub.ValidationAccept, policies.Check("a", &eth.ExecutionPayload{GasUsed: 1000, Transactions: make([]eth.Data, 2)}))
+
+	// Blocks signed by the sequencer that violate local policies are ignored, not rejected
+	m.On("RecordGossipPolicyViolation", "max_gas_used", "ignore").Once()
+	require.Equal(t, pubsub.ValidationIgnore, policies.Check("a", &eth.ExecutionPayload{GasUsed: 1001}))
+
+	m.On("RecordGossipPolicyViolation", "max_transactions", "ignore").Once()
+	require.Equal(t, pubsub.ValidationIgnore, policies.Check("b", &eth.ExecutionPayload{Transactions: make([]eth.Data, 3)}))
+
+	m.On("RecordGossipPolicyViolation", "no_block_7", "ignore").Once()
+	require.Equal(t, pubsub.ValidationIgnore, policies.Check("c", &eth.ExecutionPayload{BlockNumber: 7}))
+}
+
+func TestBlockPoliciesPeerRateLimit(t *testing.T) {
+	m := mocks.NewGossipMetricer(t)
+	scorer := &rateLimitRecorder{}
+	policies := NewBlockPolicies(testlog.Logger(t, log.LvlError), &BlockPolicyConfig{
+		PeerRateLimit: 0.001,
+		PeerRateBurst: 2,
+	}, m, scorer)
+
+	require.True(t, policies.Allow("a"))
+	require.True(t, policies.Allow("a"))
+	require.Empty(t, scorer.limited)
+	m.On("RecordGossipPolicyViolation", peerRateLimitPolicy, "ignore").Once()
+	require.False(t, policies.Allow("a"))
+	require.True(t, policies.Allow("b"), "limit is per peer")
+	require.Equal(t, []peer.ID{"a"}, scorer.limited, "rate limit violations are scored against the peer")
+}
+
+func TestBlockPolicyConfigCheck(t *testing.T) {
+	require.NoError(t, (&BlockPolicyConfig{}).Check())
+	require.Error(t, (&BlockPolicyConfig{PeerRateLimit: 1}).Check())
+	require.NoError(t, (&BlockPolicyConfig{PeerRateLimit: 1, PeerRateBurst: 1}).Check())
+	require.Error(t, (&BlockPolicyConfig{Custom: []BlockPolicy{{Name: "missing check"}}}).Check())
+}
@@ -4,8 +4,13 @@ import (
 	"context"
 	"math/big"
 	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	pb "github.com/libp2p/go-libp2p-pubsub/pb"
 
 	"github.com/ethereum-optimism/optimism/op-e2e/e2eutils"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum/go-ethereum/common"
@@ -84,3 +89,53 @@ func TestVerifyBlockSignature(t *testing.T) {
 		require.Equal(t, pubsub.ValidationIgnore, result)
 	})
 }
+
+func TestTxPreconfsValidator(t *testing.T) {
+	logger := testlog.Logger(t, log.LvlCrit)
+	cfg := &rollup.Config{
+		L2ChainID: big.NewInt(100),
+	}
+	secrets, err := e2eutils.DefaultMnemonicConfig.Secrets()
+	require.NoError(t, err)
+	runCfg := &testutils.MockRuntimeConfig{P2PSeqAddress: crypto.PubkeyToAddress(secrets.SequencerP2P.PublicKey)}
+	val := BuildTxPreconfsValidator(logger, cfg, runCfg)
+
+	makeMsg := func(t *testing.T, preconf *eth.TxPreconfirmation, domain [32]byte) *pubsub.Message {
+		data, err := preconf.MarshalBinary()
+		require.NoError(t, err)
+		sig, err := NewLocalSigner(secrets.SequencerP2P).Sign(context.Background(), domain, cfg.L2ChainID, data)
+		require.NoError(t, err)
+		msg := append(sig[:], data...)
+		return &pubsub.Message{Message: &pb.Message{Data: snappy.Encode(nil, msg)}}
+	}
+	preconf := &eth.TxPreconfirmation{
+		BlockNumber: 123,
+		ParentHash:  common.Hash{0x01},
+		Timestamp:   eth.Uint64Quantity(time.Now().Unix()),
+		TxIndex:     2,
+		TxHash:      common.Hash{0x02},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		msg := makeMsg(t, preconf, SigningDomainTxPreconfsV1)
+		require.Equal(t, pubsub.ValidationAccept, val(context.Background(), "alice", msg))
+		require.Equal(t, preconf, msg.ValidatorData)
+	})
+
+	t.Run("BlockSignature", func(t *testing.T) {
+		// signatures of other message types must not be accepted as tx preconfirmation signatures
+		msg := makeMsg(t, preconf, SigningDomainBlocksV1)
+		require.Equal(t, pubsub.ValidationReject, val(context.Background(), "alice", msg))
+	})
+
+	t.Run("TooOld", func(t *testing.T) {
+		old := *preconf
+		old.Timestamp -= 120
+		require.Equal(t, pubsub.ValidationReject, val(context.Background(), "alice", makeMsg(t, &old, SigningDomainTxPreconfsV1)))
+	})
+
+	t.Run("WrongSize", func(t *testing.T) {
+		msg := &pubsub.Message{Message: &pb.Message{Data: snappy.Encode(nil, make([]byte, 100))}}
+		require.Equal(t, pubsub.ValidationReject, val(context.Background(), "alice", msg))
+	})
+}
@@ -21,7 +21,9 @@ import (
 	"github.com/libp2p/go-libp2p/p2p/muxer/yamux"
 	"github.com/libp2p/go-libp2p/p2p/security/noise"
 	tls "github.com/libp2p/go-libp2p/p2p/security/tls"
+	quic "github.com/libp2p/go-libp2p/p2p/transport/quic"
 	"github.com/libp2p/go-libp2p/p2p/transport/tcp"
+	webtransport "github.com/libp2p/go-libp2p/p2p/transport/webtransport"
 	ma "github.com/multiformats/go-multiaddr"
 	madns "github.com/multiformats/go-multiaddr-dns"
 
@@ -183,13 +185,26 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 	if err != nil {
 		return nil, fmt.Errorf("failed to make listen addr: %w", err)
 	}
-	tcpTransport := libp2p.Transport(
-		tcp.NewTCPTransport,
-		tcp.WithConnectionTimeout(time.Minute*60)) // break unused connections
-	if err != nil {
-		return nil, fmt.Errorf("failed to create TCP transport: %w", err)
+	listenAddrs := []ma.Multiaddr{listenAddr}
+	transports := []libp2p.Option{
+		libp2p.Transport(
+			tcp.NewTCPTransport,
+			tcp.WithConnectionTimeout(time.Minute*60)), // break unused connections
+	}
+	if conf.EnableQUIC {
+		// QUIC brings its own TLS 1.3 security and stream multiplexing, the HostSecurity and HostMux options only apply to TCP.
+		quicAddr, err := quicAddrFromIPAndPort(conf.ListenIP, conf.ListenQUICPort)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make QUIC listen addr: %w", err)
+		}
+		listenAddrs = append(listenAddrs, quicAddr)
+		transports = append(transports, libp2p.Transport(quic.NewTransport))
+		if conf.EnableWebTransport {
+			// WebTransport shares the UDP socket with QUIC
+			listenAddrs = append(listenAddrs, quicAddr.Encapsulate(ma.StringCast("/webtransport")))
+			transports = append(transports, libp2p.Transport(webtransport.New))
+		}
 	}
-	// TODO: technically we can also run the node on websocket and QUIC transports. Maybe in the future?
 
 	var nat lconf.NATManagerC // disabled if nil
 	if conf.NAT {
@@ -200,12 +215,11 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		libp2p.Identity(conf.Priv),
 		// Explicitly set the user-agent, so we can differentiate from other Go libp2p users.
 		libp2p.UserAgent(conf.UserAgent),
-		tcpTransport,
 		libp2p.WithDialTimeout(conf.TimeoutDial),
 		// No relay services, direct connections between peers only.
 		libp2p.DisableRelay(),
 		// host will start and listen to network directly after construction from config.
-		libp2p.ListenAddrs(listenAddr),
+		libp2p.ListenAddrs(listenAddrs...),
 		libp2p.ConnectionGater(connGtr),
 		libp2p.ConnectionManager(connMngr),
 		//libp2p.ResourceManager(nil), // TODO use resource manager interface to manage resources per peer better.
@@ -219,6 +233,7 @@ func (conf *Config) Host(log log.Logger, reporter metrics.Reporter, metrics Host
 		libp2p.EnableNATService(),
 		libp2p.AutoNATServiceRateLimit(10, 5, time.Second*60),
 	}
+	opts = append(opts, transports...)
 	opts = append(opts, conf.HostMux...)
 	if conf.NoTransportSecurity {
 		opts = append(opts, libp2p.Security(insecure.ID, insecure.NewWithIdentity))
@@ -266,6 +281,17 @@ func addrFromIPAndPort(ip net.IP, port uint16) (ma.Multiaddr, error) {
 	return ma.NewMultiaddr(fmt.Sprintf("/%s/%s/tcp/%d", ipScheme, ip.String(), port))
 }
 
+// Creates a QUIC multi-addr to bind to, like addrFromIPAndPort, but for the UDP-based QUIC transport.
+func quicAddrFromIPAndPort(ip net.IP, port uint16) (ma.Multiaddr, error) {
+	ipScheme := "ip4"
+	if ip4 := ip.To4(); ip4 == nil {
+		ipScheme = "ip6"
+	} else {
+		ip = ip4
+	}
+	return ma.NewMultiaddr(fmt.Sprintf("/%s/%s/udp/%d/quic-v1", ipScheme, ip.String(), port))
+}
+
 func YamuxC() libp2p.Option {
 	return libp2p.Muxer("/yamux/1.0.0", yamux.DefaultTransport)
 }
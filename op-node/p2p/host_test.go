@@ -12,10 +12,13 @@ import (
 	ds "github.com/ipfs/go-datastore"
 	"github.com/ipfs/go-datastore/sync"
 	"github.com/libp2p/go-libp2p"
+	pubsub "github.com/libp2p/go-libp2p-pubsub"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	mocknet "github.com/libp2p/go-libp2p/p2p/net/mock"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/exp/slices"
 
@@ -71,7 +74,8 @@ func TestP2PSimple(t *testing.T) {
 }
 
 type mockGossipIn struct {
-	OnUnsafeL2PayloadFn func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
+	OnUnsafeL2PayloadFn   func(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error
+	OnTxPreconfirmationFn func(ctx context.Context, from peer.ID, msg *eth.TxPreconfirmation) error
 }
 
 func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg *eth.ExecutionPayload) error {
@@ -81,6 +85,13 @@ func (m *mockGossipIn) OnUnsafeL2Payload(ctx context.Context, from peer.ID, msg
 	return nil
 }
 
+func (m *mockGossipIn) OnTxPreconfirmation(ctx context.Context, from peer.ID, msg *eth.TxPreconfirmation) error {
+	if m.OnTxPreconfirmationFn != nil {
+		return m.OnTxPreconfirmationFn(ctx, from, msg)
+	}
+	return nil
+}
+
 // Full setup, using negotiated transport security and muxes
 func TestP2PFull(t *testing.T) {
 	pA, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
@@ -324,6 +335,137 @@ func TestDiscovery(t *testing.T) {
 	}
 }
 
+func isQUICAddr(addr ma.Multiaddr) bool {
+	_, err := addr.ValueForProtocol(ma.P_QUIC_V1)
+	return err == nil
+}
+
+func filterAddrs(addrs []ma.Multiaddr, fn func(addr ma.Multiaddr) bool) (out []ma.Multiaddr) {
+	for _, addr := range addrs {
+		if fn(addr) {
+			out = append(out, addr)
+		}
+	}
+	return out
+}
+
+// TestP2PMixedTransports tests that QUIC-enabled hosts interoperate with TCP-only hosts.
+func TestP2PMixedTransports(t *testing.T) {
+	confA := TestingConfig(t)
+	confA.EnableQUIC = true
+	confA.EnableWebTransport = true
+	confB := TestingConfig(t) // TCP only
+	confC := TestingConfig(t)
+	confC.EnableQUIC = true
+
+	hostA, err := confA.Host(testlog.Logger(t, log.LvlError).New("host", "A"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host A")
+	defer hostA.Close()
+	hostB, err := confB.Host(testlog.Logger(t, log.LvlError).New("host", "B"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host B")
+	defer hostB.Close()
+	hostC, err := confC.Host(testlog.Logger(t, log.LvlError).New("host", "C"), nil, metrics.NoopMetrics)
+	require.NoError(t, err, "failed to launch host C")
+	defer hostC.Close()
+
+	quicPortA, err := FindActiveQUICPort(hostA)
+	require.NoError(t, err)
+	require.NotZero(t, quicPortA, "host A listens on QUIC")
+	quicPortB, err := FindActiveQUICPort(hostB)
+	require.NoError(t, err)
+	require.Zero(t, quicPortB, "host B does not listen on QUIC")
+	require.NotEmpty(t, filterAddrs(hostA.Addrs(), func(addr ma.Multiaddr) bool {
+		_, err := addr.ValueForProtocol(ma.P_WEBTRANSPORT)
+		return err == nil
+	}), "host A listens on WebTransport")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	// B can only dial the TCP address of A
+	require.NoError(t, hostB.Connect(ctx, peer.AddrInfo{ID: hostA.ID(), Addrs: hostA.Addrs()}))
+	connsBA := hostB.Network().ConnsToPeer(hostA.ID())
+	require.Len(t, connsBA, 1)
+	require.False(t, isQUICAddr(connsBA[0].RemoteMultiaddr()), "B connects to A over TCP")
+
+	// C connects to A over QUIC
+	quicAddrsA := filterAddrs(hostA.Addrs(), isQUICAddr)
+	require.NoError(t, hostC.Connect(ctx, peer.AddrInfo{ID: hostA.ID(), Addrs: quicAddrsA}))
+	connsCA := hostC.Network().ConnsToPeer(hostA.ID())
+	require.Len(t, connsCA, 1)
+	require.True(t, isQUICAddr(connsCA[0].RemoteMultiaddr()), "C connects to A over QUIC")
+	require.Equal(t, network.Connected, hostA.Network().Connectedness(hostC.ID()))
+
+	// Gossip from C reaches B through A, across the two transports
+	const topicName = "mixed-transports"
+	topics := make([]*pubsub.Topic, 3)
+	for i, h := range []host.Host{hostA, hostB, hostC} {
+		ps, err := pubsub.NewGossipSub(ctx, h)
+		require.NoError(t, err)
+		topics[i], err = ps.Join(topicName)
+		require.NoError(t, err)
+	}
+	subB, err := topics[1].Subscribe()
+	require.NoError(t, err)
+	defer subB.Cancel()
+	subA, err := topics[0].Subscribe()
+	require.NoError(t, err)
+	defer subA.Cancel()
+
+	// wait for C to see the subscription of A, and for A to see the subscription of B, before publishing
+	for !slices.Contains(topics[2].ListPeers(), hostA.ID()) || !slices.Contains(topics[0].ListPeers(), hostB.ID()) {
+		select {
+		case <-ctx.Done():
+			t.Fatal("gossip peers did not connect")
+		case <-time.After(time.Millisecond * 50):
+		}
+	}
+	// A only forwards to B once B is in its mesh, which is formed by the gossipsub heartbeat. Re-publish until then.
+	received := make(chan *pubsub.Message, 1)
+	go func() {
+		msg, err := subB.Next(ctx)
+		if err == nil {
+			received <- msg
+		}
+	}()
+	for i := 0; ; i++ {
+		require.NoError(t, topics[2].Publish(ctx, []byte{byte(i)}))
+		select {
+		case <-ctx.Done():
+			t.Fatal("gossip from C did not reach B")
+		case msg := <-received:
+			require.Equal(t, hostC.ID(), msg.GetFrom())
+			return
+		case <-time.After(time.Millisecond * 500):
+		}
+	}
+}
+
+func TestDiscoveryQUICPort(t *testing.T) {
+	conf := TestingConfig(t)
+	conf.NoDiscovery = false
+	conf.EnableQUIC = true
+	conf.AdvertiseIP = net.IP{127, 0, 0, 1}
+	var err error
+	conf.DiscoveryDB, err = enode.OpenDB("")
+	require.NoError(t, err)
+
+	localNode, udpV5, err := conf.Discovery(testlog.Logger(t, log.LvlError), &rollup.Config{L2ChainID: big.NewInt(901)}, 9222, 9333)
+	require.NoError(t, err)
+	defer udpV5.Close()
+
+	var quicPort QUIC
+	require.NoError(t, localNode.Node().Load(&quicPort))
+	require.Equal(t, QUIC(9333), quicPort)
+
+	info, _, err := enrToAddrInfo(localNode.Node())
+	require.NoError(t, err)
+	require.Equal(t, []ma.Multiaddr{
+		ma.StringCast("/ip4/127.0.0.1/tcp/9222"),
+		ma.StringCast("/ip4/127.0.0.1/udp/9333/quic-v1"),
+	}, info.Addrs)
+}
+
 // Most tests should use mocknets instead of using the actual local host network
 func TestP2PMocknet(t *testing.T) {
 	mnet, err := mocknet.FullMeshConnected(3)
@@ -14,6 +14,11 @@ func (_m *GossipMetricer) RecordGossipEvent(evType int32) {
 	_m.Called(evType)
 }
 
+// RecordGossipPolicyViolation provides a mock function with given fields: policy, result
+func (_m *GossipMetricer) RecordGossipPolicyViolation(policy string, result string) {
+	_m.Called(policy, result)
+}
+
 type mockConstructorTestingTNewGossipMetricer interface {
 	mock.TestingT
 	Cleanup(func())
@@ -0,0 +1,246 @@
+package monitor
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/libp2p/go-libp2p/core/peer"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+// maxBanListSize limits the size of a fetched ban list, to not load arbitrarily large responses into memory.
+const maxBanListSize = 10 * 1024 * 1024
+
+// SigningDomainBanListV1 separates ban-list signatures from signatures of other messages by the same key.
+var SigningDomainBanListV1 = [32]byte{31: 2}
+
+// BanList is a list of peer and IP bans, shared with other nodes by publishing it signed by a trusted signer.
+type BanList struct {
+	// Timestamp is the unix time in seconds the list was created at.
+	// A list that is not newer than the last applied list is ignored, so an old list cannot be replayed.
+	Timestamp uint64          `json:"timestamp"`
+	PeerBans  []store.PeerBan `json:"peerBans"`
+	IPBans    []store.IPBan   `json:"ipBans"`
+}
+
+// SignedBanList is the published form of a BanList.
+// The signature commits to the compact JSON encoding of the list, so the list may be re-indented without invalidating it.
+type SignedBanList struct {
+	List      json.RawMessage `json:"list"`
+	Signature hexutil.Bytes   `json:"signature"`
+}
+
+func BanListSigningHash(list []byte) common.Hash {
+	return crypto.Keccak256Hash(SigningDomainBanListV1[:], crypto.Keccak256(list))
+}
+
+func SignBanList(list *BanList, key *ecdsa.PrivateKey) (*SignedBanList, error) {
+	data, err := json.Marshal(list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode ban list: %w", err)
+	}
+	h := BanListSigningHash(data)
+	sig, err := crypto.Sign(h[:], key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign ban list: %w", err)
+	}
+	return &SignedBanList{List: data, Signature: sig}, nil
+}
+
+// Verify checks the list was signed by the given signer, and decodes the list.
+func (s *SignedBanList) Verify(signer common.Address) (*BanList, error) {
+	if len(s.Signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length: %d", len(s.Signature))
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, s.List); err != nil {
+		return nil, fmt.Errorf("invalid ban list: %w", err)
+	}
+	h := BanListSigningHash(compact.Bytes())
+	pub, err := crypto.SigToPub(h[:], s.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	if addr := crypto.PubkeyToAddress(*pub); addr != signer {
+		return nil, fmt.Errorf("ban list signed by %s, expected %s", addr, signer)
+	}
+	var list BanList
+	if err := json.Unmarshal(s.List, &list); err != nil {
+		return nil, fmt.Errorf("invalid ban list: %w", err)
+	}
+	return &list, nil
+}
+
+// BanListConfig configures the subscription to a shared ban list.
+type BanListConfig struct {
+	// Source is the path or http(s) URL of the signed ban list.
+	Source string
+	// Signer is the address of the key that must have signed the ban list.
+	Signer common.Address
+	// Interval is the interval to fetch the ban list at.
+	Interval time.Duration
+}
+
+func (c *BanListConfig) Check() error {
+	if c.Signer == (common.Address{}) {
+		return errors.New("ban list requires a signer address")
+	}
+	if c.Interval <= 0 {
+		return fmt.Errorf("invalid ban list fetch interval: %s", c.Interval)
+	}
+	return nil
+}
+
+type Banner interface {
+	// BanPeer bans the peer until the specified time and disconnects any existing connections.
+	BanPeer(peer.ID, time.Time) error
+	// BanIP bans the IP address until the specified time and disconnects any existing connections.
+	BanIP(net.IP, time.Time) error
+}
+
+// BanListSubscriber runs a background process to periodically fetch a signed ban list, and apply the bans of it.
+type BanListSubscriber struct {
+	ctx      context.Context
+	cancelFn context.CancelFunc
+	l        log.Logger
+	clock    clock.Clock
+	cfg      BanListConfig
+	banner   Banner
+
+	bgTasks sync.WaitGroup
+
+	// Timestamp of the last applied list, must only be accessed from the background thread
+	lastTimestamp uint64
+}
+
+func NewBanListSubscriber(ctx context.Context, l log.Logger, clock clock.Clock, cfg BanListConfig, banner Banner) *BanListSubscriber {
+	ctx, cancelFn := context.WithCancel(ctx)
+	return &BanListSubscriber{
+		ctx:      ctx,
+		cancelFn: cancelFn,
+		l:        l,
+		clock:    clock,
+		cfg:      cfg,
+		banner:   banner,
+	}
+}
+
+func (s *BanListSubscriber) Start() {
+	s.bgTasks.Add(1)
+	go s.background()
+}
+
+func (s *BanListSubscriber) Stop() {
+	s.cancelFn()
+	s.bgTasks.Wait()
+}
+
+func (s *BanListSubscriber) background() {
+	defer s.bgTasks.Done()
+	ticker := s.clock.NewTicker(s.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := s.update(); err != nil {
+			s.l.Warn("Failed to update shared ban list", "source", s.cfg.Source, "err", err)
+		}
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.Ch():
+		}
+	}
+}
+
+// update fetches and verifies the ban list, and applies the bans of it if it is newer than the last applied list.
+// Bans that expired already are skipped.
+func (s *BanListSubscriber) update() error {
+	data, err := fetchBanList(s.ctx, s.cfg.Source)
+	if err != nil {
+		return err
+	}
+	var signed SignedBanList
+	if err := json.Unmarshal(data, &signed); err != nil {
+		return fmt.Errorf("invalid signed ban list: %w", err)
+	}
+	list, err := signed.Verify(s.cfg.Signer)
+	if err != nil {
+		return err
+	}
+	if list.Timestamp <= s.lastTimestamp {
+		s.l.Debug("Shared ban list is not newer than the last applied list", "timestamp", list.Timestamp, "last", s.lastTimestamp)
+		return nil
+	}
+	now := s.clock.Now()
+	var applied int
+	for _, ban := range list.PeerBans {
+		if ban.Expiry.Before(now) {
+			continue
+		}
+		if err := s.banner.BanPeer(ban.ID, ban.Expiry); err != nil {
+			return fmt.Errorf("banning peer %v: %w", ban.ID, err)
+		}
+		applied++
+	}
+	for _, ban := range list.IPBans {
+		if ban.IP == nil {
+			return errors.New("invalid banned IP")
+		}
+		if ban.Expiry.Before(now) {
+			continue
+		}
+		if err := s.banner.BanIP(ban.IP, ban.Expiry); err != nil {
+			return fmt.Errorf("banning IP %v: %w", ban.IP, err)
+		}
+		applied++
+	}
+	s.lastTimestamp = list.Timestamp
+	s.l.Info("Applied shared ban list", "timestamp", list.Timestamp, "bans", applied)
+	return nil
+}
+
+// fetchBanList reads the ban list from a http(s) URL, or from a local file otherwise.
+func fetchBanList(ctx context.Context, source string) ([]byte, error) {
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read ban list file: %w", err)
+		}
+		return data, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, time.Second*30)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, source, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ban list request: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ban list: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch ban list: status %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBanListSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ban list response: %w", err)
+	}
+	return data, nil
+}
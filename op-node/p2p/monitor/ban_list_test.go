@@ -0,0 +1,134 @@
+package monitor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	clock2 "github.com/ethereum-optimism/optimism/op-service/clock"
+)
+
+type recordingBanner struct {
+	peers map[peer.ID]time.Time
+	ips   map[string]time.Time
+}
+
+func (b *recordingBanner) BanPeer(id peer.ID, expiry time.Time) error {
+	b.peers[id] = expiry
+	return nil
+}
+
+func (b *recordingBanner) BanIP(ip net.IP, expiry time.Time) error {
+	b.ips[ip.String()] = expiry
+	return nil
+}
+
+func testPeerID(t *testing.T) peer.ID {
+	priv, _, err := p2pcrypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+	return id
+}
+
+func writeSignedBanList(t *testing.T, path string, list *BanList) []byte {
+	key, err := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	require.NoError(t, err)
+	signed, err := SignBanList(list, key)
+	require.NoError(t, err)
+	data, err := json.Marshal(signed)
+	require.NoError(t, err)
+	if path != "" {
+		require.NoError(t, os.WriteFile(path, data, 0644))
+	}
+	return data
+}
+
+func banListSetup(t *testing.T, source string) (*BanListSubscriber, *recordingBanner) {
+	key, err := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
+	require.NoError(t, err)
+	cfg := BanListConfig{
+		Source:   source,
+		Signer:   crypto.PubkeyToAddress(key.PublicKey),
+		Interval: time.Minute,
+	}
+	require.NoError(t, cfg.Check())
+	banner := &recordingBanner{peers: make(map[peer.ID]time.Time), ips: make(map[string]time.Time)}
+	clock := clock2.NewDeterministicClock(time.Unix(10000, 0))
+	return NewBanListSubscriber(context.Background(), testlog.Logger(t, log.LvlInfo), clock, cfg, banner), banner
+}
+
+func TestBanListFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	sub, banner := banListSetup(t, path)
+	a, b, c := testPeerID(t), testPeerID(t), testPeerID(t)
+	expiry := time.Unix(20000, 0).UTC()
+	writeSignedBanList(t, path, &BanList{
+		Timestamp: 100,
+		PeerBans:  []store.PeerBan{{ID: a, Expiry: expiry}, {ID: b, Expiry: time.Unix(5000, 0)}},
+		IPBans:    []store.IPBan{{IP: net.IPv4(1, 2, 3, 4), Expiry: expiry}},
+	})
+	require.NoError(t, sub.update())
+	require.Equal(t, map[peer.ID]time.Time{a: expiry}, banner.peers, "expired bans are skipped")
+	require.Equal(t, map[string]time.Time{"1.2.3.4": expiry}, banner.ips)
+
+	// a list that is not newer than the applied list is ignored
+	writeSignedBanList(t, path, &BanList{Timestamp: 100, PeerBans: []store.PeerBan{{ID: c, Expiry: expiry}}})
+	require.NoError(t, sub.update())
+	require.NotContains(t, banner.peers, c)
+
+	writeSignedBanList(t, path, &BanList{Timestamp: 101, PeerBans: []store.PeerBan{{ID: c, Expiry: expiry}}})
+	require.NoError(t, sub.update())
+	require.Contains(t, banner.peers, c)
+}
+
+func TestBanListFromURL(t *testing.T) {
+	a := testPeerID(t)
+	expiry := time.Unix(20000, 0).UTC()
+	data := writeSignedBanList(t, "", &BanList{Timestamp: 1, PeerBans: []store.PeerBan{{ID: a, Expiry: expiry}}})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(data)
+	}))
+	defer srv.Close()
+	sub, banner := banListSetup(t, srv.URL)
+	require.NoError(t, sub.update())
+	require.Equal(t, map[peer.ID]time.Time{a: expiry}, banner.peers)
+}
+
+func TestBanListUntrustedSigner(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	sub, banner := banListSetup(t, path)
+	sub.cfg.Signer[0] ^= 1
+	writeSignedBanList(t, path, &BanList{Timestamp: 1, PeerBans: []store.PeerBan{{ID: testPeerID(t), Expiry: time.Unix(20000, 0)}}})
+	require.ErrorContains(t, sub.update(), "expected")
+	require.Empty(t, banner.peers)
+}
+
+func TestBanListTampered(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bans.json")
+	sub, banner := banListSetup(t, path)
+	data := writeSignedBanList(t, "", &BanList{Timestamp: 1})
+	var signed SignedBanList
+	require.NoError(t, json.Unmarshal(data, &signed))
+	signed.List = json.RawMessage(`{"timestamp":2,"ipBans":[{"ip":"1.2.3.4","expiry":"2030-01-01T00:00:00Z"}]}`)
+	data, err := json.Marshal(&signed)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, data, 0644))
+	require.Error(t, sub.update())
+	require.Empty(t, banner.ips)
+}
@@ -36,6 +36,7 @@ type NodeP2P struct {
 	scorer      Scorer                         // writes score-updates to the peerstore and keeps metrics of score changes
 	connMgr     connmgr.ConnManager            // p2p conn manager, to keep a reliable number of peers, may be nil even with p2p enabled
 	peerMonitor *monitor.PeerMonitor           // peer monitor to disconnect bad peers, may be nil even with p2p enabled
+	banList     *monitor.BanListSubscriber     // subscription to a shared ban list, may be nil even with p2p enabled
 	store       store.ExtendedPeerstore        // peerstore of host, with extra bindings for scoring and banning
 	appScorer   ApplicationScorer
 	log         log.Logger
@@ -123,6 +124,8 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 				// register the sync protocol with libp2p host
 				payloadByNumber := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_number"), n.syncSrv.HandleSyncRequest)
 				n.host.SetStreamHandler(PayloadByNumberProtocolID(rollupCfg.L2ChainID), payloadByNumber)
+				payloadsByRange := MakeStreamHandler(resourcesCtx, log.New("serve", "payloads_by_range"), n.syncSrv.HandleRangeSyncRequest)
+				n.host.SetStreamHandler(PayloadsByRangeProtocolID(rollupCfg.L2ChainID), payloadsByRange)
 			}
 		}
 		n.scorer = NewScorer(rollupCfg, eps, metrics, n.appScorer, log)
@@ -133,7 +136,8 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 		if err != nil {
 			return fmt.Errorf("failed to start gossipsub router: %w", err)
 		}
-		n.gsOut, err = JoinGossip(resourcesCtx, n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn)
+		policies := NewBlockPolicies(log, setup.BlockPolicy(), metrics, n.appScorer)
+		n.gsOut, err = JoinGossip(resourcesCtx, n.host.ID(), n.gs, log, rollupCfg, runCfg, gossipIn, policies)
 		if err != nil {
 			return fmt.Errorf("failed to join blocks gossip topic: %w", err)
 		}
@@ -144,8 +148,13 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 			log.Warn("failed to find what TCP port p2p is binded to", "err", err)
 		}
 
+		quicPort, err := FindActiveQUICPort(n.host)
+		if err != nil {
+			log.Warn("failed to find what QUIC port p2p is binded to", "err", err)
+		}
+
 		// All nil if disabled.
-		n.dv5Local, n.dv5Udp, err = setup.Discovery(log.New("p2p", "discv5"), rollupCfg, tcpPort)
+		n.dv5Local, n.dv5Udp, err = setup.Discovery(log.New("p2p", "discv5"), rollupCfg, tcpPort, quicPort)
 		if err != nil {
 			return fmt.Errorf("failed to start discv5: %w", err)
 		}
@@ -158,6 +167,10 @@ func (n *NodeP2P) init(resourcesCtx context.Context, rollupCfg *rollup.Config, l
 			n.peerMonitor = monitor.NewPeerMonitor(resourcesCtx, log, clock.SystemClock, n, setup.BanThreshold(), setup.BanDuration())
 			n.peerMonitor.Start()
 		}
+		if cfg := setup.BanList(); cfg != nil {
+			n.banList = monitor.NewBanListSubscriber(resourcesCtx, log.New("p2p", "ban_list"), clock.SystemClock, *cfg, n)
+			n.banList.Start()
+		}
 		n.appScorer.start()
 	}
 	return nil
@@ -249,6 +262,9 @@ func (n *NodeP2P) Close() error {
 	if n.peerMonitor != nil {
 		n.peerMonitor.Stop()
 	}
+	if n.banList != nil {
+		n.banList.Stop()
+	}
 	if n.dv5Udp != nil {
 		n.dv5Udp.Close()
 	}
@@ -289,3 +305,25 @@ func FindActiveTCPPort(h host.Host) (uint16, error) {
 	}
 	return tcpPort, nil
 }
+
+// FindActiveQUICPort returns the UDP port of the QUIC transport, or 0 if QUIC is not enabled.
+func FindActiveQUICPort(h host.Host) (uint16, error) {
+	for _, addr := range h.Addrs() {
+		if _, err := addr.ValueForProtocol(ma.P_QUIC_V1); err != nil {
+			continue
+		}
+		if _, err := addr.ValueForProtocol(ma.P_WEBTRANSPORT); err == nil {
+			continue
+		}
+		udpPortStr, err := addr.ValueForProtocol(ma.P_UDP)
+		if err != nil {
+			continue
+		}
+		v, err := strconv.ParseUint(udpPortStr, 10, 16)
+		if err != nil {
+			continue
+		}
+		return uint16(v), nil
+	}
+	return 0, nil
+}
@@ -64,6 +64,19 @@ func LightPeerScoreParams(cfg *rollup.Config) pubsub.PeerScoreParams {
 				InvalidMessageDeliveriesWeight:  -140.4475,
 				InvalidMessageDeliveriesDecay:   ScoreDecay(invalidDecayPeriod, slot),
 			},
+			// Tx preconfirmations are only published while the sequencer includes transactions,
+			// so peers are not penalized for a lack of deliveries, only for invalid ones.
+			txPreconfsTopicV1(cfg): {
+				TopicWeight:                    0.2,
+				TimeInMeshWeight:               MaxInMeshScore / inMeshCap(slot),
+				TimeInMeshQuantum:              slot,
+				TimeInMeshCap:                  inMeshCap(slot),
+				FirstMessageDeliveriesWeight:   0.1,
+				FirstMessageDeliveriesDecay:    ScoreDecay(20*epoch, slot),
+				FirstMessageDeliveriesCap:      100,
+				InvalidMessageDeliveriesWeight: -140.4475,
+				InvalidMessageDeliveriesDecay:  ScoreDecay(invalidDecayPeriod, slot),
+			},
 		},
 		TopicScoreCap: 34,
 		AppSpecificScore: func(p peer.ID) float64 {
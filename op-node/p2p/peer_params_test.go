@@ -65,11 +65,15 @@ func (testSuite *PeerParamsTestSuite) TestGetPeerScoreParams_Light() {
 	scoringParams, err := GetScoringParams("light", &cfg)
 	peerParams := scoringParams.PeerScoring
 	testSuite.NoError(err)
-	// Topics should contain options for block topic
-	testSuite.Len(peerParams.Topics, 1)
+	// Topics should contain options for block and tx preconfirmation topics
+	testSuite.Len(peerParams.Topics, 2)
 	topicParams, ok := peerParams.Topics[blocksTopicV1(&cfg)]
 	testSuite.True(ok, "should have block topic params")
 	testSuite.NotZero(topicParams.TimeInMeshQuantum)
+	preconfsParams, ok := peerParams.Topics[txPreconfsTopicV1(&cfg)]
+	testSuite.True(ok, "should have tx preconfirmations topic params")
+	testSuite.Zero(preconfsParams.MeshMessageDeliveriesWeight, "no penalty for missing tx preconfirmations")
+	testSuite.Negative(preconfsParams.InvalidMessageDeliveriesWeight)
 	testSuite.Equal(peerParams.TopicScoreCap, float64(34))
 	testSuite.Equal(peerParams.AppSpecificWeight, float64(1))
 	testSuite.Equal(peerParams.IPColocationFactorWeight, float64(-35))
@@ -92,6 +96,9 @@ func (testSuite *PeerParamsTestSuite) TestGetPeerScoreParams_Light() {
 	testSuite.Positive(appParams.RejectedPayloadCap)
 	testSuite.Negative(appParams.RejectedPayloadWeight)
 	testSuite.Positive(appParams.RejectedPayloadDecay)
+	testSuite.Positive(appParams.RateLimitedCap)
+	testSuite.Negative(appParams.RateLimitedWeight)
+	testSuite.Positive(appParams.RateLimitedDecay)
 	testSuite.Equal(DecayToZero, appParams.DecayToZero)
 	testSuite.Equal(slot, appParams.DecayInterval)
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/p2p/enr"
 
+	"github.com/ethereum-optimism/optimism/op-node/p2p/monitor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 )
 
@@ -49,7 +50,7 @@ func (p *Prepared) Host(log log.Logger, reporter metrics.Reporter, metrics HostM
 }
 
 // Discovery creates a disc-v5 service. Returns nil, nil, nil if discovery is disabled.
-func (p *Prepared) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16) (*enode.LocalNode, *discover.UDPv5, error) {
+func (p *Prepared) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort uint16, quicPort uint16) (*enode.LocalNode, *discover.UDPv5, error) {
 	if p.LocalNode != nil {
 		dat := OpStackENRData{
 			chainID: rollupCfg.L2ChainID.Uint64(),
@@ -59,6 +60,9 @@ func (p *Prepared) Discovery(log log.Logger, rollupCfg *rollup.Config, tcpPort u
 		if tcpPort != 0 {
 			p.LocalNode.Set(enr.TCP(tcpPort))
 		}
+		if quicPort != 0 {
+			p.LocalNode.Set(QUIC(quicPort))
+		}
 	}
 	return p.LocalNode, p.UDPv5, nil
 }
@@ -69,6 +73,10 @@ func (p *Prepared) ConfigureGossip(rollupCfg *rollup.Config) []pubsub.Option {
 	}
 }
 
+func (p *Prepared) BlockPolicy() *BlockPolicyConfig {
+	return nil
+}
+
 func (p *Prepared) PeerScoringParams() *ScoringParams {
 	return nil
 }
@@ -85,6 +93,10 @@ func (p *Prepared) BanDuration() time.Duration {
 	return 1 * time.Hour
 }
 
+func (p *Prepared) BanList() *monitor.BanListConfig {
+	return nil
+}
+
 func (p *Prepared) Disabled() bool {
 	return false
 }
@@ -59,4 +59,6 @@ type API interface {
 	UnprotectPeer(ctx context.Context, p peer.ID) error
 	ConnectPeer(ctx context.Context, addr string) error
 	DisconnectPeer(ctx context.Context, id peer.ID) error
+	ExportReputation(ctx context.Context) (*store.Reputation, error)
+	ImportReputation(ctx context.Context, rep *store.Reputation) error
 }
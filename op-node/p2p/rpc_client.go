@@ -6,6 +6,8 @@ import (
 
 	"github.com/libp2p/go-libp2p/core/peer"
 
+	"github.com/ethereum-optimism/optimism/op-node/p2p/store"
+
 	"github.com/ethereum/go-ethereum/p2p/enode"
 	"github.com/ethereum/go-ethereum/rpc"
 )
@@ -107,3 +109,13 @@ func (c *Client) ConnectPeer(ctx context.Context, addr string) error {
 func (c *Client) DisconnectPeer(ctx context.Context, id peer.ID) error {
 	return c.c.CallContext(ctx, nil, prefixRPC("disconnectPeer"), id)
 }
+
+func (c *Client) ExportReputation(ctx context.Context) (*store.Reputation, error) {
+	var out *store.Reputation
+	err := c.c.CallContext(ctx, &out, prefixRPC("exportReputation"))
+	return out, err
+}
+
+func (c *Client) ImportReputation(ctx context.Context, rep *store.Reputation) error {
+	return c.c.CallContext(ctx, nil, prefixRPC("importReputation"), rep)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	manet "github.com/multiformats/go-multiaddr/net"
 
 	gcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
@@ -36,6 +37,7 @@ var (
 	ErrDisabledDiscovery   = errors.New("discovery disabled")
 	ErrNoConnectionManager = errors.New("no connection manager")
 	ErrNoConnectionGater   = errors.New("no connection gater")
+	ErrNoExtendedPeerstore = errors.New("no extended peerstore")
 )
 
 type Node interface {
@@ -384,3 +386,54 @@ func (s *APIBackend) DisconnectPeer(_ context.Context, id peer.ID) error {
 	defer recordDur()
 	return s.node.Host().Network().ClosePeer(id)
 }
+
+func (s *APIBackend) ExportReputation(_ context.Context) (*store.Reputation, error) {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_exportReputation")
+	defer recordDur()
+	eps, ok := s.node.Host().Peerstore().(store.ExtendedPeerstore)
+	if !ok {
+		return nil, ErrNoExtendedPeerstore
+	}
+	return eps.ExportReputation()
+}
+
+// ImportReputation imports peer scores and bans, e.g. exported from another node.
+// Active connections to peers and IP addresses that are banned by the import are closed.
+func (s *APIBackend) ImportReputation(_ context.Context, rep *store.Reputation) error {
+	recordDur := s.m.RecordRPCServerRequest("opp2p_importReputation")
+	defer recordDur()
+	h := s.node.Host()
+	eps, ok := h.Peerstore().(store.ExtendedPeerstore)
+	if !ok {
+		return ErrNoExtendedPeerstore
+	}
+	if err := eps.ImportReputation(rep); err != nil {
+		return err
+	}
+	closeBannedConns(s.log, h.Network(), rep.PeerBans, rep.IPBans, time.Now())
+	return nil
+}
+
+// closeBannedConns closes all connections to the given peers and IP addresses that are banned beyond the current time.
+func closeBannedConns(log log.Logger, nw network.Network, peerBans []store.PeerBan, ipBans []store.IPBan, now time.Time) {
+	for _, conn := range nw.Conns() {
+		id := conn.RemotePeer()
+		remoteIP, err := manet.ToIP(conn.RemoteMultiaddr())
+		if err != nil {
+			remoteIP = nil
+		}
+		banned := false
+		for _, ban := range peerBans {
+			banned = banned || (ban.ID == id && ban.Expiry.After(now))
+		}
+		for _, ban := range ipBans {
+			banned = banned || (remoteIP != nil && ban.IP.Equal(remoteIP) && ban.Expiry.After(now))
+		}
+		if !banned {
+			continue
+		}
+		if err := conn.Close(); err != nil {
+			log.Error("failed to close connection to banned peer", "peer", id, "ip", remoteIP, "err", err)
+		}
+	}
+}
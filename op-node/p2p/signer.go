@@ -15,6 +15,9 @@ import (
 
 var SigningDomainBlocksV1 = [32]byte{}
 
+// SigningDomainTxPreconfsV1 separates tx preconfirmation signatures from block signatures.
+var SigningDomainTxPreconfsV1 = [32]byte{31: 1}
+
 type Signer interface {
 	Sign(ctx context.Context, domain [32]byte, chainID *big.Int, encodedMsg []byte) (sig *[65]byte, err error)
 	io.Closer
@@ -39,6 +42,10 @@ func BlockSigningHash(cfg *rollup.Config, payloadBytes []byte) (common.Hash, err
 	return SigningHash(SigningDomainBlocksV1, cfg.L2ChainID, payloadBytes)
 }
 
+func TxPreconfSigningHash(cfg *rollup.Config, preconfBytes []byte) (common.Hash, error) {
+	return SigningHash(SigningDomainTxPreconfsV1, cfg.L2ChainID, preconfBytes)
+}
+
 // LocalSigner is suitable for testing
 type LocalSigner struct {
 	priv   *ecdsa.PrivateKey
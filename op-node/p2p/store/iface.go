@@ -58,10 +58,24 @@ func (i IncrementRejectedPayloads) Apply(rec *scoreRecord) {
 	rec.PeerScores.ReqResp.RejectedPayloads = math.Min(rec.PeerScores.ReqResp.RejectedPayloads+1, i.Cap)
 }
 
+// GossipPolicyScores tracks the gossip messages of a peer that violated the operator-configured gossip policies.
+type GossipPolicyScores struct {
+	RateLimitedBlocks float64 `json:"rateLimitedBlocks"`
+}
+
+type IncrementRateLimitedBlocks struct {
+	Cap float64
+}
+
+func (i IncrementRateLimitedBlocks) Apply(rec *scoreRecord) {
+	rec.PeerScores.GossipPolicy.RateLimitedBlocks = math.Min(rec.PeerScores.GossipPolicy.RateLimitedBlocks+1, i.Cap)
+}
+
 type DecayApplicationScores struct {
 	ValidResponseDecay   float64
 	ErrorResponseDecay   float64
 	RejectedPayloadDecay float64
+	RateLimitedDecay     float64
 	DecayToZero          float64
 }
 
@@ -76,11 +90,13 @@ func (d *DecayApplicationScores) Apply(rec *scoreRecord) {
 	rec.PeerScores.ReqResp.ValidResponses = decay(rec.PeerScores.ReqResp.ValidResponses, d.ValidResponseDecay)
 	rec.PeerScores.ReqResp.ErrorResponses = decay(rec.PeerScores.ReqResp.ErrorResponses, d.ErrorResponseDecay)
 	rec.PeerScores.ReqResp.RejectedPayloads = decay(rec.PeerScores.ReqResp.RejectedPayloads, d.RejectedPayloadDecay)
+	rec.PeerScores.GossipPolicy.RateLimitedBlocks = decay(rec.PeerScores.GossipPolicy.RateLimitedBlocks, d.RateLimitedDecay)
 }
 
 type PeerScores struct {
-	Gossip  GossipScores  `json:"gossip"`
-	ReqResp ReqRespScores `json:"reqResp"`
+	Gossip       GossipScores       `json:"gossip"`
+	ReqResp      ReqRespScores      `json:"reqResp"`
+	GossipPolicy GossipPolicyScores `json:"gossipPolicy"`
 }
 
 // ScoreDatastore defines a type-safe API for getting and setting libp2p peer score information
@@ -120,6 +136,37 @@ type IPBanStore interface {
 	GetIPBanExpiration(ip net.IP) (time.Time, error)
 }
 
+type PeerScoresEntry struct {
+	ID     peer.ID    `json:"id"`
+	Scores PeerScores `json:"scores"`
+}
+
+type PeerBan struct {
+	ID     peer.ID   `json:"id"`
+	Expiry time.Time `json:"expiry"`
+}
+
+type IPBan struct {
+	IP     net.IP    `json:"ip"`
+	Expiry time.Time `json:"expiry"`
+}
+
+// Reputation is a portable snapshot of the peer scores and bans of an extended peerstore,
+// to carry over the reputation of peers to another node.
+type Reputation struct {
+	Scores   []PeerScoresEntry `json:"scores"`
+	PeerBans []PeerBan         `json:"peerBans"`
+	IPBans   []IPBan           `json:"ipBans"`
+}
+
+type ReputationStore interface {
+	// ExportReputation returns the scores of all peers, and all bans that have not expired yet.
+	ExportReputation() (*Reputation, error)
+	// ImportReputation sets the given scores and bans, replacing the existing scores and bans of the same peers and IPs.
+	// Bans that have expired already are ignored.
+	ImportReputation(rep *Reputation) error
+}
+
 // ExtendedPeerstore defines a type-safe API to work with additional peer metadata based on a libp2p peerstore.Peerstore
 type ExtendedPeerstore interface {
 	peerstore.Peerstore
@@ -127,4 +174,5 @@ type ExtendedPeerstore interface {
 	peerstore.CertifiedAddrBook
 	PeerBanStore
 	IPBanStore
+	ReputationStore
 }
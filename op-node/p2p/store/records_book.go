@@ -147,6 +147,35 @@ func (d *recordsBook[K, V]) SetRecord(key K, diff recordDiff[V]) (V, error) {
 	return rec, nil
 }
 
+// forEach calls fn with the datastore key and value of every unexpired record in the store.
+func (d *recordsBook[K, V]) forEach(fn func(key ds.Key, v V) error) error {
+	d.RLock()
+	defer d.RUnlock()
+	results, err := d.store.Query(d.ctx, query.Query{
+		Prefix: d.dsBaseKey.String(),
+	})
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+	for result := range results.Next() {
+		if result.Error != nil {
+			return result.Error
+		}
+		v := d.newRecord()
+		if err := v.UnmarshalBinary(result.Value); err != nil {
+			return fmt.Errorf("invalid value for key %v: %w", result.Key, err)
+		}
+		if d.hasExpired(v) {
+			continue
+		}
+		if err := fn(ds.RawKey(result.Key), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // prune deletes entries from the store that are older than the configured prune expiration.
 // Entries that are eligible for deletion may still be present either because the prune function hasn't yet run or
 // because they are still preserved in the in-memory cache after having been deleted from the database.
@@ -0,0 +1,128 @@
+package store
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/multiformats/go-base32"
+)
+
+// peerIDFromKey decodes the peer ID of a datastore key encoded with peerIDKey.
+func peerIDFromKey(key ds.Key) (peer.ID, error) {
+	data, err := base32.RawStdEncoding.DecodeString(key.BaseNamespace())
+	if err != nil {
+		return "", fmt.Errorf("invalid peer ID key %v: %w", key, err)
+	}
+	return peer.ID(data), nil
+}
+
+type setPeerScores PeerScores
+
+func (s setPeerScores) Apply(rec *scoreRecord) {
+	rec.PeerScores = PeerScores(s)
+}
+
+func (d *scoreBook) exportScores() ([]PeerScoresEntry, error) {
+	var out []PeerScoresEntry
+	err := d.book.forEach(func(key ds.Key, v *scoreRecord) error {
+		id, err := peerIDFromKey(key)
+		if err != nil {
+			return err
+		}
+		out = append(out, PeerScoresEntry{ID: id, Scores: v.PeerScores})
+		return nil
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, err
+}
+
+func (d *peerBanBook) exportBans() ([]PeerBan, error) {
+	now := d.book.clock.Now()
+	var out []PeerBan
+	err := d.book.forEach(func(key ds.Key, v *peerBanRecord) error {
+		expiry := time.Unix(v.Expiry, 0)
+		if expiry.Before(now) {
+			return nil
+		}
+		id, err := peerIDFromKey(key)
+		if err != nil {
+			return err
+		}
+		out = append(out, PeerBan{ID: id, Expiry: expiry})
+		return nil
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out, err
+}
+
+func (d *ipBanBook) exportBans() ([]IPBan, error) {
+	now := d.book.clock.Now()
+	var out []IPBan
+	err := d.book.forEach(func(key ds.Key, v *ipBanRecord) error {
+		expiry := time.Unix(v.Expiry, 0)
+		if expiry.Before(now) {
+			return nil
+		}
+		ip := net.ParseIP(key.BaseNamespace())
+		if ip == nil {
+			return fmt.Errorf("invalid IP key %v", key)
+		}
+		out = append(out, IPBan{IP: ip, Expiry: expiry})
+		return nil
+	})
+	sort.Slice(out, func(i, j int) bool { return out[i].IP.String() < out[j].IP.String() })
+	return out, err
+}
+
+func (s *extendedStore) ExportReputation() (*Reputation, error) {
+	scores, err := s.scoreBook.exportScores()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export peer scores: %w", err)
+	}
+	peerBans, err := s.peerBanBook.exportBans()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export peer bans: %w", err)
+	}
+	ipBans, err := s.ipBanBook.exportBans()
+	if err != nil {
+		return nil, fmt.Errorf("failed to export IP bans: %w", err)
+	}
+	return &Reputation{
+		Scores:   scores,
+		PeerBans: peerBans,
+		IPBans:   ipBans,
+	}, nil
+}
+
+func (s *extendedStore) ImportReputation(rep *Reputation) error {
+	for _, entry := range rep.Scores {
+		if _, err := s.scoreBook.SetScore(entry.ID, setPeerScores(entry.Scores)); err != nil {
+			return fmt.Errorf("failed to import scores of peer %s: %w", entry.ID, err)
+		}
+	}
+	now := s.peerBanBook.book.clock.Now()
+	for _, ban := range rep.PeerBans {
+		if ban.Expiry.Before(now) {
+			continue
+		}
+		if err := s.SetPeerBanExpiration(ban.ID, ban.Expiry); err != nil {
+			return fmt.Errorf("failed to import ban of peer %s: %w", ban.ID, err)
+		}
+	}
+	for _, ban := range rep.IPBans {
+		if ban.IP == nil {
+			return fmt.Errorf("invalid banned IP %q", ban.IP)
+		}
+		if ban.Expiry.Before(now) {
+			continue
+		}
+		if err := s.SetIPBanExpiration(ban.IP, ban.Expiry); err != nil {
+			return fmt.Errorf("failed to import ban of IP %s: %w", ban.IP, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/stretchr/testify/require"
+)
+
+func randomPeerID(t *testing.T) peer.ID {
+	priv, _, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPrivateKey(priv)
+	require.NoError(t, err)
+	return id
+}
+
+func TestExportImportReputation(t *testing.T) {
+	src := createMemoryStore(t)
+	a, b, c, d := randomPeerID(t), randomPeerID(t), randomPeerID(t), randomPeerID(t)
+	scores := PeerScores{
+		Gossip:  GossipScores{Total: -12.5},
+		ReqResp: ReqRespScores{ValidResponses: 3, RejectedPayloads: 1},
+	}
+	setScoreRequired(t, src, a, setPeerScores(scores))
+	setScoreRequired(t, src, b, &GossipScores{Total: 5})
+	expiry := time.Unix(2484924, 0)
+	require.NoError(t, src.SetPeerBanExpiration(c, expiry))
+	require.NoError(t, src.SetPeerBanExpiration(d, time.Unix(0, 0)))
+	require.NoError(t, src.SetIPBanExpiration(net.IPv4(1, 2, 3, 4), expiry))
+	require.NoError(t, src.SetIPBanExpiration(net.ParseIP("2001:db8::1"), expiry))
+
+	rep, err := src.ExportReputation()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []PeerScoresEntry{
+		{ID: a, Scores: scores},
+		{ID: b, Scores: PeerScores{Gossip: GossipScores{Total: 5}}},
+	}, rep.Scores)
+	require.Equal(t, &Reputation{
+		Scores:   rep.Scores,
+		PeerBans: []PeerBan{{ID: c, Expiry: expiry}},
+		IPBans: []IPBan{
+			{IP: net.IPv4(1, 2, 3, 4), Expiry: expiry},
+			{IP: net.ParseIP("2001:db8::1"), Expiry: expiry},
+		},
+	}, rep, "expired bans are not exported")
+
+	// the reputation is carried over to the other node as JSON
+	data, err := json.Marshal(rep)
+	require.NoError(t, err)
+	var decoded Reputation
+	require.NoError(t, json.Unmarshal(data, &decoded))
+
+	dst := createMemoryStore(t)
+	require.NoError(t, dst.ImportReputation(&decoded))
+	imported, err := dst.ExportReputation()
+	require.NoError(t, err)
+	require.Equal(t, rep, imported)
+	assertPeerScores(t, dst, a, scores)
+	banExpiry, err := dst.GetIPBanExpiration(net.IPv4(1, 2, 3, 4))
+	require.NoError(t, err)
+	require.Equal(t, expiry, banExpiry)
+	banExpiry, err = dst.GetPeerBanExpiration(c)
+	require.NoError(t, err)
+	require.Equal(t, expiry, banExpiry)
+}
+
+func TestImportReputationInvalidIP(t *testing.T) {
+	store := createMemoryStore(t)
+	err := store.ImportReputation(&Reputation{IPBans: []IPBan{{Expiry: time.Unix(2484924, 0)}}})
+	require.ErrorContains(t, err, "invalid banned IP")
+}
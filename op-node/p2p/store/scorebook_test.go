@@ -94,30 +94,33 @@ func TestDecayApplicationScores(t *testing.T) {
 		setScoreRequired(t, store, id, IncrementValidResponses{Cap: 100})
 		setScoreRequired(t, store, id, IncrementErrorResponses{Cap: 100})
 		setScoreRequired(t, store, id, IncrementRejectedPayloads{Cap: 100})
+		setScoreRequired(t, store, id, IncrementRateLimitedBlocks{Cap: 100})
 	}
 	assertPeerScores(t, store, id, PeerScores{ReqResp: ReqRespScores{
 		ValidResponses:   10,
 		ErrorResponses:   10,
 		RejectedPayloads: 10,
-	}})
+	}, GossipPolicy: GossipPolicyScores{RateLimitedBlocks: 10}})
 
 	setScoreRequired(t, store, id, &DecayApplicationScores{
 		ValidResponseDecay:   0.8,
 		ErrorResponseDecay:   0.4,
 		RejectedPayloadDecay: 0.5,
+		RateLimitedDecay:     0.6,
 		DecayToZero:          0.1,
 	})
 	assertPeerScores(t, store, id, PeerScores{ReqResp: ReqRespScores{
 		ValidResponses:   10 * 0.8,
 		ErrorResponses:   10 * 0.4,
 		RejectedPayloads: 10 * 0.5,
-	}})
+	}, GossipPolicy: GossipPolicyScores{RateLimitedBlocks: 10 * 0.6}})
 
 	// Should be set to exactly zero when below DecayToZero
 	setScoreRequired(t, store, id, &DecayApplicationScores{
 		ValidResponseDecay:   0.8,
 		ErrorResponseDecay:   0.4,
 		RejectedPayloadDecay: 0.5,
+		RateLimitedDecay:     0.6,
 		DecayToZero:          5,
 	})
 	assertPeerScores(t, store, id, PeerScores{ReqResp: ReqRespScores{
@@ -17,6 +17,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/protocol"
+	"github.com/multiformats/go-multistream"
 	"golang.org/x/time/rate"
 
 	"github.com/ethereum/go-ethereum"
@@ -59,12 +60,22 @@ const (
 	// and eventually kick the peer based on degraded scoring if it's really not serving us well.
 	// TODO(CLI-4009): Use a backoff rather than this mechanism.
 	clientErrRateCost = peerServerBlocksBurst
+	// Do not request or serve more than 64 payloads in a single payloads-by-range request
+	maxPayloadsByRangeCount = 64
+	// a payloads-by-range request is the 32 byte hash of the last block, followed by the little-endian uint64 block count
+	payloadsByRangeRequestSize = 32 + 8
 )
 
 func PayloadByNumberProtocolID(l2ChainID *big.Int) protocol.ID {
 	return protocol.ID(fmt.Sprintf("/opstack/req/payload_by_number/%d/0", l2ChainID))
 }
 
+// PayloadsByRangeProtocolID is the protocol to request a contiguous range of payloads,
+// identified by the hash of the last block, and served in descending order by following the parent-hashes.
+func PayloadsByRangeProtocolID(l2ChainID *big.Int) protocol.ID {
+	return protocol.ID(fmt.Sprintf("/opstack/req/payloads_by_range/%d/0", l2ChainID))
+}
+
 type requestHandlerFn func(ctx context.Context, log log.Logger, stream network.Stream)
 
 func MakeStreamHandler(resourcesCtx context.Context, log log.Logger, fn requestHandlerFn) network.StreamHandler {
@@ -97,6 +108,10 @@ type syncResult struct {
 type peerRequest struct {
 	num uint64
 
+	// hash and count are set for a range request: count payloads, from block num with the given hash downwards.
+	hash  common.Hash
+	count uint64
+
 	complete *atomic.Bool
 }
 
@@ -108,6 +123,7 @@ type inFlightCheck struct {
 
 type SyncClientMetrics interface {
 	ClientPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ClientPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration)
 	PayloadsQuarantineSize(n int)
 }
 
@@ -131,6 +147,8 @@ type SyncPeerScorer interface {
 // - User sends range request: blocks on sync main loop (with ctx timeout)
 // - Main loop processes range request (from high to low), dividing block requests by number between parallel peers.
 //   - The high part of the range has a known block-hash, and is marked as trusted.
+//   - The top of the range, up to maxPayloadsByRangeCount blocks, is requested from a single peer as a contiguous range,
+//     identified by the trusted block-hash. Peers that do not support range requests fetch it block by block instead.
 //   - Once there are no more peers available for buffering requests, we stop the range request processing.
 //   - Every request buffered for a peer is tracked as in-flight, by block number.
 //   - In-flight requests are not repeated
@@ -139,6 +157,7 @@ type SyncPeerScorer interface {
 //
 // - Peers each have their own routine for processing requests.
 //   - They fetch the requested block by number, parse and validate it, and then send it back to the main loop
+//   - Range requests are streamed back block by block. Every block must match the parent-hash of the block before it.
 //   - If peers fail to fetch or process it, or fail to send it back to the main loop within timeout,
 //     then the doRequest returns an error. It then marks the in-flight request as completed.
 //
@@ -191,6 +210,7 @@ type SyncClient struct {
 
 	newStreamFn     newStreamFn
 	payloadByNumber protocol.ID
+	payloadsByRange protocol.ID
 
 	peersLock sync.Mutex
 	// syncing worker per peer
@@ -244,6 +264,7 @@ func NewSyncClient(log log.Logger, cfg *rollup.Config, newStream newStreamFn, rc
 		appScorer:       appScorer,
 		newStreamFn:     newStream,
 		payloadByNumber: PayloadByNumberProtocolID(cfg.L2ChainID),
+		payloadsByRange: PayloadsByRangeProtocolID(cfg.L2ChainID),
 		peers:           make(map[peer.ID]context.CancelFunc),
 		quarantineByNum: make(map[uint64]common.Hash),
 		inFlight:        make(map[uint64]*atomic.Bool),
@@ -390,6 +411,8 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 		}
 	}
 
+	s.scheduleRangeRequest(ctx, log, req)
+
 	// Now try to fetch lower numbers than current end, to traverse back towards the updated start.
 	for i := uint64(0); ; i++ {
 		num := req.end.Number - 1 - i
@@ -427,6 +450,51 @@ func (s *SyncClient) onRangeRequest(ctx context.Context, req rangeRequest) {
 	}
 }
 
+// scheduleRangeRequest schedules a single request for the top of the range, that links to the trusted end of the range.
+// Blocks already in quarantine that link to the end are skipped, the range starts at the first block that is missing.
+func (s *SyncClient) scheduleRangeRequest(ctx context.Context, log log.Logger, req rangeRequest) {
+	num := req.end.Number - 1
+	hash := req.end.ParentHash
+	for ; num > req.start; num-- {
+		h, ok := s.quarantineByNum[num]
+		if !ok {
+			break
+		}
+		res, ok := s.quarantine.Peek(h)
+		if !ok || h != hash {
+			// we cannot link the remaining blocks to the trusted end
+			return
+		}
+		hash = res.payload.ParentHash
+	}
+	var count uint64
+	for count < maxPayloadsByRangeCount && num-count > req.start {
+		if _, ok := s.inFlight[num-count]; ok {
+			break
+		}
+		if _, ok := s.quarantineByNum[num-count]; ok {
+			break
+		}
+		count++
+	}
+	if count == 0 {
+		return
+	}
+	pr := peerRequest{num: num, hash: hash, count: count, complete: new(atomic.Bool)}
+
+	log.Debug("Scheduling P2P block range request", "num", num, "hash", hash, "count", count)
+	select {
+	case s.peerRequests <- pr:
+		for i := uint64(0); i < count; i++ {
+			s.inFlight[num-i] = pr.complete
+		}
+	case <-ctx.Done():
+		log.Info("did not schedule P2P sync range request", "num", num, "err", ctx.Err())
+	default:
+		log.Info("no peers ready to handle P2P block range request", "num", num)
+	}
+}
+
 func (s *SyncClient) onQuarantineEvict(key common.Hash, value syncResult) {
 	delete(s.quarantineByNum, uint64(value.payload.BlockNumber))
 	s.metrics.PayloadsQuarantineSize(s.quarantine.Len())
@@ -513,6 +581,9 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 	// so we don't be too aggressive to the server.
 	rl := rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst)
 
+	// Assume the peer supports range requests, until opening a range request stream fails to negotiate the protocol.
+	rangeSupported := true
+
 	for {
 		// wait for a global allocation to be available
 		if err := s.globalRL.Wait(ctx); err != nil {
@@ -528,39 +599,108 @@ func (s *SyncClient) peerLoop(ctx context.Context, id peer.ID) {
 		case pr := <-s.peerRequests:
 			// We already established the peer is available w.r.t. rate-limiting,
 			// and this is the only loop over this peer, so we can request now.
-			start := time.Now()
-			err := s.doRequest(ctx, id, pr.num)
-			if err != nil {
-				// mark as complete if there's an error: we are not sending any result and can complete immediately.
-				pr.complete.Store(true)
-				log.Warn("failed p2p sync request", "num", pr.num, "err", err)
-				s.appScorer.onResponseError(id)
-				// If we hit an error, then count it as many requests.
-				// We'd like to avoid making more requests for a while, to back off.
-				if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+			if pr.count > 0 && rangeSupported {
+				err := s.requestRange(ctx, log, id, rl, pr)
+				if err == nil {
+					continue
+				}
+				if !errors.Is(err, errRangeUnsupported) {
 					return
 				}
-			} else {
-				log.Debug("completed p2p sync request", "num", pr.num)
-				s.appScorer.onValidResponse(id)
+				log.Debug("peer does not support range requests, requesting blocks by number", "num", pr.num, "count", pr.count)
+				rangeSupported = false
+			}
+			if err := s.requestByNumber(ctx, log, id, rl, pr.num, pr.complete); err != nil {
+				return
 			}
-			took := time.Since(start)
-
-			resultCode := byte(0)
-			if err != nil {
-				if re, ok := err.(requestResultErr); ok {
-					resultCode = re.ResultCode()
-				} else {
-					resultCode = 1
+			// fall back to requesting the remainder of the range by number
+			for i := uint64(1); i < pr.count; i++ {
+				if err := s.globalRL.Wait(ctx); err != nil {
+					return
+				}
+				if err := rl.Wait(ctx); err != nil {
+					return
+				}
+				if err := s.requestByNumber(ctx, log, id, rl, pr.num-i, pr.complete); err != nil {
+					return
 				}
 			}
-			s.metrics.ClientPayloadByNumberEvent(pr.num, resultCode, took)
 		case <-ctx.Done():
 			return
 		}
 	}
 }
 
+// requestByNumber requests a single block from the peer, and applies the result to the peer score and rate-limit.
+// An error is only returned if the peer loop has to stop.
+func (s *SyncClient) requestByNumber(ctx context.Context, log log.Logger, id peer.ID, rl *rate.Limiter, num uint64, complete *atomic.Bool) error {
+	start := time.Now()
+	err := s.doRequest(ctx, id, num)
+	if err != nil {
+		// mark as complete if there's an error: we are not sending any result and can complete immediately.
+		complete.Store(true)
+		log.Warn("failed p2p sync request", "num", num, "err", err)
+		s.appScorer.onResponseError(id)
+		// If we hit an error, then count it as many requests.
+		// We'd like to avoid making more requests for a while, to back off.
+		if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+			return err
+		}
+	} else {
+		log.Debug("completed p2p sync request", "num", num)
+		s.appScorer.onValidResponse(id)
+	}
+	took := time.Since(start)
+	s.metrics.ClientPayloadByNumberEvent(num, requestResultCode(err), took)
+	return nil
+}
+
+// requestRange requests a range of blocks from the peer, and applies the result to the peer score and rate-limit.
+// An error is returned if the peer does not support range requests, or if the peer loop has to stop.
+func (s *SyncClient) requestRange(ctx context.Context, log log.Logger, id peer.ID, rl *rate.Limiter, pr peerRequest) error {
+	start := time.Now()
+	n, err := s.doRangeRequest(ctx, id, pr)
+	if errors.Is(err, errRangeUnsupported) {
+		return err
+	}
+	if uint64(n) < pr.count {
+		// Mark as complete if we did not get all blocks, so the missing blocks can be requested again.
+		pr.complete.Store(true)
+	}
+	if err != nil {
+		log.Warn("failed p2p sync range request", "num", pr.num, "count", pr.count, "received", n, "err", err)
+		s.appScorer.onResponseError(id)
+		if err := rl.WaitN(ctx, clientErrRateCost); err != nil {
+			return err
+		}
+	} else {
+		log.Debug("completed p2p sync range request", "num", pr.num, "count", pr.count, "received", n)
+		s.appScorer.onValidResponse(id)
+	}
+	// The server charges rate-limit tokens per served block, apply the same to the blocks beyond the first one.
+	for i := 1; i < n; i++ {
+		if err := s.globalRL.Wait(ctx); err != nil {
+			return err
+		}
+		if err := rl.Wait(ctx); err != nil {
+			return err
+		}
+	}
+	took := time.Since(start)
+	s.metrics.ClientPayloadsByRangeEvent(n, requestResultCode(err), took)
+	return nil
+}
+
+func requestResultCode(err error) byte {
+	if err == nil {
+		return 0
+	}
+	if re, ok := err.(requestResultErr); ok {
+		return re.ResultCode()
+	}
+	return 1
+}
+
 type requestResultErr byte
 
 func (r requestResultErr) Error() string {
@@ -638,6 +778,130 @@ func (s *SyncClient) doRequest(ctx context.Context, id peer.ID, n uint64) error
 	return nil
 }
 
+var errRangeUnsupported = errors.New("peer does not support payloads by range")
+
+// doRangeRequest requests the range of blocks from the peer, and sends every valid block back to the main loop.
+// The number of blocks received is returned, also if the request fails midway.
+func (s *SyncClient) doRangeRequest(ctx context.Context, id peer.ID, pr peerRequest) (int, error) {
+	// open stream to peer
+	reqCtx, reqCancel := context.WithTimeout(ctx, streamTimeout)
+	str, err := s.newStreamFn(reqCtx, id, s.payloadsByRange)
+	reqCancel()
+	if err != nil {
+		if errors.Is(err, multistream.ErrNotSupported[protocol.ID]{}) {
+			return 0, errRangeUnsupported
+		}
+		return 0, fmt.Errorf("failed to open stream: %w", err)
+	}
+	defer str.Close()
+	// set write timeout (if available)
+	_ = str.SetWriteDeadline(time.Now().Add(clientWriteRequestTimeout))
+	var req [payloadsByRangeRequestSize]byte
+	copy(req[:32], pr.hash[:])
+	binary.LittleEndian.PutUint64(req[32:], pr.count)
+	if _, err := str.Write(req[:]); err != nil {
+		return 0, fmt.Errorf("failed to write range request (%d, %s, %d): %w", pr.num, pr.hash, pr.count, err)
+	}
+	if err := str.CloseWrite(); err != nil {
+		return 0, fmt.Errorf("failed to close writer side while making request: %w", err)
+	}
+
+	expectedHash := pr.hash
+	for i := uint64(0); i < pr.count; i++ {
+		// set read timeout per chunk (if available)
+		_ = str.SetReadDeadline(time.Now().Add(clientReadResponsetimeout))
+		res, err := readPayloadChunk(str)
+		if errors.Is(err, io.EOF) && i > 0 {
+			// the server may end the response early, e.g. when it reaches genesis
+			return int(i), nil
+		}
+		if err != nil {
+			return int(i), err
+		}
+		if err := verifyBlock(res, pr.num-i); err != nil {
+			return int(i), fmt.Errorf("received execution payload is invalid: %w", err)
+		}
+		if res.BlockHash != expectedHash {
+			return int(i), fmt.Errorf("received execution payload %s does not match expected hash %s", res.ID(), expectedHash)
+		}
+		select {
+		case s.results <- syncResult{payload: res, peer: id}:
+		case <-ctx.Done():
+			return int(i), fmt.Errorf("failed to process response, sync client is too busy: %w", ctx.Err())
+		}
+		expectedHash = res.ParentHash
+	}
+	return int(pr.count), nil
+}
+
+// readPayloadChunk reads a single payload chunk of a payloads-by-range response.
+// io.EOF is returned if the stream ended before the chunk started.
+func readPayloadChunk(r io.Reader) (*eth.ExecutionPayload, error) {
+	var result [1]byte
+	if _, err := io.ReadFull(r, result[:]); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to read result part of response chunk: %w", err)
+	}
+	if res := result[0]; res != 0 {
+		return nil, requestResultErr(res)
+	}
+	var header [8]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read header part of response chunk: %w", err)
+	}
+	version := binary.LittleEndian.Uint32(header[:4])
+	if version != 0 {
+		return nil, fmt.Errorf("unrecognized ExecutionPayload version: %d", version)
+	}
+	// payload is SSZ encoded with Snappy block compression, prefixed with the compressed length
+	size := binary.LittleEndian.Uint32(header[4:])
+	if size > maxGossipSize {
+		return nil, fmt.Errorf("response chunk of %d bytes is too large", size)
+	}
+	compressed := make([]byte, size)
+	if _, err := io.ReadFull(r, compressed); err != nil {
+		return nil, fmt.Errorf("failed to read response chunk: %w", err)
+	}
+	// Limit the output of decompression as well (zip-bomb)
+	if n, err := snappy.DecodedLen(compressed); err != nil {
+		return nil, fmt.Errorf("invalid response chunk: %w", err)
+	} else if n > maxGossipSize {
+		return nil, fmt.Errorf("decompressed response chunk of %d bytes is too large", n)
+	}
+	data, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress response chunk: %w", err)
+	}
+	var res eth.ExecutionPayload
+	if err := res.UnmarshalSSZ(uint32(len(data)), bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("failed to decode response chunk: %w", err)
+	}
+	return &res, nil
+}
+
+// writePayloadChunk writes a single successful payload chunk of a payloads-by-range response.
+func writePayloadChunk(w io.Writer, payload *eth.ExecutionPayload) error {
+	var buf bytes.Buffer
+	if _, err := payload.MarshalSSZ(&buf); err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+	compressed := snappy.Encode(nil, buf.Bytes())
+	// 0 - resultCode: success = 0
+	// 1:5 - version: 0
+	// 5:9 - compressed payload size
+	var header [9]byte
+	binary.LittleEndian.PutUint32(header[5:], uint32(len(compressed)))
+	if _, err := w.Write(header[:]); err != nil {
+		return fmt.Errorf("failed to write response chunk header: %w", err)
+	}
+	if _, err := w.Write(compressed); err != nil {
+		return fmt.Errorf("failed to write payload to response chunk: %w", err)
+	}
+	return nil
+}
+
 func verifyBlock(payload *eth.ExecutionPayload, expectedNum uint64) error {
 	// verify L2 block
 	if expectedNum != uint64(payload.BlockNumber) {
@@ -658,10 +922,12 @@ type peerStat struct {
 
 type L2Chain interface {
 	PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error)
+	PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error)
 }
 
 type ReqRespServerMetrics interface {
 	ServerPayloadByNumberEvent(num uint64, resultCode byte, duration time.Duration)
+	ServerPayloadsByRangeEvent(count int, resultCode byte, duration time.Duration)
 }
 
 type ReqRespServer struct {
@@ -712,13 +978,7 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 	resultCode := byte(0)
 	if err != nil {
 		log.Warn("failed to serve p2p sync request", "req", req, "err", err)
-		if errors.Is(err, ethereum.NotFound) {
-			resultCode = 1
-		} else if errors.Is(err, invalidRequestErr) {
-			resultCode = 2
-		} else {
-			resultCode = 3
-		}
+		resultCode = serverResultCode(err)
 		// try to write error code, so the other peer can understand the reason for failure.
 		_, _ = stream.Write([]byte{resultCode})
 	} else {
@@ -729,6 +989,16 @@ func (srv *ReqRespServer) HandleSyncRequest(ctx context.Context, log log.Logger,
 
 var invalidRequestErr = errors.New("invalid request")
 
+func serverResultCode(err error) byte {
+	if errors.Is(err, ethereum.NotFound) {
+		return 1
+	} else if errors.Is(err, invalidRequestErr) {
+		return 2
+	} else {
+		return 3
+	}
+}
+
 func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.Stream) (uint64, error) {
 	peerId := stream.Conn().RemotePeer()
 
@@ -810,3 +1080,127 @@ func (srv *ReqRespServer) handleSyncRequest(ctx context.Context, stream network.
 	}
 	return req, nil
 }
+
+type payloadsByRangeRequest struct {
+	end   common.Hash
+	count uint64
+}
+
+// HandleRangeSyncRequest is a stream handler function to register the L2 unsafe payloads-by-range alt-sync protocol.
+// See MakeStreamHandler to transform this into a LibP2P handler function.
+//
+// The response is streamed as one chunk per payload, starting at the requested block and following the parent-hashes.
+// Every payload is rate-limited like a single payload-by-number request.
+//
+// The caller must Close the stream.
+func (srv *ReqRespServer) HandleRangeSyncRequest(ctx context.Context, log log.Logger, stream network.Stream) {
+	start := time.Now()
+
+	req, served, err := srv.handleRangeSyncRequest(ctx, stream)
+
+	resultCode := byte(0)
+	if err != nil {
+		log.Warn("failed to serve p2p sync range request", "end", req.end, "count", req.count, "served", served, "err", err)
+		resultCode = serverResultCode(err)
+		// try to write error code, so the other peer can understand the reason for failure.
+		_, _ = stream.Write([]byte{resultCode})
+	} else {
+		log.Debug("successfully served sync range response", "end", req.end, "count", req.count, "served", served)
+	}
+	srv.metrics.ServerPayloadsByRangeEvent(served, resultCode, time.Since(start))
+}
+
+func (srv *ReqRespServer) handleRangeSyncRequest(ctx context.Context, stream network.Stream) (req payloadsByRangeRequest, served int, err error) {
+	peerId := stream.Conn().RemotePeer()
+
+	// Set read deadline, if available
+	_ = stream.SetReadDeadline(time.Now().Add(serverReadRequestTimeout))
+
+	// Read the request
+	var data [payloadsByRangeRequestSize]byte
+	if _, err := io.ReadFull(stream, data[:]); err != nil {
+		return req, 0, fmt.Errorf("failed to read range request: %w", err)
+	}
+	if err := stream.CloseRead(); err != nil {
+		return req, 0, fmt.Errorf("failed to close reading-side of a P2P sync range request call: %w", err)
+	}
+	req.end = common.BytesToHash(data[:32])
+	req.count = binary.LittleEndian.Uint64(data[32:])
+	if req.count == 0 || req.count > maxPayloadsByRangeCount {
+		return req, 0, fmt.Errorf("cannot serve range of %d blocks, expected 1 to %d: %w", req.count, maxPayloadsByRangeCount, invalidRequestErr)
+	}
+
+	// find rate limiting data of peer, or add otherwise
+	srv.peerStatsLock.Lock()
+	ps, _ := srv.peerRateLimits.Get(peerId)
+	newPeer := ps == nil
+	if newPeer {
+		ps = &peerStat{
+			Requests: rate.NewLimiter(peerServerBlocksRateLimit, peerServerBlocksBurst),
+		}
+		srv.peerRateLimits.Add(peerId, ps)
+	}
+	srv.peerStatsLock.Unlock()
+
+	// Like payloads by number, only blocks between genesis and the block expected at the current time are served
+	now := uint64(time.Now().Unix())
+	max, err := srv.cfg.TargetBlockNumber(now)
+	if err != nil {
+		return req, 0, fmt.Errorf("cannot determine max target block number to verify request: %w", invalidRequestErr)
+	}
+
+	hash := req.end
+	for i := uint64(0); i < req.count; i++ {
+		// Every block takes a token from the global and peer rate-limiters.
+		// We throttle the peer instead of disconnecting, unless the delay is unreasonable to wait for.
+		waitCtx, cancel := context.WithTimeout(ctx, maxThrottleDelay)
+		err := srv.globalRequestsRL.Wait(waitCtx)
+		if err == nil {
+			if newPeer && i == 0 {
+				// count the hit, but make it delay the next request rather than immediately waiting
+				ps.Requests.Reserve()
+			} else {
+				err = ps.Requests.Wait(waitCtx)
+			}
+		}
+		cancel()
+		if err != nil {
+			return req, served, fmt.Errorf("timed out waiting for sync rate limit: %w", err)
+		}
+
+		payload, err := srv.l2.PayloadByHash(ctx, hash)
+		if err != nil {
+			if errors.Is(err, ethereum.NotFound) {
+				if i > 0 {
+					// the parent is not available (anymore), end the response early
+					return req, served, nil
+				}
+				return req, served, fmt.Errorf("peer requested unknown block by hash: %w", err)
+			}
+			return req, served, fmt.Errorf("failed to retrieve payload to serve to peer: %w", err)
+		}
+		num := uint64(payload.BlockNumber)
+		if num < srv.cfg.Genesis.L2.Number {
+			return req, served, fmt.Errorf("cannot serve request for L2 block %d before genesis %d: %w", num, srv.cfg.Genesis.L2.Number, invalidRequestErr)
+		}
+		if num > max {
+			return req, served, fmt.Errorf("cannot serve request for L2 block %d after max expected block (%v): %w", num, max, invalidRequestErr)
+		}
+		if uint64(payload.Timestamp) > now {
+			return req, served, fmt.Errorf("cannot serve request for L2 block %d with future timestamp %d: %w", num, payload.Timestamp, invalidRequestErr)
+		}
+
+		// Reset the write deadline per chunk, if available, to safely write without blocking on a throttling peer connection
+		_ = stream.SetWriteDeadline(time.Now().Add(serverWriteChunkTimeout))
+		if err := writePayloadChunk(stream, payload); err != nil {
+			return req, served, err
+		}
+		served++
+
+		if num <= srv.cfg.Genesis.L2.Number {
+			break
+		}
+		hash = payload.ParentHash
+	}
+	return req, served, nil
+}
@@ -29,6 +29,11 @@ func (fn mockPayloadFn) PayloadByNumber(_ context.Context, number uint64) (*eth.
 	return fn(number)
 }
 
+// PayloadByHash is not supported by the mock, to serve payloads by number only
+func (fn mockPayloadFn) PayloadByHash(_ context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	return nil, ethereum.NotFound
+}
+
 var _ L2Chain = mockPayloadFn(nil)
 
 type syncTestData struct {
@@ -55,6 +60,24 @@ func (s *syncTestData) addPayload(payload *eth.ExecutionPayload) {
 	s.payloads[uint64(payload.BlockNumber)] = payload
 }
 
+// PayloadByNumber is not supported by the test data, to serve payloads by range only
+func (s *syncTestData) PayloadByNumber(_ context.Context, number uint64) (*eth.ExecutionPayload, error) {
+	return nil, ethereum.NotFound
+}
+
+func (s *syncTestData) PayloadByHash(_ context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	s.RLock()
+	defer s.RUnlock()
+	for _, p := range s.payloads {
+		if p.BlockHash == hash {
+			return p, nil
+		}
+	}
+	return nil, ethereum.NotFound
+}
+
+var _ L2Chain = (*syncTestData)(nil)
+
 func (s *syncTestData) getBlockRef(i uint64) eth.L2BlockRef {
 	s.RLock()
 	defer s.RUnlock()
@@ -131,7 +154,7 @@ func TestSinglePeerSync(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
-	// Setup host A as the server
+	// Setup host A as the server. It only serves payloads by number, so the client has to fall back from range requests.
 	srv := NewReqRespServer(cfg, servePayload, metrics.NoopMetrics)
 	payloadByNumber := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleSyncRequest)
 	hostA.SetStreamHandler(PayloadByNumberProtocolID(cfg.L2ChainID), payloadByNumber)
@@ -288,3 +311,117 @@ func TestMultiPeerSync(t *testing.T) {
 		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
 	}
 }
+
+type responseErrScorer struct {
+	NoopApplicationScorer
+	errs chan peer.ID
+}
+
+func (s *responseErrScorer) onResponseError(id peer.ID) {
+	s.errs <- id
+}
+
+func setupRangeSyncTest(t *testing.T, l2 L2Chain, cfg *rollup.Config, scorer SyncPeerScorer) (*SyncClient, chan *eth.ExecutionPayload) {
+	log := testlog.Logger(t, log.LvlError)
+
+	received := make(chan *eth.ExecutionPayload, 100)
+	receivePayload := receivePayloadFn(func(ctx context.Context, from peer.ID, payload *eth.ExecutionPayload) error {
+		received <- payload
+		return nil
+	})
+
+	mnet, err := mocknet.FullMeshConnected(2)
+	require.NoError(t, err, "failed to setup mocknet")
+	t.Cleanup(func() { _ = mnet.Close() })
+	hosts := mnet.Hosts()
+	hostA, hostB := hosts[0], hosts[1]
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// Setup host A as the server, serving payloads by range only
+	srv := NewReqRespServer(cfg, l2, metrics.NoopMetrics)
+	payloadsByRange := MakeStreamHandler(ctx, log.New("role", "server"), srv.HandleRangeSyncRequest)
+	hostA.SetStreamHandler(PayloadsByRangeProtocolID(cfg.L2ChainID), payloadsByRange)
+
+	// Setup host B as the client
+	cl := NewSyncClient(log.New("role", "client"), cfg, hostB.NewStream, receivePayload, metrics.NoopMetrics, scorer)
+	cl.AddPeer(hostA.ID())
+	cl.Start()
+	t.Cleanup(func() { _ = cl.Close() })
+	return cl, received
+}
+
+func TestRangeSync(t *testing.T) {
+	cfg, payloads := setupSyncTestData(25)
+	cl, received := setupRangeSyncTest(t, payloads, cfg, &NoopApplicationScorer{})
+
+	require.NoError(t, cl.RequestL2Range(context.Background(), payloads.getBlockRef(10), payloads.getBlockRef(20)))
+
+	// The server does not serve payloads by number, so all payloads are received through the single range request
+	for i := uint64(19); i > 10; i-- {
+		p := <-received
+		require.Equal(t, i, uint64(p.BlockNumber), "expecting payloads in order")
+		exp, ok := payloads.getPayload(i)
+		require.True(t, ok, "expecting known payload")
+		require.Equal(t, exp.BlockHash, p.BlockHash, "expecting the correct payload")
+	}
+}
+
+// forkedChain serves a different payload when the payload with the given hash is requested
+type forkedChain struct {
+	*syncTestData
+	hash common.Hash
+	fork *eth.ExecutionPayload
+}
+
+func (c *forkedChain) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	if hash == c.hash {
+		return c.fork, nil
+	}
+	return c.syncTestData.PayloadByHash(ctx, hash)
+}
+
+func TestRangeSyncRejectsBrokenLinkage(t *testing.T) {
+	cfg, payloads := setupSyncTestData(25)
+	// Serve a block 15 that is valid by itself, but is not the parent of block 16
+	orig, _ := payloads.getPayload(15)
+	fork := *orig
+	fork.Timestamp++
+	fork.BlockHash, _ = fork.CheckBlockHash()
+	chain := &forkedChain{syncTestData: payloads, hash: orig.BlockHash, fork: &fork}
+
+	scorer := &responseErrScorer{errs: make(chan peer.ID, 10)}
+	cl, received := setupRangeSyncTest(t, chain, cfg, scorer)
+
+	require.NoError(t, cl.RequestL2Range(context.Background(), payloads.getBlockRef(10), payloads.getBlockRef(20)))
+
+	for i := uint64(19); i > 15; i-- {
+		p := <-received
+		require.Equal(t, i, uint64(p.BlockNumber), "expecting payloads in order")
+	}
+	select {
+	case <-scorer.errs:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the broken range response to be rejected")
+	}
+	require.Zero(t, len(received), "no payloads after the broken link should be accepted")
+}
+
+func TestRangeSyncRejectsFutureBlocks(t *testing.T) {
+	cfg, payloads := setupSyncTestData(25)
+	// Move genesis, so block 12 is the latest block expected at the current time
+	cfg.Genesis.L2Time = uint64(time.Now().Unix()) - 12*cfg.BlockTime
+
+	scorer := &responseErrScorer{errs: make(chan peer.ID, 10)}
+	cl, received := setupRangeSyncTest(t, payloads, cfg, scorer)
+
+	require.NoError(t, cl.RequestL2Range(context.Background(), payloads.getBlockRef(10), payloads.getBlockRef(20)))
+
+	select {
+	case <-scorer.errs:
+	case <-time.After(10 * time.Second):
+		t.Fatal("expected the range request for future blocks to be refused")
+	}
+	require.Zero(t, len(received), "no payloads after the max expected block should be served")
+}
@@ -114,10 +114,16 @@ func StartPayload(ctx context.Context, eng Engine, fc eth.ForkchoiceState, attrs
 	}
 }
 
+// CommitPayloadFn is called with a newly built payload, once the engine accepted the payload, before it is made canonical.
+// If it returns an error, the payload is not made canonical.
+type CommitPayloadFn func(ctx context.Context, payload *eth.ExecutionPayload) error
+
 // ConfirmPayload ends an execution payload building process in the provided Engine, and persists the payload as the canonical head.
 // If updateSafe is true, then the payload will also be recognized as safe-head at the same time.
+// If commit is not nil, the payload has to be committed with it after it is inserted, before it is made canonical.
+// If the commit fails, the inserted payload is left as a non-canonical block, and the canonical head is unchanged.
 // The severity of the error is distinguished to determine whether the payload was valid and can become canonical.
-func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
+func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.ForkchoiceState, id eth.PayloadID, updateSafe bool, commit CommitPayloadFn) (out *eth.ExecutionPayload, errTyp BlockInsertionErrType, err error) {
 	payload, err := eng.GetPayload(ctx, id)
 	if err != nil {
 		// even if it is an input-error (unknown payload ID), it is temporary, since we will re-attempt the full payload building, not just the retrieval of the payload.
@@ -126,7 +132,6 @@ func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.Fork
 	if err := sanityCheckPayload(payload); err != nil {
 		return nil, BlockInsertPayloadErr, err
 	}
-
 	status, err := eng.NewPayload(ctx, payload)
 	if err != nil {
 		return nil, BlockInsertTemporaryErr, fmt.Errorf("failed to insert execution payload: %w", err)
@@ -137,6 +142,11 @@ func ConfirmPayload(ctx context.Context, log log.Logger, eng Engine, fc eth.Fork
 	if status.Status != eth.ExecutionValid {
 		return nil, BlockInsertTemporaryErr, eth.NewPayloadErr(payload, status)
 	}
+	if commit != nil {
+		if err := commit(ctx, payload); err != nil {
+			return nil, BlockInsertTemporaryErr, fmt.Errorf("failed to commit execution payload: %w", err)
+		}
+	}
 
 	fc.HeadBlockHash = payload.BlockHash
 	if updateSafe {
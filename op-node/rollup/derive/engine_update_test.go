@@ -0,0 +1,68 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+func TestConfirmPayloadCommit(t *testing.T) {
+	l1Info, err := L1InfoDepositBytes(0, &testutils.MockBlockInfo{InfoBaseFee: big.NewInt(1)}, eth.SystemConfig{}, false)
+	require.NoError(t, err)
+	payload := &eth.ExecutionPayload{BlockNumber: 10, Transactions: []eth.Data{l1Info}}
+	id := eth.PayloadID{1}
+	valid := &eth.PayloadStatusV1{Status: eth.ExecutionValid}
+
+	t.Run("CommitAfterInsert", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		eng.ExpectGetPayload(id, payload, nil)
+		eng.ExpectNewPayload(payload, valid, nil)
+		fc := &eth.ForkchoiceState{HeadBlockHash: payload.BlockHash}
+		eng.ExpectForkchoiceUpdate(fc, nil, &eth.ForkchoiceUpdatedResult{PayloadStatus: *valid}, nil)
+		commit := func(ctx context.Context, p *eth.ExecutionPayload) error {
+			eng.AssertCalled(t, "NewPayload", payload)
+			eng.AssertNotCalled(t, "ForkchoiceUpdate", fc, (*eth.PayloadAttributes)(nil))
+			return nil
+		}
+		out, _, err := ConfirmPayload(context.Background(), testlog.Logger(t, log.LvlError), eng, eth.ForkchoiceState{}, id, false, commit)
+		require.NoError(t, err)
+		require.Equal(t, payload, out)
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("FailedCommit", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		eng.ExpectGetPayload(id, payload, nil)
+		eng.ExpectNewPayload(payload, valid, nil)
+		commitErr := errors.New("not the leader")
+		commit := func(ctx context.Context, p *eth.ExecutionPayload) error {
+			return commitErr
+		}
+		_, errTyp, err := ConfirmPayload(context.Background(), testlog.Logger(t, log.LvlError), eng, eth.ForkchoiceState{}, id, false, commit)
+		require.ErrorIs(t, err, commitErr)
+		require.Equal(t, BlockInsertTemporaryErr, errTyp)
+		// The payload is inserted, but never made canonical
+		eng.AssertExpectations(t)
+	})
+
+	t.Run("InvalidPayloadNotCommitted", func(t *testing.T) {
+		eng := &testutils.MockEngine{}
+		eng.ExpectGetPayload(id, payload, nil)
+		eng.ExpectNewPayload(payload, &eth.PayloadStatusV1{Status: eth.ExecutionInvalid}, nil)
+		commit := func(ctx context.Context, p *eth.ExecutionPayload) error {
+			t.Fatal("invalid payload must not be committed")
+			return nil
+		}
+		_, errTyp, err := ConfirmPayload(context.Background(), testlog.Logger(t, log.LvlError), eng, eth.ForkchoiceState{}, id, false, commit)
+		require.Error(t, err)
+		require.Equal(t, BlockInsertPayloadErr, errTyp)
+	})
+}
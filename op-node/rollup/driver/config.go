@@ -20,4 +20,8 @@ type Config struct {
 	// SequencerMaxSafeLag is the maximum number of L2 blocks for restricting the distance between L2 safe and unsafe.
 	// Disabled if 0.
 	SequencerMaxSafeLag uint64 `json:"sequencer_max_safe_lag"`
+
+	// SequencerTxPreconfs is true when the sequencer should publish tx preconfirmations of newly built blocks,
+	// before the blocks are inserted and published.
+	SequencerTxPreconfs bool `json:"sequencer_tx_preconfs"`
 }
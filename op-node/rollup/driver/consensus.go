@@ -0,0 +1,155 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+var ErrNotLeader = errors.New("sequencer is not the leader")
+
+// SequencerConsensus is the consensus backend shared by a set of sequencers, of which only one sequences at a time.
+// Only the leader of the set sequences. Every new unsafe block is committed to the shared log before it becomes canonical,
+// so a new leader continues from the latest committed block, and never produces a conflicting unsafe block.
+// The backend is pluggable: LocalConsensus for sequencers in the same process,
+// and RPCConsensus for sequencers in different processes, coordinated by a ConsensusAPI server.
+// Neither is replicated: the availability of the set is bounded by the availability of the process that holds the log.
+type SequencerConsensus interface {
+	// LeaderCh returns a channel that signals whether this sequencer is the leader, whenever the leadership changes.
+	// The current leadership status is signalled first.
+	LeaderCh() <-chan bool
+	// CommitUnsafePayload appends the payload to the log. The payload is inserted into the engine before it is committed,
+	// but only made canonical once it is committed.
+	// It fails if this sequencer is not the leader, or if the payload does not extend the latest committed payload.
+	// Committing the latest committed payload again is a no-op.
+	CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error
+	// LatestUnsafePayload returns the latest committed payload, or nil if nothing was committed yet.
+	LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error)
+	// TransferLeadership hands the leadership over to another sequencer of the set.
+	TransferLeadership(ctx context.Context) error
+}
+
+// LocalConsensus is a SequencerConsensus log shared by sequencers in the same process, e.g. in tests.
+// A single-member LocalConsensus can be used to run a sequencer with a consensus without any other sequencers.
+type LocalConsensus struct {
+	mu      sync.Mutex
+	members []*LocalConsensusMember
+	leader  int
+	latest  *eth.ExecutionPayload
+}
+
+func NewLocalConsensus() *LocalConsensus {
+	return &LocalConsensus{}
+}
+
+// Join adds a new sequencer to the set. The first sequencer to join is the leader.
+func (c *LocalConsensus) Join() *LocalConsensusMember {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	m := &LocalConsensusMember{
+		c:        c,
+		index:    len(c.members),
+		leaderCh: make(chan bool, 1),
+	}
+	c.members = append(c.members, m)
+	m.signal(m.index == c.leader)
+	return m
+}
+
+// LocalConsensusMember is the view of a single sequencer on a LocalConsensus.
+type LocalConsensusMember struct {
+	c        *LocalConsensus
+	index    int
+	leaderCh chan bool
+}
+
+var _ SequencerConsensus = (*LocalConsensusMember)(nil)
+
+// signal replaces any unread leadership status with the given status.
+func (m *LocalConsensusMember) signal(leader bool) {
+	select {
+	case <-m.leaderCh:
+	default:
+	}
+	m.leaderCh <- leader
+}
+
+func (m *LocalConsensusMember) LeaderCh() <-chan bool {
+	return m.leaderCh
+}
+
+func (m *LocalConsensusMember) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	m.c.mu.Lock()
+	defer m.c.mu.Unlock()
+	if m.c.leader != m.index {
+		return ErrNotLeader
+	}
+	if latest := m.c.latest; latest != nil {
+		if latest.BlockHash == payload.BlockHash {
+			return nil
+		}
+		if latest.BlockHash != payload.ParentHash {
+			return fmt.Errorf("payload %s does not extend latest committed payload %s", payload.ID(), latest.ID())
+		}
+	}
+	m.c.latest = payload
+	return nil
+}
+
+func (m *LocalConsensusMember) LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error) {
+	return m.c.latestUnsafePayload(), nil
+}
+
+// TransferLeadership hands the leadership over to the next sequencer that joined the set.
+func (m *LocalConsensusMember) TransferLeadership(ctx context.Context) error {
+	m.c.mu.Lock()
+	defer m.c.mu.Unlock()
+	if m.c.leader != m.index {
+		return ErrNotLeader
+	}
+	if len(m.c.members) < 2 {
+		return errors.New("no other sequencer to transfer leadership to")
+	}
+	m.c.handOverLocked((m.index + 1) % len(m.c.members))
+	return nil
+}
+
+func (c *LocalConsensus) latestUnsafePayload() *eth.ExecutionPayload {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.latest
+}
+
+func (c *LocalConsensus) isLeader(index int) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.leader == index
+}
+
+// handOverFromDead hands the leadership over to the next live member that joined the set, if the leader is not live.
+// It returns the index of the new leader, or false if the leadership did not change.
+func (c *LocalConsensus) handOverFromDead(live func(index int) bool) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.members) == 0 || live(c.leader) {
+		return 0, false
+	}
+	for i := 1; i < len(c.members); i++ {
+		next := (c.leader + i) % len(c.members)
+		if live(next) {
+			c.handOverLocked(next)
+			return next, true
+		}
+	}
+	return 0, false
+}
+
+// handOverLocked makes the member at the given index the leader. The caller must hold the lock.
+func (c *LocalConsensus) handOverLocked(index int) {
+	c.members[c.leader].signal(false)
+	c.leader = index
+	c.members[index].signal(true)
+}
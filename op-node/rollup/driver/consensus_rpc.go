@@ -0,0 +1,221 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// ConsensusAPI serves a LocalConsensus to sequencers that run in other processes,
+// which join it with RPCConsensus. It is served in the "consensus" RPC namespace.
+//
+// The consensus is held in memory of the serving process, and is not replicated.
+// The leadership fails over between the sequencers, but the serving process is a single point of failure:
+// while it is down no sequencer is the leader, and the consensus log is lost when it restarts.
+// This is not a high-availability setup of the sequencer by itself.
+//
+// Remote members hold a lease, which they renew by polling their leadership status.
+// If the leader does not renew its lease in time, e.g. because its process died,
+// the leadership is handed over to the next member with a live lease.
+// Members that join in the serving process hold a lease for as long as the process runs.
+type ConsensusAPI struct {
+	log          log.Logger
+	c            *LocalConsensus
+	leaseTimeout time.Duration
+	now          func() time.Time
+
+	mu      sync.Mutex
+	members []*consensusLease
+}
+
+type consensusLease struct {
+	member *LocalConsensusMember
+	// local members never expire
+	local   bool
+	renewed time.Time
+}
+
+func NewConsensusAPI(log log.Logger, c *LocalConsensus, leaseTimeout time.Duration) *ConsensusAPI {
+	return &ConsensusAPI{
+		log:          log,
+		c:            c,
+		leaseTimeout: leaseTimeout,
+		now:          time.Now,
+	}
+}
+
+// JoinLocal adds a sequencer of the serving process to the set.
+func (api *ConsensusAPI) JoinLocal() SequencerConsensus {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	return api.join(true).member
+}
+
+// Join adds a remote sequencer to the set, and returns its member index.
+func (api *ConsensusAPI) Join(ctx context.Context) (uint64, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	lease := api.join(false)
+	api.log.Info("Sequencer joined consensus", "member", lease.member.index)
+	return uint64(lease.member.index), nil
+}
+
+func (api *ConsensusAPI) join(local bool) *consensusLease {
+	lease := &consensusLease{member: api.c.Join(), local: local, renewed: api.now()}
+	api.members = append(api.members, lease)
+	return lease
+}
+
+// IsLeader renews the lease of the member, and returns whether it is the leader.
+func (api *ConsensusAPI) IsLeader(ctx context.Context, member uint64) (bool, error) {
+	m, err := api.renew(member)
+	if err != nil {
+		return false, err
+	}
+	return api.c.isLeader(m.index), nil
+}
+
+func (api *ConsensusAPI) CommitUnsafePayload(ctx context.Context, member uint64, payload *eth.ExecutionPayload) error {
+	m, err := api.renew(member)
+	if err != nil {
+		return err
+	}
+	return m.CommitUnsafePayload(ctx, payload)
+}
+
+func (api *ConsensusAPI) LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error) {
+	return api.c.latestUnsafePayload(), nil
+}
+
+func (api *ConsensusAPI) TransferLeadership(ctx context.Context, member uint64) error {
+	m, err := api.renew(member)
+	if err != nil {
+		return err
+	}
+	return m.TransferLeadership(ctx)
+}
+
+// renew renews the lease of the member, and hands the leadership over if the lease of the leader expired.
+func (api *ConsensusAPI) renew(member uint64) (*LocalConsensusMember, error) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+	if member >= uint64(len(api.members)) {
+		return nil, fmt.Errorf("unknown consensus member %d", member)
+	}
+	now := api.now()
+	lease := api.members[member]
+	lease.renewed = now
+	live := func(index int) bool {
+		l := api.members[index]
+		return l.local || now.Sub(l.renewed) < api.leaseTimeout
+	}
+	if leader, ok := api.c.handOverFromDead(live); ok {
+		api.log.Warn("Lease of consensus leader expired, handed over leadership", "leader", leader)
+	}
+	return lease.member, nil
+}
+
+// consensusPollInterval is the interval at which RPCConsensus polls the leadership status, renewing its lease.
+// It has to be well below the lease timeout of the ConsensusAPI.
+const consensusPollInterval = time.Second
+
+// RPCConsensus is a SequencerConsensus backed by a ConsensusAPI in another process,
+// to run sequencers that share a consensus in different processes.
+// It depends on the availability of the ConsensusAPI process.
+//
+// If the leadership status cannot be retrieved, the sequencer steps down,
+// since its lease expires if the ConsensusAPI does not hear from it.
+type RPCConsensus struct {
+	log    log.Logger
+	rpc    client.RPC
+	member uint64
+
+	leaderCh chan bool
+
+	closed chan struct{}
+	wg     sync.WaitGroup
+}
+
+var _ SequencerConsensus = (*RPCConsensus)(nil)
+
+// NewRPCConsensus joins the consensus served over the RPC, and follows the leadership status until it is closed.
+func NewRPCConsensus(ctx context.Context, log log.Logger, rpc client.RPC) (*RPCConsensus, error) {
+	var member uint64
+	if err := rpc.CallContext(ctx, &member, "consensus_join"); err != nil {
+		return nil, fmt.Errorf("failed to join sequencer consensus: %w", err)
+	}
+	c := &RPCConsensus{
+		log:      log.New("member", member),
+		rpc:      rpc,
+		member:   member,
+		leaderCh: make(chan bool, 1),
+		closed:   make(chan struct{}),
+	}
+	c.wg.Add(1)
+	go c.pollLeader()
+	return c, nil
+}
+
+func (c *RPCConsensus) pollLeader() {
+	defer c.wg.Done()
+	ticker := time.NewTicker(consensusPollInterval)
+	defer ticker.Stop()
+	first := true
+	leader := false
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), consensusPollInterval)
+		var isLeader bool
+		err := c.rpc.CallContext(ctx, &isLeader, "consensus_isLeader", c.member)
+		cancel()
+		if err != nil {
+			c.log.Warn("Failed to retrieve sequencer leadership status", "err", err)
+			isLeader = false
+		}
+		if first || isLeader != leader {
+			first = false
+			leader = isLeader
+			// replace any unread status
+			select {
+			case <-c.leaderCh:
+			default:
+			}
+			c.leaderCh <- leader
+		}
+		select {
+		case <-ticker.C:
+		case <-c.closed:
+			return
+		}
+	}
+}
+
+func (c *RPCConsensus) LeaderCh() <-chan bool {
+	return c.leaderCh
+}
+
+func (c *RPCConsensus) CommitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return c.rpc.CallContext(ctx, nil, "consensus_commitUnsafePayload", c.member, payload)
+}
+
+func (c *RPCConsensus) LatestUnsafePayload(ctx context.Context) (*eth.ExecutionPayload, error) {
+	var payload *eth.ExecutionPayload
+	err := c.rpc.CallContext(ctx, &payload, "consensus_latestUnsafePayload")
+	return payload, err
+}
+
+func (c *RPCConsensus) TransferLeadership(ctx context.Context) error {
+	return c.rpc.CallContext(ctx, nil, "consensus_transferLeadership", c.member)
+}
+
+// Close stops following the leadership status, and closes the RPC.
+func (c *RPCConsensus) Close() {
+	close(c.closed)
+	c.wg.Wait()
+	c.rpc.Close()
+}
@@ -0,0 +1,132 @@
+package driver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+func newConsensusAPITest(t *testing.T) (*ConsensusAPI, func() client.RPC) {
+	api := NewConsensusAPI(testlog.Logger(t, log.LvlError), NewLocalConsensus(), time.Second*5)
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("consensus", api))
+	t.Cleanup(srv.Stop)
+	return api, func() client.RPC {
+		return client.NewBaseRPCClient(rpc.DialInProc(srv))
+	}
+}
+
+func TestRPCConsensus(t *testing.T) {
+	ctx := context.Background()
+	_, dial := newConsensusAPITest(t)
+
+	a, err := NewRPCConsensus(ctx, testlog.Logger(t, log.LvlError), dial())
+	require.NoError(t, err)
+	t.Cleanup(a.Close)
+	b, err := NewRPCConsensus(ctx, testlog.Logger(t, log.LvlError), dial())
+	require.NoError(t, err)
+	t.Cleanup(b.Close)
+	require.True(t, <-a.LeaderCh(), "first member is leader")
+	require.False(t, <-b.LeaderCh())
+
+	latest, err := b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latest, "nothing committed yet")
+
+	first := testPayload(testBlockHash(99), 100)
+	require.NoError(t, a.CommitUnsafePayload(ctx, first))
+	require.ErrorContains(t, b.CommitUnsafePayload(ctx, testPayload(first.BlockHash, 101)), ErrNotLeader.Error())
+	latest, err = b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, first.BlockHash, latest.BlockHash)
+
+	require.NoError(t, a.TransferLeadership(ctx))
+	require.True(t, <-b.LeaderCh(), "leadership status is polled")
+	require.False(t, <-a.LeaderCh())
+	require.NoError(t, b.CommitUnsafePayload(ctx, testPayload(first.BlockHash, 101)))
+}
+
+func TestConsensusAPILeaseExpiry(t *testing.T) {
+	ctx := context.Background()
+	api, _ := newConsensusAPITest(t)
+	now := time.Unix(1000, 0)
+	api.now = func() time.Time { return now }
+
+	a, err := api.Join(ctx)
+	require.NoError(t, err)
+	b, err := api.Join(ctx)
+	require.NoError(t, err)
+	c, err := api.Join(ctx)
+	require.NoError(t, err)
+
+	now = now.Add(time.Second * 4)
+	isLeader, err := api.IsLeader(ctx, b)
+	require.NoError(t, err)
+	require.False(t, isLeader, "lease of the leader did not expire yet")
+
+	// The leader a and member c do not renew their leases
+	now = now.Add(time.Second * 2)
+	isLeader, err = api.IsLeader(ctx, b)
+	require.NoError(t, err)
+	require.True(t, isLeader, "leadership is handed over to the next live member")
+	isLeader, err = api.IsLeader(ctx, a)
+	require.NoError(t, err)
+	require.False(t, isLeader)
+	require.ErrorContains(t, api.CommitUnsafePayload(ctx, a, testPayload(testBlockHash(99), 100)), ErrNotLeader.Error())
+	_, err = api.IsLeader(ctx, c+1)
+	require.ErrorContains(t, err, "unknown")
+
+	// Local members never expire
+	local := api.JoinLocal()
+	require.False(t, <-local.LeaderCh())
+	require.NoError(t, api.TransferLeadership(ctx, b)) // hands over to c, which is not live
+	now = now.Add(time.Hour)
+	isLeader, err = api.IsLeader(ctx, b)
+	require.NoError(t, err)
+	require.False(t, isLeader)
+	require.True(t, <-local.LeaderCh(), "local member takes over from expired members")
+}
+
+// fakeUnsafeHead is a DerivationPipeline that only tracks the unsafe head.
+type fakeUnsafeHead struct {
+	DerivationPipeline
+	unsafe eth.L2BlockRef
+}
+
+func (f *fakeUnsafeHead) UnsafeL2Head() eth.L2BlockRef {
+	return f.unsafe
+}
+
+func TestLeaderReady(t *testing.T) {
+	c := NewLocalConsensus()
+	leader := c.Join()
+	derivation := &fakeUnsafeHead{unsafe: eth.L2BlockRef{Number: 9}}
+	d := &Driver{
+		consensus:    leader,
+		isLeader:     true,
+		leaderTarget: &eth.BlockID{Hash: testBlockHash(10), Number: 10},
+		derivation:   derivation,
+		driverConfig: &Config{},
+		log:          testlog.Logger(t, log.LvlError),
+	}
+	require.False(t, d.leaderReady(), "has to sync to the target first")
+
+	// The unsafe head may pass the target before it is checked
+	derivation.unsafe = eth.L2BlockRef{Hash: testBlockHash(11), Number: 11}
+	require.True(t, d.leaderReady())
+	require.Equal(t, eth.BlockID{}, *d.leaderTarget, "target is reached")
+
+	// A failed commit syncs to the latest committed block again
+	require.NoError(t, leader.CommitUnsafePayload(context.Background(), testPayload(testBlockHash(11), 12)))
+	require.Error(t, d.commitUnsafePayload(context.Background(), testPayload(testBlockHash(99), 13)))
+	require.Nil(t, d.leaderTarget)
+	require.False(t, d.leaderReady())
+}
@@ -0,0 +1,124 @@
+package driver
+
+import (
+	"context"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+func testBlockHash(num uint64) (out common.Hash) {
+	out[0] = 0xaa
+	binary.BigEndian.PutUint64(out[24:], num)
+	return
+}
+
+func testPayload(parent common.Hash, num uint64) *eth.ExecutionPayload {
+	return &eth.ExecutionPayload{
+		ParentHash:  parent,
+		BlockNumber: eth.Uint64Quantity(num),
+		BlockHash:   testBlockHash(num),
+	}
+}
+
+func TestLocalConsensus(t *testing.T) {
+	ctx := context.Background()
+	c := NewLocalConsensus()
+	a := c.Join()
+	b := c.Join()
+	require.True(t, <-a.LeaderCh(), "first member is leader")
+	require.False(t, <-b.LeaderCh())
+
+	latest, err := a.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Nil(t, latest, "nothing committed yet")
+
+	first := testPayload(testBlockHash(99), 100)
+	require.NoError(t, a.CommitUnsafePayload(ctx, first))
+	require.NoError(t, a.CommitUnsafePayload(ctx, first), "committing the latest payload again is a no-op")
+	require.ErrorIs(t, b.CommitUnsafePayload(ctx, testPayload(first.BlockHash, 101)), ErrNotLeader)
+	require.ErrorContains(t, a.CommitUnsafePayload(ctx, testPayload(testBlockHash(123), 101)), "does not extend")
+
+	require.ErrorIs(t, b.TransferLeadership(ctx), ErrNotLeader)
+	require.NoError(t, a.TransferLeadership(ctx))
+	require.False(t, <-a.LeaderCh())
+	require.True(t, <-b.LeaderCh())
+
+	latest, err = b.LatestUnsafePayload(ctx)
+	require.NoError(t, err)
+	require.Equal(t, first, latest, "new leader sees the payloads committed by the previous leader")
+	require.ErrorIs(t, a.CommitUnsafePayload(ctx, testPayload(first.BlockHash, 101)), ErrNotLeader)
+	require.NoError(t, b.CommitUnsafePayload(ctx, testPayload(first.BlockHash, 101)))
+}
+
+func TestLocalConsensusSingleMember(t *testing.T) {
+	c := NewLocalConsensus()
+	a := c.Join()
+	require.True(t, <-a.LeaderCh())
+	require.ErrorContains(t, a.TransferLeadership(context.Background()), "no other sequencer")
+}
+
+// TestSequencerHandoff checks that a sequencer which lost the leadership while building a block
+// does not make that block canonical, so it does not conflict with the blocks of the new leader.
+func TestSequencerHandoff(t *testing.T) {
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L1: eth.BlockID{Hash: common.Hash{0x01}, Number: 100000},
+			L2: eth.BlockID{Hash: testBlockHash(200000), Number: 200000},
+		},
+		BlockTime: 2,
+	}
+	head := eth.L2BlockRef{Hash: cfg.Genesis.L2.Hash, Number: cfg.Genesis.L2.Number}
+	l1Info, err := derive.L1InfoDepositBytes(0, &testutils.MockBlockInfo{InfoBaseFee: big.NewInt(1)}, eth.SystemConfig{}, false)
+	require.NoError(t, err)
+	engControl := &FakeEngineControl{
+		finalized: head,
+		safe:      head,
+		unsafe:    head,
+		cfg:       cfg,
+		makePayload: func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload {
+			return &eth.ExecutionPayload{
+				ParentHash:   onto.Hash,
+				BlockNumber:  eth.Uint64Quantity(onto.Number) + 1,
+				BlockHash:    testBlockHash(onto.Number + 1),
+				Transactions: []eth.Data{l1Info},
+			}
+		},
+	}
+	engControl.timeNow = func() time.Time { return time.Unix(0, 0) }
+
+	c := NewLocalConsensus()
+	leader := c.Join()
+	follower := c.Join()
+	seq := NewSequencer(testlog.Logger(t, log.LvlError), cfg, engControl, nil, nil, metrics.NoopMetrics)
+	seq.commit = leader.CommitUnsafePayload
+
+	_, err = engControl.StartPayload(context.Background(), head, &eth.PayloadAttributes{}, false)
+	require.NoError(t, err)
+	payload, err := seq.CompleteBuildingBlock(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, payload.ID(), engControl.UnsafeL2Head().ID())
+
+	require.NoError(t, leader.TransferLeadership(context.Background()))
+	_, err = engControl.StartPayload(context.Background(), engControl.UnsafeL2Head(), &eth.PayloadAttributes{}, false)
+	require.NoError(t, err)
+	_, err = seq.CompleteBuildingBlock(context.Background())
+	require.ErrorIs(t, err, ErrNotLeader)
+	require.Equal(t, payload.ID(), engControl.UnsafeL2Head().ID(), "block of former leader must not become canonical")
+
+	latest, err := follower.LatestUnsafePayload(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, payload, latest)
+}
@@ -78,11 +78,15 @@ type SequencerIface interface {
 	PlanNextSequencerAction() time.Duration
 	RunNextSequencerAction(ctx context.Context) (*eth.ExecutionPayload, error)
 	BuildingOnto() eth.L2BlockRef
+	BuildingPayloadID() eth.PayloadID
 }
 
 type Network interface {
 	// PublishL2Payload is called by the driver whenever there is a new payload to publish, synchronously with the driver main loop.
 	PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error
+	// PublishTxPreconfirmation is called by the sequencer for every tx included in the block that it is building,
+	// if tx preconfirmations are enabled.
+	PublishTxPreconfirmation(ctx context.Context, preconf *eth.TxPreconfirmation) error
 }
 
 type AltSync interface {
@@ -110,7 +114,8 @@ type SequencerStateListener interface {
 }
 
 // NewDriver composes an events handler that tracks L1 state, triggers L2 derivation, and optionally sequences new L2 blocks.
-func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, syncCfg *sync.Config) *Driver {
+// If consensus is not nil, the sequencer only sequences while it is the leader of the consensus.
+func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, altSync AltSync, network Network, log log.Logger, snapshotLog log.Logger, metrics Metrics, sequencerStateListener SequencerStateListener, consensus SequencerConsensus, syncCfg *sync.Config) *Driver {
 	l1 = NewMeteredL1Fetcher(l1, metrics)
 	l1State := NewL1State(log, metrics)
 	sequencerConfDepth := NewConfDepth(driverCfg.SequencerConfDepth, l1State.L1Head, l1)
@@ -122,7 +127,7 @@ func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, al
 	meteredEngine := NewMeteredEngine(cfg, engine, metrics, log)
 	sequencer := NewSequencer(log, cfg, meteredEngine, attrBuilder, findL1Origin, metrics)
 
-	return &Driver{
+	d := &Driver{
 		l1State:          l1State,
 		derivation:       derivationPipeline,
 		stateReq:         make(chan chan struct{}),
@@ -131,6 +136,7 @@ func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, al
 		stopSequencer:    make(chan chan hashAndError, 10),
 		sequencerActive:  make(chan chan bool, 10),
 		sequencerNotifs:  sequencerStateListener,
+		consensus:        consensus,
 		config:           cfg,
 		driverConfig:     driverCfg,
 		done:             make(chan struct{}),
@@ -147,4 +153,12 @@ func NewDriver(driverCfg *Config, cfg *rollup.Config, l2 L2Chain, l1 L1Chain, al
 		unsafeL2Payloads: make(chan *eth.ExecutionPayload, 10),
 		altSync:          altSync,
 	}
+	if driverCfg.SequencerTxPreconfs && network != nil {
+		progress, _ := l2.(PayloadProgressFetcher)
+		d.preconfs = newTxPreconfirmer(log, network, progress, metrics)
+	}
+	if consensus != nil || d.preconfs != nil {
+		sequencer.commit = d.commitUnsafePayload
+	}
+	return d
 }
@@ -63,10 +63,10 @@ func (m *MeteredEngine) StartPayload(ctx context.Context, parent eth.L2BlockRef,
 	return errType, err
 }
 
-func (m *MeteredEngine) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
+func (m *MeteredEngine) ConfirmPayload(ctx context.Context, commit derive.CommitPayloadFn) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
 	sealingStart := time.Now()
 	// Actually execute the block and add it to the head of the chain.
-	payload, errType, err := m.inner.ConfirmPayload(ctx)
+	payload, errType, err := m.inner.ConfirmPayload(ctx, commit)
 	if err != nil {
 		m.metrics.RecordSequencingError()
 		return payload, errType, err
@@ -0,0 +1,115 @@
+package driver
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// txPreconfsInterval is the interval at which the sequencer checks the block it is building for newly included txs.
+const txPreconfsInterval = time.Millisecond * 250
+
+// methodNotFoundCode is the JSON-RPC error code of calls to methods that the server does not serve.
+const methodNotFoundCode = -32601
+
+// PayloadProgressFetcher is implemented by engines that serve the transactions included so far
+// in a payload that is still being built, without sealing it.
+type PayloadProgressFetcher interface {
+	PayloadProgress(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayload, error)
+}
+
+type PreconfsMetrics interface {
+	RecordPublishingError()
+}
+
+type preconfsBlock struct {
+	parent    common.Hash
+	timestamp uint64
+}
+
+// txPreconfirmer publishes a preconfirmation of every non-deposit tx of the blocks built by the sequencer, once per tx.
+// While a block is being built, the txs are preconfirmed as soon as the engine includes them, ahead of sealing the block.
+// Txs that the engine includes last are preconfirmed once the block is sealed, before it is inserted.
+// If the engine does not serve the payload progress, all txs are preconfirmed once the block is sealed.
+type txPreconfirmer struct {
+	log      log.Logger
+	network  Network
+	progress PayloadProgressFetcher // nil if the engine does not serve the payload progress
+	metrics  PreconfsMetrics
+
+	block     preconfsBlock
+	published map[common.Hash]struct{}
+}
+
+func newTxPreconfirmer(log log.Logger, network Network, progress PayloadProgressFetcher, metrics PreconfsMetrics) *txPreconfirmer {
+	return &txPreconfirmer{
+		log:       log,
+		network:   network,
+		progress:  progress,
+		metrics:   metrics,
+		published: make(map[common.Hash]struct{}),
+	}
+}
+
+// onProgress preconfirms the txs included so far in the block that is being built with the given id.
+func (p *txPreconfirmer) onProgress(ctx context.Context, id eth.PayloadID) {
+	if p.progress == nil {
+		return
+	}
+	payload, err := p.progress.PayloadProgress(ctx, id)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode {
+			p.log.Warn("Engine does not serve the payload progress, preconfirming txs once blocks are sealed")
+			p.progress = nil
+			return
+		}
+		p.log.Debug("Failed to fetch progress of block building", "payload_id", id, "err", err)
+		return
+	}
+	p.publish(ctx, payload)
+}
+
+// onSealed preconfirms the txs of the sealed block that were not preconfirmed while it was being built.
+func (p *txPreconfirmer) onSealed(ctx context.Context, payload *eth.ExecutionPayload) {
+	p.publish(ctx, payload)
+}
+
+func (p *txPreconfirmer) publish(ctx context.Context, payload *eth.ExecutionPayload) {
+	block := preconfsBlock{parent: payload.ParentHash, timestamp: uint64(payload.Timestamp)}
+	if block != p.block {
+		p.block = block
+		p.published = make(map[common.Hash]struct{})
+	}
+	for i, tx := range payload.Transactions {
+		// deposits are included by the protocol, not by the sequencer
+		if len(tx) > 0 && tx[0] == types.DepositTxType {
+			continue
+		}
+		txHash := crypto.Keccak256Hash(tx)
+		if _, ok := p.published[txHash]; ok {
+			continue
+		}
+		preconf := &eth.TxPreconfirmation{
+			BlockNumber: payload.BlockNumber,
+			ParentHash:  payload.ParentHash,
+			Timestamp:   payload.Timestamp,
+			TxIndex:     eth.Uint64Quantity(i),
+			TxHash:      txHash,
+		}
+		if err := p.network.PublishTxPreconfirmation(ctx, preconf); err != nil {
+			p.log.Warn("failed to publish tx preconfirmation", "tx", txHash, "err", err)
+			p.metrics.RecordPublishingError()
+			return
+		}
+		p.published[txHash] = struct{}{}
+	}
+}
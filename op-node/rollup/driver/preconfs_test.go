@@ -0,0 +1,118 @@
+package driver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+// recordingNetwork records the published tx preconfirmations to events.
+type recordingNetwork struct {
+	events *[]string
+}
+
+func (n *recordingNetwork) PublishL2Payload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	return nil
+}
+
+func (n *recordingNetwork) PublishTxPreconfirmation(ctx context.Context, preconf *eth.TxPreconfirmation) error {
+	*n.events = append(*n.events, "preconf "+preconf.TxHash.String())
+	return nil
+}
+
+type payloadProgressFn func(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayload, error)
+
+func (fn payloadProgressFn) PayloadProgress(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayload, error) {
+	return fn(ctx, id)
+}
+
+func TestTxPreconfirmations(t *testing.T) {
+	deposit := eth.Data{types.DepositTxType, 0x01}
+	tx1 := eth.Data("tx 1")
+	tx2 := eth.Data("tx 2")
+	preconf := func(tx eth.Data) string {
+		return "preconf " + crypto.Keccak256Hash(tx).String()
+	}
+
+	setup := func(t *testing.T, progress PayloadProgressFetcher) (*Driver, *FakeEngineControl, *[]string) {
+		var events []string
+		cfg := &rollup.Config{Genesis: rollup.Genesis{L2: eth.BlockID{Hash: testBlockHash(1), Number: 1}}}
+		engine := &FakeEngineControl{cfg: cfg, timeNow: time.Now}
+		engine.makePayload = func(onto eth.L2BlockRef, attrs *eth.PayloadAttributes) *eth.ExecutionPayload {
+			events = append(events, "seal")
+			payload := testPayload(onto.Hash, 1)
+			payload.Timestamp = attrs.Timestamp
+			payload.Transactions = []eth.Data{deposit, tx1, tx2}
+			return payload
+		}
+		log := testlog.Logger(t, log.LvlError)
+		d := &Driver{
+			sequencer:    &Sequencer{engine: engine},
+			driverConfig: &Config{SequencerTxPreconfs: true},
+			log:          log,
+			preconfs:     newTxPreconfirmer(log, &recordingNetwork{events: &events}, progress, metrics.NoopMetrics),
+		}
+		return d, engine, &events
+	}
+	build := func(t *testing.T, d *Driver, engine *FakeEngineControl) {
+		ctx := context.Background()
+		_, err := engine.StartPayload(ctx, eth.L2BlockRef{Hash: testBlockHash(0)}, &eth.PayloadAttributes{Timestamp: 10}, false)
+		require.NoError(t, err)
+		// Poll the progress twice while building, as the event loop does
+		for i := 0; i < 2; i++ {
+			id := d.sequencer.BuildingPayloadID()
+			require.NotEqual(t, eth.PayloadID{}, id)
+			d.preconfs.onProgress(ctx, id)
+		}
+		_, _, err = engine.ConfirmPayload(ctx, d.commitUnsafePayload)
+		require.NoError(t, err)
+		require.Equal(t, eth.PayloadID{}, d.sequencer.BuildingPayloadID(), "should not be building after sealing")
+	}
+
+	t.Run("BeforeSealing", func(t *testing.T) {
+		var engine *FakeEngineControl
+		d, engine, events := setup(t, payloadProgressFn(func(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayload, error) {
+			require.Equal(t, engine.buildingID, id)
+			// The engine included the deposit and the first tx so far
+			payload := testPayload(testBlockHash(0), 1)
+			payload.Timestamp = 10
+			payload.Transactions = []eth.Data{deposit, tx1}
+			return payload, nil
+		}))
+		build(t, d, engine)
+		require.Equal(t, []string{preconf(tx1), "seal", preconf(tx2)}, *events,
+			"should preconfirm included txs once, before sealing the block")
+	})
+
+	t.Run("ProgressNotServed", func(t *testing.T) {
+		calls := 0
+		d, engine, events := setup(t, payloadProgressFn(func(ctx context.Context, id eth.PayloadID) (*eth.ExecutionPayload, error) {
+			calls++
+			return nil, &rpcError{code: methodNotFoundCode}
+		}))
+		build(t, d, engine)
+		require.Equal(t, 1, calls, "should stop fetching the progress")
+		require.Equal(t, []string{"seal", preconf(tx1), preconf(tx2)}, *events,
+			"should preconfirm all txs once the block is sealed")
+	})
+}
+
+type rpcError struct {
+	code int
+}
+
+func (e *rpcError) Error() string  { return "rpc error" }
+func (e *rpcError) ErrorCode() int { return e.code }
+
+var _ rpc.Error = (*rpcError)(nil)
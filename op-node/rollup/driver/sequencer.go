@@ -41,6 +41,10 @@ type Sequencer struct {
 
 	metrics SequencerMetrics
 
+	// commit, if not nil, commits every newly built block to the consensus log of the sequencer set,
+	// before the block becomes canonical.
+	commit derive.CommitPayloadFn
+
 	// timeNow enables sequencer testing to mock the time
 	timeNow func() time.Time
 
@@ -107,7 +111,7 @@ func (d *Sequencer) StartBuildingBlock(ctx context.Context) error {
 // Warning: the safe and finalized L2 blocks as viewed during the initiation of the block building are reused for completion of the block building.
 // The Execution engine should not change the safe and finalized blocks between start and completion of block building.
 func (d *Sequencer) CompleteBuildingBlock(ctx context.Context) (*eth.ExecutionPayload, error) {
-	payload, errTyp, err := d.engine.ConfirmPayload(ctx)
+	payload, errTyp, err := d.engine.ConfirmPayload(ctx, d.commit)
 	if err != nil {
 		return nil, fmt.Errorf("failed to complete building block: error (%d): %w", errTyp, err)
 	}
@@ -174,6 +178,15 @@ func (d *Sequencer) BuildingOnto() eth.L2BlockRef {
 	return ref
 }
 
+// BuildingPayloadID returns the ID of the block that the sequencer is building, or a zero ID if it is not building a block.
+func (d *Sequencer) BuildingPayloadID() eth.PayloadID {
+	_, id, safe := d.engine.BuildingPayload()
+	if safe {
+		return eth.PayloadID{}
+	}
+	return id
+}
+
 // RunNextSequencerAction starts new block building work, or seals existing work,
 // and is best timed by first awaiting the delay returned by PlanNextSequencerAction.
 // If a new block is successfully sealed, it will be returned for publishing, nil otherwise.
@@ -73,14 +73,19 @@ func (m *FakeEngineControl) StartPayload(ctx context.Context, parent eth.L2Block
 	return derive.BlockInsertOK, nil
 }
 
-func (m *FakeEngineControl) ConfirmPayload(ctx context.Context) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
+func (m *FakeEngineControl) ConfirmPayload(ctx context.Context, commit derive.CommitPayloadFn) (out *eth.ExecutionPayload, errTyp derive.BlockInsertionErrType, err error) {
 	if m.err != nil {
 		return nil, m.errTyp, m.err
 	}
+	payload := m.makePayload(m.buildingOnto, m.buildingAttrs)
+	if commit != nil {
+		if err := commit(ctx, payload); err != nil {
+			return nil, derive.BlockInsertTemporaryErr, err
+		}
+	}
 	buildTime := m.timeNow().Sub(m.buildingStart)
 	m.totalBuildingTime += buildTime
 	m.totalBuiltBlocks += 1
-	payload := m.makePayload(m.buildingOnto, m.buildingAttrs)
 	ref, err := derive.PayloadToBlockRef(payload, &m.cfg.Genesis)
 	if err != nil {
 		panic(err)
@@ -55,6 +55,17 @@ type Driver struct {
 	// sequencerNotifs is notified when the sequencer is started or stopped
 	sequencerNotifs SequencerStateListener
 
+	// preconfs publishes preconfirmations of the txs of the blocks built by the sequencer, nil if disabled.
+	preconfs *txPreconfirmer
+
+	// consensus is the consensus of the sequencer set, nil if the sequencer does not run with a consensus.
+	consensus SequencerConsensus
+	// isLeader is true while this sequencer is the leader of the consensus.
+	isLeader bool
+	// leaderTarget is the latest committed block, that has to be the unsafe head before the new leader may sequence.
+	// It is nil while it is not yet retrieved, and zeroed once the leader synced to it.
+	leaderTarget *eth.BlockID
+
 	// Rollup config: rollup chain configuration
 	config *rollup.Config
 
@@ -229,12 +240,32 @@ func (s *Driver) eventLoop() {
 	defer altSyncTicker.Stop()
 	lastUnsafeL2 := s.derivation.UnsafeL2Head()
 
+	// With a consensus, follow the leadership changes, and retry catching up with the consensus log on failure.
+	var leaderCh <-chan bool
+	if s.consensus != nil {
+		leaderCh = s.consensus.LeaderCh()
+	}
+	var leaderRetry <-chan time.Time
+
+	// While building a block, preconfirm the txs as soon as the engine includes them.
+	var preconfsTicker <-chan time.Time
+	if s.preconfs != nil {
+		ticker := time.NewTicker(txPreconfsInterval)
+		defer ticker.Stop()
+		preconfsTicker = ticker.C
+	}
+
 	for {
+		// A failed commit drops the target, to sync to the latest committed block again.
+		if s.consensus != nil && s.isLeader && s.leaderTarget == nil && leaderRetry == nil {
+			leaderRetry = time.After(time.Second)
+		}
+
 		// If we are sequencing, and the L1 state is ready, update the trigger for the next sequencer action.
 		// This may adjust at any time based on fork-choice changes or previous errors.
 		// And avoid sequencing if the derivation pipeline indicates the engine is not ready.
 		if s.driverConfig.SequencerEnabled && !s.driverConfig.SequencerStopped &&
-			s.l1State.L1Head() != (eth.L1BlockRef{}) && s.derivation.EngineReady() {
+			s.l1State.L1Head() != (eth.L1BlockRef{}) && s.derivation.EngineReady() && s.leaderReady() {
 			if s.driverConfig.SequencerMaxSafeLag > 0 && s.derivation.SafeL2Head().Number+s.driverConfig.SequencerMaxSafeLag <= s.derivation.UnsafeL2Head().Number {
 				// If the safe head has fallen behind by a significant number of blocks, delay creating new blocks
 				// until the safe lag is below SequencerMaxSafeLag.
@@ -280,6 +311,31 @@ func (s *Driver) eventLoop() {
 				}
 			}
 			planSequencerAction() // schedule the next sequencer action to keep the sequencing looping
+		case <-preconfsTicker:
+			if id := s.sequencer.BuildingPayloadID(); id != (eth.PayloadID{}) {
+				ctx, cancel := context.WithTimeout(ctx, txPreconfsInterval)
+				s.preconfs.onProgress(ctx, id)
+				cancel()
+			}
+		case leader := <-leaderCh:
+			s.isLeader = leader
+			s.leaderTarget = nil
+			leaderRetry = nil
+			if !leader {
+				s.log.Warn("Sequencer lost leadership")
+				continue
+			}
+			s.log.Info("Sequencer gained leadership")
+			if err := s.loadLeaderTarget(ctx); err != nil {
+				s.log.Warn("Failed to load latest committed block, retrying", "err", err)
+				leaderRetry = time.After(time.Second)
+			}
+		case <-leaderRetry:
+			leaderRetry = nil
+			if err := s.loadLeaderTarget(ctx); err != nil {
+				s.log.Warn("Failed to load latest committed block, retrying", "err", err)
+				leaderRetry = time.After(time.Second)
+			}
 		case <-altSyncTicker.C:
 			// Check if there is a gap in the current unsafe payload queue.
 			ctx, cancel := context.WithTimeout(ctx, time.Second*2)
@@ -391,6 +447,73 @@ func (s *Driver) eventLoop() {
 	}
 }
 
+// loadLeaderTarget retrieves the latest committed block, which the new leader has to sync to before sequencing.
+func (s *Driver) loadLeaderTarget(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*10)
+	defer cancel()
+	payload, err := s.consensus.LatestUnsafePayload(ctx)
+	if err != nil {
+		return err
+	}
+	target := eth.BlockID{}
+	if payload != nil {
+		target = payload.ID()
+		if s.derivation.UnsafeL2Head().ID() != target {
+			s.log.Info("Syncing to latest committed block before sequencing", "target", target, "unsafe_l2", s.derivation.UnsafeL2Head())
+			s.derivation.AddUnsafePayload(payload)
+		}
+	}
+	s.leaderTarget = &target
+	return nil
+}
+
+// commitUnsafePayload commits a newly built block to the consensus of the sequencer set, if any,
+// before the block is made canonical, and then preconfirms the txs that were not preconfirmed while it was being built.
+// If the commit fails, e.g. since the block conflicts with a block committed by another sequencer,
+// the sequencer syncs to the latest committed block again before it continues sequencing.
+func (s *Driver) commitUnsafePayload(ctx context.Context, payload *eth.ExecutionPayload) error {
+	if s.consensus != nil {
+		if err := s.consensus.CommitUnsafePayload(ctx, payload); err != nil {
+			s.leaderTarget = nil
+			return err
+		}
+	}
+	if s.preconfs != nil {
+		s.preconfs.onSealed(ctx, payload)
+	}
+	return nil
+}
+
+// leaderReady returns whether the sequencer may sequence as far as the consensus is concerned:
+// it has to be the leader, and its unsafe head has to reach the latest block that was committed when it gained the leadership.
+// The sequencer may always sequence if it does not run with a consensus.
+func (s *Driver) leaderReady() bool {
+	if s.consensus == nil {
+		return true
+	}
+	if !s.isLeader || s.leaderTarget == nil {
+		return false
+	}
+	if *s.leaderTarget != (eth.BlockID{}) {
+		// The unsafe head may already be past the target, e.g. if it received newer blocks of the previous leader.
+		// Building on a head that does not extend the latest committed block fails to commit, and syncs again.
+		if s.derivation.UnsafeL2Head().Number < s.leaderTarget.Number {
+			return false
+		}
+		s.log.Info("Synced to latest committed block, resuming sequencing", "unsafe_l2", s.derivation.UnsafeL2Head())
+		*s.leaderTarget = eth.BlockID{}
+	}
+	return true
+}
+
+// TransferLeadership hands the leadership of the sequencer consensus over to another sequencer.
+func (s *Driver) TransferLeadership(ctx context.Context) error {
+	if s.consensus == nil {
+		return errors.New("sequencer is not running with a consensus")
+	}
+	return s.consensus.TransferLeadership(ctx)
+}
+
 // ResetDerivationPipeline forces a reset of the derivation pipeline.
 // It waits for the reset to occur. It simply unblocks the caller rather
 // than fully cancelling the reset request upon a context cancellation.
@@ -52,15 +52,29 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 
 	l1Endpoint := NewL1EndpointConfig(ctx)
 
-	l2Endpoint, err := NewL2EndpointConfig(ctx, log)
+	lightConfig, err := NewLightConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load l2 endpoints info: %w", err)
+		return nil, fmt.Errorf("failed to load light mode config: %w", err)
+	}
+
+	// the light mode does not run an execution engine, and does not need a JWT secret for it
+	l2Endpoint := &node.L2EndpointConfig{}
+	if !lightConfig.Enabled() {
+		l2Endpoint, err = NewL2EndpointConfig(ctx, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load l2 endpoints info: %w", err)
+		}
 	}
 
 	l2SyncEndpoint := NewL2SyncEndpointConfig(ctx)
 
 	syncConfig := NewSyncConfig(ctx)
 
+	stateSyncConfig, err := NewStateSyncConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load state sync config: %w", err)
+	}
+
 	cfg := &node.Config{
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
@@ -92,6 +106,13 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		},
 		ConfigPersistence: configPersistence,
 		Sync:              *syncConfig,
+		StateSync:         *stateSyncConfig,
+		Light:             *lightConfig,
+		SequencerConsensusRPC: node.SequencerConsensusRPCConfig{
+			ConsensusRPC:    ctx.String(flags.SequencerConsensusRPCFlag.Name),
+			ConsensusServer: ctx.Bool(flags.SequencerConsensusServerFlag.Name),
+			LeaseTimeout:    ctx.Duration(flags.SequencerConsensusLeaseTimeoutFlag.Name),
+		},
 	}
 
 	if err := cfg.LoadPersisted(log); err != nil {
@@ -140,8 +161,10 @@ func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConf
 	}
 
 	return &node.L2EndpointConfig{
-		L2EngineAddr:      l2Addr,
-		L2EngineJWTSecret: secret,
+		L2EngineAddr:         l2Addr,
+		L2EngineJWTSecret:    secret,
+		L2StandbyEngineAddrs: ctx.StringSlice(flags.L2StandbyEngineAddrs.Name),
+		L2EngineRecordPath:   ctx.String(flags.L2EngineRecordPath.Name),
 	}, nil
 }
 
@@ -169,6 +192,7 @@ func NewDriverConfig(ctx *cli.Context) *driver.Config {
 		SequencerEnabled:    ctx.Bool(flags.SequencerEnabledFlag.Name),
 		SequencerStopped:    ctx.Bool(flags.SequencerStoppedFlag.Name),
 		SequencerMaxSafeLag: ctx.Uint64(flags.SequencerMaxSafeLagFlag.Name),
+		SequencerTxPreconfs: ctx.Bool(flags.SequencerTxPreconfsFlag.Name),
 	}
 }
 
@@ -219,6 +243,34 @@ func NewSnapshotLogger(ctx *cli.Context) (log.Logger, error) {
 	return logger, nil
 }
 
+func NewStateSyncConfig(ctx *cli.Context) (*node.StateSyncConfig, error) {
+	cfg := &node.StateSyncConfig{
+		L2NodeAddr:         ctx.String(flags.L2StateSyncRPC.Name),
+		FinalizationPeriod: ctx.Duration(flags.L2StateSyncFinalizationPeriod.Name),
+	}
+	if addr := ctx.String(flags.L2StateSyncL2OutputOracleAddr.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid L2OutputOracle address: %q", addr)
+		}
+		cfg.L2OutputOracleAddr = common.HexToAddress(addr)
+	}
+	return cfg, nil
+}
+
+func NewLightConfig(ctx *cli.Context) (*node.LightConfig, error) {
+	cfg := &node.LightConfig{
+		L2NodeAddr:         ctx.String(flags.LightL2RPC.Name),
+		FinalizationPeriod: ctx.Duration(flags.LightFinalizationPeriod.Name),
+	}
+	if addr := ctx.String(flags.LightL2OutputOracleAddr.Name); addr != "" {
+		if !common.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid L2OutputOracle address: %q", addr)
+		}
+		cfg.L2OutputOracleAddr = common.HexToAddress(addr)
+	}
+	return cfg, nil
+}
+
 func NewSyncConfig(ctx *cli.Context) *sync.Config {
 	return &sync.Config{
 		EngineSync:         ctx.Bool(flags.L2EngineSyncEnabled.Name),
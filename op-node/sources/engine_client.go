@@ -125,3 +125,13 @@ func (s *EngineClient) GetPayload(ctx context.Context, payloadId eth.PayloadID)
 	e.Trace("Received payload")
 	return &result, nil
 }
+
+// PayloadProgress retrieves the transactions included so far in the payload that is being built, without sealing it.
+// This is served by engines with the engine_getPayloadProgressV1 extension of the engine API.
+func (s *EngineClient) PayloadProgress(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	var result eth.ExecutionPayload
+	if err := s.client.CallContext(ctx, &result, "engine_getPayloadProgressV1", payloadId); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,315 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+// DefaultStandbyEngineTimeout is the timeout of engine API calls to the standby engines.
+// It is shorter than the timeouts of the driver, so that standby engines cannot hold up the block processing of the primary.
+const DefaultStandbyEngineTimeout = 2 * time.Second
+
+// engineHealth is the health of an engine, as observed by comparing its engine API results with those of the primary.
+type engineHealth struct {
+	// inSync is true if the engine accepted the latest head of the primary as valid.
+	inSync bool
+	// diverged is true if the engine considered a block invalid that the primary considered valid.
+	// A diverged engine is never promoted to primary.
+	diverged bool
+}
+
+// FailoverEngineClient manages a primary and standby execution engines.
+//
+// Engine API calls that change the chain, engine_newPayload and engine_forkchoiceUpdated,
+// are forwarded to all engines, so the standby engines follow the chain of the primary.
+// Only the primary builds blocks: the standby engines receive forkchoice updates without payload attributes.
+// All other calls are served by the primary.
+//
+// The calls return as soon as the primary answers: the standby engines are called in the background,
+// with their own timeout, and their results are compared with the primary to detect lag and divergence.
+// If the primary fails to process a payload or forkchoice update, e.g. because it is down or times out,
+// the standby results are awaited, and the first standby engine that is in sync with the primary is promoted to primary.
+type FailoverEngineClient struct {
+	log     log.Logger
+	engines []*EngineClient
+
+	standbyTimeout time.Duration
+
+	mu      sync.RWMutex
+	primary int
+	health  []engineHealth
+	// calls is the number of calls forwarded to all engines, to order their health updates.
+	calls uint64
+	// healthCall is the number of the call whose results last updated the health.
+	healthCall uint64
+
+	// background tracks the standby calls that complete after the primary answered.
+	background sync.WaitGroup
+}
+
+// NewFailoverEngineClient creates a client that fails over from the primary to the standby engines, in the given order.
+func NewFailoverEngineClient(log log.Logger, primary *EngineClient, standbys ...*EngineClient) *FailoverEngineClient {
+	engines := append([]*EngineClient{primary}, standbys...)
+	return &FailoverEngineClient{
+		log:            log,
+		engines:        engines,
+		standbyTimeout: DefaultStandbyEngineTimeout,
+		health:         make([]engineHealth, len(engines)),
+	}
+}
+
+// Primary returns the index of the current primary engine: 0 for the initial primary, 1 and up for the standby engines.
+func (f *FailoverEngineClient) Primary() int {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.primary
+}
+
+func (f *FailoverEngineClient) current() *EngineClient {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return f.engines[f.primary]
+}
+
+// beginCall returns the current primary, and the number of a new call to forward to all engines.
+func (f *FailoverEngineClient) beginCall() (primary int, call uint64) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	return f.primary, f.calls
+}
+
+// forAll calls fn for the primary p with ctx, and concurrently for the standby engines, each with its own context
+// that times out after the standby timeout. It returns once the primary call completes,
+// with a function that waits for the standby calls to complete.
+func (f *FailoverEngineClient) forAll(ctx context.Context, p int, fn func(ctx context.Context, i int, e *EngineClient)) (waitStandbys func()) {
+	var wg sync.WaitGroup
+	for i, e := range f.engines {
+		if i == p {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, e *EngineClient) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), f.standbyTimeout)
+			defer cancel()
+			fn(ctx, i, e)
+		}(i, e)
+	}
+	fn(ctx, p, f.engines[p])
+	return wg.Wait
+}
+
+// updateHealthInBackground waits for the standby calls to complete, and then updates their health.
+func (f *FailoverEngineClient) updateHealthInBackground(waitStandbys func(), call uint64, p int, statuses func() []eth.ExecutePayloadStatus, primaryValid bool) {
+	f.background.Add(1)
+	go func() {
+		defer f.background.Done()
+		waitStandbys()
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.updateHealth(call, p, statuses(), primaryValid, nil)
+	}()
+}
+
+// updateHealth compares the status of each standby engine with the valid status of the primary p, as of the given call.
+// It returns the first engine that can be promoted, as indicated by the canPromote filter, or -1 if there is none.
+// The results of a call that completed after a later call, or after a promotion, only detect divergence:
+// whether a standby is in sync is determined by the latest results.
+// The caller must hold the lock.
+func (f *FailoverEngineClient) updateHealth(call uint64, p int, statuses []eth.ExecutePayloadStatus, primaryValid bool, canPromote func(i int) bool) int {
+	stale := call < f.healthCall || p != f.primary
+	if !stale {
+		f.healthCall = call
+	}
+	candidate := -1
+	for i, status := range statuses {
+		if i == p {
+			continue
+		}
+		h := &f.health[i]
+		if stale {
+			if primaryValid && !h.diverged && (status == eth.ExecutionInvalid || status == eth.ExecutionInvalidBlockHash) {
+				h.diverged = true
+				f.log.Error("Standby engine diverged from primary", "engine", i, "primary", p)
+			}
+			continue
+		}
+		switch status {
+		case eth.ExecutionValid:
+			h.inSync = true
+		case eth.ExecutionInvalid, eth.ExecutionInvalidBlockHash:
+			h.inSync = false
+			if primaryValid && !h.diverged {
+				h.diverged = true
+				f.log.Error("Standby engine diverged from primary", "engine", i, "primary", f.primary)
+			}
+		default: // syncing, accepted, or no status at all because of an error
+			if h.inSync && primaryValid {
+				f.log.Warn("Standby engine is lagging behind primary", "engine", i, "status", status)
+			}
+			h.inSync = false
+		}
+		if candidate < 0 && !h.diverged && canPromote != nil && canPromote(i) {
+			candidate = i
+		}
+	}
+	return candidate
+}
+
+// promote makes the given engine the primary. The caller must hold the lock.
+func (f *FailoverEngineClient) promote(i int, reason error) {
+	f.log.Warn("Promoting standby engine to primary", "engine", i, "previous", f.primary, "reason", reason)
+	f.health[f.primary].inSync = false
+	f.primary = i
+}
+
+// NewPayload executes the payload on all engines. If the primary fails to execute it,
+// a standby engine that executed it successfully is promoted to primary, and its result is returned.
+func (f *FailoverEngineClient) NewPayload(ctx context.Context, payload *eth.ExecutionPayload) (*eth.PayloadStatusV1, error) {
+	p, call := f.beginCall()
+	results := make([]*eth.PayloadStatusV1, len(f.engines))
+	errs := make([]error, len(f.engines))
+	waitStandbys := f.forAll(ctx, p, func(ctx context.Context, i int, e *EngineClient) {
+		results[i], errs[i] = e.NewPayload(ctx, payload)
+	})
+	statuses := func() []eth.ExecutePayloadStatus {
+		statuses := make([]eth.ExecutePayloadStatus, len(results))
+		for i, res := range results {
+			if errs[i] == nil {
+				statuses[i] = res.Status
+			}
+		}
+		return statuses
+	}
+	if errs[p] == nil {
+		f.updateHealthInBackground(waitStandbys, call, p, statuses, results[p].Status == eth.ExecutionValid)
+		return results[p], nil
+	}
+
+	waitStandbys()
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	candidate := f.updateHealth(call, p, statuses(), false, func(i int) bool {
+		return errs[i] == nil && results[i].Status == eth.ExecutionValid
+	})
+	if candidate < 0 || f.primary != p {
+		return nil, errs[p]
+	}
+	f.promote(candidate, errs[p])
+	return results[candidate], nil
+}
+
+// ForkchoiceUpdate updates the forkchoice of all engines, and starts building a block with the primary if attributes is not nil.
+// If the primary fails with an RPC error, a standby engine that is in sync is promoted to primary, and the update is retried with it.
+// Invalid forkchoice states or attributes (eth.InputError) are returned as-is, they are not a failure of the primary.
+func (f *FailoverEngineClient) ForkchoiceUpdate(ctx context.Context, fc *eth.ForkchoiceState, attributes *eth.PayloadAttributes) (*eth.ForkchoiceUpdatedResult, error) {
+	p, call := f.beginCall()
+	results := make([]*eth.ForkchoiceUpdatedResult, len(f.engines))
+	errs := make([]error, len(f.engines))
+	waitStandbys := f.forAll(ctx, p, func(ctx context.Context, i int, e *EngineClient) {
+		if i == p {
+			results[i], errs[i] = e.ForkchoiceUpdate(ctx, fc, attributes)
+		} else {
+			results[i], errs[i] = e.ForkchoiceUpdate(ctx, fc, nil)
+		}
+	})
+	statuses := func() []eth.ExecutePayloadStatus {
+		statuses := make([]eth.ExecutePayloadStatus, len(results))
+		for i, res := range results {
+			if errs[i] == nil {
+				statuses[i] = res.PayloadStatus.Status
+			}
+		}
+		return statuses
+	}
+	var inputErr eth.InputError
+	if errs[p] == nil || errors.As(errs[p], &inputErr) {
+		primaryValid := errs[p] == nil && results[p].PayloadStatus.Status == eth.ExecutionValid
+		f.updateHealthInBackground(waitStandbys, call, p, statuses, primaryValid)
+		return results[p], errs[p]
+	}
+
+	waitStandbys()
+	f.mu.Lock()
+	candidate := f.updateHealth(call, p, statuses(), false, func(i int) bool {
+		return errs[i] == nil && results[i].PayloadStatus.Status == eth.ExecutionValid
+	})
+	if candidate < 0 || f.primary != p {
+		f.mu.Unlock()
+		return results[p], errs[p]
+	}
+	f.promote(candidate, errs[p])
+	f.mu.Unlock()
+	if attributes == nil {
+		return results[candidate], nil
+	}
+	// the new primary has the forkchoice state already, but has to start building the block
+	return f.engines[candidate].ForkchoiceUpdate(ctx, fc, attributes)
+}
+
+// GetPayload retrieves the payload from the primary, which is the only engine that builds blocks.
+func (f *FailoverEngineClient) GetPayload(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	return f.current().GetPayload(ctx, payloadId)
+}
+
+// PayloadProgress retrieves the progress of building the payload from the primary, which is the only engine that builds blocks.
+func (f *FailoverEngineClient) PayloadProgress(ctx context.Context, payloadId eth.PayloadID) (*eth.ExecutionPayload, error) {
+	return f.current().PayloadProgress(ctx, payloadId)
+}
+
+func (f *FailoverEngineClient) ChainID(ctx context.Context) (*big.Int, error) {
+	return f.current().ChainID(ctx)
+}
+
+func (f *FailoverEngineClient) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	return f.current().InfoByHash(ctx, hash)
+}
+
+func (f *FailoverEngineClient) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	return f.current().GetProof(ctx, address, storage, blockTag)
+}
+
+func (f *FailoverEngineClient) PayloadByHash(ctx context.Context, hash common.Hash) (*eth.ExecutionPayload, error) {
+	return f.current().PayloadByHash(ctx, hash)
+}
+
+func (f *FailoverEngineClient) PayloadByNumber(ctx context.Context, number uint64) (*eth.ExecutionPayload, error) {
+	return f.current().PayloadByNumber(ctx, number)
+}
+
+func (f *FailoverEngineClient) L2BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	return f.current().L2BlockRefByLabel(ctx, label)
+}
+
+func (f *FailoverEngineClient) L2BlockRefByNumber(ctx context.Context, num uint64) (eth.L2BlockRef, error) {
+	return f.current().L2BlockRefByNumber(ctx, num)
+}
+
+func (f *FailoverEngineClient) L2BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L2BlockRef, error) {
+	return f.current().L2BlockRefByHash(ctx, hash)
+}
+
+func (f *FailoverEngineClient) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error) {
+	return f.current().SystemConfigByL2Hash(ctx, hash)
+}
+
+func (f *FailoverEngineClient) OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error) {
+	return f.current().OutputV0AtBlock(ctx, blockHash)
+}
+
+// Close waits for the pending standby calls, and closes all engines.
+func (f *FailoverEngineClient) Close() {
+	f.background.Wait()
+	for _, e := range f.engines {
+		e.Close()
+	}
+}
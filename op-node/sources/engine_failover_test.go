@@ -0,0 +1,215 @@
+package sources
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type failoverTest struct {
+	rpcs   []*mockRPC
+	client *FailoverEngineClient
+}
+
+func newFailoverTest(t *testing.T, engines int) *failoverTest {
+	logger := testlog.Logger(t, log.LvlError)
+	rpcs := make([]*mockRPC, engines)
+	clients := make([]*EngineClient, engines)
+	for i := range rpcs {
+		rpcs[i] = new(mockRPC)
+		var err error
+		clients[i], err = NewEngineClient(rpcs[i], logger, nil, EngineClientDefaultConfig(&rollup.Config{}))
+		require.NoError(t, err)
+	}
+	return &failoverTest{
+		rpcs:   rpcs,
+		client: NewFailoverEngineClient(logger, clients[0], clients[1:]...),
+	}
+}
+
+func (ft *failoverTest) expectNewPayload(i int, status eth.ExecutePayloadStatus, err error) {
+	ft.rpcs[i].On("CallContext", mock.Anything, mock.Anything, "engine_newPayloadV1", mock.Anything).Once().
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*eth.PayloadStatusV1).Status = status
+		}).Return([]error{err})
+}
+
+// expectForkchoiceUpdate expects a forkchoice update, with or without payload attributes.
+func (ft *failoverTest) expectForkchoiceUpdate(i int, withAttrs bool, status eth.ExecutePayloadStatus, err error) {
+	ft.rpcs[i].On("CallContext", mock.Anything, mock.Anything, "engine_forkchoiceUpdatedV1", mock.MatchedBy(func(args []any) bool {
+		return (args[1].(*eth.PayloadAttributes) != nil) == withAttrs
+	})).Once().Run(func(args mock.Arguments) {
+		args.Get(1).(*eth.ForkchoiceUpdatedResult).PayloadStatus.Status = status
+	}).Return([]error{err})
+}
+
+// engineRPCError is an engine API error response, implementing rpc.Error.
+type engineRPCError struct {
+	code eth.ErrorCode
+}
+
+func (e engineRPCError) Error() string {
+	return fmt.Sprintf("engine API error %d", e.code)
+}
+
+func (e engineRPCError) ErrorCode() int {
+	return int(e.code)
+}
+
+// waitHealth waits for the health of the standby engines to be updated with the results of the previous calls.
+func (ft *failoverTest) waitHealth() {
+	ft.client.background.Wait()
+}
+
+func (ft *failoverTest) assertExpectations(t *testing.T) {
+	ft.waitHealth()
+	for _, r := range ft.rpcs {
+		r.AssertExpectations(t)
+	}
+}
+
+func TestFailoverNewPayload(t *testing.T) {
+	ft := newFailoverTest(t, 3)
+	payload := &eth.ExecutionPayload{BlockHash: common.Hash{0xaa}}
+
+	// all engines execute the payload, the primary result is returned
+	ft.expectNewPayload(0, eth.ExecutionValid, nil)
+	ft.expectNewPayload(1, eth.ExecutionSyncing, nil)
+	ft.expectNewPayload(2, eth.ExecutionValid, nil)
+	res, err := ft.client.NewPayload(context.Background(), payload)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, res.Status)
+	require.Equal(t, 0, ft.client.Primary())
+
+	// the primary fails: the first standby that executed the payload is promoted, the lagging standby is skipped
+	ft.expectNewPayload(0, "", errors.New("timeout"))
+	ft.expectNewPayload(1, eth.ExecutionSyncing, nil)
+	ft.expectNewPayload(2, eth.ExecutionValid, nil)
+	res, err = ft.client.NewPayload(context.Background(), payload)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, res.Status)
+	require.Equal(t, 2, ft.client.Primary())
+	ft.assertExpectations(t)
+}
+
+func TestFailoverNewPayloadNoStandbyInSync(t *testing.T) {
+	ft := newFailoverTest(t, 2)
+	payload := &eth.ExecutionPayload{BlockHash: common.Hash{0xaa}}
+
+	ft.expectNewPayload(0, "", errors.New("timeout"))
+	ft.expectNewPayload(1, eth.ExecutionSyncing, nil)
+	_, err := ft.client.NewPayload(context.Background(), payload)
+	require.ErrorContains(t, err, "timeout")
+	require.Equal(t, 0, ft.client.Primary(), "a syncing standby is not promoted")
+	ft.assertExpectations(t)
+}
+
+func TestFailoverSkipsDivergedStandby(t *testing.T) {
+	ft := newFailoverTest(t, 3)
+	payload := &eth.ExecutionPayload{BlockHash: common.Hash{0xaa}}
+
+	// standby 1 considers a valid block invalid
+	ft.expectNewPayload(0, eth.ExecutionValid, nil)
+	ft.expectNewPayload(1, eth.ExecutionInvalid, nil)
+	ft.expectNewPayload(2, eth.ExecutionValid, nil)
+	_, err := ft.client.NewPayload(context.Background(), payload)
+	require.NoError(t, err)
+	ft.waitHealth()
+
+	// even if it accepts the next block, the diverged standby is never promoted
+	ft.expectNewPayload(0, "", errors.New("connection refused"))
+	ft.expectNewPayload(1, eth.ExecutionValid, nil)
+	ft.expectNewPayload(2, eth.ExecutionValid, nil)
+	_, err = ft.client.NewPayload(context.Background(), payload)
+	require.NoError(t, err)
+	require.Equal(t, 2, ft.client.Primary())
+	ft.assertExpectations(t)
+}
+
+func TestFailoverForkchoiceUpdate(t *testing.T) {
+	ft := newFailoverTest(t, 2)
+	fc := &eth.ForkchoiceState{HeadBlockHash: common.Hash{0xaa}}
+	attrs := &eth.PayloadAttributes{Timestamp: 42}
+
+	// only the primary builds blocks
+	ft.expectForkchoiceUpdate(0, true, eth.ExecutionValid, nil)
+	ft.expectForkchoiceUpdate(1, false, eth.ExecutionValid, nil)
+	_, err := ft.client.ForkchoiceUpdate(context.Background(), fc, attrs)
+	require.NoError(t, err)
+
+	// an invalid forkchoice state is not a failure of the primary
+	ft.expectForkchoiceUpdate(0, true, "", engineRPCError{code: eth.InvalidForkchoiceState})
+	ft.expectForkchoiceUpdate(1, false, eth.ExecutionValid, nil)
+	_, err = ft.client.ForkchoiceUpdate(context.Background(), fc, attrs)
+	var inputErr eth.InputError
+	require.ErrorAs(t, err, &inputErr)
+	require.Equal(t, 0, ft.client.Primary())
+
+	// the primary is down: the standby is promoted, and starts building the block
+	ft.expectForkchoiceUpdate(0, true, "", errors.New("connection refused"))
+	ft.expectForkchoiceUpdate(1, false, eth.ExecutionValid, nil)
+	ft.expectForkchoiceUpdate(1, true, eth.ExecutionValid, nil)
+	res, err := ft.client.ForkchoiceUpdate(context.Background(), fc, attrs)
+	require.NoError(t, err)
+	require.Equal(t, eth.ExecutionValid, res.PayloadStatus.Status)
+	require.Equal(t, 1, ft.client.Primary())
+	ft.assertExpectations(t)
+}
+
+func TestFailoverDoesNotWaitForStandby(t *testing.T) {
+	ft := newFailoverTest(t, 2)
+	ft.client.standbyTimeout = time.Second
+	payload := &eth.ExecutionPayload{BlockHash: common.Hash{0xaa}}
+
+	// the standby hangs until released
+	release := make(chan struct{})
+	deadlines := make(chan time.Time, 1)
+	ft.rpcs[1].On("CallContext", mock.Anything, mock.Anything, "engine_newPayloadV1", mock.Anything).Once().
+		Run(func(args mock.Arguments) {
+			deadline, _ := args.Get(0).(context.Context).Deadline()
+			deadlines <- deadline
+			<-release
+			args.Get(1).(*eth.PayloadStatusV1).Status = eth.ExecutionValid
+		}).Return([]error{nil})
+	ft.expectNewPayload(0, eth.ExecutionValid, nil)
+
+	start := time.Now()
+	res, err := ft.client.NewPayload(context.Background(), payload)
+	require.NoError(t, err, "returns as soon as the primary answers")
+	require.Equal(t, eth.ExecutionValid, res.Status)
+
+	deadline := <-deadlines
+	require.WithinDuration(t, start.Add(time.Second), deadline, 500*time.Millisecond, "standby calls have their own timeout")
+	close(release)
+	ft.waitHealth()
+	ft.client.mu.RLock()
+	require.True(t, ft.client.health[1].inSync, "standby health is updated once it answers")
+	ft.client.mu.RUnlock()
+	ft.assertExpectations(t)
+}
+
+func TestFailoverIgnoresStaleStandbyResults(t *testing.T) {
+	ft := newFailoverTest(t, 2)
+	ft.client.mu.Lock()
+	// the standby was in sync as of the second call, the late results of the first call do not change that
+	ft.client.healthCall = 2
+	ft.client.health[1].inSync = true
+	ft.client.updateHealth(1, 0, []eth.ExecutePayloadStatus{eth.ExecutionValid, eth.ExecutionSyncing}, true, nil)
+	require.True(t, ft.client.health[1].inSync)
+
+	// but late results still detect divergence
+	ft.client.updateHealth(1, 0, []eth.ExecutePayloadStatus{eth.ExecutionValid, eth.ExecutionInvalid}, true, nil)
+	require.True(t, ft.client.health[1].diverged)
+	ft.client.mu.Unlock()
+}
@@ -0,0 +1,105 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+)
+
+// EngineRecord is an engine API call, as recorded by the RecordingRPC.
+type EngineRecord struct {
+	// Time is the unix time in milliseconds at which the call completed.
+	Time   uint64            `json:"time"`
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+	// Result is the result of the call, omitted if the call failed.
+	Result json.RawMessage `json:"result,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// RecordingRPC records all engine API calls (the "engine_" namespace), with their results,
+// as a stream of JSON encoded EngineRecord values. All other calls are passed through without recording.
+//
+// Wrapping the RPC of an EngineClient with a RecordingRPC captures the exact sequence of forkchoice updates,
+// payload attributes and execution payloads sent to the execution engine, which can then be replayed
+// against another engine to reproduce engine bugs offline.
+type RecordingRPC struct {
+	client.RPC
+
+	mu  sync.Mutex
+	out io.WriteCloser
+	enc *json.Encoder
+}
+
+var _ client.RPC = (*RecordingRPC)(nil)
+
+// NewRecordingRPC records the engine API calls made to inner to out. Closing the RecordingRPC closes both.
+func NewRecordingRPC(inner client.RPC, out io.WriteCloser) *RecordingRPC {
+	return &RecordingRPC{
+		RPC: inner,
+		out: out,
+		enc: json.NewEncoder(out),
+	}
+}
+
+func (r *RecordingRPC) CallContext(ctx context.Context, result any, method string, args ...any) error {
+	err := r.RPC.CallContext(ctx, result, method, args...)
+	if strings.HasPrefix(method, "engine_") {
+		r.record(result, method, args, err)
+	}
+	return err
+}
+
+func (r *RecordingRPC) record(result any, method string, args []any, callErr error) {
+	rec := EngineRecord{
+		Time:   uint64(time.Now().UnixMilli()),
+		Method: method,
+		Params: make([]json.RawMessage, len(args)),
+	}
+	for i, arg := range args {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			data, _ = json.Marshal(fmt.Sprintf("failed to encode param: %v", err))
+		}
+		rec.Params[i] = data
+	}
+	if callErr != nil {
+		rec.Error = callErr.Error()
+	} else if data, err := json.Marshal(result); err == nil {
+		rec.Result = data
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	// recording is best-effort, it must not affect the engine API calls
+	_ = r.enc.Encode(&rec)
+}
+
+func (r *RecordingRPC) Close() {
+	r.RPC.Close()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_ = r.out.Close()
+}
+
+// ReadEngineRecords decodes a stream of engine API calls, as recorded by the RecordingRPC.
+// A truncated last record, e.g. from a node that crashed while recording, is ignored.
+func ReadEngineRecords(in io.Reader) ([]EngineRecord, error) {
+	dec := json.NewDecoder(in)
+	var records []EngineRecord
+	for {
+		var rec EngineRecord
+		if err := dec.Decode(&rec); errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return records, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to decode engine record %d: %w", len(records), err)
+		}
+		records = append(records, rec)
+	}
+}
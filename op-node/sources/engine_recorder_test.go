@@ -0,0 +1,64 @@
+package sources
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+)
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+func TestRecordingRPC(t *testing.T) {
+	m := new(mockRPC)
+	var buf bytes.Buffer
+	r := NewRecordingRPC(m, nopCloser{&buf})
+
+	payload := &eth.ExecutionPayload{BlockHash: common.Hash{0xaa}, BlockNumber: 7}
+	m.On("CallContext", mock.Anything, mock.Anything, "engine_newPayloadV1", []any{payload}).Once().
+		Run(func(args mock.Arguments) {
+			args.Get(1).(*eth.PayloadStatusV1).Status = eth.ExecutionValid
+		}).Return([]error{nil})
+	m.On("CallContext", mock.Anything, mock.Anything, "engine_getPayloadV1", []any{eth.PayloadID{1}}).Once().
+		Return([]error{errors.New("unknown payload")})
+	m.On("CallContext", mock.Anything, mock.Anything, "eth_chainId", []any(nil)).Once().Return([]error{nil})
+
+	var status eth.PayloadStatusV1
+	require.NoError(t, r.CallContext(context.Background(), &status, "engine_newPayloadV1", payload))
+	var result eth.ExecutionPayload
+	require.ErrorContains(t, r.CallContext(context.Background(), &result, "engine_getPayloadV1", eth.PayloadID{1}), "unknown payload")
+	var chainID string
+	require.NoError(t, r.CallContext(context.Background(), &chainID, "eth_chainId"))
+	m.AssertExpectations(t)
+
+	// a truncated record at the end of the recording is ignored
+	buf.WriteString(`{"time":1,"method":"engine_newPay`)
+	records, err := ReadEngineRecords(&buf)
+	require.NoError(t, err)
+	require.Len(t, records, 2, "only engine API calls are recorded")
+
+	require.Equal(t, "engine_newPayloadV1", records[0].Method)
+	require.Len(t, records[0].Params, 1)
+	var recordedPayload eth.ExecutionPayload
+	require.NoError(t, json.Unmarshal(records[0].Params[0], &recordedPayload))
+	require.Equal(t, payload.ID(), recordedPayload.ID())
+	var recordedStatus eth.PayloadStatusV1
+	require.NoError(t, json.Unmarshal(records[0].Result, &recordedStatus))
+	require.Equal(t, eth.ExecutionValid, recordedStatus.Status)
+
+	require.Equal(t, "engine_getPayloadV1", records[1].Method)
+	require.Equal(t, "unknown payload", records[1].Error)
+	require.Empty(t, records[1].Result)
+}
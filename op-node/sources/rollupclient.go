@@ -43,6 +43,19 @@ func (r *RollupClient) Version(ctx context.Context) (string, error) {
 	return output, err
 }
 
+// VerifiedHeader returns the latest verified L2 block of a node in light mode, with the "finalized" or "unsafe" label.
+func (r *RollupClient) VerifiedHeader(ctx context.Context, label eth.BlockLabel) (eth.L2BlockRef, error) {
+	var output eth.L2BlockRef
+	err := r.rpc.CallContext(ctx, &output, "optimism_verifiedHeader", label)
+	return output, err
+}
+
+func (r *RollupClient) VerifiedHeaderByNumber(ctx context.Context, blockNum uint64) (eth.L2BlockRef, error) {
+	var output eth.L2BlockRef
+	err := r.rpc.CallContext(ctx, &output, "optimism_verifiedHeaderByNumber", hexutil.Uint64(blockNum))
+	return output, err
+}
+
 func (r *RollupClient) StartSequencer(ctx context.Context, unsafeHead common.Hash) error {
 	return r.rpc.CallContext(ctx, nil, "admin_startSequencer", unsafeHead)
 }
@@ -58,3 +71,7 @@ func (r *RollupClient) SequencerActive(ctx context.Context) (bool, error) {
 	err := r.rpc.CallContext(ctx, &result, "admin_sequencerActive")
 	return result, err
 }
+
+func (r *RollupClient) TransferLeadership(ctx context.Context) error {
+	return r.rpc.CallContext(ctx, nil, "admin_transferLeadership")
+}
@@ -32,13 +32,35 @@ var (
 		Usage:   "HTTP provider URL for the rollup node",
 		EnvVars: prefixEnvVars("ROLLUP_RPC"),
 	}
+
+	// Optional flags
 	L2OOAddressFlag = &cli.StringFlag{
 		Name:    "l2oo-address",
-		Usage:   "Address of the L2OutputOracle contract",
+		Usage:   "Address of the L2OutputOracle contract. Mutually exclusive with --game-factory-address",
 		EnvVars: prefixEnvVars("L2OO_ADDRESS"),
 	}
-
-	// Optional flags
+	DisputeGameFactoryAddressFlag = &cli.StringFlag{
+		Name:    "game-factory-address",
+		Usage:   "Address of the DisputeGameFactory contract. If set, outputs are proposed by creating dispute games instead of calling the L2OutputOracle",
+		EnvVars: prefixEnvVars("GAME_FACTORY_ADDRESS"),
+	}
+	ProposalIntervalFlag = &cli.DurationFlag{
+		Name:    "proposal-interval",
+		Usage:   "Interval between dispute game proposals. Only used when --game-factory-address is set",
+		EnvVars: prefixEnvVars("PROPOSAL_INTERVAL"),
+	}
+	DisputeGameTypeFlag = &cli.UintFlag{
+		Name:    "game-type",
+		Usage:   "Dispute game type to create via the DisputeGameFactory",
+		Value:   0,
+		EnvVars: prefixEnvVars("GAME_TYPE"),
+	}
+	DisputeGameBondFlag = &cli.StringFlag{
+		Name:    "game-bond",
+		Usage:   "Bond in wei to send with each DisputeGameFactory create call",
+		Value:   "0",
+		EnvVars: prefixEnvVars("GAME_BOND"),
+	}
 	PollIntervalFlag = &cli.DurationFlag{
 		Name:    "poll-interval",
 		Usage:   "How frequently to poll L2 for new blocks",
@@ -50,6 +72,16 @@ var (
 		Usage:   "Allow the proposer to submit proposals for L2 blocks derived from non-finalized L1 blocks.",
 		EnvVars: prefixEnvVars("ALLOW_NON_FINALIZED"),
 	}
+	VerifierRollupRpcsFlag = &cli.StringSliceFlag{
+		Name:    "verifier-rollup-rpcs",
+		Usage:   "HTTP provider URLs of secondary rollup nodes. If set, outputs are only proposed if every secondary node reports the same output root",
+		EnvVars: prefixEnvVars("VERIFIER_ROLLUP_RPCS"),
+	}
+	VerifierL2EthRpcFlag = &cli.StringFlag{
+		Name:    "verifier-l2-eth-rpc",
+		Usage:   "HTTP provider URL of an L2 execution client. If set, outputs are only proposed if the output root recomputed from eth_getProof of the L2ToL1MessagePasser matches",
+		EnvVars: prefixEnvVars("VERIFIER_L2_ETH_RPC"),
+	}
 	// Legacy Flags
 	L2OutputHDPathFlag = txmgr.L2OutputHDPathFlag
 )
@@ -57,12 +89,18 @@ var (
 var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	RollupRpcFlag,
-	L2OOAddressFlag,
 }
 
 var optionalFlags = []cli.Flag{
+	L2OOAddressFlag,
+	DisputeGameFactoryAddressFlag,
+	ProposalIntervalFlag,
+	DisputeGameTypeFlag,
+	DisputeGameBondFlag,
 	PollIntervalFlag,
 	AllowNonFinalizedFlag,
+	VerifierRollupRpcsFlag,
+	VerifierL2EthRpcFlag,
 	L2OutputHDPathFlag,
 }
 
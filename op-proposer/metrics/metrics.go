@@ -2,6 +2,7 @@ package metrics
 
 import (
 	"context"
+	"strconv"
 
 	"github.com/ethereum-optimism/optimism/op-node/eth"
 
@@ -27,6 +28,10 @@ type Metricer interface {
 	txmetrics.TxMetricer
 
 	RecordL2BlocksProposed(l2ref eth.L2BlockRef)
+	RecordDisputeGameCreated(l2ref eth.L2BlockRef, gameType uint8)
+
+	RecordOutputVerified(l2ref eth.L2BlockRef)
+	RecordOutputMismatch(source string)
 }
 
 type Metrics struct {
@@ -39,6 +44,11 @@ type Metrics struct {
 
 	info prometheus.GaugeVec
 	up   prometheus.Gauge
+
+	gamesCreated *prometheus.CounterVec
+
+	outputMismatch   prometheus.Gauge
+	outputMismatches *prometheus.CounterVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -72,6 +82,25 @@ func NewMetrics(procName string) *Metrics {
 			Name:      "up",
 			Help:      "1 if the op-proposer has finished starting up",
 		}),
+		gamesCreated: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "dispute_games_created_total",
+			Help:      "Number of dispute games created via the DisputeGameFactory",
+		}, []string{
+			"game_type",
+		}),
+		outputMismatch: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "output_mismatch",
+			Help:      "1 if the last output to be proposed did not match a verification source",
+		}),
+		outputMismatches: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "output_mismatches_total",
+			Help:      "Number of outputs that did not match a verification source",
+		}, []string{
+			"source",
+		}),
 	}
 }
 
@@ -98,6 +127,8 @@ func (m *Metrics) RecordUp() {
 
 const (
 	BlockProposed = "proposed"
+	GameCreated   = "game_created"
+	BlockVerified = "verified"
 )
 
 // RecordL2BlocksProposed should be called when new L2 block is proposed
@@ -105,6 +136,24 @@ func (m *Metrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {
 	m.RecordL2Ref(BlockProposed, l2ref)
 }
 
+// RecordDisputeGameCreated should be called when a new dispute game is created for an L2 block
+func (m *Metrics) RecordDisputeGameCreated(l2ref eth.L2BlockRef, gameType uint8) {
+	m.RecordL2Ref(GameCreated, l2ref)
+	m.gamesCreated.WithLabelValues(strconv.Itoa(int(gameType))).Inc()
+}
+
+// RecordOutputVerified should be called when an output matched all verification sources
+func (m *Metrics) RecordOutputVerified(l2ref eth.L2BlockRef) {
+	m.RecordL2Ref(BlockVerified, l2ref)
+	m.outputMismatch.Set(0)
+}
+
+// RecordOutputMismatch should be called when an output did not match the given verification source
+func (m *Metrics) RecordOutputMismatch(source string) {
+	m.outputMismatch.Set(1)
+	m.outputMismatches.WithLabelValues(source).Inc()
+}
+
 func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
@@ -16,4 +16,8 @@ var NoopMetrics Metricer = new(noopMetrics)
 func (*noopMetrics) RecordInfo(version string) {}
 func (*noopMetrics) RecordUp()                 {}
 
-func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef) {}
+func (*noopMetrics) RecordL2BlocksProposed(l2ref eth.L2BlockRef)                   {}
+func (*noopMetrics) RecordDisputeGameCreated(l2ref eth.L2BlockRef, gameType uint8) {}
+
+func (*noopMetrics) RecordOutputVerified(l2ref eth.L2BlockRef) {}
+func (*noopMetrics) RecordOutputMismatch(source string)        {}
@@ -73,3 +73,39 @@ func TestManualABIPacking(t *testing.T) {
 
 	require.Equal(t, txData, tx.Data())
 }
+
+// TestManualDisputeGameABIPacking ensures that the manual ABI packing of the DisputeGameFactory create call
+// is the same as going through the bound contract.
+func TestManualDisputeGameABIPacking(t *testing.T) {
+	_, opts, backend, _, err := setupL2OutputOracle()
+	require.NoError(t, err)
+	rng := rand.New(rand.NewSource(1234))
+
+	abi, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(t, err)
+	contract, err := bindings.NewDisputeGameFactoryTransactor(common.Address{0xdf}, backend)
+	require.NoError(t, err)
+
+	output := testutils.RandomOutputResponse(rng)
+	gameType := uint8(1)
+
+	txData, err := proposeDisputeGameTxData(abi, gameType, output, 456)
+	require.NoError(t, err)
+
+	opts.GasLimit = 100_000
+	opts.NoSend = true
+	tx, err := contract.Create(opts, gameType, output.OutputRoot, disputeGameExtraData(output, 456))
+	require.NoError(t, err)
+
+	require.Equal(t, txData, tx.Data())
+}
+
+func TestDisputeGameExtraData(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	output := testutils.RandomOutputResponse(rng)
+
+	extraData := disputeGameExtraData(output, 456)
+	require.Len(t, extraData, 64)
+	require.Equal(t, output.BlockRef.Number, new(big.Int).SetBytes(extraData[:32]).Uint64())
+	require.Equal(t, uint64(456), new(big.Int).SetBytes(extraData[32:]).Uint64())
+}
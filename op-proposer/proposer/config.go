@@ -1,6 +1,9 @@
 package proposer
 
 import (
+	"errors"
+	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -27,6 +30,16 @@ type Config struct {
 	L1Client           *ethclient.Client
 	RollupClient       *sources.RollupClient
 	AllowNonFinalized  bool
+
+	// DisputeGameFactoryAddr is the address of the DisputeGameFactory. If set, outputs are
+	// proposed by creating dispute games rather than by calling the L2OutputOracle.
+	DisputeGameFactoryAddr *common.Address
+	ProposalInterval       time.Duration
+	DisputeGameType        uint8
+	DisputeGameBond        *big.Int
+
+	// Verifier cross-checks outputs before they are proposed. It is nil if verification is disabled.
+	Verifier *OutputVerifier
 }
 
 // CLIConfig is a well typed config that is parsed from the CLI params.
@@ -44,6 +57,28 @@ type CLIConfig struct {
 	// L2OOAddress is the L2OutputOracle contract address.
 	L2OOAddress string
 
+	// DGFAddress is the DisputeGameFactory contract address.
+	// It is mutually exclusive with L2OOAddress.
+	DGFAddress string
+
+	// ProposalInterval is the delay between creating dispute games.
+	// It is only used when proposing to the DisputeGameFactory.
+	ProposalInterval time.Duration
+
+	// DisputeGameType is the type of dispute game created by the DisputeGameFactory.
+	DisputeGameType uint8
+
+	// DisputeGameBond is the bond, in wei, sent along with each dispute game creation.
+	DisputeGameBond string
+
+	// VerifierRollupRpcs are the HTTP provider URLs of secondary rollup nodes
+	// that outputs are cross-checked against before proposing.
+	VerifierRollupRpcs []string
+
+	// VerifierL2EthRpc is the HTTP provider URL of an L2 execution client
+	// used to recompute outputs before proposing.
+	VerifierL2EthRpc string
+
 	// PollInterval is the delay between querying L2 for more transaction
 	// and creating a new batch.
 	PollInterval time.Duration
@@ -79,23 +114,55 @@ func (c CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if c.L2OOAddress == "" && c.DGFAddress == "" {
+		return errors.New("either the L2OutputOracle or the DisputeGameFactory address must be set")
+	}
+	if c.L2OOAddress != "" && c.DGFAddress != "" {
+		return errors.New("the L2OutputOracle and DisputeGameFactory addresses are mutually exclusive")
+	}
+	if c.DGFAddress != "" {
+		if c.ProposalInterval == 0 {
+			return errors.New("the proposal interval must be set when proposing to the DisputeGameFactory")
+		}
+		if _, err := parseBond(c.DisputeGameBond); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// parseBond parses a decimal wei amount. An empty string is treated as a zero bond.
+func parseBond(bond string) (*big.Int, error) {
+	if bond == "" {
+		return new(big.Int), nil
+	}
+	v, ok := new(big.Int).SetString(bond, 10)
+	if !ok || v.Sign() < 0 {
+		return nil, fmt.Errorf("invalid dispute game bond: %q", bond)
+	}
+	return v, nil
+}
+
 // NewConfig parses the Config from the provided flags or environment variables.
 func NewConfig(ctx *cli.Context) CLIConfig {
 	return CLIConfig{
 		// Required Flags
 		L1EthRpc:     ctx.String(flags.L1EthRpcFlag.Name),
 		RollupRpc:    ctx.String(flags.RollupRpcFlag.Name),
-		L2OOAddress:  ctx.String(flags.L2OOAddressFlag.Name),
 		PollInterval: ctx.Duration(flags.PollIntervalFlag.Name),
 		TxMgrConfig:  txmgr.ReadCLIConfig(ctx),
 		// Optional Flags
-		AllowNonFinalized: ctx.Bool(flags.AllowNonFinalizedFlag.Name),
-		RPCConfig:         oprpc.ReadCLIConfig(ctx),
-		LogConfig:         oplog.ReadCLIConfig(ctx),
-		MetricsConfig:     opmetrics.ReadCLIConfig(ctx),
-		PprofConfig:       oppprof.ReadCLIConfig(ctx),
+		L2OOAddress:        ctx.String(flags.L2OOAddressFlag.Name),
+		DGFAddress:         ctx.String(flags.DisputeGameFactoryAddressFlag.Name),
+		ProposalInterval:   ctx.Duration(flags.ProposalIntervalFlag.Name),
+		DisputeGameType:    uint8(ctx.Uint(flags.DisputeGameTypeFlag.Name)),
+		DisputeGameBond:    ctx.String(flags.DisputeGameBondFlag.Name),
+		VerifierRollupRpcs: ctx.StringSlice(flags.VerifierRollupRpcsFlag.Name),
+		VerifierL2EthRpc:   ctx.String(flags.VerifierL2EthRpcFlag.Name),
+		AllowNonFinalized:  ctx.Bool(flags.AllowNonFinalizedFlag.Name),
+		RPCConfig:          oprpc.ReadCLIConfig(ctx),
+		LogConfig:          oplog.ReadCLIConfig(ctx),
+		MetricsConfig:      opmetrics.ReadCLIConfig(ctx),
+		PprofConfig:        oppprof.ReadCLIConfig(ctx),
 	}
 }
@@ -93,6 +93,9 @@ func Main(version string, cliCtx *cli.Context) error {
 
 	rpcCfg := cfg.RPCConfig
 	server := oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version, oprpc.WithLogger(l))
+	if r, ok := proposerConfig.TxManager.(txmgr.PendingTxReporter); ok {
+		server.AddAPI(txmgr.NewDebugAPI(r))
+	}
 	if err := server.Start(); err != nil {
 		cancel()
 		return fmt.Errorf("error starting RPC server: %w", err)
@@ -120,11 +123,31 @@ type L2OutputSubmitter struct {
 
 	// RollupClient is used to retrieve output roots from
 	rollupClient *sources.RollupClient
+	// verifier cross-checks outputs before they are proposed, if configured
+	verifier *OutputVerifier
 
 	l2ooContract     *bindings.L2OutputOracleCaller
 	l2ooContractAddr common.Address
 	l2ooABI          *abi.ABI
 
+	// dgfContract is only set when outputs are proposed by creating dispute games
+	// through the DisputeGameFactory instead of through the L2OutputOracle.
+	dgfContract     *bindings.DisputeGameFactoryCaller
+	dgfFilterer     *bindings.DisputeGameFactoryFilterer
+	dgfContractAddr common.Address
+	dgfABI          *abi.ABI
+	gameType        uint8
+	gameBond        *big.Int
+	l1Client        bind.ContractCaller
+	// The BlockOracle of the game implementation. The L1 block a game is anchored to must be checkpointed in it.
+	blockOracleAddr     common.Address
+	blockOracleABI      *abi.ABI
+	blockOracleFilterer *bindings.BlockOracleFilterer
+	// proposedGames are the games of gameType known to be created by the factory, by L2 block number and output root.
+	proposedGames map[proposedGame]bool
+	// How frequently to create a new dispute game
+	proposalInterval time.Duration
+
 	// AllowNonFinalized enables the proposal of safe, but non-finalized L2 blocks.
 	// The L1 block-hash embedded in the proposal TX is checked and should ensure the proposal
 	// is never valid on an alternative L1 chain that would produce different L2 data.
@@ -146,7 +169,22 @@ func NewL2OutputSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Me
 
 // NewL2OutputSubmitterConfigFromCLIConfig creates the proposer config from the CLI config.
 func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*Config, error) {
-	l2ooAddress, err := opservice.ParseAddress(cfg.L2OOAddress)
+	var l2ooAddress common.Address
+	var dgfAddress *common.Address
+	if cfg.DGFAddress != "" {
+		addr, err := opservice.ParseAddress(cfg.DGFAddress)
+		if err != nil {
+			return nil, err
+		}
+		dgfAddress = &addr
+	} else {
+		addr, err := opservice.ParseAddress(cfg.L2OOAddress)
+		if err != nil {
+			return nil, err
+		}
+		l2ooAddress = addr
+	}
+	bond, err := parseBond(cfg.DisputeGameBond)
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +206,27 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		return nil, err
 	}
 
+	var verifier *OutputVerifier
+	if len(cfg.VerifierRollupRpcs) > 0 || cfg.VerifierL2EthRpc != "" {
+		var rollupSources []OutputSource
+		for _, url := range cfg.VerifierRollupRpcs {
+			cl, err := opclient.DialRollupClientWithTimeout(ctx, url, opclient.DefaultDialTimeout)
+			if err != nil {
+				return nil, err
+			}
+			rollupSources = append(rollupSources, cl)
+		}
+		var l2Source L2ProofSource
+		if cfg.VerifierL2EthRpc != "" {
+			cl, err := opclient.DialEthClientWithTimeout(ctx, cfg.VerifierL2EthRpc, opclient.DefaultDialTimeout)
+			if err != nil {
+				return nil, err
+			}
+			l2Source = &ethProofClient{cl}
+		}
+		verifier = NewOutputVerifier(l, m, rollupSources, l2Source, cfg.TxMgrConfig.NetworkTimeout)
+	}
+
 	return &Config{
 		L2OutputOracleAddr: l2ooAddress,
 		PollInterval:       cfg.PollInterval,
@@ -176,53 +235,149 @@ func NewL2OutputSubmitterConfigFromCLIConfig(cfg CLIConfig, l log.Logger, m metr
 		RollupClient:       rollupClient,
 		AllowNonFinalized:  cfg.AllowNonFinalized,
 		TxManager:          txManager,
+
+		DisputeGameFactoryAddr: dgfAddress,
+		ProposalInterval:       cfg.ProposalInterval,
+		DisputeGameType:        cfg.DisputeGameType,
+		DisputeGameBond:        bond,
+
+		Verifier: verifier,
 	}, nil
 
 }
 
 // NewL2OutputSubmitter creates a new L2 Output Submitter
 func NewL2OutputSubmitter(cfg Config, l log.Logger, m metrics.Metricer) (*L2OutputSubmitter, error) {
+	if cfg.DisputeGameFactoryAddr != nil && cfg.ProposalInterval <= 0 {
+		return nil, errors.New("the proposal interval must be positive when proposing to the DisputeGameFactory")
+	}
 	ctx, cancel := context.WithCancel(context.Background())
 
-	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
+	submitter := &L2OutputSubmitter{
+		txMgr:  cfg.TxManager,
+		done:   make(chan struct{}),
+		log:    l,
+		ctx:    ctx,
+		cancel: cancel,
+		metr:   m,
+
+		rollupClient: cfg.RollupClient,
+		verifier:     cfg.Verifier,
+
+		allowNonFinalized: cfg.AllowNonFinalized,
+		pollInterval:      cfg.PollInterval,
+		networkTimeout:    cfg.NetworkTimeout,
+	}
+
+	var err error
+	if cfg.DisputeGameFactoryAddr != nil {
+		err = submitter.initDGF(ctx, cfg)
+	} else {
+		err = submitter.initL2OO(ctx, cfg)
+	}
 	if err != nil {
 		cancel()
-		return nil, fmt.Errorf("failed to create L2OO at address %s: %w", cfg.L2OutputOracleAddr, err)
+		return nil, err
+	}
+	return submitter, nil
+}
+
+// initL2OO binds the submitter to the L2OutputOracle contract.
+func (l *L2OutputSubmitter) initL2OO(ctx context.Context, cfg Config) error {
+	l2ooContract, err := bindings.NewL2OutputOracleCaller(cfg.L2OutputOracleAddr, cfg.L1Client)
+	if err != nil {
+		return fmt.Errorf("failed to create L2OO at address %s: %w", cfg.L2OutputOracleAddr, err)
 	}
 
 	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
 	defer cCancel()
 	version, err := l2ooContract.Version(&bind.CallOpts{Context: cCtx})
 	if err != nil {
-		cancel()
-		return nil, err
+		return err
 	}
 	log.Info("Connected to L2OutputOracle", "address", cfg.L2OutputOracleAddr, "version", version)
 
 	parsed, err := bindings.L2OutputOracleMetaData.GetAbi()
 	if err != nil {
-		cancel()
-		return nil, err
+		return err
 	}
 
-	return &L2OutputSubmitter{
-		txMgr:  cfg.TxManager,
-		done:   make(chan struct{}),
-		log:    l,
-		ctx:    ctx,
-		cancel: cancel,
-		metr:   m,
+	l.l2ooContract = l2ooContract
+	l.l2ooContractAddr = cfg.L2OutputOracleAddr
+	l.l2ooABI = parsed
+	return nil
+}
 
-		rollupClient: cfg.RollupClient,
+// initDGF binds the submitter to the DisputeGameFactory contract.
+func (l *L2OutputSubmitter) initDGF(ctx context.Context, cfg Config) error {
+	dgfAddr := *cfg.DisputeGameFactoryAddr
+	dgfContract, err := bindings.NewDisputeGameFactoryCaller(dgfAddr, cfg.L1Client)
+	if err != nil {
+		return fmt.Errorf("failed to create DisputeGameFactory at address %s: %w", dgfAddr, err)
+	}
+	dgfFilterer, err := bindings.NewDisputeGameFactoryFilterer(dgfAddr, cfg.L1Client)
+	if err != nil {
+		return fmt.Errorf("failed to create DisputeGameFactory filterer at address %s: %w", dgfAddr, err)
+	}
+
+	cCtx, cCancel := context.WithTimeout(ctx, cfg.NetworkTimeout)
+	defer cCancel()
+	version, err := dgfContract.Version(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return err
+	}
+	cCtx, cCancel = context.WithTimeout(ctx, cfg.NetworkTimeout)
+	defer cCancel()
+	impl, err := dgfContract.GameImpls(&bind.CallOpts{Context: cCtx}, cfg.DisputeGameType)
+	if err != nil {
+		return err
+	}
+	if impl == (common.Address{}) {
+		return fmt.Errorf("no implementation registered for dispute game type %d", cfg.DisputeGameType)
+	}
+	implContract, err := bindings.NewFaultDisputeGameCaller(impl, cfg.L1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind dispute game implementation at address %s: %w", impl, err)
+	}
+	cCtx, cCancel = context.WithTimeout(ctx, cfg.NetworkTimeout)
+	defer cCancel()
+	blockOracleAddr, err := implContract.BLOCKORACLE(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return fmt.Errorf("failed to fetch block oracle of dispute game implementation: %w", err)
+	}
+	blockOracleFilterer, err := bindings.NewBlockOracleFilterer(blockOracleAddr, cfg.L1Client)
+	if err != nil {
+		return fmt.Errorf("failed to create BlockOracle filterer at address %s: %w", blockOracleAddr, err)
+	}
+	blockOracleABI, err := bindings.BlockOracleMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
+	log.Info("Connected to DisputeGameFactory", "address", dgfAddr, "version", version, "gameType", cfg.DisputeGameType, "impl", impl)
 
-		l2ooContract:     l2ooContract,
-		l2ooContractAddr: cfg.L2OutputOracleAddr,
-		l2ooABI:          parsed,
+	parsed, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	if err != nil {
+		return err
+	}
 
-		allowNonFinalized: cfg.AllowNonFinalized,
-		pollInterval:      cfg.PollInterval,
-		networkTimeout:    cfg.NetworkTimeout,
-	}, nil
+	bond := cfg.DisputeGameBond
+	if bond == nil {
+		bond = new(big.Int)
+	}
+
+	l.dgfContract = dgfContract
+	l.dgfFilterer = dgfFilterer
+	l.dgfContractAddr = dgfAddr
+	l.dgfABI = parsed
+	l.gameType = cfg.DisputeGameType
+	l.gameBond = bond
+	l.proposalInterval = cfg.ProposalInterval
+	l.l1Client = cfg.L1Client
+	l.blockOracleAddr = blockOracleAddr
+	l.blockOracleABI = blockOracleABI
+	l.blockOracleFilterer = blockOracleFilterer
+	l.proposedGames = make(map[proposedGame]bool)
+	return nil
 }
 
 func (l *L2OutputSubmitter) Start() error {
@@ -240,6 +395,9 @@ func (l *L2OutputSubmitter) Stop() {
 // FetchNextOutputInfo gets the block number of the next proposal.
 // It returns: the next block number, if the proposal should be made, error
 func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	if l.dgfContract != nil {
+		return l.fetchNextGameOutputInfo(ctx)
+	}
 	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
 	defer cancel()
 	callOpts := &bind.CallOpts{
@@ -276,10 +434,106 @@ func (l *L2OutputSubmitter) FetchNextOutputInfo(ctx context.Context) (*eth.Outpu
 	return l.fetchOutput(ctx, nextCheckpointBlock)
 }
 
+// fetchNextGameOutputInfo gets the output of the current finalized (or safe, if allowed) L2 block
+// to create a dispute game for. No proposal is made if a game for that output already exists.
+func (l *L2OutputSubmitter) fetchNextGameOutputInfo(ctx context.Context) (*eth.OutputResponse, bool, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	status, err := l.rollupClient.SyncStatus(cCtx)
+	if err != nil {
+		l.log.Error("proposer unable to get sync status", "err", err)
+		return nil, false, err
+	}
+
+	var currentBlockNumber *big.Int
+	if l.allowNonFinalized {
+		currentBlockNumber = new(big.Int).SetUint64(status.SafeL2.Number)
+	} else {
+		currentBlockNumber = new(big.Int).SetUint64(status.FinalizedL2.Number)
+	}
+	if currentBlockNumber.Sign() == 0 {
+		l.log.Debug("proposer has no L2 block to create a dispute game for yet")
+		return nil, false, nil
+	}
+
+	output, shouldPropose, err := l.fetchOutput(ctx, currentBlockNumber)
+	if err != nil || !shouldPropose {
+		return nil, false, err
+	}
+
+	exists, err := l.gameExists(ctx, output)
+	if err != nil {
+		l.log.Error("proposer unable to look up existing dispute games", "err", err)
+		return nil, false, err
+	}
+	if exists {
+		l.log.Debug("dispute game already exists for output", "l2_proposal", output.BlockRef)
+		return nil, false, nil
+	}
+	return output, true, nil
+}
+
+// proposedGame identifies the games proposing the same output. The L1 block a game is anchored to
+// is not part of it, as it changes with every proposal attempt.
+type proposedGame struct {
+	l2BlockNumber uint64
+	rootClaim     common.Hash
+}
+
+// gameExists returns true if a game of gameType proposing the output was created by the factory.
+// Only the creation events of games with the output as root claim are fetched, and only since the L1 origin
+// of the output, as no game can propose the output before its L2 block exists.
+func (l *L2OutputSubmitter) gameExists(ctx context.Context, output *eth.OutputResponse) (bool, error) {
+	key := proposedGame{l2BlockNumber: output.BlockRef.Number, rootClaim: common.Hash(output.OutputRoot)}
+	if l.proposedGames[key] {
+		return true, nil
+	}
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	opts := &bind.FilterOpts{Start: output.BlockRef.L1Origin.Number, Context: cCtx}
+	iter, err := l.dgfFilterer.FilterDisputeGameCreated(opts, nil, []uint8{l.gameType}, [][32]byte{key.rootClaim})
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch created games: %w", err)
+	}
+	defer iter.Close()
+	for iter.Next() {
+		game, err := l.fetchProposedGame(ctx, iter.Event.DisputeProxy, iter.Event.RootClaim)
+		if err != nil {
+			return false, err
+		}
+		l.proposedGames[*game] = true
+	}
+	if err := iter.Error(); err != nil {
+		return false, fmt.Errorf("failed to fetch created games: %w", err)
+	}
+	return l.proposedGames[key], nil
+}
+
+// fetchProposedGame fetches the L2 block number proposed by the game at addr.
+func (l *L2OutputSubmitter) fetchProposedGame(ctx context.Context, addr common.Address, rootClaim common.Hash) (*proposedGame, error) {
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	defer cancel()
+	game, err := bindings.NewFaultDisputeGameCaller(addr, l.l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind game %s: %w", addr, err)
+	}
+	extraData, err := game.ExtraData(&bind.CallOpts{Context: cCtx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch extra data of game %s: %w", addr, err)
+	}
+	if len(extraData) < 32 {
+		return nil, fmt.Errorf("invalid extra data of game %s: %x", addr, extraData)
+	}
+	return &proposedGame{
+		l2BlockNumber: new(big.Int).SetBytes(extraData[:32]).Uint64(),
+		rootClaim:     rootClaim,
+	}, nil
+}
+
 func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*eth.OutputResponse, bool, error) {
-	ctx, cancel := context.WithTimeout(ctx, l.networkTimeout)
+	cCtx, cancel := context.WithTimeout(ctx, l.networkTimeout)
 	defer cancel()
-	output, err := l.rollupClient.OutputAtBlock(ctx, block.Uint64())
+	output, err := l.rollupClient.OutputAtBlock(cCtx, block.Uint64())
 	if err != nil {
 		l.log.Error("failed to fetch output at block %d: %w", block, err)
 		return nil, false, err
@@ -302,6 +556,12 @@ func (l *L2OutputSubmitter) fetchOutput(ctx context.Context, block *big.Int) (*e
 			"allow_non_finalized", l.allowNonFinalized)
 		return nil, false, nil
 	}
+	if l.verifier != nil {
+		if err := l.verifier.Verify(ctx, output); err != nil {
+			l.log.Error("failed to verify output", "l2_proposal", output.BlockRef, "err", err)
+			return nil, false, err
+		}
+	}
 	return output, true, nil
 }
 
@@ -320,6 +580,30 @@ func proposeL2OutputTxData(abi *abi.ABI, output *eth.OutputResponse) ([]byte, er
 		new(big.Int).SetUint64(output.Status.CurrentL1.Number))
 }
 
+// ProposeDisputeGameTxData creates the transaction data for the DisputeGameFactory's create function.
+// The game is anchored to l1BlockNumber, which must be checkpointed in the BlockOracle.
+func (l *L2OutputSubmitter) ProposeDisputeGameTxData(output *eth.OutputResponse, l1BlockNumber uint64) ([]byte, error) {
+	return proposeDisputeGameTxData(l.dgfABI, l.gameType, output, l1BlockNumber)
+}
+
+// proposeDisputeGameTxData creates the transaction data for the DisputeGameFactory's create function
+func proposeDisputeGameTxData(abi *abi.ABI, gameType uint8, output *eth.OutputResponse, l1BlockNumber uint64) ([]byte, error) {
+	return abi.Pack(
+		"create",
+		gameType,
+		output.OutputRoot,
+		disputeGameExtraData(output, l1BlockNumber))
+}
+
+// disputeGameExtraData encodes the extra data of a dispute game for the given output:
+// the disputed L2 block number followed by the L1 block number the game is anchored to.
+func disputeGameExtraData(output *eth.OutputResponse, l1BlockNumber uint64) []byte {
+	extraData := make([]byte, 64)
+	new(big.Int).SetUint64(output.BlockRef.Number).FillBytes(extraData[:32])
+	new(big.Int).SetUint64(l1BlockNumber).FillBytes(extraData[32:])
+	return extraData
+}
+
 // We wait until l1head advances beyond blocknum. This is used to make sure proposal tx won't
 // immediately fail when checking the l1 blockhash. Note that EstimateGas uses "latest" state to
 // execute the transaction by default, meaning inside the call, the head block is considered
@@ -351,6 +635,9 @@ func (l *L2OutputSubmitter) waitForL1Head(ctx context.Context, blockNum uint64)
 
 // sendTransaction creates & sends transactions through the underlying transaction manager.
 func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.OutputResponse) error {
+	if l.dgfContract != nil {
+		return l.sendDisputeGameTransaction(ctx, output)
+	}
 	err := l.waitForL1Head(ctx, output.Status.HeadL1.Number+1)
 	if err != nil {
 		return err
@@ -378,13 +665,90 @@ func (l *L2OutputSubmitter) sendTransaction(ctx context.Context, output *eth.Out
 	return nil
 }
 
+// checkpointL1Block checkpoints the parent of the next L1 block in the BlockOracle,
+// returning the number of the checkpointed block for dispute games to be anchored to.
+func (l *L2OutputSubmitter) checkpointL1Block(ctx context.Context) (uint64, error) {
+	data, err := l.blockOracleABI.Pack("checkpoint")
+	if err != nil {
+		return 0, err
+	}
+	receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
+		TxData:   data,
+		To:       &l.blockOracleAddr,
+		GasLimit: 0,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to send checkpoint tx: %w", err)
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return 0, fmt.Errorf("checkpoint tx %s reverted", receipt.TxHash)
+	}
+	for _, rcptLog := range receipt.Logs {
+		if rcptLog.Address != l.blockOracleAddr {
+			continue
+		}
+		if checkpoint, err := l.blockOracleFilterer.ParseCheckpoint(*rcptLog); err == nil {
+			return checkpoint.BlockNumber.Uint64(), nil
+		}
+	}
+	return 0, fmt.Errorf("no checkpoint event in receipt of tx %s", receipt.TxHash)
+}
+
+// sendDisputeGameTransaction creates a dispute game for the output through the DisputeGameFactory,
+// anchored to an L1 block it first checkpoints in the BlockOracle.
+func (l *L2OutputSubmitter) sendDisputeGameTransaction(ctx context.Context, output *eth.OutputResponse) error {
+	l1BlockNumber, err := l.checkpointL1Block(ctx)
+	if err != nil {
+		return err
+	}
+	data, err := l.ProposeDisputeGameTxData(output, l1BlockNumber)
+	if err != nil {
+		return err
+	}
+	receipt, err := l.txMgr.Send(ctx, txmgr.TxCandidate{
+		TxData:   data,
+		To:       &l.dgfContractAddr,
+		GasLimit: 0,
+		Value:    l.gameBond,
+	})
+	if err != nil {
+		return err
+	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return fmt.Errorf("dispute game creation tx %s reverted", receipt.TxHash)
+	}
+	l.proposedGames[proposedGame{l2BlockNumber: output.BlockRef.Number, rootClaim: common.Hash(output.OutputRoot)}] = true
+	var game common.Address
+	for _, rcptLog := range receipt.Logs {
+		if rcptLog.Address != l.dgfContractAddr {
+			continue
+		}
+		if created, err := l.dgfFilterer.ParseDisputeGameCreated(*rcptLog); err == nil {
+			game = created.DisputeProxy
+			break
+		}
+	}
+	l.log.Info("dispute game created",
+		"tx_hash", receipt.TxHash,
+		"game", game,
+		"game_type", l.gameType,
+		"l2blocknum", output.BlockRef.Number,
+		"l1blocknum", l1BlockNumber)
+	l.metr.RecordDisputeGameCreated(output.BlockRef, l.gameType)
+	return nil
+}
+
 // loop is responsible for creating & submitting the next outputs
 func (l *L2OutputSubmitter) loop() {
 	defer l.wg.Done()
 
 	ctx := l.ctx
 
-	ticker := time.NewTicker(l.pollInterval)
+	interval := l.pollInterval
+	if l.dgfContract != nil {
+		interval = l.proposalInterval
+	}
+	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 	for {
 		select {
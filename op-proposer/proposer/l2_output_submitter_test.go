@@ -0,0 +1,278 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewL2OutputSubmitterRequiresProposalInterval(t *testing.T) {
+	addr := common.Address{0xdf}
+	_, err := NewL2OutputSubmitter(Config{DisputeGameFactoryAddr: &addr}, testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics)
+	require.ErrorContains(t, err, "proposal interval")
+}
+
+func TestSendDisputeGameTransaction(t *testing.T) {
+	setup := func(t *testing.T) (*L2OutputSubmitter, *backendTxManager) {
+		_, opts, backend, _, err := setupL2OutputOracle()
+		require.NoError(t, err)
+		blockOracleAddr, _, _, err := bindings.DeployBlockOracle(opts, backend)
+		require.NoError(t, err)
+		backend.Commit()
+		blockOracleFilterer, err := bindings.NewBlockOracleFilterer(blockOracleAddr, backend)
+		require.NoError(t, err)
+		blockOracleABI, err := bindings.BlockOracleMetaData.GetAbi()
+		require.NoError(t, err)
+		dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+		require.NoError(t, err)
+		txMgr := &backendTxManager{t: t, opts: opts, backend: backend, revert: make(map[common.Address]bool)}
+		submitter := &L2OutputSubmitter{
+			txMgr:               txMgr,
+			log:                 testlog.Logger(t, log.LvlInfo),
+			metr:                metrics.NoopMetrics,
+			dgfContractAddr:     common.Address{0xdf},
+			dgfABI:              dgfABI,
+			gameType:            1,
+			gameBond:            new(big.Int),
+			blockOracleAddr:     blockOracleAddr,
+			blockOracleABI:      blockOracleABI,
+			blockOracleFilterer: blockOracleFilterer,
+			proposedGames:       make(map[proposedGame]bool),
+		}
+		return submitter, txMgr
+	}
+	output := testutils.RandomOutputResponse(rand.New(rand.NewSource(1234)))
+	key := proposedGame{l2BlockNumber: output.BlockRef.Number, rootClaim: common.Hash(output.OutputRoot)}
+
+	t.Run("AnchorsToCheckpointedBlock", func(t *testing.T) {
+		submitter, txMgr := setup(t)
+		require.NoError(t, submitter.sendDisputeGameTransaction(context.Background(), output))
+		require.Len(t, txMgr.sent, 2)
+		require.Equal(t, submitter.blockOracleAddr, *txMgr.sent[0].To, "should checkpoint first")
+
+		// The game is anchored to the block checkpointed by the first tx
+		checkpointed := txMgr.receipts[0].BlockNumber.Uint64() - 1
+		expected, err := submitter.ProposeDisputeGameTxData(output, checkpointed)
+		require.NoError(t, err)
+		require.Equal(t, submitter.dgfContractAddr, *txMgr.sent[1].To)
+		require.Equal(t, expected, txMgr.sent[1].TxData)
+		require.True(t, submitter.proposedGames[key], "should remember the proposed output")
+	})
+
+	t.Run("RevertedCreation", func(t *testing.T) {
+		submitter, txMgr := setup(t)
+		txMgr.revert[submitter.dgfContractAddr] = true
+		require.ErrorContains(t, submitter.sendDisputeGameTransaction(context.Background(), output), "reverted")
+		require.False(t, submitter.proposedGames[key])
+	})
+
+	t.Run("RevertedCheckpoint", func(t *testing.T) {
+		submitter, txMgr := setup(t)
+		txMgr.revert[submitter.blockOracleAddr] = true
+		require.ErrorContains(t, submitter.sendDisputeGameTransaction(context.Background(), output), "checkpoint")
+		require.Len(t, txMgr.sent, 1, "should not create the game")
+	})
+}
+
+func TestGameExists(t *testing.T) {
+	output := testutils.RandomOutputResponse(rand.New(rand.NewSource(1234)))
+	output.BlockRef.L1Origin.Number = 100
+	setup := func(t *testing.T) (*L2OutputSubmitter, *stubGameSource) {
+		dgfAddr := common.Address{0xdf}
+		source := &stubGameSource{t: t, dgfAddr: dgfAddr, extraData: make(map[common.Address][]byte)}
+		dgfFilterer, err := bindings.NewDisputeGameFactoryFilterer(dgfAddr, source)
+		require.NoError(t, err)
+		submitter := &L2OutputSubmitter{
+			log:             testlog.Logger(t, log.LvlInfo),
+			dgfContractAddr: dgfAddr,
+			dgfFilterer:     dgfFilterer,
+			gameType:        1,
+			l1Client:        source,
+			proposedGames:   make(map[proposedGame]bool),
+			networkTimeout:  time.Minute,
+		}
+		return submitter, source
+	}
+
+	t.Run("NoGames", func(t *testing.T) {
+		submitter, source := setup(t)
+		exists, err := submitter.gameExists(context.Background(), output)
+		require.NoError(t, err)
+		require.False(t, exists)
+		require.Len(t, source.queries, 1)
+		require.Equal(t, big.NewInt(100), source.queries[0].FromBlock, "should only search since the l1 origin of the output")
+	})
+
+	t.Run("GameOfOutput", func(t *testing.T) {
+		submitter, source := setup(t)
+		source.addGame(common.Address{0xaa}, 1, output.OutputRoot, output.BlockRef.Number, 101)
+		exists, err := submitter.gameExists(context.Background(), output)
+		require.NoError(t, err)
+		require.True(t, exists)
+
+		// Known games are not fetched again
+		exists, err = submitter.gameExists(context.Background(), output)
+		require.NoError(t, err)
+		require.True(t, exists)
+		require.Len(t, source.queries, 1)
+	})
+
+	t.Run("OtherGames", func(t *testing.T) {
+		submitter, source := setup(t)
+		source.addGame(common.Address{0xaa}, 2, output.OutputRoot, output.BlockRef.Number, 101)
+		source.addGame(common.Address{0xbb}, 1, eth.Bytes32{0x01}, output.BlockRef.Number, 101)
+		source.addGame(common.Address{0xcc}, 1, output.OutputRoot, output.BlockRef.Number+1, 101)
+		exists, err := submitter.gameExists(context.Background(), output)
+		require.NoError(t, err)
+		require.False(t, exists)
+		require.Equal(t, 1, source.calls, "should only fetch the games with the output as root claim")
+	})
+}
+
+// stubGameSource serves the creation events of the dispute game factory at dgfAddr, filtered by their topics,
+// and the extra data of the created games.
+type stubGameSource struct {
+	t         *testing.T
+	dgfAddr   common.Address
+	logs      []types.Log
+	extraData map[common.Address][]byte
+	queries   []ethereum.FilterQuery
+	calls     int
+}
+
+func (s *stubGameSource) addGame(addr common.Address, gameType uint8, rootClaim eth.Bytes32, l2BlockNumber uint64, l1BlockNumber uint64) {
+	dgfABI, err := bindings.DisputeGameFactoryMetaData.GetAbi()
+	require.NoError(s.t, err)
+	s.logs = append(s.logs, types.Log{
+		Address: s.dgfAddr,
+		Topics: []common.Hash{
+			dgfABI.Events["DisputeGameCreated"].ID,
+			common.BytesToHash(addr.Bytes()),
+			common.BigToHash(new(big.Int).SetUint64(uint64(gameType))),
+			common.Hash(rootClaim),
+		},
+		BlockNumber: l1BlockNumber,
+	})
+	s.extraData[addr] = disputeGameExtraData(&eth.OutputResponse{BlockRef: eth.L2BlockRef{Number: l2BlockNumber}}, l1BlockNumber)
+}
+
+func (s *stubGameSource) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	s.queries = append(s.queries, q)
+	var result []types.Log
+	for _, l := range s.logs {
+		if q.FromBlock != nil && l.BlockNumber < q.FromBlock.Uint64() {
+			continue
+		}
+		if matchesTopics(l.Topics, q.Topics) {
+			result = append(result, l)
+		}
+	}
+	return result, nil
+}
+
+func matchesTopics(topics []common.Hash, filter [][]common.Hash) bool {
+	for i, options := range filter {
+		if len(options) == 0 {
+			continue
+		}
+		matched := false
+		for _, option := range options {
+			matched = matched || topics[i] == option
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *stubGameSource) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s *stubGameSource) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (s *stubGameSource) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	s.calls++
+	gameABI, err := bindings.FaultDisputeGameMetaData.GetAbi()
+	require.NoError(s.t, err)
+	extraData, ok := s.extraData[*call.To]
+	require.True(s.t, ok, "unknown game %s", call.To)
+	return gameABI.Methods["extraData"].Outputs.Pack(extraData)
+}
+
+// backendTxManager is a [txmgr.TxManager] that sends transactions to a simulated backend,
+// mining a block for each. Transactions to addresses in revert are not sent, and get a failed receipt.
+type backendTxManager struct {
+	t        *testing.T
+	opts     *bind.TransactOpts
+	backend  *backends.SimulatedBackend
+	revert   map[common.Address]bool
+	sent     []txmgr.TxCandidate
+	receipts []*types.Receipt
+}
+
+func (b *backendTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*types.Receipt, error) {
+	b.sent = append(b.sent, candidate)
+	if b.revert[*candidate.To] {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+	}
+	nonce, err := b.backend.PendingNonceAt(ctx, b.opts.From)
+	require.NoError(b.t, err)
+	value := candidate.Value
+	if value == nil {
+		value = new(big.Int)
+	}
+	tx := types.NewTx(&types.LegacyTx{
+		Nonce:    nonce,
+		To:       candidate.To,
+		Value:    value,
+		Gas:      1_000_000,
+		GasPrice: big.NewInt(10 * params.GWei),
+		Data:     candidate.TxData,
+	})
+	signed, err := b.opts.Signer(b.opts.From, tx)
+	require.NoError(b.t, err)
+	require.NoError(b.t, b.backend.SendTransaction(ctx, signed))
+	b.backend.Commit()
+	receipt, err := b.backend.TransactionReceipt(ctx, signed.Hash())
+	require.NoError(b.t, err)
+	b.receipts = append(b.receipts, receipt)
+	return receipt, nil
+}
+
+func (b *backendTxManager) Call(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return b.backend.CallContract(ctx, msg, blockNumber)
+}
+
+func (b *backendTxManager) From() common.Address {
+	return b.opts.From
+}
+
+func (b *backendTxManager) BlockNumber(ctx context.Context) (uint64, error) {
+	header, err := b.backend.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
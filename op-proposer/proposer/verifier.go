@@ -0,0 +1,144 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+)
+
+var ErrOutputMismatch = errors.New("output mismatch")
+
+// OutputSource is a source of L2 outputs, e.g. a secondary rollup node.
+type OutputSource interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// L2ProofSource provides the L2 block headers and account proofs required to recompute an output root.
+type L2ProofSource interface {
+	HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error)
+	GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error)
+}
+
+// OutputVerifier cross-checks outputs fetched from the primary rollup node before they are proposed.
+// Outputs are compared against the outputs of each secondary rollup node, and optionally recomputed
+// from the L2 block header and the storage root of the L2ToL1MessagePasser.
+type OutputVerifier struct {
+	log  log.Logger
+	metr metrics.Metricer
+
+	rollupSources []OutputSource
+	l2Source      L2ProofSource
+
+	networkTimeout time.Duration
+}
+
+// NewOutputVerifier creates a new OutputVerifier. The l2Source may be nil to skip local recomputation.
+func NewOutputVerifier(l log.Logger, m metrics.Metricer, rollupSources []OutputSource, l2Source L2ProofSource, networkTimeout time.Duration) *OutputVerifier {
+	return &OutputVerifier{
+		log:            l,
+		metr:           m,
+		rollupSources:  rollupSources,
+		l2Source:       l2Source,
+		networkTimeout: networkTimeout,
+	}
+}
+
+// Verify checks the output against every configured source.
+// It returns an error wrapping [ErrOutputMismatch] if any source disagrees with the output,
+// or a different error if a source could not be queried. In either case the output must not be proposed.
+func (v *OutputVerifier) Verify(ctx context.Context, output *eth.OutputResponse) error {
+	for i, src := range v.rollupSources {
+		name := fmt.Sprintf("rollup_%d", i)
+		cCtx, cancel := context.WithTimeout(ctx, v.networkTimeout)
+		other, err := src.OutputAtBlock(cCtx, output.BlockRef.Number)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to fetch output at block %d from %s: %w", output.BlockRef.Number, name, err)
+		}
+		if other.OutputRoot != output.OutputRoot {
+			v.recordMismatch(name, output, other.OutputRoot)
+			return fmt.Errorf("%w: %s reported output root %s for block %d, expected %s",
+				ErrOutputMismatch, name, other.OutputRoot, output.BlockRef.Number, output.OutputRoot)
+		}
+	}
+	if v.l2Source != nil {
+		root, err := v.computeOutputRoot(ctx, output.BlockRef)
+		if err != nil {
+			return fmt.Errorf("failed to recompute output root at block %d: %w", output.BlockRef.Number, err)
+		}
+		if root != output.OutputRoot {
+			v.recordMismatch("l2_proof", output, root)
+			return fmt.Errorf("%w: recomputed output root %s for block %d, expected %s",
+				ErrOutputMismatch, root, output.BlockRef.Number, output.OutputRoot)
+		}
+	}
+	v.metr.RecordOutputVerified(output.BlockRef)
+	return nil
+}
+
+func (v *OutputVerifier) recordMismatch(source string, output *eth.OutputResponse, other eth.Bytes32) {
+	v.log.Error("output mismatch, refusing to propose",
+		"source", source,
+		"l2_block", output.BlockRef,
+		"expected", output.OutputRoot,
+		"actual", other)
+	v.metr.RecordOutputMismatch(source)
+}
+
+// computeOutputRoot recomputes the V0 output root of the given L2 block from its header
+// and a verified account proof of the L2ToL1MessagePasser.
+func (v *OutputVerifier) computeOutputRoot(ctx context.Context, ref eth.L2BlockRef) (eth.Bytes32, error) {
+	cCtx, cancel := context.WithTimeout(ctx, v.networkTimeout)
+	defer cancel()
+	header, err := v.l2Source.HeaderByHash(cCtx, ref.Hash)
+	if err != nil {
+		return eth.Bytes32{}, fmt.Errorf("failed to get L2 block header: %w", err)
+	}
+	if header.Number.Uint64() != ref.Number {
+		return eth.Bytes32{}, fmt.Errorf("L2 block %s has number %d, expected %d", ref.Hash, header.Number, ref.Number)
+	}
+
+	cCtx, cancel = context.WithTimeout(ctx, v.networkTimeout)
+	defer cancel()
+	proof, err := v.l2Source.GetProof(cCtx, predeploys.L2ToL1MessagePasserAddr, []common.Hash{}, ref.Hash.String())
+	if err != nil {
+		return eth.Bytes32{}, fmt.Errorf("failed to get message passer proof: %w", err)
+	}
+	if proof == nil {
+		return eth.Bytes32{}, errors.New("message passer proof not found")
+	}
+	// Make sure the storage root is committed to by the state root of the block
+	if err := proof.Verify(header.Root); err != nil {
+		return eth.Bytes32{}, fmt.Errorf("invalid message passer proof: %w", err)
+	}
+	return eth.OutputRoot(&eth.OutputV0{
+		StateRoot:                eth.Bytes32(header.Root),
+		MessagePasserStorageRoot: eth.Bytes32(proof.StorageHash),
+		BlockHash:                header.Hash(),
+	}), nil
+}
+
+// ethProofClient adds eth_getProof support to an [ethclient.Client].
+type ethProofClient struct {
+	*ethclient.Client
+}
+
+var _ L2ProofSource = (*ethProofClient)(nil)
+
+func (c *ethProofClient) GetProof(ctx context.Context, address common.Address, storage []common.Hash, blockTag string) (*eth.AccountResult, error) {
+	var result *eth.AccountResult
+	if err := c.Client.Client().CallContext(ctx, &result, "eth_getProof", address, storage, blockTag); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
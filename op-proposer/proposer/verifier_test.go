@@ -0,0 +1,159 @@
+package proposer
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/predeploys"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-proposer/metrics"
+)
+
+type stubOutputSource struct {
+	output *eth.OutputResponse
+	err    error
+}
+
+func (s *stubOutputSource) OutputAtBlock(_ context.Context, _ uint64) (*eth.OutputResponse, error) {
+	return s.output, s.err
+}
+
+type stubL2ProofSource struct {
+	header *types.Header
+	proof  *eth.AccountResult
+}
+
+func (s *stubL2ProofSource) HeaderByHash(_ context.Context, _ common.Hash) (*types.Header, error) {
+	return s.header, nil
+}
+
+func (s *stubL2ProofSource) GetProof(_ context.Context, _ common.Address, _ []common.Hash, _ string) (*eth.AccountResult, error) {
+	return s.proof, nil
+}
+
+type mismatchMetrics struct {
+	metrics.Metricer
+	verified   int
+	mismatches []string
+}
+
+func (m *mismatchMetrics) RecordOutputVerified(eth.L2BlockRef) {
+	m.verified++
+}
+
+func (m *mismatchMetrics) RecordOutputMismatch(source string) {
+	m.mismatches = append(m.mismatches, source)
+}
+
+// newL2ProofSource creates a state with only the L2ToL1MessagePasser account and returns the matching
+// header and account proof, along with the output that commits to them.
+func newL2ProofSource(t *testing.T, rng *rand.Rand) (*stubL2ProofSource, *eth.OutputResponse) {
+	storageRoot := testutils.RandomHash(rng)
+	codeHash := testutils.RandomHash(rng)
+	account, err := rlp.EncodeToBytes([]any{uint64(1), big.NewInt(0).Bytes(), storageRoot, codeHash})
+	require.NoError(t, err)
+
+	tr := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	key := crypto.Keccak256(predeploys.L2ToL1MessagePasserAddr[:])
+	require.NoError(t, tr.Update(key, account))
+	stateRoot := tr.Hash()
+
+	proofDB := memorydb.New()
+	require.NoError(t, tr.Prove(key, 0, proofDB))
+	var accountProof []hexutil.Bytes
+	it := proofDB.NewIterator(nil, nil)
+	for it.Next() {
+		accountProof = append(accountProof, common.CopyBytes(it.Value()))
+	}
+	it.Release()
+
+	header := &types.Header{Number: big.NewInt(42), Root: stateRoot}
+	proof := &eth.AccountResult{
+		AccountProof: accountProof,
+		Address:      predeploys.L2ToL1MessagePasserAddr,
+		Balance:      (*hexutil.Big)(big.NewInt(0)),
+		CodeHash:     codeHash,
+		Nonce:        1,
+		StorageHash:  storageRoot,
+	}
+	output := &eth.OutputResponse{
+		OutputRoot: eth.OutputRoot(&eth.OutputV0{
+			StateRoot:                eth.Bytes32(stateRoot),
+			MessagePasserStorageRoot: eth.Bytes32(storageRoot),
+			BlockHash:                header.Hash(),
+		}),
+		BlockRef: eth.L2BlockRef{Hash: header.Hash(), Number: 42},
+	}
+	return &stubL2ProofSource{header: header, proof: proof}, output
+}
+
+func TestOutputVerifier(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	l2Source, output := newL2ProofSource(t, rng)
+
+	setup := func(t *testing.T, rollupSources []OutputSource, l2Source L2ProofSource) (*OutputVerifier, *mismatchMetrics) {
+		m := &mismatchMetrics{Metricer: metrics.NoopMetrics}
+		return NewOutputVerifier(testlog.Logger(t, log.LvlError), m, rollupSources, l2Source, time.Second), m
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		v, m := setup(t, []OutputSource{&stubOutputSource{output: output}}, l2Source)
+		require.NoError(t, v.Verify(context.Background(), output))
+		require.Equal(t, 1, m.verified)
+		require.Empty(t, m.mismatches)
+	})
+
+	t.Run("SecondaryMismatch", func(t *testing.T) {
+		other := *output
+		other.OutputRoot = eth.Bytes32(testutils.RandomHash(rng))
+		v, m := setup(t, []OutputSource{&stubOutputSource{output: output}, &stubOutputSource{output: &other}}, nil)
+		require.ErrorIs(t, v.Verify(context.Background(), output), ErrOutputMismatch)
+		require.Zero(t, m.verified)
+		require.Equal(t, []string{"rollup_1"}, m.mismatches)
+	})
+
+	t.Run("SecondaryError", func(t *testing.T) {
+		srcErr := errors.New("boom")
+		v, m := setup(t, []OutputSource{&stubOutputSource{err: srcErr}}, nil)
+		err := v.Verify(context.Background(), output)
+		require.ErrorIs(t, err, srcErr)
+		require.NotErrorIs(t, err, ErrOutputMismatch)
+		require.Zero(t, m.verified)
+		require.Empty(t, m.mismatches)
+	})
+
+	t.Run("RecomputedMismatch", func(t *testing.T) {
+		other := *output
+		other.OutputRoot = eth.Bytes32(testutils.RandomHash(rng))
+		v, m := setup(t, nil, l2Source)
+		require.ErrorIs(t, v.Verify(context.Background(), &other), ErrOutputMismatch)
+		require.Equal(t, []string{"l2_proof"}, m.mismatches)
+	})
+
+	t.Run("InvalidProof", func(t *testing.T) {
+		badProof := *l2Source.proof
+		badProof.StorageHash = testutils.RandomHash(rng)
+		v, m := setup(t, nil, &stubL2ProofSource{header: l2Source.header, proof: &badProof})
+		err := v.Verify(context.Background(), output)
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrOutputMismatch)
+		require.Empty(t, m.mismatches)
+	})
+}
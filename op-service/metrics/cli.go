@@ -13,6 +13,9 @@ const (
 	EnabledFlagName    = "metrics.enabled"
 	ListenAddrFlagName = "metrics.addr"
 	PortFlagName       = "metrics.port"
+
+	defaultListenAddr = "0.0.0.0" // TODO(CLI-4159): Switch to 127.0.0.1
+	defaultListenPort = 7300
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -25,13 +28,13 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		&cli.StringFlag{
 			Name:    ListenAddrFlagName,
 			Usage:   "Metrics listening address",
-			Value:   "0.0.0.0", // TODO(CLI-4159): Switch to 127.0.0.1
+			Value:   defaultListenAddr,
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "METRICS_ADDR"),
 		},
 		&cli.IntFlag{
 			Name:    PortFlagName,
 			Usage:   "Metrics listening port",
-			Value:   7300,
+			Value:   defaultListenPort,
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "METRICS_PORT"),
 		},
 	}
@@ -43,6 +46,13 @@ type CLIConfig struct {
 	ListenPort int
 }
 
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		ListenAddr: defaultListenAddr,
+		ListenPort: defaultListenPort,
+	}
+}
+
 func (m CLIConfig) Check() error {
 	if !m.Enabled {
 		return nil
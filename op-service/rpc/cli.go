@@ -11,6 +11,9 @@ import (
 const (
 	ListenAddrFlagName = "rpc.addr"
 	PortFlagName       = "rpc.port"
+
+	defaultListenAddr = "0.0.0.0" // TODO(CLI-4159): Switch to 127.0.0.1
+	defaultListenPort = 8545
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -18,13 +21,13 @@ func CLIFlags(envPrefix string) []cli.Flag {
 		&cli.StringFlag{
 			Name:    ListenAddrFlagName,
 			Usage:   "rpc listening address",
-			Value:   "0.0.0.0", // TODO(CLI-4159): Switch to 127.0.0.1
+			Value:   defaultListenAddr,
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_ADDR"),
 		},
 		&cli.IntFlag{
 			Name:    PortFlagName,
 			Usage:   "rpc listening port",
-			Value:   8545,
+			Value:   defaultListenPort,
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "RPC_PORT"),
 		},
 	}
@@ -35,6 +38,13 @@ type CLIConfig struct {
 	ListenPort int
 }
 
+func DefaultCLIConfig() CLIConfig {
+	return CLIConfig{
+		ListenAddr: defaultListenAddr,
+		ListenPort: defaultListenPort,
+	}
+}
+
 func (c CLIConfig) Check() error {
 	if c.ListenPort < 0 || c.ListenPort > math.MaxUint16 {
 		return errors.New("invalid RPC port")
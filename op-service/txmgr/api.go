@@ -0,0 +1,41 @@
+package txmgr
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// DebugNamespace is the RPC namespace of the transaction manager debug API.
+const DebugNamespace = "txmgr"
+
+// PendingTxReporter is implemented by transaction managers that track the nonces of their in flight transactions.
+type PendingTxReporter interface {
+	PendingNonces() []NonceState
+	NonceGaps() []uint64
+}
+
+var _ PendingTxReporter = (*SimpleTxManager)(nil)
+
+type debugAPI struct {
+	r PendingTxReporter
+}
+
+// NewDebugAPI creates the debug RPC API of the transaction manager, which exposes its pending transactions.
+// Services register it on their RPC server if their transaction manager is a [PendingTxReporter].
+func NewDebugAPI(r PendingTxReporter) rpc.API {
+	return rpc.API{
+		Namespace: DebugNamespace,
+		Service:   &debugAPI{r: r},
+	}
+}
+
+// PendingNonces returns every nonce in use with all transactions broadcast at it.
+func (a *debugAPI) PendingNonces(_ context.Context) ([]NonceState, error) {
+	return a.r.PendingNonces(), nil
+}
+
+// NonceGaps returns the abandoned nonces that block the inclusion of transactions at higher nonces.
+func (a *debugAPI) NonceGaps(_ context.Context) ([]uint64, error) {
+	return a.r.NonceGaps(), nil
+}
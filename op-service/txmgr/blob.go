@@ -0,0 +1,114 @@
+package txmgr
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// blobCommitmentVersionKZG is the version byte of versioned hashes of KZG commitments, see EIP-4844.
+const blobCommitmentVersionKZG uint8 = 0x01
+
+var ErrBlobsUnsupported = errors.New("backend does not support blob transactions")
+
+// BlobBackend is implemented by backends that can publish blob transactions.
+// Blob transactions must be published in their network encoding, which includes the blob
+// sidecar, so they cannot be published through [ETHBackend.SendTransaction].
+type BlobBackend interface {
+	// SendRawTransaction submits a network encoded transaction to L1.
+	SendRawTransaction(ctx context.Context, rawTx []byte) error
+}
+
+// BlobTxSidecar contains the blobs of a blob transaction, along with their KZG commitments and proofs.
+// It is not part of the signed transaction, but has to accompany it when the transaction is published.
+type BlobTxSidecar struct {
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+// MakeSidecar computes the KZG commitments and proofs of the given blobs.
+func MakeSidecar(blobs []kzg4844.Blob) (*BlobTxSidecar, error) {
+	sidecar := &BlobTxSidecar{
+		Blobs:       blobs,
+		Commitments: make([]kzg4844.Commitment, len(blobs)),
+		Proofs:      make([]kzg4844.Proof, len(blobs)),
+	}
+	for i, blob := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(blob)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute KZG commitment of blob %d: %w", i, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute KZG proof of blob %d: %w", i, err)
+		}
+		sidecar.Commitments[i] = commitment
+		sidecar.Proofs[i] = proof
+	}
+	return sidecar, nil
+}
+
+// BlobHashes returns the versioned hashes of the sidecar's commitments.
+func (s *BlobTxSidecar) BlobHashes() []common.Hash {
+	hashes := make([]common.Hash, len(s.Commitments))
+	for i, commitment := range s.Commitments {
+		hashes[i] = kzgToVersionedHash(commitment)
+	}
+	return hashes
+}
+
+// NetworkEncoding returns the network encoding of the signed blob transaction:
+//
+//	0x03 || rlp([tx_payload_body, blobs, commitments, proofs])
+func (s *BlobTxSidecar) NetworkEncoding(tx *types.Transaction) ([]byte, error) {
+	if tx.Type() != types.BlobTxType {
+		return nil, fmt.Errorf("not a blob transaction: type %d", tx.Type())
+	}
+	if len(tx.BlobHashes()) != len(s.Blobs) {
+		return nil, fmt.Errorf("transaction commits to %d blobs, but sidecar has %d", len(tx.BlobHashes()), len(s.Blobs))
+	}
+	enc, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// Strip the type byte to get the RLP encoded payload body
+	wrapped, err := rlp.EncodeToBytes([]any{rlp.RawValue(enc[1:]), s.Blobs, s.Commitments, s.Proofs})
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{types.BlobTxType}, wrapped...), nil
+}
+
+// kzgToVersionedHash implements kzg_to_versioned_hash from EIP-4844.
+func kzgToVersionedHash(commitment kzg4844.Commitment) common.Hash {
+	h := sha256.Sum256(commitment[:])
+	h[0] = blobCommitmentVersionKZG
+	return h
+}
+
+// calcBlobFeeCap computes the recommended blob fee cap given the blob base fee.
+// The resulting blobFeeCap is equal to 2*blobBaseFee, mirroring [calcGasFeeCap].
+func calcBlobFeeCap(blobBaseFee *big.Int) *big.Int {
+	return new(big.Int).Mul(blobBaseFee, big.NewInt(2))
+}
+
+// ethBlobClient adds support for publishing raw transactions to an [ethclient.Client].
+type ethBlobClient struct {
+	*ethclient.Client
+}
+
+var _ BlobBackend = (*ethBlobClient)(nil)
+
+func (c *ethBlobClient) SendRawTransaction(ctx context.Context, rawTx []byte) error {
+	return c.Client.Client().CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Bytes(rawTx))
+}
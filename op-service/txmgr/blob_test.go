@@ -0,0 +1,211 @@
+package txmgr
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+// blobNetworkTx is the network encoding of a blob tx, excluding the leading type byte.
+type blobNetworkTx struct {
+	Tx          rlp.RawValue
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+func decodeBlobNetworkTx(raw []byte) (*types.Transaction, *BlobTxSidecar, error) {
+	var wrapped blobNetworkTx
+	if err := rlp.DecodeBytes(raw[1:], &wrapped); err != nil {
+		return nil, nil, err
+	}
+	var tx types.Transaction
+	if err := tx.UnmarshalBinary(append([]byte{types.BlobTxType}, wrapped.Tx...)); err != nil {
+		return nil, nil, err
+	}
+	return &tx, &BlobTxSidecar{Blobs: wrapped.Blobs, Commitments: wrapped.Commitments, Proofs: wrapped.Proofs}, nil
+}
+
+func testBlobs(n int) []kzg4844.Blob {
+	blobs := make([]kzg4844.Blob, n)
+	for i := range blobs {
+		// Keep the top byte of each field element zero so that it is canonical.
+		for j := 1; j < len(blobs[i]); j += 32 {
+			blobs[i][j] = byte(i + j)
+		}
+	}
+	return blobs
+}
+
+func TestMakeSidecar(t *testing.T) {
+	blobs := testBlobs(2)
+	sidecar, err := MakeSidecar(blobs)
+	require.NoError(t, err)
+	require.Len(t, sidecar.Commitments, 2)
+	require.Len(t, sidecar.Proofs, 2)
+
+	hashes := sidecar.BlobHashes()
+	require.Len(t, hashes, 2)
+	for i := range blobs {
+		require.NoError(t, kzg4844.VerifyBlobProof(blobs[i], sidecar.Commitments[i], sidecar.Proofs[i]))
+		require.Equal(t, blobCommitmentVersionKZG, hashes[i][0])
+	}
+	require.NotEqual(t, hashes[0], hashes[1])
+}
+
+func TestMakeSidecarInvalidBlob(t *testing.T) {
+	blobs := testBlobs(1)
+	// A field element larger than the BLS modulus is not canonical.
+	for i := 0; i < 32; i++ {
+		blobs[0][i] = 0xff
+	}
+	_, err := MakeSidecar(blobs)
+	require.Error(t, err)
+}
+
+func TestBlobNetworkEncoding(t *testing.T) {
+	sidecar, err := MakeSidecar(testBlobs(1))
+	require.NoError(t, err)
+	to := common.Address{0xaa}
+	tx := types.NewTx(&types.BlobTx{
+		Nonce:      3,
+		To:         &to,
+		Data:       []byte{0x01},
+		BlobHashes: sidecar.BlobHashes(),
+	})
+
+	raw, err := sidecar.NetworkEncoding(tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), raw[0])
+
+	decodedTx, decodedSidecar, err := decodeBlobNetworkTx(raw)
+	require.NoError(t, err)
+	require.Equal(t, tx.Hash(), decodedTx.Hash())
+	require.Equal(t, sidecar, decodedSidecar)
+
+	_, err = sidecar.NetworkEncoding(types.NewTx(&types.DynamicFeeTx{}))
+	require.Error(t, err, "must not encode non-blob txs")
+}
+
+func TestTxMgr_CraftBlobTx(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	h.backend.excessDataGas = big.NewInt(10_000_000)
+	candidate := h.createTxCandidate()
+	candidate.Blobs = testBlobs(2)
+
+	tx, sidecar, err := h.mgr.craftTx(context.Background(), candidate)
+	require.NoError(t, err)
+	require.NotNil(t, sidecar)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+	require.Equal(t, sidecar.BlobHashes(), tx.BlobHashes())
+	require.Equal(t, calcBlobFeeCap(misc.CalcBlobFee(h.backend.excessDataGas)), tx.BlobGasFeeCap())
+	require.Equal(t, candidate.GasLimit, tx.Gas())
+}
+
+func TestTxMgr_CraftBlobTxPreCancun(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	candidate.Blobs = testBlobs(1)
+
+	_, _, err := h.mgr.craftTx(context.Background(), candidate)
+	require.ErrorContains(t, err, "Cancun")
+}
+
+func TestTxMgr_SendBlobTx(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	h.backend.excessDataGas = big.NewInt(0)
+	candidate := h.createTxCandidate()
+	candidate.Blobs = testBlobs(1)
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		require.Equal(t, uint8(types.BlobTxType), tx.Type())
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Len(t, h.backend.rawTxs, 1)
+}
+
+func TestIncreaseBlobGasPrice(t *testing.T) {
+	t.Parallel()
+	require.Equal(t, int64(100), blobPriceBump, "test must be updated if blobPriceBump is adjusted")
+
+	backend := failingBackend{
+		gasTip:        big.NewInt(100),
+		baseFee:       big.NewInt(1000),
+		excessDataGas: big.NewInt(0),
+	}
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+		},
+		name:    "TEST",
+		backend: &backend,
+		l:       testlog.Logger(t, log.LvlCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+
+	blobHashes := []common.Hash{{0x01}}
+	tx := types.NewTx(&types.BlobTx{
+		GasTipCap:  uint256.NewInt(100),
+		GasFeeCap:  uint256.NewInt(2100),
+		BlobFeeCap: uint256.NewInt(1),
+		BlobHashes: blobHashes,
+	})
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), newTx.Type())
+	require.Equal(t, blobHashes, newTx.BlobHashes())
+	// Geth requires all fees of a replacement blob tx to be bumped by 100%
+	require.Equal(t, big.NewInt(200), newTx.GasTipCap(), "tip must be bumped by 100%")
+	require.Equal(t, big.NewInt(4200), newTx.GasFeeCap(), "fee cap must be bumped by 100%")
+	require.Equal(t, big.NewInt(2), newTx.BlobGasFeeCap(), "blob fee cap must be bumped by 100%")
+
+	// The blob fee cap must be capped at a multiple of the suggested value
+	for i := 0; i < 10; i++ {
+		newTx, err = mgr.increaseGasPrice(context.Background(), newTx)
+		require.NoError(t, err)
+	}
+	require.Equal(t, calcBlobFeeCap(big.NewInt(feeLimitMultiplier)), newTx.BlobGasFeeCap())
+}
+
+func TestUpdateFeesBlobTx(t *testing.T) {
+	lgr := testlog.Logger(t, log.LvlCrit)
+	tip, feeCap := updateFees(big.NewInt(100), big.NewInt(2100), big.NewInt(90), big.NewInt(900), true, lgr)
+	require.Equal(t, big.NewInt(200), tip, "uses threshold tip")
+	require.Equal(t, big.NewInt(4200), feeCap, "uses threshold fee cap")
+	tip, feeCap = updateFees(big.NewInt(100), big.NewInt(2100), big.NewInt(300), big.NewInt(2000), true, lgr)
+	require.Equal(t, big.NewInt(300), tip, "uses new tip")
+	require.Equal(t, big.NewInt(4300), feeCap, "uses new fee cap")
+}
+
+func TestUpdateBlobFee(t *testing.T) {
+	lgr := testlog.Logger(t, log.LvlCrit)
+	require.Equal(t, big.NewInt(200), updateBlobFee(big.NewInt(100), big.NewInt(50), lgr), "uses threshold")
+	require.Equal(t, big.NewInt(300), updateBlobFee(big.NewInt(100), big.NewInt(150), lgr), "uses new blob fee cap")
+}
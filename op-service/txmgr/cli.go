@@ -31,6 +31,7 @@ const (
 	TxSendTimeoutFlagName             = "txmgr.send-timeout"
 	TxNotInMempoolTimeoutFlagName     = "txmgr.not-in-mempool-timeout"
 	ReceiptQueryIntervalFlagName      = "txmgr.receipt-query-interval"
+	FillNonceGapsFlagName             = "txmgr.fill-nonce-gaps"
 )
 
 var (
@@ -120,6 +121,11 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			Value:   defaultReceiptQueryInterval,
 			EnvVars: prefixEnvVars("TXMGR_RECEIPT_QUERY_INTERVAL"),
 		},
+		&cli.BoolFlag{
+			Name:    FillNonceGapsFlagName,
+			Usage:   "Fill the nonces of failed sends with cancellation txs when txs at higher nonces are in flight, instead of resetting the nonce",
+			EnvVars: prefixEnvVars("TXMGR_FILL_NONCE_GAPS"),
+		},
 	}, client.CLIFlags(envPrefix)...)
 }
 
@@ -138,6 +144,7 @@ type CLIConfig struct {
 	NetworkTimeout            time.Duration
 	TxSendTimeout             time.Duration
 	TxNotInMempoolTimeout     time.Duration
+	FillNonceGaps             bool
 }
 
 func NewCLIConfig(l1RPCURL string) CLIConfig {
@@ -198,6 +205,7 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		NetworkTimeout:            ctx.Duration(NetworkTimeoutFlagName),
 		TxSendTimeout:             ctx.Duration(TxSendTimeoutFlagName),
 		TxNotInMempoolTimeout:     ctx.Duration(TxNotInMempoolTimeoutFlagName),
+		FillNonceGaps:             ctx.Bool(FillNonceGapsFlagName),
 	}
 }
 
@@ -234,7 +242,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 	}
 
 	return Config{
-		Backend:                   l1,
+		Backend:                   &ethBlobClient{l1},
 		ResubmissionTimeout:       cfg.ResubmissionTimeout,
 		ChainID:                   chainID,
 		TxSendTimeout:             cfg.TxSendTimeout,
@@ -243,6 +251,7 @@ func NewConfig(cfg CLIConfig, l log.Logger) (Config, error) {
 		ReceiptQueryInterval:      cfg.ReceiptQueryInterval,
 		NumConfirmations:          cfg.NumConfirmations,
 		SafeAbortNonceTooLowCount: cfg.SafeAbortNonceTooLowCount,
+		FillNonceGaps:             cfg.FillNonceGaps,
 		Signer:                    signerFactory(chainID),
 		From:                      from,
 	}, nil
@@ -286,6 +295,10 @@ type Config struct {
 	// confirmation.
 	SafeAbortNonceTooLowCount uint64
 
+	// FillNonceGaps enables filling the nonces of failed sends with cancellation txs while
+	// transactions at higher nonces are in flight. Otherwise the nonce is reset after a failed send.
+	FillNonceGaps bool
+
 	// Signer is used to sign transactions when the gas price is increased.
 	Signer opcrypto.SignerFn
 	From   common.Address
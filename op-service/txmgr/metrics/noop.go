@@ -1,11 +1,18 @@
 package metrics
 
-import "github.com/ethereum/go-ethereum/core/types"
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
 
 type NoopTxMetrics struct{}
 
 func (*NoopTxMetrics) RecordNonce(uint64)                {}
 func (*NoopTxMetrics) RecordPendingTx(int64)             {}
+func (*NoopTxMetrics) RecordBlobBaseFee(*big.Int)        {}
+func (*NoopTxMetrics) RecordNonceGaps(int)               {}
+func (*NoopTxMetrics) NonceGapFilled(bool)               {}
 func (*NoopTxMetrics) RecordGasBumpCount(int)            {}
 func (*NoopTxMetrics) RecordTxConfirmationLatency(int64) {}
 func (*NoopTxMetrics) TxConfirmed(*types.Receipt)        {}
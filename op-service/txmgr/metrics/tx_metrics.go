@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"math/big"
+
 	"github.com/ethereum-optimism/optimism/op-service/metrics"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/params"
@@ -13,6 +15,9 @@ type TxMetricer interface {
 	RecordTxConfirmationLatency(int64)
 	RecordNonce(uint64)
 	RecordPendingTx(pending int64)
+	RecordBlobBaseFee(*big.Int)
+	RecordNonceGaps(gaps int)
+	NonceGapFilled(success bool)
 	TxConfirmed(*types.Receipt)
 	TxPublished(string)
 	RPCError()
@@ -23,9 +28,12 @@ type TxMetrics struct {
 	txFees             prometheus.Counter
 	TxGasBump          prometheus.Gauge
 	txFeeHistogram     prometheus.Histogram
+	blobBaseFee        prometheus.Gauge
 	LatencyConfirmedTx prometheus.Gauge
 	currentNonce       prometheus.Gauge
 	pendingTxs         prometheus.Gauge
+	nonceGaps          prometheus.Gauge
+	nonceGapFills      *prometheus.CounterVec
 	txPublishError     *prometheus.CounterVec
 	publishEvent       metrics.Event
 	confirmEvent       metrics.EventVec
@@ -66,6 +74,12 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Subsystem: "txmgr",
 			Buckets:   []float64{0.5, 1, 2, 5, 10, 20, 40, 60, 80, 100, 200, 400, 800, 1600},
 		}),
+		blobBaseFee: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "blob_basefee_wei",
+			Help:      "Latest L1 blob basefee in wei",
+			Subsystem: "txmgr",
+		}),
 		TxGasBump: factory.NewGauge(prometheus.GaugeOpts{
 			Namespace: ns,
 			Name:      "tx_gas_bump",
@@ -90,6 +104,18 @@ func MakeTxMetrics(ns string, factory metrics.Factory) TxMetrics {
 			Help:      "Number of transactions pending receipts",
 			Subsystem: "txmgr",
 		}),
+		nonceGaps: factory.NewGauge(prometheus.GaugeOpts{
+			Namespace: ns,
+			Name:      "nonce_gaps",
+			Help:      "Number of abandoned nonces below nonces of in flight transactions",
+			Subsystem: "txmgr",
+		}),
+		nonceGapFills: factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "nonce_gap_fills_total",
+			Help:      "Count of nonce gaps filled with cancellation transactions, by result",
+			Subsystem: "txmgr",
+		}, []string{"result"}),
 		txPublishError: factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: ns,
 			Name:      "tx_publish_error_count",
@@ -115,6 +141,23 @@ func (t *TxMetrics) RecordPendingTx(pending int64) {
 	t.pendingTxs.Set(float64(pending))
 }
 
+func (t *TxMetrics) RecordNonceGaps(gaps int) {
+	t.nonceGaps.Set(float64(gaps))
+}
+
+func (t *TxMetrics) NonceGapFilled(success bool) {
+	if success {
+		t.nonceGapFills.WithLabelValues("success").Inc()
+	} else {
+		t.nonceGapFills.WithLabelValues("failed").Inc()
+	}
+}
+
+func (t *TxMetrics) RecordBlobBaseFee(blobBaseFee *big.Int) {
+	f, _ := new(big.Float).SetInt(blobBaseFee).Float64()
+	t.blobBaseFee.Set(f)
+}
+
 // TxConfirmed records lots of information about the confirmed transaction
 func (t *TxMetrics) TxConfirmed(receipt *types.Receipt) {
 	fee := float64(receipt.EffectiveGasPrice.Uint64() * receipt.GasUsed / params.GWei)
@@ -0,0 +1,203 @@
+package txmgr
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// NonceStatus describes what the transaction manager is doing with a nonce.
+type NonceStatus string
+
+const (
+	// NonceReserved is the status of a nonce that was handed out, but not yet published at.
+	NonceReserved NonceStatus = "reserved"
+	// NoncePending is the status of a nonce with at least one transaction in the mempool.
+	NoncePending NonceStatus = "pending"
+	// NonceAbandoned is the status of a nonce whose send failed before any transaction was confirmed.
+	NonceAbandoned NonceStatus = "abandoned"
+	// NonceCancelling is the status of an abandoned nonce that is being filled with a cancellation tx.
+	NonceCancelling NonceStatus = "cancelling"
+)
+
+// TxAttempt is a single broadcast of a transaction.
+type TxAttempt struct {
+	Hash common.Hash `json:"hash"`
+	// Replaces is the hash of the previously published transaction at the same nonce, if any.
+	Replaces   *common.Hash `json:"replaces,omitempty"`
+	GasTipCap  *hexutil.Big `json:"gasTipCap"`
+	GasFeeCap  *hexutil.Big `json:"gasFeeCap"`
+	BlobFeeCap *hexutil.Big `json:"blobFeeCap,omitempty"`
+	// Cancel is set if the transaction is a cancellation tx, filling a nonce gap.
+	Cancel bool      `json:"cancel"`
+	Time   time.Time `json:"time"`
+	// Error is the error returned when publishing the transaction, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// NonceState is the state of a nonce that is in use by the transaction manager,
+// along with every transaction that was broadcast at it.
+type NonceState struct {
+	Nonce    uint64      `json:"nonce"`
+	Status   NonceStatus `json:"status"`
+	Attempts []TxAttempt `json:"attempts"`
+}
+
+// lastPublished returns the last transaction that was successfully published at the nonce.
+func (s *NonceState) lastPublished() *TxAttempt {
+	for i := len(s.Attempts) - 1; i >= 0; i-- {
+		if s.Attempts[i].Error == "" {
+			return &s.Attempts[i]
+		}
+	}
+	return nil
+}
+
+// NonceTracker tracks the nonces the transaction manager handed out until the transactions
+// sent at them are confirmed. Abandoned nonces below a nonce that is still in use are gaps:
+// the transactions at higher nonces cannot be included until the gap is filled.
+//
+// The zero value is ready to use. It is safe for concurrent use.
+type NonceTracker struct {
+	mu     sync.Mutex
+	nonces map[uint64]*NonceState
+}
+
+// Reserve starts tracking the nonce for a new transaction, replacing any previous state of the nonce.
+func (t *NonceTracker) Reserve(nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.nonces == nil {
+		t.nonces = make(map[uint64]*NonceState)
+	}
+	t.nonces[nonce] = &NonceState{Nonce: nonce, Status: NonceReserved}
+}
+
+// RecordAttempt records a broadcast of the transaction, along with the error returned when publishing it.
+func (t *NonceTracker) RecordAttempt(tx *types.Transaction, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.nonces[tx.Nonce()]
+	if !ok {
+		return
+	}
+	attempt := TxAttempt{
+		Hash:      tx.Hash(),
+		GasTipCap: (*hexutil.Big)(tx.GasTipCap()),
+		GasFeeCap: (*hexutil.Big)(tx.GasFeeCap()),
+		Cancel:    state.Status == NonceCancelling,
+		Time:      time.Now(),
+	}
+	if tx.Type() == types.BlobTxType {
+		attempt.BlobFeeCap = (*hexutil.Big)(tx.BlobGasFeeCap())
+	}
+	if prev := state.lastPublished(); prev != nil && prev.Hash != attempt.Hash {
+		replaces := prev.Hash
+		attempt.Replaces = &replaces
+	}
+	if err != nil {
+		attempt.Error = err.Error()
+	} else if state.Status == NonceReserved {
+		state.Status = NoncePending
+	}
+	state.Attempts = append(state.Attempts, attempt)
+}
+
+// Confirm stops tracking the nonce, and all nonces below it, since a transaction was confirmed at it.
+func (t *NonceTracker) Confirm(nonce uint64) {
+	t.Prune(nonce + 1)
+}
+
+// Prune stops tracking all nonces below the given account nonce, since they are used on chain.
+func (t *NonceTracker) Prune(accountNonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for nonce := range t.nonces {
+		if nonce < accountNonce {
+			delete(t.nonces, nonce)
+		}
+	}
+}
+
+// Abandon marks the nonce as abandoned, since its send failed without a transaction being confirmed.
+func (t *NonceTracker) Abandon(nonce uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if state, ok := t.nonces[nonce]; ok {
+		state.Status = NonceAbandoned
+	}
+}
+
+// Gaps returns the abandoned nonces below the highest nonce that is still in use, in ascending order.
+func (t *NonceTracker) Gaps() []uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.gaps()
+}
+
+// ClaimGaps marks all current gaps as being cancelled and returns them in ascending order.
+// Gaps are only returned to a single caller, so that each gap is filled once.
+func (t *NonceTracker) ClaimGaps() []uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	gaps := t.gaps()
+	for _, nonce := range gaps {
+		t.nonces[nonce].Status = NonceCancelling
+	}
+	return gaps
+}
+
+func (t *NonceTracker) gaps() []uint64 {
+	var highest uint64
+	var inUse bool
+	for nonce, state := range t.nonces {
+		if state.Status != NonceAbandoned && (!inUse || nonce > highest) {
+			highest = nonce
+			inUse = true
+		}
+	}
+	var gaps []uint64
+	for nonce, state := range t.nonces {
+		if state.Status == NonceAbandoned && inUse && nonce < highest {
+			gaps = append(gaps, nonce)
+		}
+	}
+	sort.Slice(gaps, func(i, j int) bool { return gaps[i] < gaps[j] })
+	return gaps
+}
+
+// LastPublished returns the fee caps of the last transaction successfully published at the nonce.
+// The blob fee cap is nil if the transaction is not a blob transaction.
+// It returns false if no transaction was published at the nonce.
+func (t *NonceTracker) LastPublished(nonce uint64) (gasTipCap *big.Int, gasFeeCap *big.Int, blobFeeCap *big.Int, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state, ok := t.nonces[nonce]
+	if !ok {
+		return nil, nil, nil, false
+	}
+	prev := state.lastPublished()
+	if prev == nil {
+		return nil, nil, nil, false
+	}
+	return prev.GasTipCap.ToInt(), prev.GasFeeCap.ToInt(), prev.BlobFeeCap.ToInt(), true
+}
+
+// States returns a copy of the state of all tracked nonces, in ascending nonce order.
+func (t *NonceTracker) States() []NonceState {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	states := make([]NonceState, 0, len(t.nonces))
+	for _, state := range t.nonces {
+		cpy := *state
+		cpy.Attempts = append([]TxAttempt(nil), state.Attempts...)
+		states = append(states, cpy)
+	}
+	sort.Slice(states, func(i, j int) bool { return states[i].Nonce < states[j].Nonce })
+	return states
+}
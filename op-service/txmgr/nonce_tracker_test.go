@@ -0,0 +1,93 @@
+package txmgr
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+func trackerTestTx(nonce uint64, gasTipCap int64) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		Nonce:     nonce,
+		GasTipCap: big.NewInt(gasTipCap),
+		GasFeeCap: big.NewInt(gasTipCap * 2),
+	})
+}
+
+func TestNonceTrackerRecordsReplacements(t *testing.T) {
+	var tracker NonceTracker
+	tracker.Reserve(5)
+	require.Equal(t, []NonceState{{Nonce: 5, Status: NonceReserved}}, tracker.States())
+
+	tx1 := trackerTestTx(5, 10)
+	tracker.RecordAttempt(tx1, nil)
+	tx2 := trackerTestTx(5, 11)
+	tracker.RecordAttempt(tx2, errors.New("replacement transaction underpriced"))
+	tx3 := trackerTestTx(5, 12)
+	tracker.RecordAttempt(tx3, nil)
+	// Attempts at untracked nonces are ignored
+	tracker.RecordAttempt(trackerTestTx(6, 10), nil)
+
+	states := tracker.States()
+	require.Len(t, states, 1)
+	require.Equal(t, NoncePending, states[0].Status)
+	attempts := states[0].Attempts
+	require.Len(t, attempts, 3)
+	require.Equal(t, tx1.Hash(), attempts[0].Hash)
+	require.Nil(t, attempts[0].Replaces)
+	require.Equal(t, tx1.Hash(), *attempts[1].Replaces)
+	require.Equal(t, "replacement transaction underpriced", attempts[1].Error)
+	// The failed attempt never replaced the first tx
+	require.Equal(t, tx1.Hash(), *attempts[2].Replaces)
+
+	tip, feeCap, blobFeeCap, ok := tracker.LastPublished(5)
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(12), tip)
+	require.Equal(t, big.NewInt(24), feeCap)
+	require.Nil(t, blobFeeCap)
+	_, _, _, ok = tracker.LastPublished(6)
+	require.False(t, ok)
+
+	// Returned states are copies
+	states[0].Attempts[0].Error = "modified"
+	require.Empty(t, tracker.States()[0].Attempts[0].Error)
+}
+
+func TestNonceTrackerGaps(t *testing.T) {
+	var tracker NonceTracker
+	for nonce := uint64(0); nonce < 5; nonce++ {
+		tracker.Reserve(nonce)
+	}
+	require.Empty(t, tracker.Gaps())
+
+	tracker.Abandon(1)
+	tracker.Abandon(2)
+	// The highest nonce is not a gap, as no tx depends on it
+	tracker.Abandon(4)
+	require.Equal(t, []uint64{1, 2}, tracker.Gaps())
+
+	require.Equal(t, []uint64{1, 2}, tracker.ClaimGaps())
+	require.Empty(t, tracker.ClaimGaps(), "gaps must only be claimed once")
+	tracker.RecordAttempt(trackerTestTx(1, 10), nil)
+	require.True(t, tracker.States()[1].Attempts[0].Cancel)
+
+	// Failing to fill a gap makes it claimable again
+	tracker.Abandon(2)
+	require.Equal(t, []uint64{2}, tracker.Gaps())
+
+	tracker.Confirm(2)
+	require.Empty(t, tracker.Gaps())
+	states := tracker.States()
+	require.Len(t, states, 2)
+	require.EqualValues(t, 3, states[0].Nonce)
+
+	// Once the remaining nonce in use is abandoned, there is nothing to fill
+	tracker.Abandon(3)
+	require.Empty(t, tracker.Gaps())
+
+	tracker.Prune(5)
+	require.Empty(t, tracker.States())
+}
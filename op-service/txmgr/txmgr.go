@@ -12,10 +12,14 @@ import (
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
 
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
@@ -24,12 +28,19 @@ const (
 	// Geth requires a minimum fee bump of 10% for tx resubmission
 	priceBump int64 = 10
 
+	// Geth requires a minimum blob fee bump of 100% for blob tx resubmission
+	blobPriceBump int64 = 100
+
 	// The multiplier applied to fee suggestions to put a hard limit on fee increases
 	feeLimitMultiplier = 5
+
+	// The maximum duration of filling a nonce gap with a cancellation tx
+	nonceGapFillTimeout = 10 * time.Minute
 )
 
 // new = old * (100 + priceBump) / 100
 var priceBumpPercent = big.NewInt(100 + priceBump)
+var blobPriceBumpPercent = big.NewInt(100 + blobPriceBump)
 var oneHundred = big.NewInt(100)
 
 // TxManager is an interface that allows callers to reliably publish txs,
@@ -101,6 +112,7 @@ type SimpleTxManager struct {
 
 	nonce     *uint64
 	nonceLock sync.RWMutex
+	nonces    NonceTracker
 
 	pending atomic.Int64
 }
@@ -139,6 +151,10 @@ type TxCandidate struct {
 	To *common.Address
 	// GasLimit is the gas limit to be used in the constructed tx.
 	GasLimit uint64
+	// Value is the value to be used in the constructed tx. Nil means no value is transferred.
+	Value *big.Int
+	// Blobs are the EIP-4844 data blobs to be attached to the constructed tx. If set, a blob tx is constructed.
+	Blobs []kzg4844.Blob
 }
 
 // Send is used to publish a transaction with incrementally higher gas prices
@@ -157,7 +173,7 @@ func (m *SimpleTxManager) Send(ctx context.Context, candidate TxCandidate) (*typ
 	}()
 	receipt, err := m.send(ctx, candidate)
 	if err != nil {
-		m.resetNonce()
+		m.handleSendError()
 	}
 	return receipt, err
 }
@@ -175,63 +191,107 @@ func (m *SimpleTxManager) send(ctx context.Context, candidate TxCandidate) (*typ
 		ctx, cancel = context.WithTimeout(ctx, m.cfg.TxSendTimeout)
 		defer cancel()
 	}
-	tx, err := m.craftTx(ctx, candidate)
+	tx, sidecar, err := m.craftTx(ctx, candidate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the tx: %w", err)
 	}
-	return m.sendTx(ctx, tx)
+	receipt, err := m.sendTx(ctx, tx, sidecar)
+	if err != nil {
+		m.abandonNonce(tx.Nonce())
+		return nil, err
+	}
+	m.nonces.Confirm(tx.Nonce())
+	return receipt, nil
 }
 
-// craftTx creates the signed transaction
+// craftTx creates the signed transaction, and the sidecar of its blobs if the candidate has any.
 // It queries L1 for the current fee market conditions as well as for the nonce.
 // NOTE: This method SHOULD NOT publish the resulting transaction.
 // NOTE: If the [TxCandidate.GasLimit] is non-zero, it will be used as the transaction's gas.
 // NOTE: Otherwise, the [SimpleTxManager] will query the specified backend for an estimate.
-func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, error) {
-	gasTipCap, basefee, err := m.suggestGasPriceCaps(ctx)
+func (m *SimpleTxManager) craftTx(ctx context.Context, candidate TxCandidate) (*types.Transaction, *BlobTxSidecar, error) {
+	gasTipCap, basefee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, fmt.Errorf("failed to get gas price info: %w", err)
+		return nil, nil, fmt.Errorf("failed to get gas price info: %w", err)
 	}
 	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
 
-	nonce, err := m.nextNonce(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	rawTx := &types.DynamicFeeTx{
-		ChainID:   m.chainID,
-		Nonce:     nonce,
-		To:        candidate.To,
-		GasTipCap: gasTipCap,
-		GasFeeCap: gasFeeCap,
-		Data:      candidate.TxData,
+	var sidecar *BlobTxSidecar
+	if len(candidate.Blobs) > 0 {
+		if candidate.To == nil {
+			return nil, nil, errors.New("blob txs cannot deploy contracts")
+		}
+		if blobBaseFee == nil {
+			return nil, nil, errors.New("blob txs are not supported before the L1 Cancun upgrade")
+		}
+		if sidecar, err = MakeSidecar(candidate.Blobs); err != nil {
+			return nil, nil, fmt.Errorf("failed to make blob sidecar: %w", err)
+		}
 	}
 
-	m.l.Info("Creating tx", "to", rawTx.To, "from", m.cfg.From)
-
+	var gas uint64
 	// If the gas limit is set, we can use that as the gas
 	if candidate.GasLimit != 0 {
-		rawTx.Gas = candidate.GasLimit
+		gas = candidate.GasLimit
 	} else {
 		// Calculate the intrinsic gas for the transaction
-		gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
+		gas, err = m.backend.EstimateGas(ctx, ethereum.CallMsg{
 			From:      m.cfg.From,
 			To:        candidate.To,
 			GasFeeCap: gasFeeCap,
 			GasTipCap: gasTipCap,
-			Data:      rawTx.Data,
+			Value:     candidate.Value,
+			Data:      candidate.TxData,
 		})
 		if err != nil {
-			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+			return nil, nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+	}
+
+	nonce, err := m.nextNonce(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var txMessage types.TxData
+	if sidecar != nil {
+		txMessage = &types.BlobTx{
+			ChainID:    uint256.MustFromBig(m.chainID),
+			Nonce:      nonce,
+			To:         candidate.To,
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			Gas:        gas,
+			Value:      uint256.MustFromBig(valueOrZero(candidate.Value)),
+			Data:       candidate.TxData,
+			BlobFeeCap: uint256.MustFromBig(calcBlobFeeCap(blobBaseFee)),
+			BlobHashes: sidecar.BlobHashes(),
+		}
+	} else {
+		txMessage = &types.DynamicFeeTx{
+			ChainID:   m.chainID,
+			Nonce:     nonce,
+			To:        candidate.To,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       gas,
+			Value:     candidate.Value,
+			Data:      candidate.TxData,
 		}
-		rawTx.Gas = gas
 	}
 
+	m.l.Info("Creating tx", "to", candidate.To, "from", m.cfg.From, "blobs", len(candidate.Blobs))
+
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	return m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	tx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
+	if err != nil {
+		// Nothing will be published at the nonce
+		m.abandonNonce(nonce)
+		return nil, nil, err
+	}
+	return tx, sidecar, nil
 }
 
 // nextNonce returns a nonce to use for the next transaction. It uses
@@ -252,25 +312,150 @@ func (m *SimpleTxManager) nextNonce(ctx context.Context) (uint64, error) {
 			return 0, fmt.Errorf("failed to get nonce: %w", err)
 		}
 		m.nonce = &nonce
+		// All nonces below the account nonce are used on chain
+		m.nonces.Prune(nonce)
 	} else {
 		*m.nonce++
 	}
 
+	m.nonces.Reserve(*m.nonce)
 	m.metr.RecordNonce(*m.nonce)
 	return *m.nonce, nil
 }
 
-// resetNonce resets the internal nonce tracking. This is called if any pending send
-// returns an error.
-func (m *SimpleTxManager) resetNonce() {
+// abandonNonce records that no transaction will be confirmed at the nonce by the send it was reserved for.
+func (m *SimpleTxManager) abandonNonce(nonce uint64) {
+	m.nonces.Abandon(nonce)
+	m.metr.RecordNonceGaps(len(m.nonces.Gaps()))
+}
+
+// handleSendError is called when a send fails. If [Config.FillNonceGaps] is set and transactions
+// at higher nonces are still in flight, the abandoned nonces are filled with cancellation txs in the
+// background, so that the in flight transactions can be included without blocking the failed send.
+// Otherwise the internal nonce tracking is reset, so the nonce is fetched from L1 again for the next transaction.
+func (m *SimpleTxManager) handleSendError() {
+	m.nonceLock.Lock()
+	var gaps []uint64
+	if m.cfg.FillNonceGaps {
+		// Claim the gaps while holding the nonce lock, so no nonce is handed out in the meantime
+		gaps = m.nonces.ClaimGaps()
+	}
+	if len(gaps) == 0 {
+		m.nonce = nil
+	}
+	m.nonceLock.Unlock()
+
+	if len(gaps) > 0 {
+		go func() {
+			for _, nonce := range gaps {
+				m.fillNonceGap(nonce)
+			}
+		}()
+	}
+}
+
+// fillNonceGap publishes a cancellation tx at the nonce and waits for it to be confirmed.
+// The cancellation tx is a zero value transfer to the sender, with fees high enough to replace any
+// transaction previously published at the nonce. If the gap cannot be filled, the internal nonce tracking
+// is reset, so the nonce is fetched from L1 again for the next transaction.
+func (m *SimpleTxManager) fillNonceGap(nonce uint64) {
+	ctx, cancel := context.WithTimeout(context.Background(), nonceGapFillTimeout)
+	defer cancel()
+	log := m.l.New("nonce", nonce)
+	log.Warn("Filling nonce gap with cancellation tx")
+
+	tx, sidecar, err := m.craftCancelTx(ctx, nonce)
+	if err != nil {
+		log.Error("Failed to create cancellation tx", "err", err)
+		m.nonceGapFillFailed(nonce)
+		return
+	}
+	if _, err := m.sendTx(ctx, tx, sidecar); err != nil {
+		log.Error("Failed to fill nonce gap", "err", err)
+		m.nonceGapFillFailed(nonce)
+		return
+	}
+	log.Info("Filled nonce gap", "hash", tx.Hash())
+	m.nonces.Confirm(nonce)
+	m.metr.RecordNonceGaps(len(m.nonces.Gaps()))
+	m.metr.NonceGapFilled(true)
+}
+
+func (m *SimpleTxManager) nonceGapFillFailed(nonce uint64) {
+	m.abandonNonce(nonce)
+	m.metr.NonceGapFilled(false)
 	m.nonceLock.Lock()
-	defer m.nonceLock.Unlock()
 	m.nonce = nil
+	m.nonceLock.Unlock()
+}
+
+// craftCancelTx creates the signed cancellation tx for the nonce.
+// A blob tx can only be replaced by another blob tx, so if the last tx published at the nonce is a blob tx,
+// the cancellation tx is a blob tx with a single empty blob, returned along with its sidecar.
+func (m *SimpleTxManager) craftCancelTx(ctx context.Context, nonce uint64) (*types.Transaction, *BlobTxSidecar, error) {
+	gasTipCap, basefee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
+	if err != nil {
+		m.metr.RPCError()
+		return nil, nil, fmt.Errorf("failed to get gas price info: %w", err)
+	}
+	gasFeeCap := calcGasFeeCap(basefee, gasTipCap)
+	prevTip, prevFeeCap, prevBlobFeeCap, ok := m.nonces.LastPublished(nonce)
+	if ok {
+		gasTipCap, gasFeeCap = updateFees(prevTip, prevFeeCap, gasTipCap, basefee, prevBlobFeeCap != nil, m.l)
+	}
+	var txMessage types.TxData
+	var sidecar *BlobTxSidecar
+	if prevBlobFeeCap != nil {
+		if blobBaseFee == nil {
+			return nil, nil, errors.New("blob base fee is not available to cancel blob tx")
+		}
+		if sidecar, err = MakeSidecar([]kzg4844.Blob{{}}); err != nil {
+			return nil, nil, fmt.Errorf("failed to make cancellation blob sidecar: %w", err)
+		}
+		txMessage = &types.BlobTx{
+			ChainID:    uint256.MustFromBig(m.chainID),
+			Nonce:      nonce,
+			To:         &m.cfg.From,
+			GasTipCap:  uint256.MustFromBig(gasTipCap),
+			GasFeeCap:  uint256.MustFromBig(gasFeeCap),
+			Gas:        params.TxGas,
+			Value:      new(uint256.Int),
+			BlobFeeCap: uint256.MustFromBig(updateBlobFee(prevBlobFeeCap, blobBaseFee, m.l)),
+			BlobHashes: sidecar.BlobHashes(),
+		}
+	} else {
+		txMessage = &types.DynamicFeeTx{
+			ChainID:   m.chainID,
+			Nonce:     nonce,
+			To:        &m.cfg.From,
+			GasTipCap: gasTipCap,
+			GasFeeCap: gasFeeCap,
+			Gas:       params.TxGas,
+		}
+	}
+	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
+	defer cancel()
+	tx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
+	if err != nil {
+		return nil, nil, err
+	}
+	return tx, sidecar, nil
+}
+
+// PendingNonces returns the state of all nonces the transaction manager is tracking.
+func (m *SimpleTxManager) PendingNonces() []NonceState {
+	return m.nonces.States()
+}
+
+// NonceGaps returns the abandoned nonces that block the inclusion of transactions at higher nonces.
+func (m *SimpleTxManager) NonceGaps() []uint64 {
+	return m.nonces.Gaps()
 }
 
 // send submits the same transaction several times with increasing gas prices as necessary.
 // It waits for the transaction to be confirmed on chain.
-func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+// The sidecar must be set for blob transactions, and is published alongside every fee bumped version of the tx.
+func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction, sidecar *BlobTxSidecar) (*types.Receipt, error) {
 	var wg sync.WaitGroup
 	defer wg.Wait()
 	ctx, cancel := context.WithCancel(ctx)
@@ -280,7 +465,7 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 	receiptChan := make(chan *types.Receipt, 1)
 	sendTxAsync := func(tx *types.Transaction) {
 		defer wg.Done()
-		m.publishAndWaitForTx(ctx, tx, sendState, receiptChan)
+		m.publishAndWaitForTx(ctx, tx, sidecar, sendState, receiptChan)
 	}
 
 	// Immediately publish a transaction before starting the resumbission loop
@@ -331,15 +516,19 @@ func (m *SimpleTxManager) sendTx(ctx context.Context, tx *types.Transaction) (*t
 // publishAndWaitForTx publishes the transaction to the transaction pool and then waits for it with [waitMined].
 // It should be called in a new go-routine. It will send the receipt to receiptChan in a non-blocking way if a receipt is found
 // for the transaction.
-func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Transaction, sendState *SendState, receiptChan chan *types.Receipt) {
+func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Transaction, sidecar *BlobTxSidecar, sendState *SendState, receiptChan chan *types.Receipt) {
 	log := m.l.New("hash", tx.Hash(), "nonce", tx.Nonce(), "gasTipCap", tx.GasTipCap(), "gasFeeCap", tx.GasFeeCap())
+	if sidecar != nil {
+		log = log.New("blobFeeCap", tx.BlobGasFeeCap(), "blobs", len(sidecar.Blobs))
+	}
 	log.Info("Publishing transaction")
 
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	t := time.Now()
-	err := m.backend.SendTransaction(cCtx, tx)
+	err := m.publishTx(cCtx, tx, sidecar)
 	sendState.ProcessSendError(err)
+	m.nonces.RecordAttempt(tx, err)
 
 	// Properly log & exit if there is an error
 	if err != nil {
@@ -384,6 +573,22 @@ func (m *SimpleTxManager) publishAndWaitForTx(ctx context.Context, tx *types.Tra
 	}
 }
 
+// publishTx publishes the transaction, using its network encoding with the blob sidecar for blob transactions.
+func (m *SimpleTxManager) publishTx(ctx context.Context, tx *types.Transaction, sidecar *BlobTxSidecar) error {
+	if sidecar == nil {
+		return m.backend.SendTransaction(ctx, tx)
+	}
+	blobBackend, ok := m.backend.(BlobBackend)
+	if !ok {
+		return ErrBlobsUnsupported
+	}
+	raw, err := sidecar.NetworkEncoding(tx)
+	if err != nil {
+		return fmt.Errorf("failed to encode blob tx: %w", err)
+	}
+	return blobBackend.SendRawTransaction(ctx, raw)
+}
+
 // waitMined waits for the transaction to be mined or for the context to be cancelled.
 func (m *SimpleTxManager) waitMined(ctx context.Context, tx *types.Transaction, sendState *SendState) (*types.Receipt, error) {
 	txHash := tx.Hash()
@@ -458,12 +663,12 @@ func (m *SimpleTxManager) queryReceipt(ctx context.Context, txHash common.Hash,
 // `feeLimitMultiplier` multiple of the suggested values.
 func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
 	m.l.Info("bumping gas price for tx", "hash", tx.Hash(), "tip", tx.GasTipCap(), "fee", tx.GasFeeCap(), "gaslimit", tx.Gas())
-	tip, basefee, err := m.suggestGasPriceCaps(ctx)
+	tip, basefee, blobBaseFee, err := m.suggestGasPriceCaps(ctx)
 	if err != nil {
 		m.l.Warn("failed to get suggested gas tip and basefee", "err", err)
 		return nil, err
 	}
-	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, m.l)
+	bumpedTip, bumpedFee := updateFees(tx.GasTipCap(), tx.GasFeeCap(), tip, basefee, tx.Type() == types.BlobTxType, m.l)
 
 	// Make sure increase is at most 5x the suggested values
 	maxTip := new(big.Int).Mul(tip, big.NewInt(feeLimitMultiplier))
@@ -476,24 +681,28 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 		m.l.Warn("bumped fee getting capped at multiple of the implied suggested value", "bumped", bumpedFee, "suggestion", maxFee)
 		bumpedFee.Set(maxFee)
 	}
-	rawTx := &types.DynamicFeeTx{
-		ChainID:    tx.ChainId(),
-		Nonce:      tx.Nonce(),
-		GasTipCap:  bumpedTip,
-		GasFeeCap:  bumpedFee,
-		To:         tx.To(),
-		Value:      tx.Value(),
-		Data:       tx.Data(),
-		AccessList: tx.AccessList(),
+
+	var bumpedBlobFee *big.Int
+	if tx.Type() == types.BlobTxType {
+		if blobBaseFee == nil {
+			return nil, errors.New("blob base fee is not available to bump blob tx")
+		}
+		bumpedBlobFee = updateBlobFee(tx.BlobGasFeeCap(), blobBaseFee, m.l)
+		maxBlobFee := calcBlobFeeCap(new(big.Int).Mul(blobBaseFee, big.NewInt(feeLimitMultiplier)))
+		if bumpedBlobFee.Cmp(maxBlobFee) > 0 {
+			m.l.Warn("bumped blob fee getting capped at multiple of the implied suggested value", "bumped", bumpedBlobFee, "suggestion", maxBlobFee)
+			bumpedBlobFee.Set(maxBlobFee)
+		}
 	}
 
 	// Re-estimate gaslimit in case things have changed or a previous gaslimit estimate was wrong
 	gas, err := m.backend.EstimateGas(ctx, ethereum.CallMsg{
 		From:      m.cfg.From,
-		To:        rawTx.To,
+		To:        tx.To(),
 		GasFeeCap: bumpedTip,
 		GasTipCap: bumpedFee,
-		Data:      rawTx.Data,
+		Value:     tx.Value(),
+		Data:      tx.Data(),
 	})
 	if err != nil {
 		// If this is a transaction resubmission, we sometimes see this outcome because the
@@ -506,11 +715,39 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	if tx.Gas() != gas {
 		m.l.Info("re-estimated gas differs", "oldgas", tx.Gas(), "newgas", gas)
 	}
-	rawTx.Gas = gas
+
+	var txMessage types.TxData
+	if bumpedBlobFee != nil {
+		txMessage = &types.BlobTx{
+			ChainID:    uint256.MustFromBig(tx.ChainId()),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  uint256.MustFromBig(bumpedTip),
+			GasFeeCap:  uint256.MustFromBig(bumpedFee),
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      uint256.MustFromBig(tx.Value()),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+			BlobFeeCap: uint256.MustFromBig(bumpedBlobFee),
+			BlobHashes: tx.BlobHashes(),
+		}
+	} else {
+		txMessage = &types.DynamicFeeTx{
+			ChainID:    tx.ChainId(),
+			Nonce:      tx.Nonce(),
+			GasTipCap:  bumpedTip,
+			GasFeeCap:  bumpedFee,
+			Gas:        gas,
+			To:         tx.To(),
+			Value:      tx.Value(),
+			Data:       tx.Data(),
+			AccessList: tx.AccessList(),
+		}
+	}
 
 	ctx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
-	newTx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(rawTx))
+	newTx, err := m.cfg.Signer(ctx, m.cfg.From, types.NewTx(txMessage))
 	if err != nil {
 		m.l.Warn("failed to sign new transaction", "err", err)
 		return tx, nil
@@ -518,32 +755,42 @@ func (m *SimpleTxManager) increaseGasPrice(ctx context.Context, tx *types.Transa
 	return newTx, nil
 }
 
-// suggestGasPriceCaps suggests what the new tip & new basefee should be based on the current L1 conditions
-func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, error) {
+// suggestGasPriceCaps suggests what the new tip, new basefee & new blob basefee should be based on
+// the current L1 conditions. The blob basefee is nil if L1 has not activated EIP-4844 yet.
+func (m *SimpleTxManager) suggestGasPriceCaps(ctx context.Context) (*big.Int, *big.Int, *big.Int, error) {
 	cCtx, cancel := context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	tip, err := m.backend.SuggestGasTipCap(cCtx)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested gas tip cap: %w", err)
 	} else if tip == nil {
-		return nil, nil, errors.New("the suggested tip was nil")
+		return nil, nil, nil, errors.New("the suggested tip was nil")
 	}
 	cCtx, cancel = context.WithTimeout(ctx, m.cfg.NetworkTimeout)
 	defer cancel()
 	head, err := m.backend.HeaderByNumber(cCtx, nil)
 	if err != nil {
 		m.metr.RPCError()
-		return nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to fetch the suggested basefee: %w", err)
 	} else if head.BaseFee == nil {
-		return nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
+		return nil, nil, nil, errors.New("txmgr does not support pre-london blocks that do not have a basefee")
 	}
-	return tip, head.BaseFee, nil
+	var blobBaseFee *big.Int
+	if head.ExcessDataGas != nil {
+		blobBaseFee = misc.CalcBlobFee(head.ExcessDataGas)
+		m.metr.RecordBlobBaseFee(blobBaseFee)
+	}
+	return tip, head.BaseFee, blobBaseFee, nil
 }
 
-// calcThresholdValue returns x * priceBumpPercent / 100
-func calcThresholdValue(x *big.Int) *big.Int {
-	threshold := new(big.Int).Mul(priceBumpPercent, x)
+// calcThresholdValue returns x * priceBumpPercent / 100, or x * blobPriceBumpPercent / 100 for blob txs
+func calcThresholdValue(x *big.Int, isBlobTx bool) *big.Int {
+	bumpPercent := priceBumpPercent
+	if isBlobTx {
+		bumpPercent = blobPriceBumpPercent
+	}
+	threshold := new(big.Int).Mul(bumpPercent, x)
 	threshold = threshold.Div(threshold, oneHundred)
 	return threshold
 }
@@ -551,14 +798,14 @@ func calcThresholdValue(x *big.Int) *big.Int {
 // updateFees takes an old transaction's tip & fee cap plus a new tip & basefee, and returns
 // a suggested tip and fee cap such that:
 //
-//	(a) each satisfies geth's required tx-replacement fee bumps (we use a 10% increase), and
+//	(a) each satisfies geth's required tx-replacement fee bumps (we use a 10% increase, or 100% for blob txs), and
 //	(b) gasTipCap is no less than new tip, and
 //	(c) gasFeeCap is no less than calcGasFee(newBaseFee, newTip)
-func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, lgr log.Logger) (*big.Int, *big.Int) {
+func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, isBlobTx bool, lgr log.Logger) (*big.Int, *big.Int) {
 	newFeeCap := calcGasFeeCap(newBaseFee, newTip)
 	lgr = lgr.New("old_tip", oldTip, "old_feecap", oldFeeCap, "new_tip", newTip, "new_feecap", newFeeCap)
-	thresholdTip := calcThresholdValue(oldTip)
-	thresholdFeeCap := calcThresholdValue(oldFeeCap)
+	thresholdTip := calcThresholdValue(oldTip, isBlobTx)
+	thresholdFeeCap := calcThresholdValue(oldFeeCap, isBlobTx)
 	if newTip.Cmp(thresholdTip) >= 0 && newFeeCap.Cmp(thresholdFeeCap) >= 0 {
 		lgr.Debug("Using new tip and feecap")
 		return newTip, newFeeCap
@@ -580,6 +827,28 @@ func updateFees(oldTip, oldFeeCap, newTip, newBaseFee *big.Int, lgr log.Logger)
 	}
 }
 
+// updateBlobFee takes an old blob transaction's blob fee cap plus a new blob basefee, and returns
+// a suggested blob fee cap that satisfies geth's required blob tx-replacement fee bump (we use a 100% increase),
+// and is no less than calcBlobFeeCap(newBlobBaseFee).
+func updateBlobFee(oldBlobFeeCap, newBlobBaseFee *big.Int, lgr log.Logger) *big.Int {
+	newBlobFeeCap := calcBlobFeeCap(newBlobBaseFee)
+	thresholdBlobFeeCap := calcThresholdValue(oldBlobFeeCap, true)
+	if newBlobFeeCap.Cmp(thresholdBlobFeeCap) >= 0 {
+		lgr.Debug("Using new blob feecap", "old_blob_feecap", oldBlobFeeCap, "new_blob_feecap", newBlobFeeCap)
+		return newBlobFeeCap
+	}
+	lgr.Debug("Using threshold blob feecap", "old_blob_feecap", oldBlobFeeCap, "new_blob_feecap", thresholdBlobFeeCap)
+	return thresholdBlobFeeCap
+}
+
+// valueOrZero returns the value, or zero if it is nil.
+func valueOrZero(v *big.Int) *big.Int {
+	if v == nil {
+		return new(big.Int)
+	}
+	return v
+}
+
 // calcGasFeeCap deterministically computes the recommended gas fee cap given
 // the base fee and gasTipCap. The resulting gasFeeCap is equal to:
 //
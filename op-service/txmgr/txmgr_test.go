@@ -9,10 +9,14 @@ import (
 	"testing"
 	"time"
 
+	"github.com/holiman/uint256"
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	opcrypto "github.com/ethereum-optimism/optimism/op-service/crypto"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+	"github.com/ethereum-optimism/optimism/op-signer/service"
+	"github.com/ethereum-optimism/optimism/op-signer/signertest"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
@@ -155,6 +159,11 @@ type mockBackend struct {
 
 	// minedTxs maps the hash of a mined transaction to its details.
 	minedTxs map[common.Hash]minedTxInfo
+
+	// excessDataGas is reported in headers if set, to enable blob txs.
+	excessDataGas *big.Int
+	// rawTxs records all network encoded txs sent with SendRawTransaction.
+	rawTxs [][]byte
 }
 
 // newMockBackend initializes a new mockBackend.
@@ -201,7 +210,8 @@ func (b *mockBackend) CallContract(ctx context.Context, call ethereum.CallMsg, b
 
 func (b *mockBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.g.basefee(),
+		BaseFee:       b.g.basefee(),
+		ExcessDataGas: b.excessDataGas,
 	}, nil
 }
 
@@ -221,6 +231,17 @@ func (b *mockBackend) SendTransaction(ctx context.Context, tx *types.Transaction
 	return b.send(ctx, tx)
 }
 
+func (b *mockBackend) SendRawTransaction(ctx context.Context, rawTx []byte) error {
+	b.mu.Lock()
+	b.rawTxs = append(b.rawTxs, rawTx)
+	b.mu.Unlock()
+	tx, _, err := decodeBlobNetworkTx(rawTx)
+	if err != nil {
+		return err
+	}
+	return b.SendTransaction(ctx, tx)
+}
+
 func (b *mockBackend) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
 	return 0, nil
 }
@@ -282,7 +303,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -310,7 +331,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -339,7 +360,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -370,7 +391,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -383,7 +404,7 @@ func TestTxMgr_CraftTx(t *testing.T) {
 
 	// Craft the transaction.
 	gasTipCap, gasFeeCap := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
-	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	tx, _, err := h.mgr.craftTx(context.Background(), candidate)
 	require.Nil(t, err)
 	require.NotNil(t, tx)
 
@@ -398,6 +419,20 @@ func TestTxMgr_CraftTx(t *testing.T) {
 	require.Equal(t, candidate.GasLimit, tx.Gas())
 }
 
+// TestTxMgr_CraftTxWithValue ensures that the tx manager will set the
+// candidate's value on the crafted transaction.
+func TestTxMgr_CraftTxWithValue(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	candidate.Value = big.NewInt(1337)
+
+	tx, _, err := h.mgr.craftTx(context.Background(), candidate)
+	require.Nil(t, err)
+	require.NotNil(t, tx)
+	require.Equal(t, candidate.Value, tx.Value())
+}
+
 // TestTxMgr_EstimateGas ensures that the tx manager will estimate
 // the gas when candidate gas limit is zero in [CraftTx].
 func TestTxMgr_EstimateGas(t *testing.T) {
@@ -412,7 +447,7 @@ func TestTxMgr_EstimateGas(t *testing.T) {
 	gasEstimate := h.gasPricer.baseBaseFee.Uint64()
 
 	// Craft the transaction.
-	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	tx, _, err := h.mgr.craftTx(context.Background(), candidate)
 	require.Nil(t, err)
 	require.NotNil(t, tx)
 
@@ -448,7 +483,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -483,7 +518,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -528,7 +563,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -619,6 +654,7 @@ type failingBackend struct {
 	returnSuccessBlockNumber bool
 	returnSuccessReceipt     bool
 	baseFee, gasTip          *big.Int
+	excessDataGas            *big.Int
 }
 
 // BlockNumber for the failingBackend returns errRpcFailure on the first
@@ -650,7 +686,8 @@ func (b *failingBackend) TransactionReceipt(
 
 func (b *failingBackend) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.baseFee,
+		BaseFee:       b.baseFee,
+		ExcessDataGas: b.excessDataGas,
 	}, nil
 }
 
@@ -922,3 +959,154 @@ func TestNonceReset(t *testing.T) {
 	// internal nonce tracking should be reset every 3rd tx
 	require.Equal(t, []uint64{0, 0, 1, 2, 0, 1, 2, 0}, nonces)
 }
+
+func TestFillNonceGaps(t *testing.T) {
+	conf := configWithNumConfs(1)
+	conf.SafeAbortNonceTooLowCount = 1
+	conf.FillNonceGaps = true
+	h := newTestHarnessWithConfig(t, conf)
+
+	var mu sync.Mutex
+	var cancelTx *types.Transaction
+	var blockedTx *types.Transaction
+	var lastNonce uint64
+	published := make(chan uint64, 100)
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		mu.Lock()
+		defer mu.Unlock()
+		lastNonce = tx.Nonce()
+		defer func() { published <- tx.Nonce() }()
+		txHash := tx.Hash()
+		switch {
+		case *tx.To() == conf.From:
+			// The cancellation tx fills the gap, which unblocks the tx at the next nonce
+			cancelTx = tx
+			h.backend.mine(&txHash, tx.GasFeeCap())
+			if blockedTx != nil {
+				blockedHash := blockedTx.Hash()
+				h.backend.mine(&blockedHash, blockedTx.GasFeeCap())
+			}
+		case tx.Nonce() == 0:
+			return core.ErrNonceTooLow
+		case cancelTx == nil:
+			blockedTx = tx
+		default:
+			h.backend.mine(&txHash, tx.GasFeeCap())
+		}
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx := context.Background()
+	inbox := common.Address{0xaa}
+	failedErr := make(chan error, 1)
+	go func() {
+		_, err := h.mgr.Send(ctx, TxCandidate{To: &inbox})
+		failedErr <- err
+	}()
+	require.Equal(t, uint64(0), <-published)
+
+	receipt, err := h.mgr.Send(ctx, TxCandidate{To: &inbox})
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+	require.Error(t, <-failedErr)
+
+	mu.Lock()
+	require.NotNil(t, cancelTx)
+	require.Equal(t, uint64(0), cancelTx.Nonce())
+	require.Zero(t, cancelTx.Value().Sign())
+	require.Empty(t, cancelTx.Data())
+	mu.Unlock()
+	// The gap is filled in the background, and is tracked until the cancellation tx is confirmed
+	require.Eventually(t, func() bool {
+		return len(h.mgr.NonceGaps()) == 0 && len(h.mgr.PendingNonces()) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The nonce was not reset, as the gap was filled
+	_, err = h.mgr.Send(ctx, TxCandidate{To: &inbox})
+	require.NoError(t, err)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, uint64(2), lastNonce)
+}
+
+func TestFillNonceGapFailureResetsNonce(t *testing.T) {
+	conf := configWithNumConfs(1)
+	conf.Signer = func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return nil, errors.New("signer unavailable")
+	}
+	h := newTestHarnessWithConfig(t, conf)
+	nonce := uint64(5)
+	h.mgr.nonce = &nonce
+	h.mgr.nonces.Reserve(0)
+
+	h.mgr.fillNonceGap(0)
+	require.Nil(t, h.mgr.nonce, "should query the nonce again for the next tx")
+}
+
+func TestCancelBlobTx(t *testing.T) {
+	conf := configWithNumConfs(1)
+	conf.ChainID = big.NewInt(1)
+	h := newTestHarnessWithConfig(t, conf)
+	h.backend.excessDataGas = big.NewInt(1)
+
+	blobFeeCap := big.NewInt(1000)
+	inbox := common.Address{0xaa}
+	h.mgr.nonces.Reserve(3)
+	h.mgr.nonces.RecordAttempt(types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(1),
+		Nonce:      3,
+		To:         &inbox,
+		GasTipCap:  uint256.NewInt(10),
+		GasFeeCap:  uint256.NewInt(100),
+		BlobFeeCap: uint256.MustFromBig(blobFeeCap),
+	}), nil)
+
+	tx, sidecar, err := h.mgr.craftCancelTx(context.Background(), 3)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type(), "only a blob tx can replace a blob tx")
+	require.Equal(t, conf.From, *tx.To())
+	require.Equal(t, uint64(3), tx.Nonce())
+	require.NotNil(t, sidecar)
+	require.Equal(t, sidecar.BlobHashes(), tx.BlobHashes())
+	// Geth requires all fees of a replacement blob tx to be bumped by 100%
+	require.GreaterOrEqual(t, tx.GasTipCap().Int64(), int64(20), "should bump the tip by 100%")
+	require.GreaterOrEqual(t, tx.GasFeeCap().Int64(), int64(200), "should bump the fee cap by 100%")
+	require.GreaterOrEqual(t, tx.BlobGasFeeCap().Cmp(new(big.Int).Mul(blobFeeCap, big.NewInt(2))), 0, "should bump the blob fee cap by 100%")
+
+	// A cancellation of a regular tx is a regular tx
+	h.mgr.nonces.Reserve(4)
+	h.mgr.nonces.RecordAttempt(types.NewTx(&types.DynamicFeeTx{Nonce: 4, To: &inbox}), nil)
+	tx, sidecar, err = h.mgr.craftCancelTx(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	require.Nil(t, sidecar)
+}
+
+func TestTxMgrRemoteSigner(t *testing.T) {
+	inbox := common.Address{0xff}
+	signer := signertest.NewHarness(t, service.ClientPolicy{ChainID: 1, AllowedTo: []common.Address{inbox}})
+	signerFactory, from, err := opcrypto.SignerFactoryFromConfig(testlog.Logger(t, log.LvlInfo), "", "", "", signer.ClientConfig)
+	require.NoError(t, err)
+	require.Equal(t, signer.Address, from)
+
+	conf := configWithNumConfs(1)
+	conf.ChainID = big.NewInt(1)
+	conf.NetworkTimeout = 10 * time.Second
+	conf.Signer = signerFactory(conf.ChainID)
+	conf.From = from
+	h := newTestHarnessWithConfig(t, conf)
+	h.backend.setTxSender(func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	})
+
+	receipt, err := h.mgr.Send(context.Background(), TxCandidate{To: &inbox, GasLimit: 21000})
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+
+	// The signer rejects transactions its policy does not allow
+	_, err = h.mgr.Send(context.Background(), TxCandidate{To: &common.Address{0x01}, GasLimit: 21000})
+	require.ErrorContains(t, err, service.ErrPolicyViolation.Error())
+}
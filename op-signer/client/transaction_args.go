@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
 )
 
 // TransactionArgs represents the arguments to construct a new transaction
@@ -28,9 +29,14 @@ type TransactionArgs struct {
 
 	AccessList *types.AccessList `json:"accessList,omitempty"`
 	ChainID    *hexutil.Big      `json:"chainId,omitempty"`
+
+	// For blob transactions. The blob sidecar is not part of the signed transaction,
+	// so it stays with the sender and is not sent to the signer.
+	BlobFeeCap *hexutil.Big  `json:"maxFeePerBlobGas,omitempty"`
+	BlobHashes []common.Hash `json:"blobVersionedHashes,omitempty"`
 }
 
-// NewTransactionArgsFromTransaction creates a TransactionArgs struct from an EIP-1559 transaction
+// NewTransactionArgsFromTransaction creates a TransactionArgs struct from an EIP-1559 or EIP-4844 transaction
 func NewTransactionArgsFromTransaction(chainId *big.Int, from common.Address, tx *types.Transaction) *TransactionArgs {
 	data := hexutil.Bytes(tx.Data())
 	nonce := hexutil.Uint64(tx.Nonce())
@@ -48,6 +54,10 @@ func NewTransactionArgsFromTransaction(chainId *big.Int, from common.Address, tx
 		MaxPriorityFeePerGas: (*hexutil.Big)(tx.GasTipCap()),
 		AccessList:           &accesses,
 	}
+	if tx.Type() == types.BlobTxType {
+		args.BlobFeeCap = (*hexutil.Big)(tx.BlobGasFeeCap())
+		args.BlobHashes = tx.BlobHashes()
+	}
 	return args
 }
 
@@ -63,12 +73,29 @@ func (args *TransactionArgs) data() []byte {
 }
 
 // ToTransaction converts the arguments to a transaction.
+// It is a blob transaction if the arguments have blob hashes, an EIP-1559 transaction otherwise.
 func (args *TransactionArgs) ToTransaction() *types.Transaction {
 	var data types.TxData
 	al := types.AccessList{}
 	if args.AccessList != nil {
 		al = *args.AccessList
 	}
+	if len(args.BlobHashes) > 0 {
+		data = &types.BlobTx{
+			To:         args.To,
+			ChainID:    toUint256(args.ChainID),
+			Nonce:      uint64(*args.Nonce),
+			Gas:        uint64(*args.Gas),
+			GasFeeCap:  toUint256(args.MaxFeePerGas),
+			GasTipCap:  toUint256(args.MaxPriorityFeePerGas),
+			Value:      toUint256(args.Value),
+			Data:       args.data(),
+			AccessList: al,
+			BlobFeeCap: toUint256(args.BlobFeeCap),
+			BlobHashes: args.BlobHashes,
+		}
+		return types.NewTx(data)
+	}
 	data = &types.DynamicFeeTx{
 		To:         args.To,
 		ChainID:    (*big.Int)(args.ChainID),
@@ -82,3 +109,11 @@ func (args *TransactionArgs) ToTransaction() *types.Transaction {
 	}
 	return types.NewTx(data)
 }
+
+// toUint256 converts a field of the arguments to a uint256, nil being zero.
+func toUint256(v *hexutil.Big) *uint256.Int {
+	if v == nil {
+		return new(uint256.Int)
+	}
+	return uint256.MustFromBig((*big.Int)(v))
+}
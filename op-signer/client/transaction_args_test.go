@@ -0,0 +1,58 @@
+package client
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransactionArgsRoundTrip(t *testing.T) {
+	chainID := big.NewInt(900)
+	from := common.Address{0xaa}
+	txs := map[string]*types.Transaction{
+		"DynamicFeeTx": types.NewTx(&types.DynamicFeeTx{
+			ChainID:    chainID,
+			Nonce:      3,
+			To:         &common.Address{0xff},
+			Gas:        21000,
+			GasTipCap:  big.NewInt(1),
+			GasFeeCap:  big.NewInt(2),
+			Value:      big.NewInt(4),
+			Data:       []byte{1, 2, 3},
+			AccessList: types.AccessList{{Address: common.Address{0xcc}, StorageKeys: []common.Hash{{0xdd}}}},
+		}),
+		"BlobTx": types.NewTx(&types.BlobTx{
+			ChainID:    uint256.NewInt(900),
+			Nonce:      3,
+			To:         &common.Address{0xff},
+			Gas:        21000,
+			GasTipCap:  uint256.NewInt(1),
+			GasFeeCap:  uint256.NewInt(2),
+			Value:      uint256.NewInt(4),
+			Data:       []byte{1, 2, 3},
+			AccessList: types.AccessList{{Address: common.Address{0xcc}, StorageKeys: []common.Hash{{0xdd}}}},
+			BlobFeeCap: uint256.NewInt(5),
+			BlobHashes: []common.Hash{{0x01, 0xaa}, {0x01, 0xbb}},
+		}),
+	}
+	for name, tx := range txs {
+		tx := tx
+		t.Run(name, func(t *testing.T) {
+			// Through JSON, as sent to the signer
+			encoded, err := json.Marshal(NewTransactionArgsFromTransaction(chainID, from, tx))
+			require.NoError(t, err)
+			var args TransactionArgs
+			require.NoError(t, json.Unmarshal(encoded, &args))
+
+			result := args.ToTransaction()
+			require.Equal(t, tx.Type(), result.Type())
+			signer := types.LatestSignerForChainID(chainID)
+			require.Equal(t, signer.Hash(tx), signer.Hash(result), "should sign the same transaction")
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package flags
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+)
+
+const EnvVarPrefix = "OP_SIGNER"
+
+func prefixEnvVars(name string) []string {
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
+}
+
+var (
+	// Required Flags
+	KeystoreFlag = &cli.StringFlag{
+		Name:    "keystore",
+		Usage:   "Directory of the encrypted keystore holding the signing keys",
+		EnvVars: prefixEnvVars("KEYSTORE"),
+	}
+	KeystorePasswordFileFlag = &cli.StringFlag{
+		Name:    "keystore.password-file",
+		Usage:   "File containing the password to decrypt the keys in the keystore",
+		EnvVars: prefixEnvVars("KEYSTORE_PASSWORD_FILE"),
+	}
+	PolicyFlag = &cli.StringFlag{
+		Name:    "policy",
+		Usage:   "JSON file with the signing policies of the clients, identified by the DNS name of their TLS certificate",
+		EnvVars: prefixEnvVars("POLICY"),
+	}
+	AuditLogFlag = &cli.StringFlag{
+		Name:    "audit-log",
+		Usage:   "File that every signing request is appended to",
+		EnvVars: prefixEnvVars("AUDIT_LOG"),
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	KeystoreFlag,
+	KeystorePasswordFileFlag,
+	PolicyFlag,
+	AuditLogFlag,
+}
+
+var optionalFlags []cli.Flag
+
+func init() {
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, optls.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+
+	Flags = append(requiredFlags, optionalFlags...)
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func CheckRequired(ctx *cli.Context) error {
+	for _, f := range requiredFlags {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// AuditEntry records a single signing request.
+type AuditEntry struct {
+	Time         time.Time       `json:"time"`
+	Client       string          `json:"client"`
+	Key          string          `json:"key,omitempty"`
+	From         *common.Address `json:"from"`
+	To           *common.Address `json:"to"`
+	ChainID      *hexutil.Big    `json:"chainId"`
+	Nonce        *hexutil.Uint64 `json:"nonce"`
+	Value        *hexutil.Big    `json:"value"`
+	MaxFeePerGas *hexutil.Big    `json:"maxFeePerGas"`
+	// MaxFeePerBlobGas and BlobHashes are only set for blob transactions.
+	MaxFeePerBlobGas *hexutil.Big  `json:"maxFeePerBlobGas,omitempty"`
+	BlobHashes       []common.Hash `json:"blobVersionedHashes,omitempty"`
+	// TxHash is the hash of the signed transaction. It is only set if the transaction was signed.
+	TxHash *common.Hash `json:"txHash,omitempty"`
+	// Error is the reason the request was rejected.
+	Error string `json:"error,omitempty"`
+}
+
+// AuditLog is an append-only log of signing requests, stored as JSON lines.
+type AuditLog struct {
+	mu sync.Mutex
+	f  *os.File
+}
+
+// OpenAuditLog opens the audit log at path, creating it if it does not exist.
+func OpenAuditLog(path string) (*AuditLog, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &AuditLog{f: f}, nil
+}
+
+// Record appends the entry to the log, and syncs it to disk before returning.
+func (a *AuditLog) Record(entry AuditEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode audit entry: %w", err)
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.f.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write audit entry: %w", err)
+	}
+	return a.f.Sync()
+}
+
+func (a *AuditLog) Close() error {
+	return a.f.Close()
+}
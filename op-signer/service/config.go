@@ -0,0 +1,63 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/urfave/cli/v2"
+
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-signer/flags"
+)
+
+type Config struct {
+	KeystoreDir          string
+	KeystorePasswordFile string
+	PolicyFile           string
+	AuditLogPath         string
+
+	RPC oprpc.CLIConfig
+	TLS optls.CLIConfig
+	Log oplog.CLIConfig
+}
+
+func (c Config) Check() error {
+	if c.KeystoreDir == "" {
+		return errors.New("must specify a keystore directory")
+	}
+	if c.KeystorePasswordFile == "" {
+		return errors.New("must specify a keystore password file")
+	}
+	if c.PolicyFile == "" {
+		return errors.New("must specify a policy file")
+	}
+	if c.AuditLogPath == "" {
+		return errors.New("must specify an audit log path")
+	}
+	if err := c.RPC.Check(); err != nil {
+		return err
+	}
+	if !c.TLS.TLSEnabled() {
+		return errors.New("TLS must be enabled, clients are identified by their TLS certificates")
+	}
+	if err := c.TLS.Check(); err != nil {
+		return err
+	}
+	if err := c.Log.Check(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func NewConfig(ctx *cli.Context) Config {
+	return Config{
+		KeystoreDir:          ctx.String(flags.KeystoreFlag.Name),
+		KeystorePasswordFile: ctx.String(flags.KeystorePasswordFileFlag.Name),
+		PolicyFile:           ctx.String(flags.PolicyFlag.Name),
+		AuditLogPath:         ctx.String(flags.AuditLogFlag.Name),
+		RPC:                  oprpc.ReadCLIConfig(ctx),
+		TLS:                  optls.ReadCLIConfig(ctx),
+		Log:                  oplog.ReadCLIConfig(ctx),
+	}
+}
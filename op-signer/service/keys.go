@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrUnknownKey = errors.New("unknown key")
+
+// KeyBackend holds the signing keys of the signer. Keys are referred to by name, so that backends
+// like HSMs can use their own key identifiers (e.g. PKCS#11 key labels), and keys never have to leave the backend.
+type KeyBackend interface {
+	// Address returns the address of the key.
+	Address(ctx context.Context, key string) (common.Address, error)
+	// SignHash signs the hash with the key, returning the signature in the [R || S || V] format where V is 0 or 1.
+	SignHash(ctx context.Context, key string, hash []byte) ([]byte, error)
+}
+
+// LocalKeystore is a KeyBackend using the keys of an encrypted keystore directory.
+// Keys are named by their address.
+type LocalKeystore struct {
+	ks *keystore.KeyStore
+}
+
+var _ KeyBackend = (*LocalKeystore)(nil)
+
+// NewLocalKeystore opens the keystore in dir, and unlocks all of its keys with the password.
+func NewLocalKeystore(dir string, password string) (*LocalKeystore, error) {
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+	if len(ks.Accounts()) == 0 {
+		return nil, fmt.Errorf("no keys found in keystore %s", dir)
+	}
+	for _, account := range ks.Accounts() {
+		if err := ks.Unlock(account, password); err != nil {
+			return nil, fmt.Errorf("failed to unlock key %s: %w", account.Address, err)
+		}
+	}
+	return &LocalKeystore{ks: ks}, nil
+}
+
+// NewLocalKeystoreFromConfig opens the keystore with the password read from the configured password file.
+func NewLocalKeystoreFromConfig(cfg Config) (*LocalKeystore, error) {
+	password, err := os.ReadFile(cfg.KeystorePasswordFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore password: %w", err)
+	}
+	return NewLocalKeystore(cfg.KeystoreDir, strings.TrimRight(string(password), "\r\n"))
+}
+
+func (k *LocalKeystore) account(key string) (accounts.Account, error) {
+	if !common.IsHexAddress(key) {
+		return accounts.Account{}, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+	}
+	account, err := k.ks.Find(accounts.Account{Address: common.HexToAddress(key)})
+	if err != nil {
+		return accounts.Account{}, fmt.Errorf("%w: %s", ErrUnknownKey, key)
+	}
+	return account, nil
+}
+
+func (k *LocalKeystore) Address(_ context.Context, key string) (common.Address, error) {
+	account, err := k.account(key)
+	if err != nil {
+		return common.Address{}, err
+	}
+	return account.Address, nil
+}
+
+func (k *LocalKeystore) SignHash(_ context.Context, key string, hash []byte) ([]byte, error) {
+	account, err := k.account(key)
+	if err != nil {
+		return nil, err
+	}
+	return k.ks.SignHash(account, hash)
+}
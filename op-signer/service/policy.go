@@ -0,0 +1,106 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+)
+
+var ErrPolicyViolation = errors.New("policy violation")
+
+// ClientPolicy restricts the transactions a client may have signed.
+type ClientPolicy struct {
+	// Name identifies the client. It must match the first DNS name of the client's TLS certificate.
+	Name string `json:"name"`
+	// Key is the name of the key the client signs with, in the key backend.
+	Key string `json:"key"`
+	// ChainID is the only chain ID the client may sign transactions for.
+	ChainID uint64 `json:"chainId"`
+	// AllowedTo lists the addresses the client may send transactions to. Transactions to any address are
+	// allowed if it is empty. Contract creations are never allowed.
+	AllowedTo []common.Address `json:"allowedTo"`
+	// MaxValue is the maximum value of a transaction. No value may be transferred if it is nil.
+	MaxValue *hexutil.Big `json:"maxValue,omitempty"`
+	// MaxGasPrice is the maximum fee cap of a transaction. The fee cap is not limited if it is nil.
+	MaxGasPrice *hexutil.Big `json:"maxGasPrice,omitempty"`
+	// MaxBlobGasPrice is the maximum blob fee cap of a blob transaction. Blob transactions are not allowed if it is nil.
+	MaxBlobGasPrice *hexutil.Big `json:"maxBlobGasPrice,omitempty"`
+}
+
+// PolicyConfig is the policy file of the signer.
+type PolicyConfig struct {
+	Clients []ClientPolicy `json:"clients"`
+}
+
+// LoadPolicies reads the client policies from the JSON policy file at path.
+func LoadPolicies(path string) ([]ClientPolicy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	var cfg PolicyConfig
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode policy file: %w", err)
+	}
+	return cfg.Clients, nil
+}
+
+// Check returns an error wrapping [ErrPolicyViolation] if the transaction is not allowed by the policy.
+func (p *ClientPolicy) Check(args *client.TransactionArgs) error {
+	if args.ChainID == nil || args.ChainID.ToInt().Cmp(new(big.Int).SetUint64(p.ChainID)) != 0 {
+		return fmt.Errorf("%w: chain ID %v is not allowed", ErrPolicyViolation, args.ChainID)
+	}
+	if args.To == nil {
+		return fmt.Errorf("%w: contract creation is not allowed", ErrPolicyViolation)
+	}
+	if len(p.AllowedTo) > 0 && !containsAddress(p.AllowedTo, *args.To) {
+		return fmt.Errorf("%w: recipient %s is not allowed", ErrPolicyViolation, args.To)
+	}
+	value := new(big.Int)
+	if args.Value != nil {
+		value = args.Value.ToInt()
+	}
+	maxValue := new(big.Int)
+	if p.MaxValue != nil {
+		maxValue = p.MaxValue.ToInt()
+	}
+	if value.Cmp(maxValue) > 0 {
+		return fmt.Errorf("%w: value %v exceeds the maximum of %v", ErrPolicyViolation, value, maxValue)
+	}
+	if p.MaxGasPrice != nil {
+		if args.MaxFeePerGas == nil {
+			return fmt.Errorf("%w: missing fee cap", ErrPolicyViolation)
+		}
+		if args.MaxFeePerGas.ToInt().Cmp(p.MaxGasPrice.ToInt()) > 0 {
+			return fmt.Errorf("%w: fee cap %v exceeds the maximum of %v", ErrPolicyViolation, args.MaxFeePerGas, p.MaxGasPrice)
+		}
+	}
+	if len(args.BlobHashes) > 0 || args.BlobFeeCap != nil {
+		if p.MaxBlobGasPrice == nil {
+			return fmt.Errorf("%w: blob transactions are not allowed", ErrPolicyViolation)
+		}
+		if args.BlobFeeCap == nil {
+			return fmt.Errorf("%w: missing blob fee cap", ErrPolicyViolation)
+		}
+		if args.BlobFeeCap.ToInt().Cmp(p.MaxBlobGasPrice.ToInt()) > 0 {
+			return fmt.Errorf("%w: blob fee cap %v exceeds the maximum of %v", ErrPolicyViolation, args.BlobFeeCap, p.MaxBlobGasPrice)
+		}
+	}
+	return nil
+}
+
+func containsAddress(addrs []common.Address, addr common.Address) bool {
+	for _, a := range addrs {
+		if a == addr {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+)
+
+func TestClientPolicyCheck(t *testing.T) {
+	inbox := common.Address{0xff}
+	oracle := common.Address{0xee}
+	policy := ClientPolicy{
+		ChainID:     10,
+		AllowedTo:   []common.Address{inbox, oracle},
+		MaxValue:    (*hexutil.Big)(big.NewInt(100)),
+		MaxGasPrice: (*hexutil.Big)(big.NewInt(1000)),
+
+		MaxBlobGasPrice: (*hexutil.Big)(big.NewInt(50)),
+	}
+	validArgs := func() *client.TransactionArgs {
+		to := inbox
+		return &client.TransactionArgs{
+			To:           &to,
+			ChainID:      (*hexutil.Big)(big.NewInt(10)),
+			Value:        (*hexutil.Big)(big.NewInt(100)),
+			MaxFeePerGas: (*hexutil.Big)(big.NewInt(1000)),
+		}
+	}
+	require.NoError(t, policy.Check(validArgs()))
+
+	tests := []struct {
+		name   string
+		policy func(p *ClientPolicy)
+		args   func(args *client.TransactionArgs)
+		valid  bool
+	}{
+		{name: "OtherAllowedTo", args: func(args *client.TransactionArgs) { args.To = &oracle }, valid: true},
+		{name: "AnyToIfUnrestricted", policy: func(p *ClientPolicy) { p.AllowedTo = nil }, args: func(args *client.TransactionArgs) { args.To = &common.Address{0x01} }, valid: true},
+		{name: "NilValue", args: func(args *client.TransactionArgs) { args.Value = nil }, valid: true},
+		{name: "UnlimitedGasPrice", policy: func(p *ClientPolicy) { p.MaxGasPrice = nil }, args: func(args *client.TransactionArgs) { args.MaxFeePerGas = (*hexutil.Big)(big.NewInt(1001)) }, valid: true},
+		{name: "WrongChainID", args: func(args *client.TransactionArgs) { args.ChainID = (*hexutil.Big)(big.NewInt(11)) }},
+		{name: "MissingChainID", args: func(args *client.TransactionArgs) { args.ChainID = nil }},
+		{name: "DisallowedTo", args: func(args *client.TransactionArgs) { args.To = &common.Address{0x01} }},
+		{name: "ContractCreation", args: func(args *client.TransactionArgs) { args.To = nil }},
+		{name: "ValueTooHigh", args: func(args *client.TransactionArgs) { args.Value = (*hexutil.Big)(big.NewInt(101)) }},
+		{name: "NoValueAllowed", policy: func(p *ClientPolicy) { p.MaxValue = nil }, args: func(args *client.TransactionArgs) { args.Value = (*hexutil.Big)(big.NewInt(1)) }},
+		{name: "GasPriceTooHigh", args: func(args *client.TransactionArgs) { args.MaxFeePerGas = (*hexutil.Big)(big.NewInt(1001)) }},
+		{name: "MissingGasPrice", args: func(args *client.TransactionArgs) { args.MaxFeePerGas = nil }},
+		{name: "BlobTx", args: withBlobs(50), valid: true},
+		{name: "BlobGasPriceTooHigh", args: withBlobs(51)},
+		{name: "MissingBlobGasPrice", args: func(args *client.TransactionArgs) { args.BlobHashes = []common.Hash{{0x01}} }},
+		{name: "BlobsNotAllowed", policy: func(p *ClientPolicy) { p.MaxBlobGasPrice = nil }, args: withBlobs(1)},
+		{name: "BlobGasPriceWithoutBlobs", policy: func(p *ClientPolicy) { p.MaxBlobGasPrice = nil }, args: func(args *client.TransactionArgs) { args.BlobFeeCap = (*hexutil.Big)(big.NewInt(1)) }},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p := policy
+			if test.policy != nil {
+				test.policy(&p)
+			}
+			args := validArgs()
+			test.args(args)
+			err := p.Check(args)
+			if test.valid {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, ErrPolicyViolation)
+			}
+		})
+	}
+}
+
+func withBlobs(blobFeeCap int64) func(args *client.TransactionArgs) {
+	return func(args *client.TransactionArgs) {
+		args.BlobFeeCap = (*hexutil.Big)(big.NewInt(blobFeeCap))
+		args.BlobHashes = []common.Hash{{0x01}}
+	}
+}
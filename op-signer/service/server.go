@@ -0,0 +1,116 @@
+package service
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-service/tls/certman"
+	"github.com/ethereum-optimism/optimism/op-signer/flags"
+)
+
+// Main is the entrypoint into the signer service.
+func Main(version string) func(ctx *cli.Context) error {
+	return func(cliCtx *cli.Context) error {
+		if err := flags.CheckRequired(cliCtx); err != nil {
+			return err
+		}
+		cfg := NewConfig(cliCtx)
+		if err := cfg.Check(); err != nil {
+			return fmt.Errorf("invalid CLI flags: %w", err)
+		}
+
+		l := oplog.NewLogger(cfg.Log)
+		opservice.ValidateEnvVars(flags.EnvVarPrefix, flags.Flags, l)
+		l.Info("Initializing signer", "version", version)
+
+		keys, err := NewLocalKeystoreFromConfig(cfg)
+		if err != nil {
+			return err
+		}
+		policies, err := LoadPolicies(cfg.PolicyFile)
+		if err != nil {
+			return err
+		}
+		audit, err := OpenAuditLog(cfg.AuditLogPath)
+		if err != nil {
+			return err
+		}
+		defer audit.Close()
+		svc, err := NewSignerService(l, keys, policies, audit)
+		if err != nil {
+			return err
+		}
+
+		server, err := NewServer(l, version, cfg.RPC, cfg.TLS, svc)
+		if err != nil {
+			return err
+		}
+		if err := server.Start(); err != nil {
+			return fmt.Errorf("error starting RPC server: %w", err)
+		}
+		l.Info("Signer started", "endpoint", server.Endpoint(), "clients", len(policies))
+
+		opio.BlockOnInterrupts()
+		if err := server.Stop(); err != nil {
+			l.Error("Error shutting down RPC server", "err", err)
+		}
+		return nil
+	}
+}
+
+// NewServer creates the RPC server of the signer service. Clients must authenticate with a TLS
+// certificate signed by the configured CA.
+func NewServer(l log.Logger, version string, rpcCfg oprpc.CLIConfig, tlsCfg optls.CLIConfig, svc *SignerService) (*oprpc.Server, error) {
+	serverTLS, err := newServerTLSConfig(l, tlsCfg)
+	if err != nil {
+		return nil, err
+	}
+	server := oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version,
+		oprpc.WithLogger(l),
+		oprpc.WithTLSConfig(serverTLS),
+	)
+	server.AddAPI(svc.API())
+	return server, nil
+}
+
+func newServerTLSConfig(l log.Logger, cfg optls.CLIConfig) (*oprpc.ServerTLSConfig, error) {
+	if !cfg.TLSEnabled() {
+		return nil, errors.New("TLS is required")
+	}
+	caCert, err := os.ReadFile(cfg.TLSCaCert)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tls.ca: %w", err)
+	}
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, errors.New("no certificates found in tls.ca")
+	}
+	// certman watches for newer server certificates and automatically reloads them
+	cm, err := certman.New(l, cfg.TLSCert, cfg.TLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tls cert or key: %w", err)
+	}
+	if err := cm.Watch(); err != nil {
+		return nil, fmt.Errorf("failed to start certman watcher: %w", err)
+	}
+	return &oprpc.ServerTLSConfig{
+		Config: &tls.Config{
+			MinVersion:     tls.VersionTLS13,
+			GetCertificate: cm.GetCertificate,
+			ClientCAs:      caCertPool,
+			ClientAuth:     tls.RequireAndVerifyClientCert,
+		},
+		CLIConfig: &cfg,
+	}, nil
+}
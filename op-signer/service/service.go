@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+)
+
+var ErrUnauthorized = errors.New("unauthorized client")
+
+// SignerService signs transactions of TLS-authenticated clients, as long as they satisfy the client's policy.
+type SignerService struct {
+	log      log.Logger
+	keys     KeyBackend
+	policies map[string]ClientPolicy
+	audit    *AuditLog
+}
+
+// NewSignerService creates a new SignerService. Every client policy must refer to a key of the key backend.
+func NewSignerService(l log.Logger, keys KeyBackend, policies []ClientPolicy, audit *AuditLog) (*SignerService, error) {
+	byName := make(map[string]ClientPolicy, len(policies))
+	for _, p := range policies {
+		if p.Name == "" {
+			return nil, errors.New("client policy without name")
+		}
+		if _, ok := byName[p.Name]; ok {
+			return nil, fmt.Errorf("duplicate policy for client %s", p.Name)
+		}
+		if _, err := keys.Address(context.Background(), p.Key); err != nil {
+			return nil, fmt.Errorf("invalid key of client %s: %w", p.Name, err)
+		}
+		byName[p.Name] = p
+	}
+	return &SignerService{
+		log:      l,
+		keys:     keys,
+		policies: byName,
+		audit:    audit,
+	}, nil
+}
+
+// API returns the RPC API of the service, which serves eth_signTransaction.
+func (s *SignerService) API() rpc.API {
+	return rpc.API{
+		Namespace: "eth",
+		Service:   &ethAPI{s: s},
+	}
+}
+
+type ethAPI struct {
+	s *SignerService
+}
+
+// SignTransaction signs the transaction with the key of the calling client and returns it RLP encoded.
+func (a *ethAPI) SignTransaction(ctx context.Context, args client.TransactionArgs) (hexutil.Bytes, error) {
+	return a.s.SignTransaction(ctx, clientName(ctx), &args)
+}
+
+// clientName returns the name of the client, the first DNS name of its TLS certificate.
+func clientName(ctx context.Context) string {
+	cert := optls.PeerTLSInfoFromContext(ctx).LeafCertificate
+	if cert == nil || len(cert.DNSNames) == 0 {
+		return ""
+	}
+	return cert.DNSNames[0]
+}
+
+// SignTransaction signs the transaction on behalf of the named client. The request is recorded in the
+// audit log, whether it is signed or rejected. A signature is only returned once it has been recorded.
+func (s *SignerService) SignTransaction(ctx context.Context, name string, args *client.TransactionArgs) (hexutil.Bytes, error) {
+	entry := AuditEntry{
+		Time:         time.Now(),
+		Client:       name,
+		From:         args.From,
+		To:           args.To,
+		ChainID:      args.ChainID,
+		Nonce:        args.Nonce,
+		Value:        args.Value,
+		MaxFeePerGas: args.MaxFeePerGas,
+
+		MaxFeePerBlobGas: args.BlobFeeCap,
+		BlobHashes:       args.BlobHashes,
+	}
+	signed, err := s.signTransaction(ctx, name, args, &entry)
+	if err != nil {
+		entry.Error = err.Error()
+		s.log.Warn("Rejected signing request", "client", name, "err", err)
+	} else {
+		hash := signed.Hash()
+		entry.TxHash = &hash
+	}
+	if auditErr := s.audit.Record(entry); auditErr != nil {
+		s.log.Error("Failed to record signing request", "client", name, "err", auditErr)
+		return nil, errors.New("failed to record signing request")
+	}
+	if err != nil {
+		return nil, err
+	}
+	s.log.Info("Signed transaction", "client", name, "hash", entry.TxHash, "nonce", signed.Nonce(), "to", signed.To())
+	return signed.MarshalBinary()
+}
+
+func (s *SignerService) signTransaction(ctx context.Context, name string, args *client.TransactionArgs, entry *AuditEntry) (*types.Transaction, error) {
+	policy, ok := s.policies[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnauthorized, name)
+	}
+	entry.Key = policy.Key
+	from, err := s.keys.Address(ctx, policy.Key)
+	if err != nil {
+		return nil, err
+	}
+	if args.From == nil || *args.From != from {
+		return nil, fmt.Errorf("%w: client may only sign for %s", ErrPolicyViolation, from)
+	}
+	if args.Nonce == nil || args.Gas == nil || args.MaxFeePerGas == nil || args.MaxPriorityFeePerGas == nil {
+		return nil, errors.New("missing nonce, gas or fee fields")
+	}
+	if len(args.BlobHashes) > 0 && (args.To == nil || args.BlobFeeCap == nil) {
+		return nil, errors.New("blob transactions require to and maxFeePerBlobGas fields")
+	}
+	if err := policy.Check(args); err != nil {
+		return nil, err
+	}
+
+	tx := args.ToTransaction()
+	signer := types.LatestSignerForChainID(args.ChainID.ToInt())
+	sig, err := s.keys.SignHash(ctx, policy.Key, signer.Hash(tx).Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	signed, err := tx.WithSignature(signer, sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	// Make sure the key backend signed with the expected key
+	if sender, err := types.Sender(signer, signed); err != nil || sender != from {
+		return nil, fmt.Errorf("signature does not recover to %s", from)
+	}
+	return signed, nil
+}
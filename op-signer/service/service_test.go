@@ -0,0 +1,157 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+)
+
+func setupService(t *testing.T) (*SignerService, common.Address, string) {
+	dir := t.TempDir()
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	account, err := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, "password")
+	require.NoError(t, err)
+	keys, err := NewLocalKeystore(dir, "password")
+	require.NoError(t, err)
+
+	auditPath := filepath.Join(t.TempDir(), "audit.log")
+	audit, err := OpenAuditLog(auditPath)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, audit.Close()) })
+
+	policies := []ClientPolicy{{
+		Name:      "batcher",
+		Key:       account.Address.Hex(),
+		ChainID:   900,
+		AllowedTo: []common.Address{{0xff}},
+
+		MaxBlobGasPrice: (*hexutil.Big)(big.NewInt(10)),
+	}}
+	svc, err := NewSignerService(testlog.Logger(t, log.LvlError), keys, policies, audit)
+	require.NoError(t, err)
+	return svc, account.Address, auditPath
+}
+
+func readAuditLog(t *testing.T, path string) []AuditEntry {
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry AuditEntry
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+		entries = append(entries, entry)
+	}
+	require.NoError(t, scanner.Err())
+	return entries
+}
+
+func TestSignTransaction(t *testing.T) {
+	svc, from, auditPath := setupService(t)
+	chainID := big.NewInt(900)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     3,
+		To:        &common.Address{0xff},
+		Gas:       21000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+		Data:      []byte{1, 2, 3},
+	})
+	ctx := context.Background()
+
+	raw, err := svc.SignTransaction(ctx, "batcher", client.NewTransactionArgsFromTransaction(chainID, from, tx))
+	require.NoError(t, err)
+	signed := new(types.Transaction)
+	require.NoError(t, signed.UnmarshalBinary(raw))
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+	require.Equal(t, tx.Nonce(), signed.Nonce())
+	require.Equal(t, tx.Data(), signed.Data())
+
+	_, err = svc.SignTransaction(ctx, "proposer", client.NewTransactionArgsFromTransaction(chainID, from, tx))
+	require.ErrorIs(t, err, ErrUnauthorized)
+	_, err = svc.SignTransaction(ctx, "batcher", client.NewTransactionArgsFromTransaction(chainID, common.Address{0x01}, tx))
+	require.ErrorIs(t, err, ErrPolicyViolation)
+	_, err = svc.SignTransaction(ctx, "batcher", client.NewTransactionArgsFromTransaction(big.NewInt(1), from, tx))
+	require.ErrorIs(t, err, ErrPolicyViolation)
+
+	entries := readAuditLog(t, auditPath)
+	require.Len(t, entries, 4)
+	require.Equal(t, "batcher", entries[0].Client)
+	require.Equal(t, signed.Hash(), *entries[0].TxHash)
+	require.Empty(t, entries[0].Error)
+	require.Equal(t, "proposer", entries[1].Client)
+	for _, entry := range entries[1:] {
+		require.Nil(t, entry.TxHash)
+		require.NotEmpty(t, entry.Error)
+	}
+}
+
+func TestSignBlobTransaction(t *testing.T) {
+	svc, from, auditPath := setupService(t)
+	chainID := big.NewInt(900)
+	tx := types.NewTx(&types.BlobTx{
+		ChainID:    uint256.NewInt(900),
+		Nonce:      3,
+		To:         &common.Address{0xff},
+		Gas:        21000,
+		GasTipCap:  uint256.NewInt(1),
+		GasFeeCap:  uint256.NewInt(2),
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.NewInt(3),
+		BlobHashes: []common.Hash{{0x01, 0xaa}},
+	})
+	ctx := context.Background()
+
+	raw, err := svc.SignTransaction(ctx, "batcher", client.NewTransactionArgsFromTransaction(chainID, from, tx))
+	require.NoError(t, err)
+	signed := new(types.Transaction)
+	require.NoError(t, signed.UnmarshalBinary(raw))
+	require.Equal(t, uint8(types.BlobTxType), signed.Type())
+	require.Equal(t, tx.BlobHashes(), signed.BlobHashes())
+	require.Equal(t, tx.BlobGasFeeCap(), signed.BlobGasFeeCap())
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, from, sender)
+
+	entries := readAuditLog(t, auditPath)
+	require.Len(t, entries, 1)
+	require.Equal(t, tx.BlobGasFeeCap(), entries[0].MaxFeePerBlobGas.ToInt(), "should record the blob fee cap")
+	require.Equal(t, tx.BlobHashes(), entries[0].BlobHashes)
+
+	args := client.NewTransactionArgsFromTransaction(chainID, from, tx)
+	args.BlobFeeCap = (*hexutil.Big)(big.NewInt(11))
+	_, err = svc.SignTransaction(ctx, "batcher", args)
+	require.ErrorIs(t, err, ErrPolicyViolation, "should limit the blob fee cap")
+
+	args = client.NewTransactionArgsFromTransaction(chainID, from, tx)
+	args.BlobFeeCap = nil
+	_, err = svc.SignTransaction(ctx, "batcher", args)
+	require.ErrorContains(t, err, "maxFeePerBlobGas")
+}
+
+func TestNewSignerServiceRejectsUnknownKey(t *testing.T) {
+	svc, _, _ := setupService(t)
+	_, err := NewSignerService(svc.log, svc.keys, []ClientPolicy{{Name: "batcher", Key: common.Address{0x01}.Hex()}}, svc.audit)
+	require.ErrorIs(t, err, ErrUnknownKey)
+}
@@ -0,0 +1,157 @@
+// Package signertest runs an op-signer service in-process, for tests of op-signer clients like the txmgr.
+package signertest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
+	optls "github.com/ethereum-optimism/optimism/op-service/tls"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+	"github.com/ethereum-optimism/optimism/op-signer/service"
+)
+
+// ClientName is the name of the client in the TLS certificate of [Harness.ClientConfig].
+const ClientName = "signertest-client"
+
+const keystorePassword = "signertest"
+
+// Harness is an op-signer service running in-process, with a fresh keystore, CA and client certificate.
+type Harness struct {
+	// Address is the address of the key the client signs with.
+	Address common.Address
+	// ClientConfig configures an op-signer client to connect to the service.
+	ClientConfig client.CLIConfig
+	// AuditLogPath is the path of the audit log of the service.
+	AuditLogPath string
+}
+
+// NewHarness starts an op-signer service enforcing the policy for the client of [Harness.ClientConfig].
+// The name and key of the policy are set by the harness. The service is stopped when the test ends.
+func NewHarness(t *testing.T, policy service.ClientPolicy) *Harness {
+	dir := t.TempDir()
+	logger := testlog.Logger(t, log.LvlInfo).New("role", "op-signer")
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	keystoreDir := filepath.Join(dir, "keystore")
+	require.NoError(t, os.Mkdir(keystoreDir, 0o700))
+	account, err := keystore.NewKeyStore(keystoreDir, keystore.LightScryptN, keystore.LightScryptP).ImportECDSA(key, keystorePassword)
+	require.NoError(t, err)
+	keys, err := service.NewLocalKeystore(keystoreDir, keystorePassword)
+	require.NoError(t, err)
+
+	policy.Name = ClientName
+	policy.Key = account.Address.Hex()
+	auditLogPath := filepath.Join(dir, "audit.log")
+	audit, err := service.OpenAuditLog(auditLogPath)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, audit.Close())
+	})
+	svc, err := service.NewSignerService(logger, keys, []service.ClientPolicy{policy}, audit)
+	require.NoError(t, err)
+
+	serverTLS, clientTLS := writeCertificates(t, dir)
+	port := freePort(t)
+	server, err := service.NewServer(logger, "signertest", oprpc.CLIConfig{ListenAddr: "127.0.0.1", ListenPort: port}, serverTLS, svc)
+	require.NoError(t, err)
+	require.NoError(t, server.Start())
+	t.Cleanup(func() {
+		require.NoError(t, server.Stop())
+	})
+
+	return &Harness{
+		Address: account.Address,
+		ClientConfig: client.CLIConfig{
+			Endpoint:  fmt.Sprintf("https://127.0.0.1:%d", port),
+			Address:   account.Address.Hex(),
+			TLSConfig: clientTLS,
+		},
+		AuditLogPath: auditLogPath,
+	}
+}
+
+// writeCertificates creates a CA, and server and client certificates signed by it.
+// It returns the TLS configs of the server and the client.
+func writeCertificates(t *testing.T, dir string) (optls.CLIConfig, optls.CLIConfig) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "signertest-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+	caPath := filepath.Join(dir, "ca.crt")
+	writePEM(t, caPath, "CERTIFICATE", caDER)
+
+	issue := func(name string, serial int64, template *x509.Certificate) optls.CLIConfig {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template.SerialNumber = big.NewInt(serial)
+		template.Subject = pkix.Name{CommonName: name}
+		template.NotBefore = time.Now().Add(-time.Hour)
+		template.NotAfter = time.Now().Add(24 * time.Hour)
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		keyDER, err := x509.MarshalECPrivateKey(key)
+		require.NoError(t, err)
+		cfg := optls.CLIConfig{
+			TLSCaCert: caPath,
+			TLSCert:   filepath.Join(dir, name+".crt"),
+			TLSKey:    filepath.Join(dir, name+".key"),
+		}
+		writePEM(t, cfg.TLSCert, "CERTIFICATE", der)
+		writePEM(t, cfg.TLSKey, "EC PRIVATE KEY", keyDER)
+		return cfg
+	}
+	serverCfg := issue("server", 2, &x509.Certificate{
+		DNSNames:    []string{"localhost"},
+		IPAddresses: []net.IP{net.IPv4(127, 0, 0, 1)},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	clientCfg := issue("client", 3, &x509.Certificate{
+		DNSNames:    []string{ClientName},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	return serverCfg, clientCfg
+}
+
+func writePEM(t *testing.T, path string, blockType string, der []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+}
+
+func freePort(t *testing.T) int {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port
+}
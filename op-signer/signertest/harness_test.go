@@ -0,0 +1,49 @@
+package signertest
+
+import (
+	"context"
+	"math/big"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-signer/client"
+	"github.com/ethereum-optimism/optimism/op-signer/service"
+)
+
+func TestHarnessSignsOverMutualTLS(t *testing.T) {
+	inbox := common.Address{0xff}
+	h := NewHarness(t, service.ClientPolicy{ChainID: 900, AllowedTo: []common.Address{inbox}})
+
+	signer, err := client.NewSignerClientFromConfig(testlog.Logger(t, log.LvlInfo), h.ClientConfig)
+	require.NoError(t, err)
+
+	chainID := big.NewInt(900)
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   chainID,
+		To:        &inbox,
+		Gas:       21000,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(2),
+	})
+	signed, err := signer.SignTransaction(context.Background(), chainID, h.Address, tx)
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	require.NoError(t, err)
+	require.Equal(t, h.Address, sender)
+
+	other := common.Address{0x01}
+	tx = types.NewTx(&types.DynamicFeeTx{ChainID: chainID, To: &other, Gas: 21000, GasTipCap: big.NewInt(1), GasFeeCap: big.NewInt(2)})
+	_, err = signer.SignTransaction(context.Background(), chainID, h.Address, tx)
+	require.ErrorContains(t, err, service.ErrPolicyViolation.Error())
+
+	audit, err := os.ReadFile(h.AuditLogPath)
+	require.NoError(t, err)
+	require.Len(t, strings.Split(strings.TrimSpace(string(audit)), "\n"), 2)
+}
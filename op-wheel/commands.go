@@ -19,6 +19,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -454,6 +455,117 @@ var (
 			return engine.Copy(context.Background(), source, dest)
 		}),
 	}
+	EngineReplayCmd = &cli.Command{
+		Name:  "replay",
+		Usage: "Replay engine API calls recorded by op-node, and compare the results with the recording.",
+		Description: "The recording is created with the op-node --l2.engine-record flag. " +
+			"The engine must have the chain up to the parent of the first recorded payload, e.g. from a copy of the datadir of the original engine. " +
+			"With a reference RPC, the replayed blocks are also compared block by block with the blocks of the reference engine.",
+		Flags: append([]cli.Flag{
+			EngineEndpoint, EngineJWTPath,
+			&cli.StringFlag{
+				Name:      "recording",
+				Usage:     "Engine API recording file to replay",
+				TakesFile: true,
+				Required:  true,
+			},
+			&cli.StringFlag{
+				Name:  "reference",
+				Usage: "Optional unauthenticated regular eth JSON RPC of an engine to compare the replayed blocks with, can be HTTP/WS/IPC.",
+			},
+		}, oplog.CLIFlags(envVarPrefix)...),
+		Action: EngineAction(func(ctx *cli.Context, dest client.RPC) error {
+			logCfg := oplog.ReadCLIConfig(ctx)
+			if err := logCfg.Check(); err != nil {
+				return fmt.Errorf("failed to parse log configuration: %w", err)
+			}
+			l := oplog.NewLogger(logCfg)
+
+			f, err := os.Open(ctx.String("recording"))
+			if err != nil {
+				return fmt.Errorf("failed to open recording: %w", err)
+			}
+			defer f.Close()
+			records, err := sources.ReadEngineRecords(f)
+			if err != nil {
+				return err
+			}
+			res, err := engine.Replay(context.Background(), l, records, dest)
+			if err != nil {
+				return err
+			}
+			out := struct {
+				*engine.ReplayResult
+				Diffs []engine.BlockDiff `json:"diffs,omitempty"`
+			}{ReplayResult: res}
+			if ref := ctx.String("reference"); ref != "" && len(res.Blocks) > 0 {
+				rpcClient, err := rpc.DialOptions(context.Background(), ref)
+				if err != nil {
+					return fmt.Errorf("failed to dial reference endpoint: %w", err)
+				}
+				from, to := res.Blocks[0].Number, res.Blocks[0].Number
+				for _, id := range res.Blocks {
+					if id.Number < from {
+						from = id.Number
+					}
+					if id.Number > to {
+						to = id.Number
+					}
+				}
+				out.Diffs, err = engine.Diff(context.Background(), dest, client.NewBaseRPCClient(rpcClient), from, to)
+				if err != nil {
+					return err
+				}
+			}
+			enc := json.NewEncoder(ctx.App.Writer)
+			enc.SetIndent("", "  ")
+			return enc.Encode(out)
+		}),
+	}
+	EngineDiffCmd = &cli.Command{
+		Name:  "diff",
+		Usage: "Compare the blocks of two engines block by block: state roots, receipt roots, and receipts.",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:     "a",
+				Usage:    "Unauthenticated regular eth JSON RPC of the first engine, can be HTTP/WS/IPC.",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:     "b",
+				Usage:    "Unauthenticated regular eth JSON RPC of the second engine, can be HTTP/WS/IPC.",
+				Required: true,
+			},
+			&cli.Uint64Flag{
+				Name:     "from",
+				Usage:    "First block number to compare",
+				Required: true,
+			},
+			&cli.Uint64Flag{
+				Name:     "to",
+				Usage:    "Last block number to compare (inclusive)",
+				Required: true,
+			},
+		},
+		Action: func(ctx *cli.Context) error {
+			rpcA, err := rpc.DialOptions(context.Background(), ctx.String("a"))
+			if err != nil {
+				return fmt.Errorf("failed to dial endpoint a: %w", err)
+			}
+			rpcB, err := rpc.DialOptions(context.Background(), ctx.String("b"))
+			if err != nil {
+				return fmt.Errorf("failed to dial endpoint b: %w", err)
+			}
+			diffs, err := engine.Diff(context.Background(), client.NewBaseRPCClient(rpcA), client.NewBaseRPCClient(rpcB),
+				ctx.Uint64("from"), ctx.Uint64("to"))
+			if err != nil {
+				return err
+			}
+			enc := json.NewEncoder(ctx.App.Writer)
+			enc.SetIndent("", "  ")
+			return enc.Encode(diffs)
+		},
+	}
 )
 
 var CheatCmd = &cli.Command{
@@ -481,5 +593,7 @@ var EngineCmd = &cli.Command{
 		EngineAutoCmd,
 		EngineStatusCmd,
 		EngineCopyCmd,
+		EngineReplayCmd,
+		EngineDiffCmd,
 	},
 }
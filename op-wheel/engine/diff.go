@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+)
+
+// BlockDiff is a difference between the blocks with the same number of two engines.
+type BlockDiff struct {
+	Number uint64 `json:"number"`
+	Field  string `json:"field"`
+	A      string `json:"a"`
+	B      string `json:"b"`
+}
+
+// Diff compares the blocks from..to (inclusive) of engines a and b: the state roots, receipt roots and, if the latter differ,
+// the receipts of each transaction. The first difference is usually the block to debug, later blocks build on the diverged state.
+func Diff(ctx context.Context, a client.RPC, b client.RPC, from uint64, to uint64) ([]BlockDiff, error) {
+	var diffs []BlockDiff
+	for num := from; num <= to; num++ {
+		blockA, err := getBlock(ctx, a, "eth_getBlockByNumber", hexutil.Uint64(num).String())
+		if err != nil {
+			return diffs, fmt.Errorf("failed to get block %d from a: %w", num, err)
+		}
+		blockB, err := getBlock(ctx, b, "eth_getBlockByNumber", hexutil.Uint64(num).String())
+		if err != nil {
+			return diffs, fmt.Errorf("failed to get block %d from b: %w", num, err)
+		}
+		diff := func(field string, a, b any) {
+			diffs = append(diffs, BlockDiff{Number: num, Field: field, A: fmt.Sprint(a), B: fmt.Sprint(b)})
+		}
+		if blockA.Hash() == blockB.Hash() {
+			continue
+		}
+		diff("hash", blockA.Hash(), blockB.Hash())
+		if blockA.ParentHash() != blockB.ParentHash() {
+			diff("parentHash", blockA.ParentHash(), blockB.ParentHash())
+		}
+		if blockA.Root() != blockB.Root() {
+			diff("stateRoot", blockA.Root(), blockB.Root())
+		}
+		if blockA.GasUsed() != blockB.GasUsed() {
+			diff("gasUsed", blockA.GasUsed(), blockB.GasUsed())
+		}
+		if blockA.ReceiptHash() == blockB.ReceiptHash() {
+			continue
+		}
+		diff("receiptsRoot", blockA.ReceiptHash(), blockB.ReceiptHash())
+		txsA, txsB := blockA.Transactions(), blockB.Transactions()
+		if len(txsA) != len(txsB) {
+			diff("transactions", len(txsA), len(txsB))
+		}
+		for i := 0; i < len(txsA) && i < len(txsB); i++ {
+			if txsA[i].Hash() != txsB[i].Hash() {
+				diff(fmt.Sprintf("tx[%d].hash", i), txsA[i].Hash(), txsB[i].Hash())
+				continue
+			}
+			receiptA, err := getReceipt(ctx, a, txsA[i].Hash())
+			if err != nil {
+				return diffs, fmt.Errorf("failed to get receipt %d of block %d from a: %w", i, num, err)
+			}
+			receiptB, err := getReceipt(ctx, b, txsB[i].Hash())
+			if err != nil {
+				return diffs, fmt.Errorf("failed to get receipt %d of block %d from b: %w", i, num, err)
+			}
+			if receiptA.Status != receiptB.Status {
+				diff(fmt.Sprintf("receipt[%d].status", i), receiptA.Status, receiptB.Status)
+			}
+			if receiptA.GasUsed != receiptB.GasUsed {
+				diff(fmt.Sprintf("receipt[%d].gasUsed", i), receiptA.GasUsed, receiptB.GasUsed)
+			}
+			if receiptA.CumulativeGasUsed != receiptB.CumulativeGasUsed {
+				diff(fmt.Sprintf("receipt[%d].cumulativeGasUsed", i), receiptA.CumulativeGasUsed, receiptB.CumulativeGasUsed)
+			}
+			if len(receiptA.Logs) != len(receiptB.Logs) {
+				diff(fmt.Sprintf("receipt[%d].logs", i), len(receiptA.Logs), len(receiptB.Logs))
+			}
+			if receiptA.Bloom != receiptB.Bloom {
+				diff(fmt.Sprintf("receipt[%d].logsBloom", i), receiptA.Bloom, receiptB.Bloom)
+			}
+		}
+	}
+	return diffs, nil
+}
+
+func getReceipt(ctx context.Context, client client.RPC, txHash common.Hash) (*types.Receipt, error) {
+	var receipt *types.Receipt
+	if err := client.CallContext(ctx, &receipt, "eth_getTransactionReceipt", txHash); err != nil {
+		return nil, err
+	}
+	if receipt == nil {
+		return nil, fmt.Errorf("receipt of tx %s not found", txHash)
+	}
+	return receipt, nil
+}
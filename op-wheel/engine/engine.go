@@ -62,6 +62,9 @@ func getBlock(ctx context.Context, client client.RPC, method string, tag string)
 	if err != nil {
 		return nil, err
 	}
+	if bl == nil {
+		return nil, fmt.Errorf("block %s not found", tag)
+	}
 	return types.NewBlockWithHeader(&bl.Header).WithBody(bl.Transactions, nil), nil
 }
 
@@ -0,0 +1,185 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/ethereum-optimism/optimism/op-node/client"
+	"github.com/ethereum-optimism/optimism/op-node/eth"
+	"github.com/ethereum-optimism/optimism/op-node/sources"
+)
+
+// ReplayMismatch is a difference between the recorded and the replayed result of an engine API call.
+type ReplayMismatch struct {
+	// Record is the index of the call in the recording.
+	Record int    `json:"record"`
+	Method string `json:"method"`
+	// Block is the number of the block the call applies to, if known.
+	Block    uint64 `json:"block,omitempty"`
+	Field    string `json:"field"`
+	Recorded string `json:"recorded"`
+	Replayed string `json:"replayed"`
+}
+
+type ReplayResult struct {
+	Mismatches []ReplayMismatch `json:"mismatches"`
+	// Blocks are the payloads that were executed during the replay, in order.
+	Blocks []eth.BlockID `json:"blocks"`
+}
+
+// Replay replays the engine API calls recorded by op-node against the dest engine, and compares the results.
+// The dest engine must have the chain up to the parent of the first recorded payload, e.g. by copying the datadir of the original engine.
+//
+// Payloads built by the dest engine are only compared with the recorded payloads if the attributes exclude the tx-pool,
+// as the dest engine does not have the tx-pool of the original engine. The recorded payloads are executed regardless,
+// so the replayed chain follows the recorded chain.
+func Replay(ctx context.Context, log log.Logger, records []sources.EngineRecord, dest client.RPC) (*ReplayResult, error) {
+	res := &ReplayResult{}
+	// recorded payload ID -> replayed payload ID, and whether the payload should be compared
+	payloadIDs := make(map[eth.PayloadID]eth.PayloadID)
+	comparePayload := make(map[eth.PayloadID]bool)
+	for i, rec := range records {
+		mismatch := func(block uint64, field string, recorded, replayed any) {
+			m := ReplayMismatch{Record: i, Method: rec.Method, Block: block, Field: field,
+				Recorded: fmt.Sprint(recorded), Replayed: fmt.Sprint(replayed)}
+			log.Warn("Replayed engine API call does not match recording", "record", i, "method", rec.Method,
+				"block", block, "field", field, "recorded", m.Recorded, "replayed", m.Replayed)
+			res.Mismatches = append(res.Mismatches, m)
+		}
+		// compareResults checks if both the recorded and the replayed call failed, or both succeeded.
+		// The results are only compared if both succeeded.
+		compareResults := func(block uint64, replayErr string) bool {
+			if (replayErr != "") != (rec.Error != "") {
+				mismatch(block, "error", rec.Error, replayErr)
+				return false
+			}
+			return replayErr == ""
+		}
+		params := make([]any, len(rec.Params))
+		for j, p := range rec.Params {
+			params[j] = p
+		}
+
+		switch rec.Method {
+		case "engine_forkchoiceUpdatedV1", "engine_forkchoiceUpdatedV2":
+			var attrs *eth.PayloadAttributes
+			if len(rec.Params) > 1 {
+				if err := json.Unmarshal(rec.Params[1], &attrs); err != nil {
+					return nil, fmt.Errorf("record %d: invalid payload attributes: %w", i, err)
+				}
+			}
+			var replayed eth.ForkchoiceUpdatedResult
+			replayErr, err := callRecorded(ctx, dest, &replayed, rec, params)
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", i, err)
+			}
+			if !compareResults(0, replayErr) {
+				continue
+			}
+			var recorded eth.ForkchoiceUpdatedResult
+			if err := json.Unmarshal(rec.Result, &recorded); err != nil {
+				return nil, fmt.Errorf("record %d: invalid forkchoice updated result: %w", i, err)
+			}
+			if recorded.PayloadStatus.Status != replayed.PayloadStatus.Status {
+				mismatch(0, "status", recorded.PayloadStatus.Status, replayed.PayloadStatus.Status)
+			}
+			if recorded.PayloadID != nil && replayed.PayloadID != nil {
+				payloadIDs[*recorded.PayloadID] = *replayed.PayloadID
+				comparePayload[*recorded.PayloadID] = attrs != nil && attrs.NoTxPool
+			}
+		case "engine_getPayloadV1", "engine_getPayloadV2":
+			var recordedID eth.PayloadID
+			if len(rec.Params) != 1 || json.Unmarshal(rec.Params[0], &recordedID) != nil {
+				return nil, fmt.Errorf("record %d: invalid payload ID", i)
+			}
+			replayedID, ok := payloadIDs[recordedID]
+			if !ok {
+				log.Warn("Skipping retrieval of payload that was not built during replay", "record", i, "payload_id", recordedID)
+				continue
+			}
+			delete(payloadIDs, recordedID)
+			params[0] = replayedID
+			var replayed eth.ExecutionPayload
+			replayErr, err := callRecorded(ctx, dest, &replayed, rec, params)
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", i, err)
+			}
+			if !compareResults(0, replayErr) || !comparePayload[recordedID] {
+				continue
+			}
+			var recorded eth.ExecutionPayload
+			if err := json.Unmarshal(rec.Result, &recorded); err != nil {
+				return nil, fmt.Errorf("record %d: invalid payload: %w", i, err)
+			}
+			num := uint64(recorded.BlockNumber)
+			if recorded.StateRoot != replayed.StateRoot {
+				mismatch(num, "stateRoot", recorded.StateRoot, replayed.StateRoot)
+			}
+			if recorded.ReceiptsRoot != replayed.ReceiptsRoot {
+				mismatch(num, "receiptsRoot", recorded.ReceiptsRoot, replayed.ReceiptsRoot)
+			}
+			if recorded.GasUsed != replayed.GasUsed {
+				mismatch(num, "gasUsed", recorded.GasUsed, replayed.GasUsed)
+			}
+			if len(recorded.Transactions) != len(replayed.Transactions) {
+				mismatch(num, "transactions", len(recorded.Transactions), len(replayed.Transactions))
+			}
+			if recorded.BlockHash != replayed.BlockHash {
+				mismatch(num, "blockHash", recorded.BlockHash, replayed.BlockHash)
+			}
+		case "engine_newPayloadV1", "engine_newPayloadV2":
+			var payload eth.ExecutionPayload
+			if len(rec.Params) != 1 || json.Unmarshal(rec.Params[0], &payload) != nil {
+				return nil, fmt.Errorf("record %d: invalid execution payload", i)
+			}
+			num := uint64(payload.BlockNumber)
+			var replayed eth.PayloadStatusV1
+			replayErr, err := callRecorded(ctx, dest, &replayed, rec, params)
+			if err != nil {
+				return nil, fmt.Errorf("record %d: %w", i, err)
+			}
+			res.Blocks = append(res.Blocks, payload.ID())
+			if !compareResults(num, replayErr) {
+				continue
+			}
+			var recorded eth.PayloadStatusV1
+			if err := json.Unmarshal(rec.Result, &recorded); err != nil {
+				return nil, fmt.Errorf("record %d: invalid payload status: %w", i, err)
+			}
+			if recorded.Status != replayed.Status {
+				mismatch(num, "status", recorded.Status, replayed.Status)
+			}
+			if deref(recorded.ValidationError) != deref(replayed.ValidationError) {
+				mismatch(num, "validationError", deref(recorded.ValidationError), deref(replayed.ValidationError))
+			}
+		default:
+			log.Debug("Skipping unsupported engine API call", "record", i, "method", rec.Method)
+		}
+	}
+	return res, nil
+}
+
+// callRecorded calls the recorded method with the given params, and returns the engine API error, if any.
+// Other errors, like timeouts, are returned as error, as they are not a result of the replay.
+func callRecorded(ctx context.Context, dest client.RPC, result any, rec sources.EngineRecord, params []any) (string, error) {
+	err := dest.CallContext(ctx, result, rec.Method, params...)
+	var rpcErr rpc.Error
+	if err == nil {
+		return "", nil
+	} else if errors.As(err, &rpcErr) {
+		return err.Error(), nil
+	}
+	return "", fmt.Errorf("failed to call %s: %w", rec.Method, err)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}